@@ -0,0 +1,59 @@
+package optim
+
+/*
+basis.go
+Description:
+	Defines the objects used to describe (and warm start from) the basis of a simplex-type solver.
+*/
+
+/*
+BasisStatus
+Description:
+
+	Describes the position of a variable (or of the activity of a constraint) in a basis. The
+	codes match the VBasis and CBasis attributes used by Gurobi.
+*/
+type BasisStatus int
+
+const (
+	BasisStatus_BASIC      BasisStatus = 0
+	BasisStatus_AT_LOWER   BasisStatus = -1
+	BasisStatus_AT_UPPER   BasisStatus = -2
+	BasisStatus_SUPERBASIC BasisStatus = -3
+)
+
+/*
+Basis
+Description:
+
+	The basis of a linear program. Variables contains the status of each variable, keyed by
+	Variable.ID. Constraints contains the status of the activity of each constraint (i.e. the
+	value of its left hand side minus its right hand side) in the order the constraints were
+	added; for example, an active <= constraint is AT_UPPER and an active >= constraint is
+	AT_LOWER.
+*/
+type Basis struct {
+	Variables   map[uint64]BasisStatus
+	Constraints []BasisStatus
+}
+
+/*
+NumBasic
+Description:
+
+	Counts the number of variables and constraints which are basic.
+*/
+func (b Basis) NumBasic() int {
+	count := 0
+	for _, status := range b.Variables {
+		if status == BasisStatus_BASIC {
+			count++
+		}
+	}
+	for _, status := range b.Constraints {
+		if status == BasisStatus_BASIC {
+			count++
+		}
+	}
+	return count
+}
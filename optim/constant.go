@@ -67,8 +67,10 @@ func (c K) Plus(e interface{}, extras ...interface{}) (ScalarExpression, error)
 		return eAsSLE.Plus(c)
 	case ScalarQuadraticExpression:
 		return e.(ScalarQuadraticExpression).Plus(c) // Very compact, but potentially confusing to read?
+	case SparseScalarLinearExpr, SparseScalarQuadraticExpression:
+		return e.(ScalarExpression).Plus(c)
 	default:
-		return c, fmt.Errorf("Unexpected type in K.Plus() for constant %v: %T", e)
+		return c, fmt.Errorf("Unexpected type in K.Plus() for constant %v: %T", c, e)
 	}
 }
 
@@ -78,6 +80,55 @@ func (c K) Mult(val float64) (ScalarExpression, error) {
 	return K(float64(c) * val), nil
 }
 
+/*
+Minus
+Description:
+
+	Returns the difference between the constant and e, which can be a float64 or any
+	scalar expression. The error returned while computing e can be passed as an extra argument:
+		diff, err := c.Minus(y.Mult(2))
+*/
+func (c K) Minus(e interface{}, extras ...interface{}) (ScalarExpression, error) {
+	return scalarMinus(c, e, extras)
+}
+
+/*
+Neg
+Description:
+
+	Returns the constant -c.
+*/
+func (c K) Neg() ScalarExpression {
+	return -c
+}
+
+/*
+Div
+Description:
+
+	Divides the constant by the constant val. Division by zero returns an error.
+*/
+func (c K) Div(val float64) (ScalarExpression, error) {
+	// Input Checking
+	if err := checkDivisor(val); err != nil {
+		return c, err
+	}
+
+	// Algorithm
+	return c.Mult(1.0 / val)
+}
+
+/*
+Equals
+Description:
+
+	Returns true if the constant is equal to other (a float64 or scalar expression with no
+	non-zero coefficients) up to tol.
+*/
+func (c K) Equals(other interface{}, tol float64) bool {
+	return scalarEquals(c, other, tol)
+}
+
 // LessEq returns a less than or equal to (<=) constraint between the
 // current expression and another
 func (c K) LessEq(other ScalarExpression) (ScalarConstraint, error) {
@@ -106,33 +157,16 @@ func (c K) Comparison(rhs ScalarExpression, sense ConstrSense) (ScalarConstraint
 	// Constants
 
 	// Algorithm
-	return ScalarConstraint{c, rhs, sense}, nil
+	return ScalarConstraint{LeftHandSide: c, RightHandSide: rhs, Sense: sense}, nil
 }
 
 /*
 Multiply
 Description:
 
-	This method multiplies the input constant by another expression.
+	This method multiplies the input constant by another expression (and then by each of the
+	extras).
 */
-func (c K) Multiply(term1 interface{}) (Expression, error) {
-	// Constants
-
-	// Algorithm
-	switch term1.(type) {
-	case float64:
-		term1AsFloat, _ := term1.(float64)
-		return c.Multiply(K(term1AsFloat))
-	case K:
-		term1AsK, _ := term1.(K)
-		return c * term1AsK, nil
-	//case ScalarLinearExpr:
-	//	term1AsSLE, _ := term1.(ScalarLinearExpr)
-	//	product := QuadraticExpr{
-	//		Q: float64[][]{}, // TODO: Finish this. Maybe switch quadratic expression to use gonum.Matrix objects instead of the current system.
-	//	}
-	default:
-		return K(0), fmt.Errorf("Unexpected type of term1 in the Multiply() method: %T (%v)", term1, term1)
-
-	}
+func (c K) Multiply(term1 interface{}, extras ...interface{}) (Expression, error) {
+	return Multiply(c, term1, extras...)
 }
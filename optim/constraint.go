@@ -3,8 +3,8 @@ package optim
 /*
 constraint.go
 Description:
-	Defines an interface that we are meant to use with the ScalarContraint and VectorConstraint
-	objects.
+	Defines an interface that we are meant to use with the ScalarContraint, VectorConstraint
+	and MatrixConstraint objects.
 */
 
 type Constraint interface {
@@ -20,8 +20,12 @@ func IsConstraint(c Constraint) bool {
 		return true
 	case *VectorConstraint:
 		return true
+	case MatrixConstraint:
+		return true
+	case *MatrixConstraint:
+		return true
 	}
 
-	// Return false, if the constraint is not a scalar or vector constraint.
+	// Return false, if the constraint is not a scalar, vector or matrix constraint.
 	return false
 }
@@ -16,7 +16,7 @@ Description:
 Expression
 Description:
 
-	This interface should be implemented by and ScalarExpression and VectorExpression
+	This interface should be implemented by and ScalarExpression, VectorExpression and MatrixExpression
 */
 type Expression interface {
 	// NumVars returns the number of variables in the expression
@@ -80,6 +80,12 @@ func ToExpression(eIn interface{}) (Expression, error) {
 	case ScalarQuadraticExpression:
 		eAsSQE, _ := eIn.(ScalarQuadraticExpression)
 		return eAsSQE, nil
+	case SparseScalarLinearExpr:
+		eAsSSLE, _ := eIn.(SparseScalarLinearExpr)
+		return eAsSSLE, nil
+	case SparseScalarQuadraticExpression:
+		eAsSSQE, _ := eIn.(SparseScalarQuadraticExpression)
+		return eAsSSQE, nil
 	case mat.VecDense:
 		eAsVD, _ := eIn.(mat.VecDense)
 		return ToExpression(KVector(eAsVD))
@@ -92,6 +98,24 @@ func ToExpression(eIn interface{}) (Expression, error) {
 	case VectorLinearExpr:
 		eAsVLE, _ := eIn.(VectorLinearExpr)
 		return eAsVLE, nil
+	case VectorQuadraticExpression:
+		eAsVQE, _ := eIn.(VectorQuadraticExpression)
+		return eAsVQE, nil
+	case SparseVectorLinearExpr:
+		eAsSVLE, _ := eIn.(SparseVectorLinearExpr)
+		return eAsSVLE, nil
+	case mat.Dense:
+		eAsDense, _ := eIn.(mat.Dense)
+		return ToExpression(KMatrix(eAsDense))
+	case KMatrix:
+		eAsKM, _ := eIn.(KMatrix)
+		return eAsKM, nil
+	case VarMatrix:
+		eAsVM, _ := eIn.(VarMatrix)
+		return eAsVM, nil
+	case MatrixLinearExpr:
+		eAsMLE, _ := eIn.(MatrixLinearExpr)
+		return eAsMLE, nil
 	default:
 		return K(-1.0), fmt.Errorf("Unexpected type input to ToExpression(): %T", eIn)
 	}
@@ -109,6 +133,7 @@ func IsExpression(e interface{}) bool {
 	// Checks
 	_, isScalarExpression := e.(ScalarExpression)
 	_, isVectorExpression := e.(VectorExpression)
+	_, isMatrixExpression := e.(MatrixExpression)
 
-	return isScalarExpression || isVectorExpression
+	return isScalarExpression || isVectorExpression || isMatrixExpression
 }
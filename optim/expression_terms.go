@@ -0,0 +1,499 @@
+package optim
+
+/*
+expression_terms.go
+Description:
+	Defines a flat representation of the terms in a scalar expression. The file writers (and
+	readers) use it to work with every kind of scalar expression in the same way.
+*/
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// Type Definitions
+// ================
+
+/*
+LinearTerm
+Description:
+
+	The term Coeff * x_ID.
+*/
+type LinearTerm struct {
+	ID    uint64
+	Coeff float64
+}
+
+/*
+QuadraticTerm
+Description:
+
+	The term Coeff * x_ID1 * x_ID2, where ID1 <= ID2.
+*/
+type QuadraticTerm struct {
+	ID1   uint64
+	ID2   uint64
+	Coeff float64
+}
+
+/*
+SparseTerms
+Description:
+
+	The terms of a scalar expression in coordinate form
+		sum Quadratic[k].Coeff x_ID1 x_ID2 + sum Linear[k].Coeff x_ID + Constant
+	as returned by SparseTermsOf. Each variable (or pair of variables) appears at most once and
+	every coefficient is nonzero.
+*/
+type SparseTerms struct {
+	Linear    []LinearTerm
+	Quadratic []QuadraticTerm
+	Constant  float64
+}
+
+/*
+expressionTerms
+Description:
+
+	The terms of a scalar expression
+		sum Quadratic[k].Coeff x_ID1 x_ID2 + sum Linear[k].Coeff x_ID + Constant
+	Each variable (or pair of variables) appears at most once, in the order in which it first
+	appears in the expression.
+*/
+type expressionTerms struct {
+	Linear    []LinearTerm
+	Quadratic []QuadraticTerm
+	Constant  float64
+
+	linearIndex    map[uint64]int
+	quadraticIndex map[[2]uint64]int
+}
+
+// Functions
+// =========
+
+/*
+termsOf
+Description:
+
+	Collects the terms of the scalar expression se.
+*/
+func termsOf(se ScalarExpression) (expressionTerms, error) {
+	// Constants
+	var et expressionTerms
+
+	// Algorithm
+	switch e := se.(type) {
+	case K:
+		et.Constant = float64(e)
+	case Variable:
+		et.addLinear(e.ID, 1.0)
+	case ScalarLinearExpr:
+		for eltIndex, tempVar := range e.X.Elements {
+			et.addLinear(tempVar.ID, e.L.AtVec(eltIndex))
+		}
+		et.Constant = e.C
+	case SparseScalarLinearExpr:
+		for _, term := range e.Linear {
+			et.addLinear(term.ID, term.Coeff)
+		}
+		et.Constant = e.C
+	case SparseScalarQuadraticExpression:
+		for _, term := range e.Quadratic {
+			et.addQuadratic(term.ID1, term.ID2, term.Coeff)
+		}
+		for _, term := range e.Linear {
+			et.addLinear(term.ID, term.Coeff)
+		}
+		et.Constant = e.C
+	case ScalarQuadraticExpression:
+		for i, vi := range e.X.Elements {
+			for j, vj := range e.X.Elements {
+				et.addQuadratic(vi.ID, vj.ID, e.Q.At(i, j))
+			}
+		}
+		for eltIndex, tempVar := range e.X.Elements {
+			et.addLinear(tempVar.ID, e.L.AtVec(eltIndex))
+		}
+		et.Constant = e.C
+	default:
+		return et, fmt.Errorf("Unexpected type of expression %T", se)
+	}
+
+	return et, nil
+}
+
+/*
+SparseTermsOf
+Description:
+
+	Returns the nonzero terms of the scalar expression se. Solvers use it to read expressions of
+	every type without building dense coefficient matrices; the sparse expressions are read in
+	time proportional to their number of terms.
+*/
+func SparseTermsOf(se ScalarExpression) (SparseTerms, error) {
+	// Input Checking
+	if checker, ok := se.(interface{ Check() error }); ok {
+		if err := checker.Check(); err != nil {
+			return SparseTerms{}, err
+		}
+	}
+
+	// Algorithm
+	et, err := termsOf(se)
+	if err != nil {
+		return SparseTerms{}, err
+	}
+	et = et.WithoutZeros()
+
+	return SparseTerms{Linear: et.Linear, Quadratic: et.Quadratic, Constant: et.Constant}, nil
+}
+
+/*
+addLinear
+Description:
+
+	Adds coeff * x_id to the terms.
+*/
+func (et *expressionTerms) addLinear(id uint64, coeff float64) {
+	if et.linearIndex == nil {
+		et.linearIndex = make(map[uint64]int)
+	}
+	if termIndex, found := et.linearIndex[id]; found {
+		et.Linear[termIndex].Coeff += coeff
+		return
+	}
+	et.linearIndex[id] = len(et.Linear)
+	et.Linear = append(et.Linear, LinearTerm{ID: id, Coeff: coeff})
+}
+
+/*
+addQuadratic
+Description:
+
+	Adds coeff * x_id1 * x_id2 to the terms.
+*/
+func (et *expressionTerms) addQuadratic(id1, id2 uint64, coeff float64) {
+	if id2 < id1 {
+		id1, id2 = id2, id1
+	}
+	if et.quadraticIndex == nil {
+		et.quadraticIndex = make(map[[2]uint64]int)
+	}
+	key := [2]uint64{id1, id2}
+	if termIndex, found := et.quadraticIndex[key]; found {
+		et.Quadratic[termIndex].Coeff += coeff
+		return
+	}
+	et.quadraticIndex[key] = len(et.Quadratic)
+	et.Quadratic = append(et.Quadratic, QuadraticTerm{ID1: id1, ID2: id2, Coeff: coeff})
+}
+
+/*
+Minus
+Description:
+
+	Returns the terms of the difference et - other.
+*/
+func (et expressionTerms) Minus(other expressionTerms) expressionTerms {
+	var diff expressionTerms
+	for _, term := range et.Linear {
+		diff.addLinear(term.ID, term.Coeff)
+	}
+	for _, term := range other.Linear {
+		diff.addLinear(term.ID, -term.Coeff)
+	}
+	for _, term := range et.Quadratic {
+		diff.addQuadratic(term.ID1, term.ID2, term.Coeff)
+	}
+	for _, term := range other.Quadratic {
+		diff.addQuadratic(term.ID1, term.ID2, -term.Coeff)
+	}
+	diff.Constant = et.Constant - other.Constant
+	return diff
+}
+
+/*
+Plus
+Description:
+
+	Returns the terms of the sum et + other.
+*/
+func (et expressionTerms) Plus(other expressionTerms) expressionTerms {
+	var sum expressionTerms
+	for _, terms := range []expressionTerms{et, other} {
+		for _, term := range terms.Linear {
+			sum.addLinear(term.ID, term.Coeff)
+		}
+		for _, term := range terms.Quadratic {
+			sum.addQuadratic(term.ID1, term.ID2, term.Coeff)
+		}
+	}
+	sum.Constant = et.Constant + other.Constant
+	return sum
+}
+
+/*
+Times
+Description:
+
+	Returns the terms of the product et * other. Terms of degree three or more are dropped, so
+	callers must check that Degree() of the two factors adds up to two or less.
+*/
+func (et expressionTerms) Times(other expressionTerms) expressionTerms {
+	var product expressionTerms
+	for _, term := range et.Linear {
+		product.addLinear(term.ID, term.Coeff*other.Constant)
+	}
+	for _, term := range other.Linear {
+		product.addLinear(term.ID, et.Constant*term.Coeff)
+	}
+	for _, term1 := range et.Linear {
+		for _, term2 := range other.Linear {
+			product.addQuadratic(term1.ID, term2.ID, term1.Coeff*term2.Coeff)
+		}
+	}
+	for _, term := range et.Quadratic {
+		product.addQuadratic(term.ID1, term.ID2, term.Coeff*other.Constant)
+	}
+	for _, term := range other.Quadratic {
+		product.addQuadratic(term.ID1, term.ID2, et.Constant*term.Coeff)
+	}
+	product.Constant = et.Constant * other.Constant
+	return product
+}
+
+/*
+Degree
+Description:
+
+	Returns the degree of the terms: 2 if there are quadratic terms, 1 if there are only linear
+	terms and 0 for a constant. Terms with a zero coefficient still count.
+*/
+func (et expressionTerms) Degree() int {
+	switch {
+	case len(et.Quadratic) > 0:
+		return 2
+	case len(et.Linear) > 0:
+		return 1
+	default:
+		return 0
+	}
+}
+
+/*
+WithoutZeros
+Description:
+
+	Returns the terms with all zero coefficients removed.
+*/
+func (et expressionTerms) WithoutZeros() expressionTerms {
+	out := expressionTerms{Constant: et.Constant}
+	for _, term := range et.Linear {
+		if term.Coeff != 0 {
+			out.addLinear(term.ID, term.Coeff)
+		}
+	}
+	for _, term := range et.Quadratic {
+		if term.Coeff != 0 {
+			out.addQuadratic(term.ID1, term.ID2, term.Coeff)
+		}
+	}
+	return out
+}
+
+/*
+EqualsWithin
+Description:
+
+	Returns true if every coefficient (and the constant) of et differs from the matching
+	coefficient of other by at most tol.
+*/
+func (et expressionTerms) EqualsWithin(other expressionTerms, tol float64) bool {
+	diff := et.Minus(other)
+	if math.Abs(diff.Constant) > tol {
+		return false
+	}
+	for _, term := range diff.Linear {
+		if math.Abs(term.Coeff) > tol {
+			return false
+		}
+	}
+	for _, term := range diff.Quadratic {
+		if math.Abs(term.Coeff) > tol {
+			return false
+		}
+	}
+	return true
+}
+
+/*
+sortedVariables
+Description:
+
+	Returns the variables which appear in the terms sorted by ID, and the position of each ID in
+	that order. The variables are taken from pool, which must contain every variable of the terms.
+*/
+func (et expressionTerms) sortedVariables(pool []Variable) (VarVector, map[uint64]int) {
+	// Constants
+	varsByID := make(map[uint64]Variable)
+	for _, tempVar := range pool {
+		varsByID[tempVar.ID] = tempVar
+	}
+
+	// Collect the IDs
+	idSet := make(map[uint64]bool)
+	for _, term := range et.Linear {
+		idSet[term.ID] = true
+	}
+	for _, term := range et.Quadratic {
+		idSet[term.ID1] = true
+		idSet[term.ID2] = true
+	}
+	ids := make([]uint64, 0, len(idSet))
+	for id := range idSet {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	// Algorithm
+	positionOf := make(map[uint64]int)
+	var elements []Variable
+	for position, id := range ids {
+		positionOf[id] = position
+		elements = append(elements, varsByID[id])
+	}
+
+	return VarVector{Elements: elements}, positionOf
+}
+
+/*
+constraintTermsOf
+Description:
+
+	Collects the terms of (LeftHandSide - RightHandSide) for the constraint sc.
+*/
+func constraintTermsOf(sc ScalarConstraint) (expressionTerms, error) {
+	lhs, err := termsOf(sc.LeftHandSide)
+	if err != nil {
+		return lhs, fmt.Errorf("There was an issue reading the left hand side: %v", err)
+	}
+	rhs, err := termsOf(sc.RightHandSide)
+	if err != nil {
+		return rhs, fmt.Errorf("There was an issue reading the right hand side: %v", err)
+	}
+	return lhs.Minus(rhs).WithoutZeros(), nil
+}
+
+/*
+ToScalarExpression
+Description:
+
+	Builds the scalar expression described by the terms. vars maps the ID of each variable of the
+	model to the variable. The result is a K if there are no
+	variables, a ScalarLinearExpr if there are no quadratic terms and a ScalarQuadraticExpression
+	otherwise.
+*/
+func (et expressionTerms) ToScalarExpression(vars map[uint64]Variable) ScalarExpression {
+	// Constants
+	if len(et.Linear)+len(et.Quadratic) == 0 {
+		return K(et.Constant)
+	}
+
+	// Collect the variables in order of appearance
+	positionOf := make(map[uint64]int)
+	var elements []Variable
+	addElement := func(id uint64) {
+		if _, found := positionOf[id]; !found {
+			positionOf[id] = len(elements)
+			elements = append(elements, vars[id])
+		}
+	}
+	for _, term := range et.Linear {
+		addElement(term.ID)
+	}
+	for _, term := range et.Quadratic {
+		addElement(term.ID1)
+		addElement(term.ID2)
+	}
+
+	// Algorithm
+	n := len(elements)
+	L := et.linearCoefficients(positionOf, n)
+
+	if len(et.Quadratic) == 0 {
+		return ScalarLinearExpr{X: VarVector{Elements: elements}, L: *L, C: et.Constant}
+	}
+
+	Q := et.quadraticCoefficients(positionOf, n)
+
+	return ScalarQuadraticExpression{Q: *Q, L: *L, C: et.Constant, X: VarVector{Elements: elements}}
+}
+
+/*
+linearCoefficients
+Description:
+
+	Returns the linear coefficients of the terms with respect to a vector of n variables, where
+	positionOf maps the ID of each variable to its position in the vector.
+*/
+func (et expressionTerms) linearCoefficients(positionOf map[uint64]int, n int) *mat.VecDense {
+	L := mat.NewVecDense(n, nil)
+	for _, term := range et.Linear {
+		L.SetVec(positionOf[term.ID], L.AtVec(positionOf[term.ID])+term.Coeff)
+	}
+	return L
+}
+
+/*
+quadraticCoefficients
+Description:
+
+	Returns the symmetric matrix of quadratic coefficients of the terms with respect to a vector of
+	n variables, where positionOf maps the ID of each variable to its position in the vector.
+*/
+func (et expressionTerms) quadraticCoefficients(positionOf map[uint64]int, n int) *mat.Dense {
+	Q := mat.NewDense(n, n, nil)
+	for _, term := range et.Quadratic {
+		i, j := positionOf[term.ID1], positionOf[term.ID2]
+		if i == j {
+			Q.Set(i, i, Q.At(i, i)+term.Coeff)
+			continue
+		}
+		// Split the cross term evenly so that Q is symmetric.
+		Q.Set(i, j, Q.At(i, j)+term.Coeff/2)
+		Q.Set(j, i, Q.At(j, i)+term.Coeff/2)
+	}
+	return Q
+}
+
+/*
+toSparseScalarExpression
+Description:
+
+	Builds a SparseScalarLinearExpr from the nonzero terms if there are no quadratic terms and a
+	SparseScalarQuadraticExpression otherwise. The variables are taken from pool, which must
+	contain every variable of the terms.
+*/
+func (et expressionTerms) toSparseScalarExpression(pool []Variable) ScalarExpression {
+	// Constants
+	nonzero := et.WithoutZeros()
+	x, _ := nonzero.sortedVariables(pool)
+
+	// Algorithm
+	if len(nonzero.Quadratic) == 0 {
+		return SparseScalarLinearExpr{X: x, Linear: nonzero.Linear, C: nonzero.Constant}
+	}
+
+	return SparseScalarQuadraticExpression{
+		X:         x,
+		Quadratic: nonzero.Quadratic,
+		Linear:    nonzero.Linear,
+		C:         nonzero.Constant,
+	}
+}
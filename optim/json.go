@@ -0,0 +1,1382 @@
+package optim
+
+/*
+json.go
+Description:
+	Defines the JSON encoding of models, expressions and constraints. Every expression and
+	constraint is written as an object with a "kind" field naming its type, so that interface
+	fields (e.g. the sides of a constraint) can be decoded into the right type. Numbers which JSON
+	can not represent (+Inf, -Inf and NaN) are written as the strings "Infinity", "-Infinity" and
+	"NaN". Matrices are written as lists of rows.
+*/
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"time"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// Constants
+// =========
+
+// ModelJSONVersion is the version of the JSON schema written by Model.MarshalJSON.
+const ModelJSONVersion = 1
+
+// Type Definitions
+// ================
+
+/*
+jsonFloat
+Description:
+
+	A float64 which can also hold the values +Inf, -Inf and NaN in JSON.
+*/
+type jsonFloat float64
+
+type jsonKind struct {
+	Kind string `json:"kind"`
+}
+
+type jsonVariable struct {
+	ID    uint64    `json:"id"`
+	Lower jsonFloat `json:"lower"`
+	Upper jsonFloat `json:"upper"`
+	Type  string    `json:"type"`
+	Name  string    `json:"name,omitempty"`
+}
+
+type jsonConstant struct {
+	Kind  string    `json:"kind"`
+	Value jsonFloat `json:"value"`
+}
+
+type jsonVariableExpression struct {
+	Kind     string   `json:"kind"`
+	Variable Variable `json:"variable"`
+}
+
+type jsonScalarLinearExpr struct {
+	Kind string      `json:"kind"`
+	X    []Variable  `json:"x"`
+	L    []jsonFloat `json:"l"`
+	C    jsonFloat   `json:"c"`
+}
+
+type jsonScalarQuadraticExpression struct {
+	Kind string        `json:"kind"`
+	X    []Variable    `json:"x"`
+	Q    [][]jsonFloat `json:"q"`
+	L    []jsonFloat   `json:"l"`
+	C    jsonFloat     `json:"c"`
+}
+
+type jsonVarVector struct {
+	Kind     string     `json:"kind"`
+	Elements []Variable `json:"elements"`
+}
+
+type jsonKVector struct {
+	Kind   string      `json:"kind"`
+	Values []jsonFloat `json:"values"`
+}
+
+type jsonVectorLinearExpr struct {
+	Kind string        `json:"kind"`
+	X    []Variable    `json:"x"`
+	L    [][]jsonFloat `json:"l"`
+	C    []jsonFloat   `json:"c"`
+}
+
+type jsonVectorQuadraticExpression struct {
+	Kind string          `json:"kind"`
+	X    []Variable      `json:"x"`
+	Q    [][][]jsonFloat `json:"q"`
+	L    [][]jsonFloat   `json:"l"`
+	C    []jsonFloat     `json:"c"`
+}
+
+type jsonVarMatrix struct {
+	Kind     string       `json:"kind"`
+	Elements [][]Variable `json:"elements"`
+}
+
+type jsonKMatrix struct {
+	Kind   string        `json:"kind"`
+	Values [][]jsonFloat `json:"values"`
+}
+
+type jsonMatrixLinearExpr struct {
+	Kind string        `json:"kind"`
+	X    []Variable    `json:"x"`
+	L    [][]jsonFloat `json:"l"`
+	C    [][]jsonFloat `json:"c"`
+}
+
+type jsonLinearTerm struct {
+	ID    uint64    `json:"id"`
+	Coeff jsonFloat `json:"coeff"`
+}
+
+type jsonQuadraticTerm struct {
+	ID1   uint64    `json:"id1"`
+	ID2   uint64    `json:"id2"`
+	Coeff jsonFloat `json:"coeff"`
+}
+
+type jsonSparseScalarLinearExpr struct {
+	Kind   string           `json:"kind"`
+	X      []Variable       `json:"x"`
+	Linear []jsonLinearTerm `json:"linear"`
+	C      jsonFloat        `json:"c"`
+}
+
+type jsonSparseScalarQuadraticExpression struct {
+	Kind      string              `json:"kind"`
+	X         []Variable          `json:"x"`
+	Quadratic []jsonQuadraticTerm `json:"quadratic"`
+	Linear    []jsonLinearTerm    `json:"linear"`
+	C         jsonFloat           `json:"c"`
+}
+
+type jsonSparseVectorLinearExpr struct {
+	Kind string             `json:"kind"`
+	X    []Variable         `json:"x"`
+	Rows [][]jsonLinearTerm `json:"rows"`
+	C    []jsonFloat        `json:"c"`
+}
+
+type jsonConstraint struct {
+	Kind          string          `json:"kind"`
+	LeftHandSide  json.RawMessage `json:"lhs"`
+	RightHandSide json.RawMessage `json:"rhs"`
+	Sense         string          `json:"sense"`
+	Name          string          `json:"name,omitempty"`
+}
+
+type jsonObjective struct {
+	Expression json.RawMessage `json:"expression"`
+	Sense      string          `json:"sense"`
+}
+
+/*
+jsonModel
+Description:
+
+	The JSON schema of a Model. TimeLimit is given in nanoseconds.
+*/
+type jsonModel struct {
+	Version         int               `json:"version"`
+	Variables       []Variable        `json:"variables"`
+	Constraints     []json.RawMessage `json:"constraints"`
+	Objective       *Objective        `json:"objective,omitempty"`
+	ShowLog         bool              `json:"showLog"`
+	TimeLimit       int64             `json:"timeLimit"`
+	KeepSolverAlive bool              `json:"keepSolverAlive"`
+}
+
+// Functions
+// =========
+
+/*
+MarshalJSON
+Description:
+
+	Writes finite values as JSON numbers and infinite or NaN values as strings.
+*/
+func (f jsonFloat) MarshalJSON() ([]byte, error) {
+	value := float64(f)
+	switch {
+	case math.IsInf(value, 1):
+		return []byte(`"Infinity"`), nil
+	case math.IsInf(value, -1):
+		return []byte(`"-Infinity"`), nil
+	case math.IsNaN(value):
+		return []byte(`"NaN"`), nil
+	}
+	return strconv.AppendFloat(nil, value, 'g', -1, 64), nil
+}
+
+/*
+UnmarshalJSON
+Description:
+
+	Reads a JSON number or one of the strings "Infinity", "-Infinity" and "NaN".
+*/
+func (f *jsonFloat) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		switch text {
+		case "Infinity":
+			*f = jsonFloat(math.Inf(1))
+		case "-Infinity":
+			*f = jsonFloat(math.Inf(-1))
+		case "NaN":
+			*f = jsonFloat(math.NaN())
+		default:
+			return fmt.Errorf("Unexpected number %q", text)
+		}
+		return nil
+	}
+
+	var value float64
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	*f = jsonFloat(value)
+	return nil
+}
+
+/*
+fromJSONFloats
+Description:
+
+	Converts a slice of jsonFloat to a slice of float64.
+*/
+func fromJSONFloats(values []jsonFloat) []float64 {
+	out := make([]float64, len(values))
+	for index, value := range values {
+		out[index] = float64(value)
+	}
+	return out
+}
+
+/*
+vecToJSON
+Description:
+
+	Converts a vector to a slice of jsonFloat. The zero value of mat.VecDense becomes an empty
+	slice.
+*/
+func vecToJSON(v *mat.VecDense) []jsonFloat {
+	out := make([]jsonFloat, v.Len())
+	for index := range out {
+		out[index] = jsonFloat(v.AtVec(index))
+	}
+	return out
+}
+
+/*
+vecFromJSON
+Description:
+
+	Converts a slice of jsonFloat to a vector. An empty slice becomes the zero value of
+	mat.VecDense (which mat.NewVecDense can not create).
+*/
+func vecFromJSON(values []jsonFloat) mat.VecDense {
+	if len(values) == 0 {
+		return mat.VecDense{}
+	}
+	return *mat.NewVecDense(len(values), fromJSONFloats(values))
+}
+
+/*
+denseToJSON
+Description:
+
+	Converts a matrix to a list of rows.
+*/
+func denseToJSON(M *mat.Dense) [][]jsonFloat {
+	if M.IsEmpty() {
+		return [][]jsonFloat{}
+	}
+	nRows, nCols := M.Dims()
+	out := make([][]jsonFloat, nRows)
+	for rowIndex := range out {
+		out[rowIndex] = make([]jsonFloat, nCols)
+		for colIndex := range out[rowIndex] {
+			out[rowIndex][colIndex] = jsonFloat(M.At(rowIndex, colIndex))
+		}
+	}
+	return out
+}
+
+/*
+denseFromJSON
+Description:
+
+	Converts a list of rows to a matrix. Every row must have the same length.
+*/
+func denseFromJSON(rows [][]jsonFloat) (mat.Dense, error) {
+	if len(rows) == 0 || len(rows[0]) == 0 {
+		return mat.Dense{}, nil
+	}
+
+	nCols := len(rows[0])
+	data := make([]float64, 0, len(rows)*nCols)
+	for rowIndex, row := range rows {
+		if len(row) != nCols {
+			return mat.Dense{}, fmt.Errorf("Row %v of the matrix has length %v; expected %v", rowIndex, len(row), nCols)
+		}
+		data = append(data, fromJSONFloats(row)...)
+	}
+	return *mat.NewDense(len(rows), nCols, data), nil
+}
+
+/*
+MarshalJSON
+Description:
+
+	Writes the variable's ID, bounds, type and (if it has one) name.
+*/
+func (v Variable) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonVariable{
+		ID:    v.ID,
+		Lower: jsonFloat(v.Lower),
+		Upper: jsonFloat(v.Upper),
+		Type:  string(rune(v.Vtype)),
+		Name:  v.Name,
+	})
+}
+
+/*
+UnmarshalJSON
+Description:
+
+	Reads a variable written by MarshalJSON.
+*/
+func (v *Variable) UnmarshalJSON(data []byte) error {
+	var jv jsonVariable
+	if err := json.Unmarshal(data, &jv); err != nil {
+		return err
+	}
+
+	if len(jv.Type) != 1 {
+		return fmt.Errorf("Unexpected variable type %q", jv.Type)
+	}
+	switch VarType(jv.Type[0]) {
+	case Continuous, Binary, Integer:
+	default:
+		return fmt.Errorf("Unexpected variable type %q", jv.Type)
+	}
+
+	*v = Variable{ID: jv.ID, Lower: float64(jv.Lower), Upper: float64(jv.Upper), Vtype: VarType(jv.Type[0]), Name: jv.Name}
+	return nil
+}
+
+/*
+MarshalJSON
+Description:
+
+	Writes the constant as {"kind": "K", "value": c}.
+*/
+func (c K) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonConstant{Kind: "K", Value: jsonFloat(c)})
+}
+
+/*
+UnmarshalJSON
+Description:
+
+	Reads a constant written by MarshalJSON.
+*/
+func (c *K) UnmarshalJSON(data []byte) error {
+	var jc jsonConstant
+	if err := unmarshalKind(data, "K", &jc); err != nil {
+		return err
+	}
+	*c = K(jc.Value)
+	return nil
+}
+
+/*
+MarshalJSON
+Description:
+
+	Writes the linear expression as {"kind": "ScalarLinearExpr", "x": ..., "l": ..., "c": ...}.
+*/
+func (sle ScalarLinearExpr) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonScalarLinearExpr{
+		Kind: "ScalarLinearExpr",
+		X:    sle.X.Elements,
+		L:    vecToJSON(&sle.L),
+		C:    jsonFloat(sle.C),
+	})
+}
+
+/*
+UnmarshalJSON
+Description:
+
+	Reads a linear expression written by MarshalJSON.
+*/
+func (sle *ScalarLinearExpr) UnmarshalJSON(data []byte) error {
+	var jsle jsonScalarLinearExpr
+	if err := unmarshalKind(data, "ScalarLinearExpr", &jsle); err != nil {
+		return err
+	}
+	if len(jsle.X) != len(jsle.L) {
+		return fmt.Errorf("The expression has %v variables but %v coefficients", len(jsle.X), len(jsle.L))
+	}
+
+	*sle = ScalarLinearExpr{X: VarVector{Elements: jsle.X}, L: vecFromJSON(jsle.L), C: float64(jsle.C)}
+	return nil
+}
+
+/*
+MarshalJSON
+Description:
+
+	Writes the quadratic expression as
+		{"kind": "ScalarQuadraticExpression", "x": ..., "q": ..., "l": ..., "c": ...}
+	where q is the list of the rows of Q.
+*/
+func (qe ScalarQuadraticExpression) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonScalarQuadraticExpression{
+		Kind: "ScalarQuadraticExpression",
+		X:    qe.X.Elements,
+		Q:    denseToJSON(&qe.Q),
+		L:    vecToJSON(&qe.L),
+		C:    jsonFloat(qe.C),
+	})
+}
+
+/*
+UnmarshalJSON
+Description:
+
+	Reads a quadratic expression written by MarshalJSON.
+*/
+func (qe *ScalarQuadraticExpression) UnmarshalJSON(data []byte) error {
+	var jqe jsonScalarQuadraticExpression
+	if err := unmarshalKind(data, "ScalarQuadraticExpression", &jqe); err != nil {
+		return err
+	}
+
+	Q, err := denseFromJSON(jqe.Q)
+	if err != nil {
+		return err
+	}
+
+	*qe = ScalarQuadraticExpression{Q: Q, L: vecFromJSON(jqe.L), C: float64(jqe.C), X: VarVector{Elements: jqe.X}}
+	return qe.Check()
+}
+
+/*
+MarshalJSON
+Description:
+
+	Writes the vector of variables as {"kind": "VarVector", "elements": ...}.
+*/
+func (vv VarVector) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonVarVector{Kind: "VarVector", Elements: vv.Elements})
+}
+
+/*
+UnmarshalJSON
+Description:
+
+	Reads a vector of variables written by MarshalJSON.
+*/
+func (vv *VarVector) UnmarshalJSON(data []byte) error {
+	var jvv jsonVarVector
+	if err := unmarshalKind(data, "VarVector", &jvv); err != nil {
+		return err
+	}
+	*vv = VarVector{Elements: jvv.Elements}
+	return nil
+}
+
+/*
+MarshalJSON
+Description:
+
+	Writes the constant vector as {"kind": "KVector", "values": ...}.
+*/
+func (kv KVector) MarshalJSON() ([]byte, error) {
+	kvAsVector := mat.VecDense(kv)
+	return json.Marshal(jsonKVector{Kind: "KVector", Values: vecToJSON(&kvAsVector)})
+}
+
+/*
+UnmarshalJSON
+Description:
+
+	Reads a constant vector written by MarshalJSON.
+*/
+func (kv *KVector) UnmarshalJSON(data []byte) error {
+	var jkv jsonKVector
+	if err := unmarshalKind(data, "KVector", &jkv); err != nil {
+		return err
+	}
+	*kv = KVector(vecFromJSON(jkv.Values))
+	return nil
+}
+
+/*
+MarshalJSON
+Description:
+
+	Writes the vector linear expression as
+		{"kind": "VectorLinearExpr", "x": ..., "l": ..., "c": ...}
+	where l is the list of the rows of L.
+*/
+func (vle VectorLinearExpr) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonVectorLinearExpr{
+		Kind: "VectorLinearExpr",
+		X:    vle.X.Elements,
+		L:    denseToJSON(&vle.L),
+		C:    vecToJSON(&vle.C),
+	})
+}
+
+/*
+UnmarshalJSON
+Description:
+
+	Reads a vector linear expression written by MarshalJSON.
+*/
+func (vle *VectorLinearExpr) UnmarshalJSON(data []byte) error {
+	var jvle jsonVectorLinearExpr
+	if err := unmarshalKind(data, "VectorLinearExpr", &jvle); err != nil {
+		return err
+	}
+
+	L, err := denseFromJSON(jvle.L)
+	if err != nil {
+		return err
+	}
+
+	*vle = VectorLinearExpr{X: VarVector{Elements: jvle.X}, L: L, C: vecFromJSON(jvle.C)}
+	return vle.Check()
+}
+
+/*
+MarshalJSON
+Description:
+
+	Writes the vector quadratic expression as
+		{"kind": "VectorQuadraticExpression", "x": ..., "q": ..., "l": ..., "c": ...}
+	where q is the list of the quadratic terms (each a list of rows) and l is the list of the
+	rows of L.
+*/
+func (vqe VectorQuadraticExpression) MarshalJSON() ([]byte, error) {
+	Q := make([][][]jsonFloat, len(vqe.Q))
+	for qIndex := range vqe.Q {
+		Q[qIndex] = denseToJSON(&vqe.Q[qIndex])
+	}
+
+	return json.Marshal(jsonVectorQuadraticExpression{
+		Kind: "VectorQuadraticExpression",
+		X:    vqe.X.Elements,
+		Q:    Q,
+		L:    denseToJSON(&vqe.L),
+		C:    vecToJSON(&vqe.C),
+	})
+}
+
+/*
+UnmarshalJSON
+Description:
+
+	Reads a vector quadratic expression written by MarshalJSON.
+*/
+func (vqe *VectorQuadraticExpression) UnmarshalJSON(data []byte) error {
+	var jvqe jsonVectorQuadraticExpression
+	if err := unmarshalKind(data, "VectorQuadraticExpression", &jvqe); err != nil {
+		return err
+	}
+
+	Q := make([]mat.Dense, len(jvqe.Q))
+	for qIndex, jsonQ := range jvqe.Q {
+		tempQ, err := denseFromJSON(jsonQ)
+		if err != nil {
+			return err
+		}
+		Q[qIndex] = tempQ
+	}
+	L, err := denseFromJSON(jvqe.L)
+	if err != nil {
+		return err
+	}
+
+	*vqe = VectorQuadraticExpression{Q: Q, L: L, C: vecFromJSON(jvqe.C), X: VarVector{Elements: jvqe.X}}
+	return vqe.Check()
+}
+
+/*
+MarshalJSON
+Description:
+
+	Writes the matrix of variables as {"kind": "VarMatrix", "elements": ...}, where elements is the
+	list of the rows of the matrix.
+*/
+func (vm VarMatrix) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonVarMatrix{Kind: "VarMatrix", Elements: vm.Elements})
+}
+
+/*
+UnmarshalJSON
+Description:
+
+	Reads a matrix of variables written by MarshalJSON.
+*/
+func (vm *VarMatrix) UnmarshalJSON(data []byte) error {
+	var jvm jsonVarMatrix
+	if err := unmarshalKind(data, "VarMatrix", &jvm); err != nil {
+		return err
+	}
+	*vm = VarMatrix{Elements: jvm.Elements}
+	return vm.Check()
+}
+
+/*
+MarshalJSON
+Description:
+
+	Writes the constant matrix as {"kind": "KMatrix", "values": ...}, where values is the list of
+	the rows of the matrix.
+*/
+func (km KMatrix) MarshalJSON() ([]byte, error) {
+	kmAsDense := mat.Dense(km)
+	return json.Marshal(jsonKMatrix{Kind: "KMatrix", Values: denseToJSON(&kmAsDense)})
+}
+
+/*
+UnmarshalJSON
+Description:
+
+	Reads a constant matrix written by MarshalJSON.
+*/
+func (km *KMatrix) UnmarshalJSON(data []byte) error {
+	var jkm jsonKMatrix
+	if err := unmarshalKind(data, "KMatrix", &jkm); err != nil {
+		return err
+	}
+
+	values, err := denseFromJSON(jkm.Values)
+	if err != nil {
+		return err
+	}
+
+	*km = KMatrix(values)
+	return nil
+}
+
+/*
+MarshalJSON
+Description:
+
+	Writes the matrix linear expression as
+		{"kind": "MatrixLinearExpr", "x": ..., "l": ..., "c": ...}
+	where l and c are the lists of the rows of L and C.
+*/
+func (mle MatrixLinearExpr) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonMatrixLinearExpr{
+		Kind: "MatrixLinearExpr",
+		X:    mle.X.Elements,
+		L:    denseToJSON(&mle.L),
+		C:    denseToJSON(&mle.C),
+	})
+}
+
+/*
+UnmarshalJSON
+Description:
+
+	Reads a matrix linear expression written by MarshalJSON.
+*/
+func (mle *MatrixLinearExpr) UnmarshalJSON(data []byte) error {
+	var jmle jsonMatrixLinearExpr
+	if err := unmarshalKind(data, "MatrixLinearExpr", &jmle); err != nil {
+		return err
+	}
+
+	L, err := denseFromJSON(jmle.L)
+	if err != nil {
+		return err
+	}
+	C, err := denseFromJSON(jmle.C)
+	if err != nil {
+		return err
+	}
+
+	*mle = MatrixLinearExpr{X: VarVector{Elements: jmle.X}, L: L, C: C}
+	return mle.Check()
+}
+
+/*
+linearTermsToJSON
+Description:
+
+	Converts linear terms to their JSON representation.
+*/
+func linearTermsToJSON(terms []LinearTerm) []jsonLinearTerm {
+	termsOut := make([]jsonLinearTerm, len(terms))
+	for termIndex, term := range terms {
+		termsOut[termIndex] = jsonLinearTerm{ID: term.ID, Coeff: jsonFloat(term.Coeff)}
+	}
+	return termsOut
+}
+
+/*
+linearTermsFromJSON
+Description:
+
+	Converts the JSON representation of linear terms back to linear terms.
+*/
+func linearTermsFromJSON(terms []jsonLinearTerm) []LinearTerm {
+	if len(terms) == 0 {
+		return nil
+	}
+	termsOut := make([]LinearTerm, len(terms))
+	for termIndex, term := range terms {
+		termsOut[termIndex] = LinearTerm{ID: term.ID, Coeff: float64(term.Coeff)}
+	}
+	return termsOut
+}
+
+/*
+MarshalJSON
+Description:
+
+	Writes the sparse linear expression as
+		{"kind": "SparseScalarLinearExpr", "x": ..., "linear": [{"id": ..., "coeff": ...}], "c": ...}
+*/
+func (sle SparseScalarLinearExpr) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonSparseScalarLinearExpr{
+		Kind:   "SparseScalarLinearExpr",
+		X:      sle.X.Elements,
+		Linear: linearTermsToJSON(sle.Linear),
+		C:      jsonFloat(sle.C),
+	})
+}
+
+/*
+UnmarshalJSON
+Description:
+
+	Reads a sparse linear expression written by MarshalJSON.
+*/
+func (sle *SparseScalarLinearExpr) UnmarshalJSON(data []byte) error {
+	var jsle jsonSparseScalarLinearExpr
+	if err := unmarshalKind(data, "SparseScalarLinearExpr", &jsle); err != nil {
+		return err
+	}
+
+	*sle = SparseScalarLinearExpr{
+		X:      VarVector{Elements: jsle.X},
+		Linear: linearTermsFromJSON(jsle.Linear),
+		C:      float64(jsle.C),
+	}
+	return sle.Check()
+}
+
+/*
+MarshalJSON
+Description:
+
+	Writes the sparse quadratic expression as
+		{"kind": "SparseScalarQuadraticExpression", "x": ...,
+			"quadratic": [{"id1": ..., "id2": ..., "coeff": ...}], "linear": ..., "c": ...}
+*/
+func (qe SparseScalarQuadraticExpression) MarshalJSON() ([]byte, error) {
+	jqe := jsonSparseScalarQuadraticExpression{
+		Kind:      "SparseScalarQuadraticExpression",
+		X:         qe.X.Elements,
+		Quadratic: make([]jsonQuadraticTerm, len(qe.Quadratic)),
+		Linear:    linearTermsToJSON(qe.Linear),
+		C:         jsonFloat(qe.C),
+	}
+	for termIndex, term := range qe.Quadratic {
+		jqe.Quadratic[termIndex] = jsonQuadraticTerm{ID1: term.ID1, ID2: term.ID2, Coeff: jsonFloat(term.Coeff)}
+	}
+
+	return json.Marshal(jqe)
+}
+
+/*
+UnmarshalJSON
+Description:
+
+	Reads a sparse quadratic expression written by MarshalJSON.
+*/
+func (qe *SparseScalarQuadraticExpression) UnmarshalJSON(data []byte) error {
+	var jqe jsonSparseScalarQuadraticExpression
+	if err := unmarshalKind(data, "SparseScalarQuadraticExpression", &jqe); err != nil {
+		return err
+	}
+
+	*qe = SparseScalarQuadraticExpression{
+		X:      VarVector{Elements: jqe.X},
+		Linear: linearTermsFromJSON(jqe.Linear),
+		C:      float64(jqe.C),
+	}
+	for _, term := range jqe.Quadratic {
+		qe.Quadratic = append(qe.Quadratic, QuadraticTerm{ID1: term.ID1, ID2: term.ID2, Coeff: float64(term.Coeff)})
+	}
+	return qe.Check()
+}
+
+/*
+MarshalJSON
+Description:
+
+	Writes the sparse vector linear expression as
+		{"kind": "SparseVectorLinearExpr", "x": ..., "rows": ..., "c": ...}
+	where rows holds the list of linear terms of each element.
+*/
+func (svle SparseVectorLinearExpr) MarshalJSON() ([]byte, error) {
+	jsvle := jsonSparseVectorLinearExpr{
+		Kind: "SparseVectorLinearExpr",
+		X:    svle.X.Elements,
+		Rows: make([][]jsonLinearTerm, len(svle.Rows)),
+		C:    vecToJSON(&svle.C),
+	}
+	for rowIndex, row := range svle.Rows {
+		jsvle.Rows[rowIndex] = linearTermsToJSON(row)
+	}
+
+	return json.Marshal(jsvle)
+}
+
+/*
+UnmarshalJSON
+Description:
+
+	Reads a sparse vector linear expression written by MarshalJSON.
+*/
+func (svle *SparseVectorLinearExpr) UnmarshalJSON(data []byte) error {
+	var jsvle jsonSparseVectorLinearExpr
+	if err := unmarshalKind(data, "SparseVectorLinearExpr", &jsvle); err != nil {
+		return err
+	}
+
+	*svle = SparseVectorLinearExpr{
+		X:    VarVector{Elements: jsvle.X},
+		Rows: make([][]LinearTerm, len(jsvle.Rows)),
+		C:    vecFromJSON(jsvle.C),
+	}
+	for rowIndex, row := range jsvle.Rows {
+		svle.Rows[rowIndex] = linearTermsFromJSON(row)
+	}
+	return svle.Check()
+}
+
+/*
+unmarshalKind
+Description:
+
+	Unmarshals data into out after checking that its "kind" field is the expected one.
+*/
+func unmarshalKind(data []byte, expectedKind string, out interface{}) error {
+	var jk jsonKind
+	if err := json.Unmarshal(data, &jk); err != nil {
+		return err
+	}
+	if jk.Kind != expectedKind {
+		return fmt.Errorf("Expected an object of kind %v; received kind %q", expectedKind, jk.Kind)
+	}
+	return json.Unmarshal(data, out)
+}
+
+/*
+unmarshalScalarExpression
+Description:
+
+	Reads any scalar expression, using its "kind" field to decide its type.
+*/
+func unmarshalScalarExpression(data []byte) (ScalarExpression, error) {
+	var jk jsonKind
+	if err := json.Unmarshal(data, &jk); err != nil {
+		return nil, err
+	}
+
+	switch jk.Kind {
+	case "K":
+		var c K
+		err := json.Unmarshal(data, &c)
+		return c, err
+	case "Variable":
+		var jve jsonVariableExpression
+		err := json.Unmarshal(data, &jve)
+		return jve.Variable, err
+	case "ScalarLinearExpr":
+		var sle ScalarLinearExpr
+		err := json.Unmarshal(data, &sle)
+		return sle, err
+	case "ScalarQuadraticExpression":
+		var qe ScalarQuadraticExpression
+		err := json.Unmarshal(data, &qe)
+		return qe, err
+	case "SparseScalarLinearExpr":
+		var sle SparseScalarLinearExpr
+		err := json.Unmarshal(data, &sle)
+		return sle, err
+	case "SparseScalarQuadraticExpression":
+		var qe SparseScalarQuadraticExpression
+		err := json.Unmarshal(data, &qe)
+		return qe, err
+	}
+	return nil, fmt.Errorf("Unexpected kind of scalar expression %q", jk.Kind)
+}
+
+/*
+unmarshalVectorExpression
+Description:
+
+	Reads any vector expression, using its "kind" field to decide its type.
+*/
+func unmarshalVectorExpression(data []byte) (VectorExpression, error) {
+	var jk jsonKind
+	if err := json.Unmarshal(data, &jk); err != nil {
+		return nil, err
+	}
+
+	switch jk.Kind {
+	case "VarVector":
+		var vv VarVector
+		err := json.Unmarshal(data, &vv)
+		return vv, err
+	case "KVector":
+		var kv KVector
+		err := json.Unmarshal(data, &kv)
+		return kv, err
+	case "VectorLinearExpr":
+		var vle VectorLinearExpr
+		err := json.Unmarshal(data, &vle)
+		return vle, err
+	case "VectorQuadraticExpression":
+		var vqe VectorQuadraticExpression
+		err := json.Unmarshal(data, &vqe)
+		return vqe, err
+	case "SparseVectorLinearExpr":
+		var svle SparseVectorLinearExpr
+		err := json.Unmarshal(data, &svle)
+		return svle, err
+	}
+	return nil, fmt.Errorf("Unexpected kind of vector expression %q", jk.Kind)
+}
+
+/*
+unmarshalMatrixExpression
+Description:
+
+	Reads any matrix expression, using its "kind" field to decide its type.
+*/
+func unmarshalMatrixExpression(data []byte) (MatrixExpression, error) {
+	var jk jsonKind
+	if err := json.Unmarshal(data, &jk); err != nil {
+		return nil, err
+	}
+
+	switch jk.Kind {
+	case "VarMatrix":
+		var vm VarMatrix
+		err := json.Unmarshal(data, &vm)
+		return vm, err
+	case "KMatrix":
+		var km KMatrix
+		err := json.Unmarshal(data, &km)
+		return km, err
+	case "MatrixLinearExpr":
+		var mle MatrixLinearExpr
+		err := json.Unmarshal(data, &mle)
+		return mle, err
+	}
+	return nil, fmt.Errorf("Unexpected kind of matrix expression %q", jk.Kind)
+}
+
+/*
+unmarshalConstraint
+Description:
+
+	Reads a scalar, vector or matrix constraint, choosing the type from its kind.
+*/
+func unmarshalConstraint(data []byte) (Constraint, error) {
+	var jk jsonKind
+	if err := json.Unmarshal(data, &jk); err != nil {
+		return nil, err
+	}
+
+	switch jk.Kind {
+	case "ScalarConstraint":
+		var sc ScalarConstraint
+		err := json.Unmarshal(data, &sc)
+		return sc, err
+	case "VectorConstraint":
+		var vc VectorConstraint
+		if err := json.Unmarshal(data, &vc); err != nil {
+			return nil, err
+		}
+		return vc, vc.Check()
+	case "MatrixConstraint":
+		var mc MatrixConstraint
+		if err := json.Unmarshal(data, &mc); err != nil {
+			return nil, err
+		}
+		return mc, mc.Check()
+	}
+	return nil, fmt.Errorf("Unexpected kind of constraint %q", jk.Kind)
+}
+
+/*
+marshalScalarExpression
+Description:
+
+	Writes a scalar expression. A Variable used as an expression is wrapped as
+	{"kind": "Variable", "variable": ...}, since a plain variable has no kind.
+*/
+func marshalScalarExpression(se ScalarExpression) (json.RawMessage, error) {
+	switch e := se.(type) {
+	case Variable:
+		return json.Marshal(jsonVariableExpression{Kind: "Variable", Variable: e})
+	case K, ScalarLinearExpr, ScalarQuadraticExpression, SparseScalarLinearExpr, SparseScalarQuadraticExpression:
+		return json.Marshal(e)
+	}
+	return nil, fmt.Errorf("Unexpected type of scalar expression %T", se)
+}
+
+/*
+constraintSenseToJSON
+Description:
+
+	Converts a constraint sense to one of the strings "<=", ">=" and "=".
+*/
+func constraintSenseToJSON(sense ConstrSense) (string, error) {
+	return lpSenseString(sense)
+}
+
+/*
+constraintSenseFromJSON
+Description:
+
+	Converts one of the strings "<=", ">=" and "=" to a constraint sense.
+*/
+func constraintSenseFromJSON(text string) (ConstrSense, error) {
+	switch text {
+	case "<=":
+		return SenseLessThanEqual, nil
+	case ">=":
+		return SenseGreaterThanEqual, nil
+	case "=":
+		return SenseEqual, nil
+	}
+	return SenseEqual, fmt.Errorf("Unexpected constraint sense %q", text)
+}
+
+/*
+MarshalJSON
+Description:
+
+	Writes the constraint as {"kind": "ScalarConstraint", "lhs": ..., "rhs": ..., "sense": ...}, with
+	an optional "name".
+*/
+func (sc ScalarConstraint) MarshalJSON() ([]byte, error) {
+	lhs, err := marshalScalarExpression(sc.LeftHandSide)
+	if err != nil {
+		return nil, fmt.Errorf("There was an issue writing the left hand side: %v", err)
+	}
+	rhs, err := marshalScalarExpression(sc.RightHandSide)
+	if err != nil {
+		return nil, fmt.Errorf("There was an issue writing the right hand side: %v", err)
+	}
+	sense, err := constraintSenseToJSON(sc.Sense)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(jsonConstraint{Kind: "ScalarConstraint", LeftHandSide: lhs, RightHandSide: rhs, Sense: sense, Name: sc.Name})
+}
+
+/*
+UnmarshalJSON
+Description:
+
+	Reads a constraint written by MarshalJSON.
+*/
+func (sc *ScalarConstraint) UnmarshalJSON(data []byte) error {
+	var jc jsonConstraint
+	if err := unmarshalKind(data, "ScalarConstraint", &jc); err != nil {
+		return err
+	}
+
+	lhs, err := unmarshalScalarExpression(jc.LeftHandSide)
+	if err != nil {
+		return fmt.Errorf("There was an issue reading the left hand side: %v", err)
+	}
+	rhs, err := unmarshalScalarExpression(jc.RightHandSide)
+	if err != nil {
+		return fmt.Errorf("There was an issue reading the right hand side: %v", err)
+	}
+	sense, err := constraintSenseFromJSON(jc.Sense)
+	if err != nil {
+		return err
+	}
+
+	*sc = ScalarConstraint{LeftHandSide: lhs, RightHandSide: rhs, Sense: sense, Name: jc.Name}
+	return nil
+}
+
+/*
+MarshalJSON
+Description:
+
+	Writes the constraint as {"kind": "VectorConstraint", "lhs": ..., "rhs": ..., "sense": ...}, with
+	an optional "name".
+*/
+func (vc VectorConstraint) MarshalJSON() ([]byte, error) {
+	lhs, err := json.Marshal(vc.LeftHandSide)
+	if err != nil {
+		return nil, fmt.Errorf("There was an issue writing the left hand side: %v", err)
+	}
+	rhs, err := json.Marshal(vc.RightHandSide)
+	if err != nil {
+		return nil, fmt.Errorf("There was an issue writing the right hand side: %v", err)
+	}
+	sense, err := constraintSenseToJSON(vc.Sense)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(jsonConstraint{Kind: "VectorConstraint", LeftHandSide: lhs, RightHandSide: rhs, Sense: sense, Name: vc.Name})
+}
+
+/*
+UnmarshalJSON
+Description:
+
+	Reads a constraint written by MarshalJSON.
+*/
+func (vc *VectorConstraint) UnmarshalJSON(data []byte) error {
+	var jc jsonConstraint
+	if err := unmarshalKind(data, "VectorConstraint", &jc); err != nil {
+		return err
+	}
+
+	lhs, err := unmarshalVectorExpression(jc.LeftHandSide)
+	if err != nil {
+		return fmt.Errorf("There was an issue reading the left hand side: %v", err)
+	}
+	rhs, err := unmarshalVectorExpression(jc.RightHandSide)
+	if err != nil {
+		return fmt.Errorf("There was an issue reading the right hand side: %v", err)
+	}
+	sense, err := constraintSenseFromJSON(jc.Sense)
+	if err != nil {
+		return err
+	}
+
+	*vc = VectorConstraint{LeftHandSide: lhs, RightHandSide: rhs, Sense: sense, Name: jc.Name}
+	return nil
+}
+
+/*
+MarshalJSON
+Description:
+
+	Writes the constraint as {"kind": "MatrixConstraint", "lhs": ..., "rhs": ..., "sense": ...}, with
+	an optional "name".
+*/
+func (mc MatrixConstraint) MarshalJSON() ([]byte, error) {
+	lhs, err := json.Marshal(mc.LeftHandSide)
+	if err != nil {
+		return nil, fmt.Errorf("There was an issue writing the left hand side: %v", err)
+	}
+	rhs, err := json.Marshal(mc.RightHandSide)
+	if err != nil {
+		return nil, fmt.Errorf("There was an issue writing the right hand side: %v", err)
+	}
+	sense, err := constraintSenseToJSON(mc.Sense)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(jsonConstraint{Kind: "MatrixConstraint", LeftHandSide: lhs, RightHandSide: rhs, Sense: sense, Name: mc.Name})
+}
+
+/*
+UnmarshalJSON
+Description:
+
+	Reads a constraint written by MarshalJSON.
+*/
+func (mc *MatrixConstraint) UnmarshalJSON(data []byte) error {
+	var jc jsonConstraint
+	if err := unmarshalKind(data, "MatrixConstraint", &jc); err != nil {
+		return err
+	}
+
+	lhs, err := unmarshalMatrixExpression(jc.LeftHandSide)
+	if err != nil {
+		return fmt.Errorf("There was an issue reading the left hand side: %v", err)
+	}
+	rhs, err := unmarshalMatrixExpression(jc.RightHandSide)
+	if err != nil {
+		return fmt.Errorf("There was an issue reading the right hand side: %v", err)
+	}
+	sense, err := constraintSenseFromJSON(jc.Sense)
+	if err != nil {
+		return err
+	}
+
+	*mc = MatrixConstraint{LeftHandSide: lhs, RightHandSide: rhs, Sense: sense, Name: jc.Name}
+	return nil
+}
+
+/*
+MarshalJSON
+Description:
+
+	Writes the objective as {"expression": ..., "sense": "minimize" or "maximize"}.
+*/
+func (o Objective) MarshalJSON() ([]byte, error) {
+	expression, err := marshalScalarExpression(o.ScalarExpression)
+	if err != nil {
+		return nil, fmt.Errorf("There was an issue writing the objective: %v", err)
+	}
+
+	sense := "minimize"
+	switch o.Sense {
+	case SenseMinimize:
+	case SenseMaximize:
+		sense = "maximize"
+	default:
+		return nil, fmt.Errorf("Unexpected objective sense %v", o.Sense)
+	}
+
+	return json.Marshal(jsonObjective{Expression: expression, Sense: sense})
+}
+
+/*
+UnmarshalJSON
+Description:
+
+	Reads an objective written by MarshalJSON.
+*/
+func (o *Objective) UnmarshalJSON(data []byte) error {
+	var jo jsonObjective
+	if err := json.Unmarshal(data, &jo); err != nil {
+		return err
+	}
+
+	expression, err := unmarshalScalarExpression(jo.Expression)
+	if err != nil {
+		return fmt.Errorf("There was an issue reading the objective: %v", err)
+	}
+
+	switch jo.Sense {
+	case "minimize":
+		*o = Objective{expression, SenseMinimize}
+	case "maximize":
+		*o = Objective{expression, SenseMaximize}
+	default:
+		return fmt.Errorf("Unexpected objective sense %q", jo.Sense)
+	}
+	return nil
+}
+
+/*
+MarshalJSON
+Description:
+
+	Writes the whole model (variables, constraints, objective and settings) using version
+	ModelJSONVersion of the schema.
+*/
+func (m *Model) MarshalJSON() ([]byte, error) {
+	// Constants
+	jm := jsonModel{
+		Version:         ModelJSONVersion,
+		Variables:       m.Variables,
+		Constraints:     make([]json.RawMessage, len(m.constrs)),
+		Objective:       m.obj,
+		ShowLog:         m.showLog,
+		TimeLimit:       int64(m.timeLimit),
+		KeepSolverAlive: m.keepSolverAlive,
+	}
+	if jm.Variables == nil {
+		jm.Variables = []Variable{}
+	}
+
+	// Algorithm
+	for constrIndex, constr := range m.constrs {
+		constrJSON, err := json.Marshal(constr)
+		if err != nil {
+			return nil, fmt.Errorf("There was an issue writing constraint %v: %v", constrIndex, err)
+		}
+		jm.Constraints[constrIndex] = constrJSON
+	}
+
+	return json.Marshal(jm)
+}
+
+/*
+UnmarshalJSON
+Description:
+
+	Reads a model written by MarshalJSON, replacing the contents of m.
+*/
+func (m *Model) UnmarshalJSON(data []byte) error {
+	// Input Processing
+	var jm jsonModel
+	if err := json.Unmarshal(data, &jm); err != nil {
+		return err
+	}
+	if jm.Version != ModelJSONVersion {
+		return fmt.Errorf("Unsupported model JSON version %v (expected %v)", jm.Version, ModelJSONVersion)
+	}
+
+	// Algorithm
+	*m = Model{
+		Variables:       jm.Variables,
+		obj:             jm.Objective,
+		showLog:         jm.ShowLog,
+		timeLimit:       time.Duration(jm.TimeLimit),
+		keepSolverAlive: jm.KeepSolverAlive,
+	}
+
+	for constrIndex, constrJSON := range jm.Constraints {
+		constr, err := unmarshalConstraint(constrJSON)
+		if err != nil {
+			return fmt.Errorf("There was an issue reading constraint %v: %v", constrIndex, err)
+		}
+		m.appendConstr(constr)
+	}
+
+	// New variables are numbered after the largest ID
+	for _, tempVar := range m.Variables {
+		if tempVar.ID >= m.nextVarID {
+			m.nextVarID = tempVar.ID + 1
+		}
+		if tempVar.Name != "" {
+			m.namedVariables() // Indexes the names, as AddNamedVariable does
+		}
+	}
+
+	return nil
+}
+
+/*
+WriteJSON
+Description:
+
+	Writes the model to w as JSON (see MarshalJSON).
+*/
+func (m *Model) WriteJSON(w io.Writer) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("There was an issue converting the model to JSON: %v", err)
+	}
+	if _, err := w.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("There was an issue writing the model: %v", err)
+	}
+	return nil
+}
+
+/*
+ReadJSON
+Description:
+
+	Reads a model written by WriteJSON (or MarshalJSON) from r.
+*/
+func ReadJSON(r io.Reader) (*Model, error) {
+	m := NewModel()
+	if err := json.NewDecoder(r).Decode(m); err != nil {
+		return nil, fmt.Errorf("There was an issue reading the model: %v", err)
+	}
+	return m, nil
+}
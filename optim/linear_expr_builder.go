@@ -0,0 +1,191 @@
+package optim
+
+/*
+linear_expr_builder.go
+Description:
+	Defines LinearExprBuilder, which accumulates a linear expression in place. Each term is added
+	in constant time (variables are indexed by ID in a map), so large sums such as objectives with
+	tens of thousands of terms build in linear time instead of copying the expression on every
+	Plus.
+*/
+
+import (
+	"fmt"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// Type Definitions
+// ================
+
+/*
+LinearExprBuilder
+Description:
+
+	Accumulates the linear expression
+		sum_i coeffs[i] vars[i] + constant
+	Each variable is stored once, in the order in which it was first added. The zero value is an
+	empty builder ready to use.
+
+Usage:
+
+	var b optim.LinearExprBuilder
+	for i, x := range xs {
+		b.AddTerm(c[i], x)
+	}
+	objective := b.Build()
+*/
+type LinearExprBuilder struct {
+	vars       []Variable
+	coeffs     []float64
+	positionOf map[uint64]int
+	constant   float64
+}
+
+// Functions
+// =========
+
+/*
+NewLinearExprBuilder
+Description:
+
+	Returns an empty builder with room for capacity variables.
+*/
+func NewLinearExprBuilder(capacity int) *LinearExprBuilder {
+	return &LinearExprBuilder{
+		vars:       make([]Variable, 0, capacity),
+		coeffs:     make([]float64, 0, capacity),
+		positionOf: make(map[uint64]int, capacity),
+	}
+}
+
+// Member Functions
+// ================
+
+/*
+AddTerm
+Description:
+
+	Adds coeff * v to the expression. If v was already added, then coeff is added to its
+	coefficient.
+*/
+func (b *LinearExprBuilder) AddTerm(coeff float64, v Variable) {
+	if b.positionOf == nil {
+		b.positionOf = make(map[uint64]int)
+	}
+
+	if position, found := b.positionOf[v.ID]; found {
+		b.coeffs[position] += coeff
+		return
+	}
+	b.positionOf[v.ID] = len(b.vars)
+	b.vars = append(b.vars, v)
+	b.coeffs = append(b.coeffs, coeff)
+}
+
+/*
+AddConstant
+Description:
+
+	Adds c to the constant of the expression.
+*/
+func (b *LinearExprBuilder) AddConstant(c float64) {
+	b.constant += c
+}
+
+/*
+Add
+Description:
+
+	Adds the linear expression e (a K, Variable, ScalarLinearExpr or SparseScalarLinearExpr) to
+	the expression in time proportional to its number of terms. Quadratic expressions return an
+	error.
+*/
+func (b *LinearExprBuilder) Add(e ScalarExpression) error {
+	switch eIn := e.(type) {
+	case K:
+		b.AddConstant(float64(eIn))
+	case Variable:
+		b.AddTerm(1.0, eIn)
+	case ScalarLinearExpr:
+		if eIn.X.Len() != eIn.L.Len() {
+			return fmt.Errorf("The linear expression has %v variables but %v coefficients.", eIn.X.Len(), eIn.L.Len())
+		}
+		for eltIndex, tempVar := range eIn.X.Elements {
+			b.AddTerm(eIn.L.AtVec(eltIndex), tempVar)
+		}
+		b.AddConstant(eIn.C)
+	case SparseScalarLinearExpr:
+		positionOf, err := sparsePositions(eIn.X)
+		if err != nil {
+			return err
+		}
+		if err := checkLinearTerms(eIn.Linear, positionOf); err != nil {
+			return err
+		}
+		for _, term := range eIn.Linear {
+			b.AddTerm(term.Coeff, eIn.X.Elements[positionOf[term.ID]])
+		}
+		b.AddConstant(eIn.C)
+	default:
+		return fmt.Errorf("LinearExprBuilder can not add the expression of type %T; only linear expressions are supported.", e)
+	}
+
+	return nil
+}
+
+/*
+NumVars
+Description:
+
+	Returns the number of distinct variables added so far.
+*/
+func (b *LinearExprBuilder) NumVars() int {
+	return len(b.vars)
+}
+
+/*
+Build
+Description:
+
+	Returns the ScalarLinearExpr accumulated so far. The expression does not share data with the
+	builder, so the builder can keep being used afterwards.
+*/
+func (b *LinearExprBuilder) Build() ScalarLinearExpr {
+	sleOut := ScalarLinearExpr{C: b.constant}
+	if len(b.vars) > 0 {
+		sleOut.X = VarVector{Elements: append([]Variable(nil), b.vars...)}
+		sleOut.L = *mat.NewVecDense(len(b.coeffs), append([]float64(nil), b.coeffs...))
+	}
+	return sleOut
+}
+
+/*
+BuildSparse
+Description:
+
+	Returns the SparseScalarLinearExpr accumulated so far. Only the nonzero terms are kept, but
+	X contains every variable which was added.
+*/
+func (b *LinearExprBuilder) BuildSparse() SparseScalarLinearExpr {
+	sleOut := SparseScalarLinearExpr{C: b.constant}
+	if len(b.vars) > 0 {
+		sleOut.X = VarVector{Elements: append([]Variable(nil), b.vars...)}
+	}
+	for position, coeff := range b.coeffs {
+		if coeff != 0 {
+			sleOut.Linear = append(sleOut.Linear, LinearTerm{ID: b.vars[position].ID, Coeff: coeff})
+		}
+	}
+	return sleOut
+}
+
+/*
+Reset
+Description:
+
+	Empties the builder so that it can accumulate a new expression.
+*/
+func (b *LinearExprBuilder) Reset() {
+	*b = LinearExprBuilder{}
+}
@@ -0,0 +1,856 @@
+package optim
+
+/*
+lp_reader.go
+Description:
+	Defines the functions that read a model from a file in the CPLEX LP format. The reader
+	supports the objective (with quadratic terms), linear and quadratic constraints, the Bounds
+	section and the General and Binary sections.
+*/
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/kwesiRutledge/gurobi.go/gurobi"
+)
+
+// Type Definitions
+// ================
+
+/*
+ParseError
+Description:
+
+	An error found while reading a model file. Line and Column start at 1.
+*/
+type ParseError struct {
+	Line   int
+	Column int
+	Msg    string
+}
+
+type lpTokenKind int
+
+const (
+	lpTokenEOF lpTokenKind = iota
+	lpTokenNumber
+	lpTokenName
+	lpTokenSymbol // One of + - * ^ [ ] / :
+	lpTokenSense  // One of <=, >= or =
+)
+
+/*
+lpToken
+Description:
+
+	A token of an LP file. FirstOnLine is true if the token is the first one on its line; section
+	keywords are only recognized at the start of a line.
+*/
+type lpToken struct {
+	Kind        lpTokenKind
+	Text        string
+	Value       float64
+	Line        int
+	Column      int
+	FirstOnLine bool
+}
+
+type lpSection int
+
+const (
+	lpSectionNone lpSection = iota
+	lpSectionMinimize
+	lpSectionMaximize
+	lpSectionConstraints
+	lpSectionBounds
+	lpSectionGeneral
+	lpSectionBinary
+	lpSectionUnsupported
+	lpSectionEnd
+)
+
+// lpSectionKeywords maps the (lower case) keywords which start a section to the section.
+// "subject" and "such" only start a section when they are followed by "to" and "that".
+var lpSectionKeywords = map[string]lpSection{
+	"minimize": lpSectionMinimize, "minimise": lpSectionMinimize, "minimum": lpSectionMinimize, "min": lpSectionMinimize,
+	"maximize": lpSectionMaximize, "maximise": lpSectionMaximize, "maximum": lpSectionMaximize, "max": lpSectionMaximize,
+	"st": lpSectionConstraints, "s.t.": lpSectionConstraints, "st.": lpSectionConstraints,
+	"subject": lpSectionConstraints, "such": lpSectionConstraints,
+	"bounds": lpSectionBounds, "bound": lpSectionBounds,
+	"general": lpSectionGeneral, "generals": lpSectionGeneral, "gen": lpSectionGeneral,
+	"binary": lpSectionBinary, "binaries": lpSectionBinary, "bin": lpSectionBinary,
+	"semi-continuous": lpSectionUnsupported, "semi": lpSectionUnsupported, "semis": lpSectionUnsupported,
+	"sos": lpSectionUnsupported,
+	"end": lpSectionEnd,
+}
+
+/*
+lpConstraint
+Description:
+
+	A constraint read from an LP file, with its left hand side stored as terms of the variable
+	indices.
+*/
+type lpConstraint struct {
+	Name  string
+	Terms expressionTerms
+	Sense ConstrSense
+	RHS   float64
+}
+
+/*
+lpParser
+Description:
+
+	Holds the tokens of an LP file and everything read from them so far. Variables are numbered in
+	the order in which they first appear in the file.
+*/
+type lpParser struct {
+	tokens []lpToken
+	pos    int
+
+	varNames []string
+	varIndex map[string]int
+	lower    []float64
+	upper    []float64
+	vtypes   []VarType
+
+	objSense    ObjSense
+	objTerms    expressionTerms
+	hasObj      bool
+	constraints []lpConstraint
+}
+
+// Functions
+// =========
+
+/*
+Error
+Description:
+
+	Describes the parse error and where it was found.
+*/
+func (pe ParseError) Error() string {
+	return fmt.Sprintf("line %v, column %v: %v", pe.Line, pe.Column, pe.Msg)
+}
+
+/*
+ReadLP
+Description:
+
+	Reads a model written in the CPLEX LP format from r. Returns the model and a map from the names
+	used in the file to the variables of the model. The variables and the labelled constraints
+	keep the names used in the file. Errors in the file are reported as a ParseError with the line
+	and column where they were found.
+*/
+func ReadLP(r io.Reader) (*Model, map[string]Variable, error) {
+	// Input Processing
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("There was an issue reading the LP file: %v", err)
+	}
+
+	tokens, err := tokenizeLP(string(content))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Algorithm
+	p := &lpParser{tokens: tokens, varIndex: make(map[string]int), objSense: SenseMinimize}
+	if err := p.parse(); err != nil {
+		return nil, nil, err
+	}
+
+	// Build the model
+	m := NewModel()
+	vars := make(map[string]Variable)
+	for varIndex, name := range p.varNames {
+		newVar, err := m.AddNamedVariable(name, p.lower[varIndex], p.upper[varIndex], p.vtypes[varIndex])
+		if err != nil {
+			return nil, nil, fmt.Errorf("There was an issue creating the variable %v: %v", name, err)
+		}
+		vars[name] = newVar
+	}
+	varsByID := m.variablesByID()
+
+	if p.hasObj {
+		if err := m.SetObjective(p.objTerms.ToScalarExpression(varsByID), p.objSense); err != nil {
+			return nil, nil, fmt.Errorf("There was an issue setting the objective: %v", err)
+		}
+	}
+
+	for constrIndex, constr := range p.constraints {
+		_, err := m.AddConstr(ScalarConstraint{
+			LeftHandSide:  constr.Terms.ToScalarExpression(varsByID),
+			RightHandSide: K(constr.RHS),
+			Sense:         constr.Sense,
+			Name:          constr.Name,
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("There was an issue adding constraint %v: %v", constrIndex, err)
+		}
+	}
+
+	return m, vars, nil
+}
+
+/*
+tokenizeLP
+Description:
+
+	Splits the content of an LP file into tokens. Comments start with a backslash and run until
+	the end of the line.
+*/
+func tokenizeLP(content string) ([]lpToken, error) {
+	// Constants
+	var tokens []lpToken
+
+	// Algorithm
+	for lineIndex, line := range strings.Split(content, "\n") {
+		firstOnLine := true
+		for col := 0; col < len(line); {
+			ch := line[col]
+			tok := lpToken{Line: lineIndex + 1, Column: col + 1, FirstOnLine: firstOnLine}
+
+			switch {
+			case ch == '\\':
+				col = len(line)
+				continue
+			case ch == ' ' || ch == '\t' || ch == '\r':
+				col++
+				continue
+			case isLPDigit(ch) || (ch == '.' && col+1 < len(line) && isLPDigit(line[col+1])):
+				end := lpNumberEnd(line, col)
+				value, err := strconv.ParseFloat(line[col:end], 64)
+				if err != nil {
+					return nil, ParseError{tok.Line, tok.Column, fmt.Sprintf("invalid number %q", line[col:end])}
+				}
+				tok.Kind, tok.Text, tok.Value = lpTokenNumber, line[col:end], value
+				col = end
+			case ch == '<' || ch == '>' || ch == '=':
+				end := col + 1
+				if end < len(line) && (line[end] == '=' || (ch == '=' && (line[end] == '<' || line[end] == '>'))) {
+					end++
+				}
+				tok.Kind, tok.Text = lpTokenSense, line[col:end]
+				col = end
+			case strings.IndexByte("+-*^[]/:", ch) >= 0:
+				tok.Kind, tok.Text = lpTokenSymbol, string(ch)
+				col++
+			case isLPNameStart(ch):
+				end := col + 1
+				for end < len(line) && (isLPNameStart(line[end]) || isLPDigit(line[end]) || line[end] == '.') {
+					end++
+				}
+				end = lpIndexSuffixEnd(line, end)
+				tok.Kind, tok.Text = lpTokenName, line[col:end]
+				col = end
+			default:
+				return nil, ParseError{tok.Line, tok.Column, fmt.Sprintf("unexpected character %q", ch)}
+			}
+
+			tokens = append(tokens, tok)
+			firstOnLine = false
+		}
+	}
+
+	lastLine := strings.Count(content, "\n") + 1
+	tokens = append(tokens, lpToken{Kind: lpTokenEOF, Line: lastLine, Column: 1, FirstOnLine: true})
+	return tokens, nil
+}
+
+/*
+lpIndexSuffixEnd
+Description:
+
+	Returns the index just past the end of the index suffixes (e.g. [2] or [0,1]) which directly
+	follow a name ending at line[start], so that names like x[0,1] are read as one token. A bracket
+	which is not part of a suffix (e.g. the start of a quadratic block) is left alone.
+*/
+func lpIndexSuffixEnd(line string, start int) int {
+	end := start
+	for end < len(line) && line[end] == '[' {
+		close := end + 1
+		for close < len(line) && (isLPNameStart(line[close]) || isLPDigit(line[close]) || line[close] == '.') {
+			close++
+		}
+		if close == end+1 || close >= len(line) || line[close] != ']' {
+			break
+		}
+		end = close + 1
+	}
+	return end
+}
+
+func isLPDigit(ch byte) bool {
+	return ch >= '0' && ch <= '9'
+}
+
+func isLPNameStart(ch byte) bool {
+	return (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z') || strings.IndexByte("_!\"#$%&(),;?@'{}|~", ch) >= 0
+}
+
+/*
+lpNumberEnd
+Description:
+
+	Returns the index just past the end of the number starting at line[start]. An exponent is only
+	part of the number if it contains at least one digit, so that 2e is read as 2 times e.
+*/
+func lpNumberEnd(line string, start int) int {
+	end := start
+	for end < len(line) && (isLPDigit(line[end]) || line[end] == '.') {
+		end++
+	}
+	if end < len(line) && (line[end] == 'e' || line[end] == 'E') {
+		expEnd := end + 1
+		if expEnd < len(line) && (line[expEnd] == '+' || line[expEnd] == '-') {
+			expEnd++
+		}
+		if expEnd < len(line) && isLPDigit(line[expEnd]) {
+			for expEnd < len(line) && isLPDigit(line[expEnd]) {
+				expEnd++
+			}
+			end = expEnd
+		}
+	}
+	return end
+}
+
+/*
+peek
+Description:
+
+	Returns the next token without consuming it.
+*/
+func (p *lpParser) peek() lpToken {
+	return p.tokens[p.pos]
+}
+
+/*
+next
+Description:
+
+	Consumes and returns the next token.
+*/
+func (p *lpParser) next() lpToken {
+	tok := p.tokens[p.pos]
+	if tok.Kind != lpTokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+/*
+errorAt
+Description:
+
+	Creates a ParseError located at the token tok.
+*/
+func (p *lpParser) errorAt(tok lpToken, format string, args ...interface{}) error {
+	return ParseError{Line: tok.Line, Column: tok.Column, Msg: fmt.Sprintf(format, args...)}
+}
+
+/*
+describe
+Description:
+
+	Describes a token for error messages.
+*/
+func (tok lpToken) describe() string {
+	if tok.Kind == lpTokenEOF {
+		return "end of file"
+	}
+	return fmt.Sprintf("%q", tok.Text)
+}
+
+/*
+sectionAt
+Description:
+
+	Determines whether or not a section keyword starts at the next token. Returns the section and
+	the number of tokens in the keyword (e.g. 2 for "Subject To").
+*/
+func (p *lpParser) sectionAt() (lpSection, int) {
+	// Constants
+	tok := p.peek()
+	if tok.Kind == lpTokenEOF {
+		return lpSectionEnd, 0
+	}
+	if tok.Kind != lpTokenName || !tok.FirstOnLine {
+		return lpSectionNone, 0
+	}
+
+	// Algorithm
+	word := strings.ToLower(tok.Text)
+	section, isKeyword := lpSectionKeywords[word]
+	if !isKeyword {
+		return lpSectionNone, 0
+	}
+	if word == "subject" || word == "such" {
+		second := p.tokens[p.pos+1]
+		if second.Kind == lpTokenName && !second.FirstOnLine {
+			secondWord := strings.ToLower(second.Text)
+			if (word == "subject" && secondWord == "to") || (word == "such" && secondWord == "that") {
+				return lpSectionConstraints, 2
+			}
+		}
+		return lpSectionNone, 0
+	}
+	return section, 1
+}
+
+/*
+parse
+Description:
+
+	Reads every section of the file.
+*/
+func (p *lpParser) parse() error {
+	for {
+		section, keywordLength := p.sectionAt()
+		keyword := p.peek()
+		p.pos += keywordLength
+
+		var err error
+		switch section {
+		case lpSectionEnd:
+			return nil
+		case lpSectionNone:
+			return p.errorAt(keyword, "expected a section keyword (e.g. Minimize or Subject To), found %v", keyword.describe())
+		case lpSectionUnsupported:
+			return p.errorAt(keyword, "the section %q is not supported", keyword.Text)
+		case lpSectionMinimize, lpSectionMaximize:
+			err = p.parseObjective(section)
+		case lpSectionConstraints:
+			err = p.parseConstraints()
+		case lpSectionBounds:
+			err = p.parseBounds()
+		case lpSectionGeneral, lpSectionBinary:
+			err = p.parseVarTypes(section)
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+/*
+variable
+Description:
+
+	Returns the index of the variable with the given name, creating it (with the default bounds
+	0 <= x < +inf) if it has not appeared before.
+*/
+func (p *lpParser) variable(name string) int {
+	if varIndex, found := p.varIndex[name]; found {
+		return varIndex
+	}
+	p.varIndex[name] = len(p.varNames)
+	p.varNames = append(p.varNames, name)
+	p.lower = append(p.lower, 0.0)
+	p.upper = append(p.upper, gurobi.INFINITY)
+	p.vtypes = append(p.vtypes, Continuous)
+	return len(p.varNames) - 1
+}
+
+/*
+atLabel
+Description:
+
+	Determines whether or not the next tokens are a label (name followed by a colon).
+*/
+func (p *lpParser) atLabel() bool {
+	return p.peek().Kind == lpTokenName && p.tokens[p.pos+1].Kind == lpTokenSymbol && p.tokens[p.pos+1].Text == ":"
+}
+
+/*
+skipLabel
+Description:
+
+	Consumes a label if there is one.
+*/
+func (p *lpParser) skipLabel() {
+	if p.atLabel() {
+		p.pos += 2
+	}
+}
+
+/*
+atVariable
+Description:
+
+	Determines whether or not the next token is a variable name (and not a section keyword or
+	the label of the next constraint).
+*/
+func (p *lpParser) atVariable() bool {
+	if section, _ := p.sectionAt(); section != lpSectionNone {
+		return false
+	}
+	return p.peek().Kind == lpTokenName && !p.atLabel()
+}
+
+/*
+isSymbol
+Description:
+
+	Determines whether or not the token is the given symbol.
+*/
+func (tok lpToken) isSymbol(symbol string) bool {
+	return tok.Kind == lpTokenSymbol && tok.Text == symbol
+}
+
+/*
+parseObjective
+Description:
+
+	Reads the objective, which has an optional label.
+*/
+func (p *lpParser) parseObjective(section lpSection) error {
+	p.hasObj = true
+	p.objSense = SenseMinimize
+	if section == lpSectionMaximize {
+		p.objSense = SenseMaximize
+	}
+
+	p.skipLabel()
+	terms, err := p.parseExpression(true)
+	if err != nil {
+		return err
+	}
+	p.objTerms = terms
+
+	if section, _ := p.sectionAt(); section == lpSectionNone {
+		return p.errorAt(p.peek(), "unexpected %v in the objective", p.peek().describe())
+	}
+	return nil
+}
+
+/*
+parseConstraints
+Description:
+
+	Reads constraints of the form
+		[label:] expression sense rhs
+	until the next section.
+*/
+func (p *lpParser) parseConstraints() error {
+	for {
+		if section, _ := p.sectionAt(); section != lpSectionNone {
+			return nil
+		}
+
+		name := ""
+		if p.atLabel() {
+			name = p.peek().Text
+		}
+		p.skipLabel()
+		start := p.peek()
+		terms, err := p.parseExpression(false)
+		if err != nil {
+			return err
+		}
+		if len(terms.Linear)+len(terms.Quadratic) == 0 {
+			return p.errorAt(start, "expected a constraint, found %v", start.describe())
+		}
+
+		senseTok := p.next()
+		if senseTok.Kind != lpTokenSense {
+			return p.errorAt(senseTok, "expected <=, >= or = in the constraint, found %v", senseTok.describe())
+		}
+
+		rhs, err := p.parseSignedNumber()
+		if err != nil {
+			return err
+		}
+
+		p.constraints = append(p.constraints, lpConstraint{Name: name, Terms: terms, Sense: lpSenseOf(senseTok.Text), RHS: rhs})
+	}
+}
+
+/*
+lpSenseOf
+Description:
+
+	Converts the text of a sense token to a ConstrSense.
+*/
+func lpSenseOf(text string) ConstrSense {
+	switch text {
+	case "<", "<=", "=<":
+		return SenseLessThanEqual
+	case ">", ">=", "=>":
+		return SenseGreaterThanEqual
+	}
+	return SenseEqual
+}
+
+/*
+parseExpression
+Description:
+
+	Reads a sum of terms. Each term is a constant, a (possibly scaled) variable or a block of
+	quadratic terms in brackets. Quadratic blocks in the objective must be followed by / 2.
+	The terms are stored with the variable indices as IDs.
+*/
+func (p *lpParser) parseExpression(isObjective bool) (expressionTerms, error) {
+	// Constants
+	var terms expressionTerms
+
+	// Algorithm
+	for first := true; ; first = false {
+		sign := 1.0
+		signTok := p.peek()
+		hasSign := signTok.isSymbol("+") || signTok.isSymbol("-")
+		if hasSign {
+			p.next()
+			if signTok.Text == "-" {
+				sign = -1.0
+			}
+		} else if !first {
+			return terms, nil
+		}
+
+		tok := p.peek()
+		switch {
+		case tok.isSymbol("["):
+			if err := p.parseQuadraticBlock(&terms, sign, isObjective); err != nil {
+				return terms, err
+			}
+		case tok.Kind == lpTokenNumber:
+			p.next()
+			if p.atVariable() {
+				terms.addLinear(uint64(p.variable(p.next().Text)), sign*tok.Value)
+			} else {
+				terms.Constant += sign * tok.Value
+			}
+		case p.atVariable():
+			p.next()
+			terms.addLinear(uint64(p.variable(tok.Text)), sign)
+		default:
+			if hasSign {
+				return terms, p.errorAt(tok, "expected a term after %q, found %v", signTok.Text, tok.describe())
+			}
+			return terms, nil
+		}
+	}
+}
+
+/*
+parseQuadraticBlock
+Description:
+
+	Reads a block of quadratic terms
+		[ a x ^ 2 + b x * y ... ]
+	and adds it (multiplied by sign) to terms. In the objective, the block must be followed by / 2.
+*/
+func (p *lpParser) parseQuadraticBlock(terms *expressionTerms, sign float64, isObjective bool) error {
+	// Constants
+	open := p.next()
+	var blockTerms expressionTerms
+
+	// Algorithm
+	for first := true; ; first = false {
+		termSign := 1.0
+		tok := p.peek()
+		if tok.isSymbol("]") && !first {
+			p.next()
+			break
+		}
+		if tok.isSymbol("+") || tok.isSymbol("-") {
+			p.next()
+			if tok.Text == "-" {
+				termSign = -1.0
+			}
+		} else if !first {
+			return p.errorAt(tok, "expected + or - between quadratic terms, found %v", tok.describe())
+		}
+
+		coeff := 1.0
+		if p.peek().Kind == lpTokenNumber {
+			coeff = p.next().Value
+		}
+
+		name1 := p.next()
+		if name1.Kind != lpTokenName {
+			return p.errorAt(name1, "expected a variable in the quadratic term, found %v", name1.describe())
+		}
+
+		operator := p.next()
+		switch {
+		case operator.isSymbol("^"):
+			power := p.next()
+			if power.Kind != lpTokenNumber || power.Value != 2 {
+				return p.errorAt(power, "expected the exponent 2, found %v", power.describe())
+			}
+			blockTerms.addQuadratic(uint64(p.variable(name1.Text)), uint64(p.variable(name1.Text)), termSign*coeff)
+		case operator.isSymbol("*"):
+			name2 := p.next()
+			if name2.Kind != lpTokenName {
+				return p.errorAt(name2, "expected a variable after *, found %v", name2.describe())
+			}
+			blockTerms.addQuadratic(uint64(p.variable(name1.Text)), uint64(p.variable(name2.Text)), termSign*coeff)
+		default:
+			return p.errorAt(operator, "expected ^ or * in the quadratic term, found %v", operator.describe())
+		}
+	}
+
+	// The objective block is written as [ ... ] / 2
+	scale := sign
+	if p.peek().isSymbol("/") {
+		slash := p.next()
+		divisor := p.next()
+		if !isObjective || divisor.Kind != lpTokenNumber || divisor.Value != 2 {
+			return p.errorAt(slash, "unexpected division of the quadratic terms")
+		}
+		scale /= 2
+	} else if isObjective {
+		return p.errorAt(open, "the quadratic terms of the objective must be followed by / 2")
+	}
+
+	for _, term := range blockTerms.Quadratic {
+		terms.addQuadratic(term.ID1, term.ID2, scale*term.Coeff)
+	}
+	return nil
+}
+
+/*
+parseSignedNumber
+Description:
+
+	Reads a number with an optional sign. The names inf and infinity are read as infinite values.
+*/
+func (p *lpParser) parseSignedNumber() (float64, error) {
+	sign := 1.0
+	if tok := p.peek(); tok.isSymbol("+") || tok.isSymbol("-") {
+		p.next()
+		if tok.Text == "-" {
+			sign = -1.0
+		}
+	}
+
+	tok := p.next()
+	switch {
+	case tok.Kind == lpTokenNumber:
+		return sign * tok.Value, nil
+	case tok.Kind == lpTokenName && isLPInfinity(tok.Text):
+		return sign * gurobi.INFINITY, nil
+	}
+	return 0, p.errorAt(tok, "expected a number, found %v", tok.describe())
+}
+
+func isLPInfinity(text string) bool {
+	lower := strings.ToLower(text)
+	return lower == "inf" || lower == "infinity"
+}
+
+/*
+parseBounds
+Description:
+
+	Reads bounds of the forms
+		x free
+		x sense value
+		value sense x [sense value]
+	until the next section.
+*/
+func (p *lpParser) parseBounds() error {
+	for {
+		if section, _ := p.sectionAt(); section != lpSectionNone {
+			return nil
+		}
+
+		tok := p.peek()
+		if tok.Kind == lpTokenName && !isLPInfinity(tok.Text) {
+			// x free or x sense value
+			varIndex := p.variable(p.next().Text)
+			senseTok := p.next()
+			if senseTok.Kind == lpTokenName && strings.ToLower(senseTok.Text) == "free" {
+				p.lower[varIndex], p.upper[varIndex] = -gurobi.INFINITY, gurobi.INFINITY
+				continue
+			}
+			if senseTok.Kind != lpTokenSense {
+				return p.errorAt(senseTok, "expected a sense or \"free\" in the bound, found %v", senseTok.describe())
+			}
+			value, err := p.parseSignedNumber()
+			if err != nil {
+				return err
+			}
+			p.setBound(varIndex, lpSenseOf(senseTok.Text), value)
+			continue
+		}
+
+		// value sense x [sense value]
+		value, err := p.parseSignedNumber()
+		if err != nil {
+			return err
+		}
+		senseTok := p.next()
+		if senseTok.Kind != lpTokenSense {
+			return p.errorAt(senseTok, "expected <=, >= or = in the bound, found %v", senseTok.describe())
+		}
+		nameTok := p.next()
+		if nameTok.Kind != lpTokenName {
+			return p.errorAt(nameTok, "expected a variable in the bound, found %v", nameTok.describe())
+		}
+		varIndex := p.variable(nameTok.Text)
+
+		// value <= x is the same as x >= value
+		p.setBound(varIndex, lpSenseOf(senseTok.Text).Reverse(), value)
+
+		if p.peek().Kind == lpTokenSense {
+			secondSense := p.next()
+			secondValue, err := p.parseSignedNumber()
+			if err != nil {
+				return err
+			}
+			p.setBound(varIndex, lpSenseOf(secondSense.Text), secondValue)
+		}
+	}
+}
+
+/*
+setBound
+Description:
+
+	Applies the bound x_varIndex (sense) value.
+*/
+func (p *lpParser) setBound(varIndex int, sense ConstrSense, value float64) {
+	switch sense {
+	case SenseLessThanEqual:
+		p.upper[varIndex] = value
+	case SenseGreaterThanEqual:
+		p.lower[varIndex] = value
+	case SenseEqual:
+		p.lower[varIndex], p.upper[varIndex] = value, value
+	}
+}
+
+/*
+parseVarTypes
+Description:
+
+	Reads the names in a General or Binary section. Binary variables get the bounds [0, 1].
+*/
+func (p *lpParser) parseVarTypes(section lpSection) error {
+	for {
+		if section, _ := p.sectionAt(); section != lpSectionNone {
+			return nil
+		}
+
+		tok := p.next()
+		if tok.Kind != lpTokenName {
+			return p.errorAt(tok, "expected a variable name, found %v", tok.describe())
+		}
+
+		varIndex := p.variable(tok.Text)
+		if section == lpSectionBinary {
+			p.vtypes[varIndex] = Binary
+			p.lower[varIndex], p.upper[varIndex] = 0, 1
+		} else {
+			p.vtypes[varIndex] = Integer
+		}
+	}
+}
@@ -0,0 +1,411 @@
+package optim
+
+/*
+lp_writer.go
+Description:
+	Defines the functions that write a Model to a file in the CPLEX LP format. Variables and
+	constraints keep their names; unnamed variables are named x<ID> and unnamed constraints are
+	named c<index> (using the order in which they were added to the model), so the names are
+	stable across runs.
+*/
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Constants
+// =========
+
+const (
+	lpMaxLineLength = 80 // Lines are wrapped once they get longer than this.
+)
+
+// Type Definitions
+// ================
+
+/*
+lpLineWriter
+Description:
+
+	Writes space separated tokens to a writer, starting a new (indented) line whenever the current
+	line gets too long. LP files should not contain lines longer than 255 characters.
+*/
+type lpLineWriter struct {
+	w          *bufio.Writer
+	lineLength int
+	names      map[uint64]string // The names of the variables
+}
+
+/*
+fileNames
+Description:
+
+	The names of the variables (by ID) and of the scalar constraints (in the order given by
+	Model.scalarConstraints) used when a model is written to a file.
+*/
+type fileNames struct {
+	Variables   map[uint64]string
+	Constraints []string
+}
+
+// Functions
+// =========
+
+/*
+WriteLP
+Description:
+
+	Writes the model to w in the CPLEX LP format. The file contains the objective (including
+	quadratic terms, written in the [ ... ] / 2 syntax), every constraint, the bounds of every
+	variable and the General and Binary sections for integer and binary variables.
+*/
+func (m *Model) WriteLP(w io.Writer) error {
+	// Input Checking
+	names, err := m.fileNames(lpCheckName)
+	if err != nil {
+		return err
+	}
+
+	// Constants
+	lw := &lpLineWriter{w: bufio.NewWriter(w), names: names.Variables}
+
+	// Algorithm
+	lw.writeLine("\\ Model written by goop2")
+
+	// Objective
+	objTerms, sense := expressionTerms{}, SenseMinimize
+	if m.obj != nil {
+		objTerms, err = termsOf(m.obj.ScalarExpression)
+		if err != nil {
+			return fmt.Errorf("There was an issue writing the objective: %v", err)
+		}
+		objTerms = objTerms.WithoutZeros()
+		sense = m.obj.Sense
+	}
+
+	if sense == SenseMaximize {
+		lw.writeLine("Maximize")
+	} else {
+		lw.writeLine("Minimize")
+	}
+	lw.writeToken(" obj:")
+	lw.writeTerms(objTerms, true)
+	if objTerms.Constant != 0 {
+		lw.writeToken(lpSignedNumber(objTerms.Constant, len(objTerms.Linear)+len(objTerms.Quadratic) == 0))
+	}
+	lw.endLine()
+
+	// Constraints
+	lw.writeLine("Subject To")
+	for constrIndex, constr := range m.scalarConstraints() {
+		terms, err := constraintTermsOf(constr)
+		if err != nil {
+			return fmt.Errorf("There was an issue writing constraint %v: %v", constrIndex, err)
+		}
+
+		lw.writeToken(" " + names.Constraints[constrIndex] + ":")
+		if len(terms.Linear)+len(terms.Quadratic) == 0 && len(m.Variables) > 0 {
+			// The LP format requires at least one variable in each constraint.
+			lw.writeToken("0 " + names.Variables[m.Variables[0].ID])
+		}
+		lw.writeTerms(terms, false)
+
+		senseString, err := lpSenseString(constr.Sense)
+		if err != nil {
+			return fmt.Errorf("There was an issue writing constraint %v: %v", constrIndex, err)
+		}
+		lw.writeToken(senseString)
+		lw.writeToken(lpNumber(-terms.Constant))
+		lw.endLine()
+	}
+
+	// Bounds
+	lw.writeLine("Bounds")
+	for _, tempVar := range m.Variables {
+		if tempVar.Vtype == Binary && tempVar.Lower == 0 && tempVar.Upper == 1 {
+			continue
+		}
+		lw.writeLine(" " + lpBoundString(tempVar, names.Variables[tempVar.ID]))
+	}
+
+	// Integer and binary variables
+	for _, section := range []struct {
+		Name  string
+		Vtype VarType
+	}{{"General", Integer}, {"Binary", Binary}} {
+		var sectionNames []string
+		for _, tempVar := range m.Variables {
+			if tempVar.Vtype == section.Vtype {
+				sectionNames = append(sectionNames, names.Variables[tempVar.ID])
+			}
+		}
+		if len(sectionNames) == 0 {
+			continue
+		}
+		lw.writeLine(section.Name)
+		for _, name := range sectionNames {
+			lw.writeToken(" " + name)
+		}
+		lw.endLine()
+	}
+
+	lw.writeLine("End")
+
+	return lw.w.Flush()
+}
+
+/*
+writeTerms
+Description:
+
+	Writes the linear and quadratic terms of et. Quadratic terms of an objective are written as
+	[ 2 q ] / 2, as required by the LP format.
+*/
+func (lw *lpLineWriter) writeTerms(et expressionTerms, isObjective bool) {
+	first := true
+	for _, term := range et.Linear {
+		lw.writeToken(lpSignedCoefficient(term.Coeff, first) + lw.names[term.ID])
+		first = false
+	}
+
+	if len(et.Quadratic) == 0 {
+		return
+	}
+
+	factor := 1.0
+	if isObjective {
+		factor = 2.0
+	}
+	if first {
+		lw.writeToken("[")
+	} else {
+		lw.writeToken("+ [")
+	}
+	for termIndex, term := range et.Quadratic {
+		product := lw.names[term.ID1] + " ^ 2"
+		if term.ID1 != term.ID2 {
+			product = lw.names[term.ID1] + " * " + lw.names[term.ID2]
+		}
+		lw.writeToken(lpSignedCoefficient(factor*term.Coeff, termIndex == 0) + product)
+	}
+	if isObjective {
+		lw.writeToken("] / 2")
+	} else {
+		lw.writeToken("]")
+	}
+}
+
+/*
+writeToken
+Description:
+
+	Writes a token (preceded by a space, unless it already starts with one), wrapping the line
+	if necessary.
+*/
+func (lw *lpLineWriter) writeToken(token string) {
+	if !strings.HasPrefix(token, " ") {
+		token = " " + token
+	}
+	if lw.lineLength > 0 && lw.lineLength+len(token) > lpMaxLineLength {
+		lw.endLine()
+		token = "  " + token
+	}
+	lw.w.WriteString(token)
+	lw.lineLength += len(token)
+}
+
+/*
+writeLine
+Description:
+
+	Writes a complete line.
+*/
+func (lw *lpLineWriter) writeLine(line string) {
+	if lw.lineLength > 0 {
+		lw.endLine()
+	}
+	lw.w.WriteString(line)
+	lw.endLine()
+}
+
+func (lw *lpLineWriter) endLine() {
+	lw.w.WriteString("\n")
+	lw.lineLength = 0
+}
+
+/*
+fileNames
+Description:
+
+	Collects the names of the variables and scalar constraints of the model which are used when
+	it is written to a file. Unnamed constraints are named c<index>. Returns an error if a name is
+	rejected by checkName or is used by more than one variable (or constraint).
+*/
+func (m *Model) fileNames(checkName func(name string) error) (fileNames, error) {
+	// Constants
+	names := fileNames{Variables: make(map[uint64]string)}
+
+	// Variables
+	varIDs := make(map[string]uint64)
+	for _, tempVar := range m.Variables {
+		name := tempVar.DisplayName()
+		if err := checkName(name); err != nil {
+			return names, fmt.Errorf("There was an issue with the name of variable %v: %v", tempVar.ID, err)
+		}
+		if otherID, found := varIDs[name]; found && otherID != tempVar.ID {
+			return names, fmt.Errorf("The variables %v and %v are both named %q", otherID, tempVar.ID, name)
+		}
+		varIDs[name] = tempVar.ID
+		names.Variables[tempVar.ID] = name
+	}
+
+	// Constraints
+	constrIndices := make(map[string]int)
+	for constrIndex, constr := range m.scalarConstraints() {
+		name := constr.Name
+		if name == "" {
+			name = fmt.Sprintf("c%v", constrIndex)
+		}
+		if err := checkName(name); err != nil {
+			return names, fmt.Errorf("There was an issue with the name of constraint %v: %v", constrIndex, err)
+		}
+		if otherIndex, found := constrIndices[name]; found {
+			return names, fmt.Errorf("The constraints %v and %v are both named %q", otherIndex, constrIndex, name)
+		}
+		constrIndices[name] = constrIndex
+		names.Constraints = append(names.Constraints, name)
+	}
+
+	return names, nil
+}
+
+/*
+lpCheckName
+Description:
+
+	Verifies that the name can be written in an LP file: it must be read back as a single name
+	and must not be a keyword of the format.
+*/
+func lpCheckName(name string) error {
+	// Constants
+	tokens, err := tokenizeLP(name)
+
+	// Algorithm
+	if err != nil || len(tokens) != 2 || tokens[0].Kind != lpTokenName || tokens[0].Text != name {
+		return fmt.Errorf("The name %q can not be written in an LP file", name)
+	}
+
+	word := strings.ToLower(name)
+	if _, isKeyword := lpSectionKeywords[word]; isKeyword || isLPInfinity(word) || word == "free" {
+		return fmt.Errorf("The name %q is a keyword of the LP format", name)
+	}
+	return nil
+}
+
+/*
+lpNumber
+Description:
+
+	Formats a number with as many digits as needed to represent it exactly.
+*/
+func lpNumber(value float64) string {
+	switch {
+	case math.IsInf(value, 1) || value >= 1e30:
+		return "+inf"
+	case math.IsInf(value, -1) || value <= -1e30:
+		return "-inf"
+	case value == 0:
+		return "0" // Avoids writing -0
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+/*
+lpSignedNumber
+Description:
+
+	Formats a number with an explicit sign (e.g. "+ 3" or "- 3"). The first term of an
+	expression is written without a leading "+".
+*/
+func lpSignedNumber(value float64, first bool) string {
+	switch {
+	case value < 0:
+		return "- " + lpNumber(-value)
+	case first:
+		return lpNumber(value)
+	default:
+		return "+ " + lpNumber(value)
+	}
+}
+
+/*
+lpSignedCoefficient
+Description:
+
+	Formats the coefficient of a term, followed by a space. Coefficients equal to one are
+	omitted.
+*/
+func lpSignedCoefficient(coeff float64, first bool) string {
+	sign := "+ "
+	switch {
+	case coeff < 0:
+		sign = "- "
+		coeff = -coeff
+	case first:
+		sign = ""
+	}
+
+	if coeff == 1 {
+		return sign
+	}
+	return sign + lpNumber(coeff) + " "
+}
+
+/*
+lpSenseString
+Description:
+
+	Returns the LP format string of a constraint sense.
+*/
+func lpSenseString(sense ConstrSense) (string, error) {
+	switch sense {
+	case SenseLessThanEqual:
+		return "<=", nil
+	case SenseGreaterThanEqual:
+		return ">=", nil
+	case SenseEqual:
+		return "=", nil
+	}
+	return "", fmt.Errorf("Unexpected constraint sense %v", sense)
+}
+
+/*
+lpBoundString
+Description:
+
+	Returns the line of the Bounds section describing the bounds of a variable with the given
+	name.
+*/
+func lpBoundString(v Variable, name string) string {
+	// Constants
+	lowerIsInf := math.IsInf(v.Lower, -1) || v.Lower <= -1e30
+	upperIsInf := math.IsInf(v.Upper, 1) || v.Upper >= 1e30
+
+	// Algorithm
+	switch {
+	case lowerIsInf && upperIsInf:
+		return name + " free"
+	case upperIsInf:
+		return name + " >= " + lpNumber(v.Lower)
+	case lowerIsInf:
+		return "-inf <= " + name + " <= " + lpNumber(v.Upper)
+	case v.Lower == v.Upper:
+		return name + " = " + lpNumber(v.Lower)
+	}
+	return lpNumber(v.Lower) + " <= " + name + " <= " + lpNumber(v.Upper)
+}
@@ -0,0 +1,219 @@
+package optim
+
+/*
+matrix_constant.go
+Description:
+	Creates a matrix extension of the constant type K from the original goop.
+*/
+
+import (
+	"fmt"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+/*
+KMatrix
+
+	A constant matrix expression which is built on top of gonum's mat.Dense.
+*/
+type KMatrix mat.Dense // Inherit all methods from mat.Dense
+
+/*
+Dims
+Description:
+
+	Returns the number of rows and columns of the constant matrix.
+*/
+func (km KMatrix) Dims() (int, int) {
+	kmAsDense := mat.Dense(km)
+	return kmAsDense.Dims()
+}
+
+/*
+At
+Description:
+
+	Returns the constant in row i and column j.
+*/
+func (km KMatrix) At(i, j int) ScalarExpression {
+	kmAsDense := mat.Dense(km)
+	return K(kmAsDense.At(i, j))
+}
+
+/*
+NumVars
+Description:
+
+	This returns the number of variables in the expression. For constants, this is 0.
+*/
+func (km KMatrix) NumVars() int {
+	return 0
+}
+
+/*
+IDs
+Description:
+
+	This function returns a slice of the Var ids in the expression. For constants, this is always nil.
+*/
+func (km KMatrix) IDs() []uint64 {
+	return nil
+}
+
+/*
+Row
+Description:
+
+	Returns a copy of row i of the matrix as a KVector.
+*/
+func (km KMatrix) Row(i int) KVector {
+	kmAsDense := mat.Dense(km)
+	return KVector(*mat.VecDenseCopyOf(kmAsDense.RowView(i)))
+}
+
+/*
+Col
+Description:
+
+	Returns a copy of column j of the matrix as a KVector.
+*/
+func (km KMatrix) Col(j int) KVector {
+	kmAsDense := mat.Dense(km)
+	return KVector(*mat.VecDenseCopyOf(kmAsDense.ColView(j)))
+}
+
+/*
+T
+Description:
+
+	Returns a copy of the transpose of the matrix (a KMatrix).
+*/
+func (km KMatrix) T() MatrixExpression {
+	kmAsDense := mat.Dense(km)
+	return KMatrix(*mat.DenseCopyOf(kmAsDense.T()))
+}
+
+/*
+Trace
+Description:
+
+	Returns the sum of the diagonal elements of a square matrix as a K.
+*/
+func (km KMatrix) Trace() (ScalarExpression, error) {
+	// Input Checking
+	if err := checkSquare(km); err != nil {
+		return K(0), err
+	}
+
+	// Algorithm
+	kmAsDense := mat.Dense(km)
+	return K(mat.Trace(&kmAsDense)), nil
+}
+
+/*
+Plus
+Description:
+
+	Adds the current expression to another and returns the resulting expression. The sum of two
+	constant matrices is a KMatrix.
+*/
+func (km KMatrix) Plus(e interface{}, extras ...interface{}) (MatrixExpression, error) {
+	// Input Processing
+	nR, nC := km.Dims()
+	eAsME, err := toMatrixExpression(e, nR, nC)
+	if err != nil {
+		return km, fmt.Errorf("There was an issue computing the sum of a KMatrix: %v", err)
+	}
+
+	// Algorithm
+	switch eIn := eAsME.(type) {
+	case KMatrix:
+		var result mat.Dense
+		kmAsDense, eAsDense := mat.Dense(km), mat.Dense(eIn)
+		result.Add(&kmAsDense, &eAsDense)
+
+		return KMatrix(result), nil
+	default:
+		// Addition commutes
+		return eAsME.Plus(km)
+	}
+}
+
+/*
+Mult
+Description:
+
+	This method multiplies every element of the constant matrix by val.
+*/
+func (km KMatrix) Mult(val float64) (MatrixExpression, error) {
+	// Use mat.Dense's scaling method
+	var result mat.Dense
+	kmAsDense := mat.Dense(km)
+	result.Scale(val, &kmAsDense)
+
+	return KMatrix(result), nil
+}
+
+/*
+Equals
+Description:
+
+	Returns true if other is a matrix with the same dimensions as km whose elements match
+	those of km up to tol.
+*/
+func (km KMatrix) Equals(other interface{}, tol float64) bool {
+	return matrixEquals(km, other, tol)
+}
+
+/*
+Multiply
+Description:
+
+	Returns the matrix product of the constant matrix with term1 (and then with each of the
+	extras). For example, a KMatrix times a VarVector is a VectorLinearExpr.
+*/
+func (km KMatrix) Multiply(term1 interface{}, extras ...interface{}) (Expression, error) {
+	return Multiply(km, term1, extras...)
+}
+
+/*
+LessEq
+Description:
+
+	Returns a less than or equal to (<=) constraint between the current expression and another
+*/
+func (km KMatrix) LessEq(rhs interface{}) (MatrixConstraint, error) {
+	return km.Comparison(rhs, SenseLessThanEqual)
+}
+
+/*
+GreaterEq
+Description:
+
+	This method returns a greater than or equal to (>=) constraint between the current expression and another
+*/
+func (km KMatrix) GreaterEq(rhs interface{}) (MatrixConstraint, error) {
+	return km.Comparison(rhs, SenseGreaterThanEqual)
+}
+
+/*
+Eq
+Description:
+
+	This method returns an equality (==) constraint between the current expression and another
+*/
+func (km KMatrix) Eq(rhs interface{}) (MatrixConstraint, error) {
+	return km.Comparison(rhs, SenseEqual)
+}
+
+/*
+Comparison
+Description:
+
+	Returns an element-wise constraint of type sense between the constant matrix (as left hand
+	side) and rhs (as right hand side), which must have the same dimensions.
+*/
+func (km KMatrix) Comparison(rhs interface{}, sense ConstrSense) (MatrixConstraint, error) {
+	return matrixComparison(km, rhs, sense)
+}
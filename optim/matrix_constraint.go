@@ -0,0 +1,113 @@
+package optim
+
+/*
+matrix_constraint.go
+Description:
+	Defines the MatrixConstraint, which compares two matrix expressions of the same dimensions
+	element by element.
+*/
+
+import "fmt"
+
+type MatrixConstraint struct {
+	LeftHandSide  MatrixExpression
+	RightHandSide MatrixExpression
+	Sense         ConstrSense
+	Name          string // Optional. The element in row i and column j is named Name[i,j].
+}
+
+/*
+Check
+Description:
+
+	Verifies that both sides of the constraint are well-defined and have the same dimensions.
+*/
+func (mc MatrixConstraint) Check() error {
+	// Check that both sides exist
+	if mc.LeftHandSide == nil || mc.RightHandSide == nil {
+		return fmt.Errorf("The matrix constraint %v is missing one of its sides.", mc)
+	}
+
+	// Check each side
+	for sideIndex, side := range []MatrixExpression{mc.LeftHandSide, mc.RightHandSide} {
+		var err error
+		switch sideIn := side.(type) {
+		case VarMatrix:
+			err = sideIn.Check()
+		case MatrixLinearExpr:
+			err = sideIn.Check()
+		}
+		if err != nil {
+			return fmt.Errorf("Side #%v of the matrix constraint is not valid: %v", sideIndex+1, err)
+		}
+	}
+
+	// Check dimensions
+	lhsNR, lhsNC := mc.LeftHandSide.Dims()
+	rhsNR, rhsNC := mc.RightHandSide.Dims()
+	if lhsNR != rhsNR || lhsNC != rhsNC {
+		return fmt.Errorf(
+			"The left hand side's dimensions (%v x %v) and the right hand side's dimensions (%v x %v) do not match!",
+			lhsNR, lhsNC, rhsNR, rhsNC,
+		)
+	}
+
+	return nil
+}
+
+/*
+Dims
+Description:
+
+	Returns the number of rows and columns of the constraint.
+*/
+func (mc MatrixConstraint) Dims() (int, int) {
+	return mc.LeftHandSide.Dims()
+}
+
+/*
+At
+Description:
+
+	Returns the scalar constraint given by the element in row i and column j of each side. If the
+	matrix constraint is named, then the element is named Name[i,j].
+*/
+func (mc MatrixConstraint) At(i, j int) ScalarConstraint {
+	// Constants
+	name := ""
+	if mc.Name != "" {
+		name = fmt.Sprintf("%v[%v,%v]", mc.Name, i, j)
+	}
+
+	// Algorithm
+	return ScalarConstraint{
+		LeftHandSide:  mc.LeftHandSide.At(i, j),
+		RightHandSide: mc.RightHandSide.At(i, j),
+		Sense:         mc.Sense,
+		Name:          name,
+	}
+}
+
+/*
+ScalarConstraints
+Description:
+
+	Expands the matrix constraint into one scalar constraint per element, in row-major order.
+*/
+func (mc MatrixConstraint) ScalarConstraints() ([]ScalarConstraint, error) {
+	// Input Checking
+	if err := mc.Check(); err != nil {
+		return nil, err
+	}
+
+	// Algorithm
+	nR, nC := mc.Dims()
+	constrs := make([]ScalarConstraint, 0, nR*nC)
+	for rowIndex := 0; rowIndex < nR; rowIndex++ {
+		for colIndex := 0; colIndex < nC; colIndex++ {
+			constrs = append(constrs, mc.At(rowIndex, colIndex))
+		}
+	}
+
+	return constrs, nil
+}
@@ -0,0 +1,209 @@
+package optim
+
+/*
+matrix_expression.go
+Description:
+	Defines the MatrixExpression interface, the matrix counterpart of VectorExpression. It is
+	implemented by VarMatrix, KMatrix and MatrixLinearExpr.
+*/
+
+import (
+	"fmt"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+/*
+MatrixExpression
+Description:
+
+	This interface represents any expression whose value is a matrix. Each element of the matrix
+	is a scalar expression of the variables (e.g. X + C where X is a matrix of variables and C is a
+	constant matrix).
+*/
+type MatrixExpression interface {
+	// NumVars returns the number of variables in the expression
+	NumVars() int
+
+	// IDs returns a slice of the Var ids in the expression
+	IDs() []uint64
+
+	// Dims returns the number of rows and columns of the expression
+	Dims() (int, int)
+
+	// At returns the expression in row i and column j
+	At(i, j int) ScalarExpression
+
+	// Plus adds the current expression to another and returns the resulting
+	// expression
+	Plus(e interface{}, extras ...interface{}) (MatrixExpression, error)
+
+	// Mult multiplies every element of the current expression by c and returns
+	// the resulting expression
+	Mult(c float64) (MatrixExpression, error)
+
+	// Equals returns true if each element of the other expression has the same
+	// coefficients as the matching element of the current expression, up to
+	// the tolerance tol
+	Equals(other interface{}, tol float64) bool
+
+	// Multiply computes the matrix product of the current expression with
+	// another expression and returns the resulting expression
+	Multiply(term1 interface{}, extras ...interface{}) (Expression, error)
+
+	// T returns the transpose of the expression
+	T() MatrixExpression
+
+	// Trace returns the sum of the diagonal elements of a square expression
+	Trace() (ScalarExpression, error)
+
+	// LessEq returns a less than or equal to (<=) constraint between the
+	// current expression and another
+	LessEq(rhs interface{}) (MatrixConstraint, error)
+
+	// GreaterEq returns a greater than or equal to (>=) constraint between the
+	// current expression and another
+	GreaterEq(rhs interface{}) (MatrixConstraint, error)
+
+	// Eq returns an equality (==) constraint between the current expression
+	// and another
+	Eq(rhs interface{}) (MatrixConstraint, error)
+
+	// Comparison
+	// Returns a constraint with respect to the sense (senseIn) between the
+	// current expression and another.
+	Comparison(rhs interface{}, sense ConstrSense) (MatrixConstraint, error)
+}
+
+/*
+toMatrixExpression
+Description:
+
+	Converts the input e to a MatrixExpression with nR rows and nC columns. Constants (float64 and
+	K) become a KMatrix with every element equal to the constant and a mat.Dense becomes a KMatrix.
+	An error is returned if e has a different type or different dimensions.
+*/
+func toMatrixExpression(e interface{}, nR, nC int) (MatrixExpression, error) {
+	// Algorithm
+	var eAsME MatrixExpression
+	switch eIn := e.(type) {
+	case float64:
+		if nR == 0 || nC == 0 {
+			return nil, fmt.Errorf("A constant can not be expanded to an empty (%v x %v) matrix.", nR, nC)
+		}
+		constantMatrix := mat.NewDense(nR, nC, nil)
+		for rowIndex := 0; rowIndex < nR; rowIndex++ {
+			for colIndex := 0; colIndex < nC; colIndex++ {
+				constantMatrix.Set(rowIndex, colIndex, eIn)
+			}
+		}
+		return KMatrix(*constantMatrix), nil
+	case K:
+		return toMatrixExpression(float64(eIn), nR, nC)
+	case mat.Dense:
+		eAsME = KMatrix(eIn)
+	case *mat.Dense:
+		eAsME = KMatrix(*eIn)
+	case KMatrix:
+		eAsME = eIn
+	case VarMatrix:
+		if err := eIn.Check(); err != nil {
+			return nil, err
+		}
+		eAsME = eIn
+	case MatrixLinearExpr:
+		if err := eIn.Check(); err != nil {
+			return nil, err
+		}
+		eAsME = eIn
+	default:
+		return nil, fmt.Errorf("Unexpected type %T for a matrix expression; expected a MatrixExpression, mat.Dense or constant.", e)
+	}
+
+	// Check Dimensions
+	if eNR, eNC := eAsME.Dims(); eNR != nR || eNC != nC {
+		return nil, fmt.Errorf(
+			"The dimensions of the two matrix expressions do not match; #1 is %v x %v and #2 is %v x %v.",
+			nR, nC, eNR, eNC,
+		)
+	}
+
+	return eAsME, nil
+}
+
+/*
+matrixComparison
+Description:
+
+	Creates the constraint lhs (sense) rhs after converting rhs to a MatrixExpression with the
+	same dimensions as lhs.
+*/
+func matrixComparison(lhs MatrixExpression, rhs interface{}, sense ConstrSense) (MatrixConstraint, error) {
+	// Constants
+	nR, nC := lhs.Dims()
+
+	// Algorithm
+	rhsAsME, err := toMatrixExpression(rhs, nR, nC)
+	if err != nil {
+		return MatrixConstraint{}, fmt.Errorf("There was an issue with the right hand side of the comparison '%v': %v", sense, err)
+	}
+
+	return MatrixConstraint{LeftHandSide: lhs, RightHandSide: rhsAsME, Sense: sense}, nil
+}
+
+/*
+checkSquare
+Description:
+
+	Returns an error if the matrix expression is not square. Used by the Trace methods.
+*/
+func checkSquare(me MatrixExpression) error {
+	if nR, nC := me.Dims(); nR != nC {
+		return fmt.Errorf("The trace is only defined for square matrices; received a %v x %v matrix.", nR, nC)
+	}
+	return nil
+}
+
+/*
+matrixEquals
+Description:
+
+	Returns true if other is a mat.Dense or matrix expression with the same dimensions as me whose
+	elements are each equal (see scalarEquals) to the matching element of me up to tol.
+*/
+func matrixEquals(me MatrixExpression, other interface{}, tol float64) bool {
+	// Input Processing
+	var otherAsME MatrixExpression
+	switch otherIn := other.(type) {
+	case mat.Dense:
+		otherAsME = KMatrix(otherIn)
+	case *mat.Dense:
+		otherAsME = KMatrix(*otherIn)
+	case MatrixExpression:
+		otherAsME = otherIn
+	default:
+		return false
+	}
+
+	for _, tempME := range []MatrixExpression{me, otherAsME} {
+		if checker, ok := tempME.(interface{ Check() error }); ok && checker.Check() != nil {
+			return false
+		}
+	}
+
+	nR, nC := me.Dims()
+	if otherNR, otherNC := otherAsME.Dims(); otherNR != nR || otherNC != nC {
+		return false
+	}
+
+	// Algorithm
+	for rowIndex := 0; rowIndex < nR; rowIndex++ {
+		for colIndex := 0; colIndex < nC; colIndex++ {
+			if !scalarEquals(me.At(rowIndex, colIndex), otherAsME.At(rowIndex, colIndex), tol) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
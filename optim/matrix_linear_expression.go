@@ -0,0 +1,378 @@
+package optim
+
+/*
+matrix_linear_expression.go
+Description:
+	Defines the MatrixLinearExpr, a matrix whose elements are linear expressions of the same
+	vector of variables.
+*/
+
+import (
+	"fmt"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// MatrixLinearExpr represents a matrix whose element in row i and column j is the linear expression
+//
+//	L[i*nC+j]' * x + C[i][j]
+//
+// where nC is the number of columns of the matrix, row i*nC+j of L holds the coefficients of that
+// element (so L has one row per element and one column per variable in x) and C is a constant matrix
+// which gives the dimensions of the expression.
+type MatrixLinearExpr struct {
+	X VarVector
+	L mat.Dense // Matrix of coefficients. Should have one row per element and one column per variable of X
+	C mat.Dense
+}
+
+/*
+Check
+Description:
+
+	Checks to see if the MatrixLinearExpr is well-defined.
+*/
+func (mle MatrixLinearExpr) Check() error {
+	// Constants
+	nR, nC := mle.Dims()
+	nLR, nLC := mle.L.Dims()
+
+	// Compare the number of rows of L with the number of elements
+	if nLR != nR*nC {
+		return fmt.Errorf("The number of rows of L (%v) does not match the number of elements of C (%v x %v).", nLR, nR, nC)
+	}
+
+	// Compare the number of columns of L with the number of variables
+	if nLC != mle.X.Len() {
+		return fmt.Errorf("Dimensions of L (%v x %v) and x (length %v) do not match appropriately.", nLR, nLC, mle.X.Len())
+	}
+
+	// If all other checks passed, then the MatrixLinearExpr seems valid.
+	return nil
+}
+
+/*
+Dims
+Description:
+
+	Returns the number of rows and columns of the expression.
+*/
+func (mle MatrixLinearExpr) Dims() (int, int) {
+	return mle.C.Dims()
+}
+
+/*
+IDs
+Description:
+
+	Returns the goop2 ID of each variable in the current matrix linear expression.
+*/
+func (mle MatrixLinearExpr) IDs() []uint64 {
+	return mle.X.IDs()
+}
+
+/*
+NumVars
+Description:
+
+	Returns the number of unique variables in the current matrix linear expression.
+*/
+func (mle MatrixLinearExpr) NumVars() int {
+	return len(mle.IDs())
+}
+
+/*
+At
+Description:
+
+	Returns the linear expression in row i and column j.
+*/
+func (mle MatrixLinearExpr) At(i, j int) ScalarExpression {
+	// Constants
+	_, nC := mle.Dims()
+	Lij := mat.VecDenseCopyOf(mle.L.RowView(i*nC + j)) // Copied so that the new expression does not share data with L
+
+	return ScalarLinearExpr{
+		L: *Lij,
+		X: mle.X,
+		C: mle.C.At(i, j),
+	}
+}
+
+/*
+elements
+Description:
+
+	Returns the expression formed by the elements whose indices (i*nC+j) are given, in order.
+*/
+func (mle MatrixLinearExpr) elements(elementIndices []int) VectorLinearExpr {
+	// Constants
+	_, nC := mle.Dims()
+
+	// Algorithm
+	L := mat.NewDense(len(elementIndices), mle.X.Len(), nil)
+	C := mat.NewVecDense(len(elementIndices), nil)
+	for outIndex, elementIndex := range elementIndices {
+		L.SetRow(outIndex, mat.Row(nil, elementIndex, &mle.L))
+		C.SetVec(outIndex, mle.C.At(elementIndex/nC, elementIndex%nC))
+	}
+
+	return VectorLinearExpr{X: mle.X, L: *L, C: *C}
+}
+
+/*
+Row
+Description:
+
+	Returns row i of the expression as a VectorLinearExpr.
+*/
+func (mle MatrixLinearExpr) Row(i int) VectorLinearExpr {
+	// Constants
+	_, nC := mle.Dims()
+
+	// Algorithm
+	elementIndices := make([]int, nC)
+	for colIndex := range elementIndices {
+		elementIndices[colIndex] = i*nC + colIndex
+	}
+
+	return mle.elements(elementIndices)
+}
+
+/*
+Col
+Description:
+
+	Returns column j of the expression as a VectorLinearExpr.
+*/
+func (mle MatrixLinearExpr) Col(j int) VectorLinearExpr {
+	// Constants
+	nR, nC := mle.Dims()
+
+	// Algorithm
+	elementIndices := make([]int, nR)
+	for rowIndex := range elementIndices {
+		elementIndices[rowIndex] = rowIndex*nC + j
+	}
+
+	return mle.elements(elementIndices)
+}
+
+/*
+T
+Description:
+
+	Returns the transpose of the expression (a MatrixLinearExpr).
+*/
+func (mle MatrixLinearExpr) T() MatrixExpression {
+	// Constants
+	nR, nC := mle.Dims()
+
+	// Algorithm
+	L := mat.NewDense(nR*nC, mle.X.Len(), nil)
+	for rowIndex := 0; rowIndex < nR; rowIndex++ {
+		for colIndex := 0; colIndex < nC; colIndex++ {
+			// Element (i, j) becomes element (j, i) of the transpose
+			L.SetRow(colIndex*nR+rowIndex, mat.Row(nil, rowIndex*nC+colIndex, &mle.L))
+		}
+	}
+
+	return MatrixLinearExpr{X: mle.X, L: *L, C: *mat.DenseCopyOf(mle.C.T())}
+}
+
+/*
+Trace
+Description:
+
+	Returns the sum of the diagonal elements of a square expression as a ScalarLinearExpr.
+*/
+func (mle MatrixLinearExpr) Trace() (ScalarExpression, error) {
+	// Input Checking
+	if err := checkSquare(mle); err != nil {
+		return nil, err
+	}
+
+	// Algorithm
+	nR, nC := mle.Dims()
+	L := mat.NewVecDense(mle.X.Len(), nil)
+	for diagIndex := 0; diagIndex < nR; diagIndex++ {
+		L.AddVec(L, mle.L.RowView(diagIndex*nC+diagIndex))
+	}
+
+	return ScalarLinearExpr{X: mle.X, L: *L, C: mat.Trace(&mle.C)}, nil
+}
+
+/*
+Plus
+Description:
+
+	Returns an expression which adds the expression e (or a constant, which is added to every
+	element) to the matrix linear expression at hand.
+*/
+func (mle MatrixLinearExpr) Plus(e interface{}, extras ...interface{}) (MatrixExpression, error) {
+	// Input Processing
+	if err := mle.Check(); err != nil {
+		return mle, err
+	}
+
+	nR, nC := mle.Dims()
+	eAsME, err := toMatrixExpression(e, nR, nC)
+	if err != nil {
+		return mle, fmt.Errorf("There was an issue computing the sum of a MatrixLinearExpr: %v", err)
+	}
+
+	// Algorithm
+	switch eIn := eAsME.(type) {
+	case KMatrix:
+		// Add to the constant
+		var C mat.Dense
+		eAsDense := mat.Dense(eIn)
+		C.Add(&mle.C, &eAsDense)
+
+		return MatrixLinearExpr{X: mle.X, L: *mat.DenseCopyOf(&mle.L), C: C}, nil
+
+	case VarMatrix:
+		eAsMLE, err := eIn.ToMatrixLinearExpr()
+		if err != nil {
+			return mle, err
+		}
+
+		return mle.Plus(eAsMLE)
+
+	case MatrixLinearExpr:
+		// Rewrite both expressions in terms of all of their variables
+		uniqueVV := VarVector{UniqueVars(append(append([]Variable(nil), mle.X.Elements...), eIn.X.Elements...))}
+		mleOut := mle.RewriteInTermsOf(uniqueVV)
+		eRewritten := eIn.RewriteInTermsOf(uniqueVV)
+
+		mleOut.L.Add(&mleOut.L, &eRewritten.L)
+		mleOut.C.Add(&mleOut.C, &eRewritten.C)
+
+		return mleOut, nil
+	}
+
+	return mle, fmt.Errorf("The addition of a MatrixLinearExpr and a %T has not yet been implemented!", eAsME)
+}
+
+/*
+Mult
+Description:
+
+	Returns an expression which scales every element of the matrix linear expression by c.
+*/
+func (mle MatrixLinearExpr) Mult(c float64) (MatrixExpression, error) {
+	// Input Checking
+	if err := mle.Check(); err != nil {
+		return mle, err
+	}
+
+	// Algorithm
+	var L, C mat.Dense
+	L.Scale(c, &mle.L)
+	C.Scale(c, &mle.C)
+
+	return MatrixLinearExpr{X: mle.X, L: L, C: C}, nil
+}
+
+/*
+Equals
+Description:
+
+	Returns true if each element of other has the same coefficients and constant as the
+	matching element of mle, up to tol.
+*/
+func (mle MatrixLinearExpr) Equals(other interface{}, tol float64) bool {
+	return matrixEquals(mle, other, tol)
+}
+
+/*
+Multiply
+Description:
+
+	Returns the matrix product of the matrix linear expression with term1 (and then with each
+	of the extras).
+*/
+func (mle MatrixLinearExpr) Multiply(term1 interface{}, extras ...interface{}) (Expression, error) {
+	return Multiply(mle, term1, extras...)
+}
+
+/*
+RewriteInTermsOf
+Description:
+
+	Rewrites the MatrixLinearExpr in terms of a new set of variables vv. The returned expression
+	does not share data with mle.
+
+Assumes:
+
+	vv contains all unique variables.
+	All elements of mle.X are in vv.
+*/
+func (mle MatrixLinearExpr) RewriteInTermsOf(vv VarVector) MatrixLinearExpr {
+	// Constants
+	nLR, _ := mle.L.Dims()
+
+	// Create new L (variables which appear more than once in mle.X have their coefficients added)
+	L := mat.NewDense(nLR, vv.Len(), nil)
+	for xIndex, tempVar := range mle.X.Elements {
+		xIndexInVV, _ := FindInSlice(tempVar, vv.Elements)
+		for rowIndex := 0; rowIndex < nLR; rowIndex++ {
+			L.Set(rowIndex, xIndexInVV, L.At(rowIndex, xIndexInVV)+mle.L.At(rowIndex, xIndex))
+		}
+	}
+
+	return MatrixLinearExpr{X: vv, L: *L, C: *mat.DenseCopyOf(&mle.C)}
+}
+
+/*
+LessEq
+Description:
+
+	Creates a MatrixConstraint that declares mle is less than or equal to the right hand side rhs
+	(element-wise).
+*/
+func (mle MatrixLinearExpr) LessEq(rhs interface{}) (MatrixConstraint, error) {
+	return mle.Comparison(rhs, SenseLessThanEqual)
+}
+
+/*
+GreaterEq
+Description:
+
+	Creates a MatrixConstraint that declares mle is greater than or equal to the right hand side
+	rhs (element-wise).
+*/
+func (mle MatrixLinearExpr) GreaterEq(rhs interface{}) (MatrixConstraint, error) {
+	return mle.Comparison(rhs, SenseGreaterThanEqual)
+}
+
+/*
+Eq
+Description:
+
+	Creates a MatrixConstraint that declares mle is equal to the right hand side rhs
+	(element-wise).
+*/
+func (mle MatrixLinearExpr) Eq(rhs interface{}) (MatrixConstraint, error) {
+	return mle.Comparison(rhs, SenseEqual)
+}
+
+/*
+Comparison
+Description:
+
+	Compares the matrix linear expression with the expression rhs element-wise, in the sense
+	given by sense.
+*/
+func (mle MatrixLinearExpr) Comparison(rhs interface{}, sense ConstrSense) (MatrixConstraint, error) {
+	// Input Checking
+	if err := mle.Check(); err != nil {
+		return MatrixConstraint{}, fmt.Errorf(
+			"There was an issue in the provided matrix linear expression %v: %v",
+			mle, err,
+		)
+	}
+
+	// Algorithm
+	return matrixComparison(mle, rhs, sense)
+}
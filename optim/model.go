@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"github.com/kwesiRutledge/gurobi.go/gurobi"
+	"math"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -14,13 +15,26 @@ import (
 // problem, constraints, objective, and parameters. New variables can only be
 // created using an instantiated Model.
 type Model struct {
-	Variables []Variable
-	constrs   []ScalarConstraint
-	obj       *Objective
-	showLog   bool
-	timeLimit time.Duration
+	Variables       []Variable
+	constrs         []Constraint
+	constrHandles   []ConstrHandle // The handle of each constraint in constrs
+	obj             *Objective
+	showLog         bool
+	timeLimit       time.Duration
+	keepSolverAlive bool
+
+	nextVarID        uint64
+	nextConstrHandle ConstrHandle
+	fixedBounds      map[uint64][2]float64   // The bounds of the fixed variables before they were fixed
+	session          *solverSession          // What the kept alive IncrementalSolver holds
+	varsByName       map[string]Variable     // The named variables (see namedVariables)
+	constrsByName    map[string]ConstrHandle // The constraint that uses each name (see namedConstrs)
 }
 
+// ConstrHandle identifies a constraint added to a Model. A handle stays valid (and keeps
+// referring to the same constraint) when other constraints are removed or edited.
+type ConstrHandle uint64
+
 // NewModel returns a new model with some default arguments such as not to show
 // the log and no time limit.
 func NewModel() *Model {
@@ -54,21 +68,41 @@ Description:
 	Adds a Real variable to the model and returns said variable.
 */
 func (m *Model) AddRealVariable() Variable {
-	return m.AddVariableClassic(-gurobi.INFINITY, gurobi.INFINITY, Continuous)
+	newVar, _ := m.AddVariableClassic(-gurobi.INFINITY, gurobi.INFINITY, Continuous) // These bounds are always valid
+	return newVar
+}
+
+// KeepSolverAlive decides whether or not Optimize deletes the solver after it
+// finishes. When the solver is kept alive, it can reuse its internal state
+// (e.g. a factorized basis) the next time the model is optimized with it. If
+// the solver is an IncrementalSolver, the next call to Optimize only sends the
+// changes made to the model since the last one; otherwise, if the solver is a
+// SessionSolver, Optimize calls ClearModel before loading the model again.
+// Only SessionSolvers can be kept alive; Optimize returns an error for any
+// other solver. Solvers which are kept alive must be deleted by the caller.
+func (m *Model) KeepSolverAlive(keepAlive bool) {
+	m.keepSolverAlive = keepAlive
 }
 
-// AddVariable adds a variable of a given variable type to the model given the lower
-// and upper value limits. This variable is returned.
-func (m *Model) AddVariableClassic(lower, upper float64, vtype VarType) Variable {
-	id := uint64(len(m.Variables))
-	newVar := Variable{id, lower, upper, vtype}
+// AddVariableClassic adds a variable of a given variable type to the model given
+// the lower and upper value limits. This variable is returned. An error is
+// returned (and no variable is added) if the bounds or the type are not valid.
+func (m *Model) AddVariableClassic(lower, upper float64, vtype VarType) (Variable, error) {
+	// Input Checking
+	if err := checkVariableSettings(lower, upper, vtype); err != nil {
+		return Variable{}, err
+	}
+
+	// Algorithm
+	newVar := Variable{ID: m.newVariableIDs(1), Lower: lower, Upper: upper, Vtype: vtype}
 	m.Variables = append(m.Variables, newVar)
-	return newVar
+	return newVar, nil
 }
 
 // AddBinaryVar adds a binary variable to the model and returns said variable.
 func (m *Model) AddBinaryVariable() Variable {
-	return m.AddVariableClassic(0, 1, Binary)
+	newVar, _ := m.AddVariableClassic(0, 1, Binary) // These bounds are always valid
+	return newVar
 }
 
 // AddVariableVector adds a vector of variables of a given variable type to the
@@ -79,106 +113,622 @@ Description:
 	Creates a VarVector object using a constructor that assumes you want an "unbounded" vector of real optimization
 	variables.
 */
-func (m *Model) AddVariableVector(dim int) VarVector {
-	// Constants
-
-	// Algorithm
-	varSlice := make([]Variable, dim)
-	for eltIndex := 0; eltIndex < dim; eltIndex++ {
-		varSlice[eltIndex] = m.AddVariable()
-	}
-	return VarVector{varSlice}
+func (m *Model) AddVariableVector(dim int) (VarVector, error) {
+	return m.AddVariableVectorClassic(dim, -gurobi.INFINITY, gurobi.INFINITY, Continuous)
 }
 
 /*
 AddVariableVectorClassic
 Description:
 
-	The classic version of AddVariableVector defined in the original goop.
+	The classic version of AddVariableVector defined in the original goop. An error is returned
+	(and no variables are added) if num is negative or if the bounds or the type are not valid.
 */
 func (m *Model) AddVariableVectorClassic(
 	num int, lower, upper float64, vtype VarType,
-) VarVector {
-	stID := uint64(len(m.Variables))
+) (VarVector, error) {
+	// Input Checking
+	if num < 0 {
+		return VarVector{}, fmt.Errorf("The number of variables in a vector must be nonnegative; received %v", num)
+	}
+	if err := checkVariableSettings(lower, upper, vtype); err != nil {
+		return VarVector{}, err
+	}
+
+	// Algorithm
+	stID := m.newVariableIDs(num)
 	vs := make([]Variable, num)
 	for i := range vs {
-		vs[i] = Variable{stID + uint64(i), lower, upper, vtype}
+		vs[i] = Variable{ID: stID + uint64(i), Lower: lower, Upper: upper, Vtype: vtype}
 	}
 
 	m.Variables = append(m.Variables, vs...)
-	return VarVector{vs}
+	return VarVector{vs}, nil
 }
 
 // AddBinaryVariableVector adds a vector of binary variables to the model and
 // returns the slice.
-func (m *Model) AddBinaryVariableVector(num int) VarVector {
+func (m *Model) AddBinaryVariableVector(num int) (VarVector, error) {
 	return m.AddVariableVectorClassic(num, 0, 1, Binary)
 }
 
 // AddVariableMatrix adds a matrix of variables of a given type to the model with
-// lower and upper value limits and returns the resulting slice.
+// lower and upper value limits and returns the resulting VarMatrix.
 func (m *Model) AddVariableMatrix(
 	rows, cols int, lower, upper float64, vtype VarType,
-) [][]Variable {
+) (VarMatrix, error) {
+	// Input Checking
+	if rows < 0 || cols < 0 {
+		return VarMatrix{}, fmt.Errorf("The dimensions of a variable matrix must be nonnegative; received %v x %v", rows, cols)
+	}
+	if err := checkVariableSettings(lower, upper, vtype); err != nil {
+		return VarMatrix{}, err
+	}
+
+	// Algorithm
 	vs := make([][]Variable, rows)
 	for i := range vs {
-		tempVV := m.AddVariableVectorClassic(cols, lower, upper, vtype)
+		tempVV, _ := m.AddVariableVectorClassic(cols, lower, upper, vtype) // The inputs were checked above
 		vs[i] = tempVV.Elements
 	}
 
-	return vs
+	return VarMatrix{vs}, nil
 }
 
 // AddBinaryVariableMatrix adds a matrix of binary variables to the model and returns
-// the resulting slice.
-func (m *Model) AddBinaryVariableMatrix(rows, cols int) [][]Variable {
+// the resulting VarMatrix.
+func (m *Model) AddBinaryVariableMatrix(rows, cols int) (VarMatrix, error) {
 	return m.AddVariableMatrix(rows, cols, 0, 1, Binary)
 }
 
-// AddConstr adds a the given constraint to the model.
-func (m *Model) AddConstr(constr ScalarConstraint, extras ...interface{}) {
+/*
+AddNamedVariable
+Description:
+
+	Adds a variable with the given name, bounds and type to the model. An error is returned (and
+	no variable is added) if the name is empty or already used by another variable of the model.
+*/
+func (m *Model) AddNamedVariable(name string, lower, upper float64, vtype VarType) (Variable, error) {
+	// Input Checking
+	if err := m.checkNewVariableNames(name); err != nil {
+		return Variable{}, err
+	}
+
+	// Algorithm
+	newVar, err := m.AddVariableClassic(lower, upper, vtype)
+	if err != nil {
+		return Variable{}, err
+	}
+
+	newVar.Name = name
+	m.Variables[len(m.Variables)-1] = newVar
+	m.namedVariables()[name] = newVar
+	return newVar, nil
+}
+
+/*
+AddNamedVariableVector
+Description:
+
+	Adds a vector of num variables to the model. The elements are named name[0], name[1], ...
+*/
+func (m *Model) AddNamedVariableVector(
+	name string, num int, lower, upper float64, vtype VarType,
+) (VarVector, error) {
 	// Constants
-	nExtraArguments := len(extras)
+	var names []string
+	for i := 0; i < num; i++ {
+		names = append(names, fmt.Sprintf("%v[%v]", name, i))
+	}
 
-	// Input Processing
-	if nExtraArguments > 1 {
-		// Do nothing, but report an error.
-		logrus.Error(
-			fmt.Sprintf("The optimizer tried to add a constraint using a bad call to AddConstr! Skipping this constraint: %v , because of extra inputs %v", constr, extras),
-		)
-		return
+	// Input Checking
+	if name == "" {
+		return VarVector{}, fmt.Errorf("The name of a variable vector must not be empty.")
+	}
+	if err := m.checkNewVariableNames(names...); err != nil {
+		return VarVector{}, err
 	}
 
-	optionalErrorArgument := extras[0]
-	switch optionalErrorArgument.(type) {
-	case error:
-		// Cast argument
-		err, _ := optionalErrorArgument.(error)
-		if err != nil {
-			logrus.Error(
-				fmt.Sprintf("There was an error computing constraint %v: %v", constr, err),
-			)
-			return
+	// Algorithm
+	vv, err := m.AddVariableVectorClassic(num, lower, upper, vtype)
+	if err != nil {
+		return VarVector{}, err
+	}
+
+	stIndex := len(m.Variables) - num
+	for i := range vv.Elements {
+		vv.Elements[i].Name = names[i]
+		m.Variables[stIndex+i].Name = names[i]
+		m.namedVariables()[names[i]] = vv.Elements[i]
+	}
+
+	return vv, nil
+}
+
+/*
+AddNamedVariableMatrix
+Description:
+
+	Adds a matrix of variables to the model. The element in row i and column j is named
+	name[i,j].
+*/
+func (m *Model) AddNamedVariableMatrix(
+	name string, rows, cols int, lower, upper float64, vtype VarType,
+) (VarMatrix, error) {
+	// Constants
+	var names []string
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			names = append(names, fmt.Sprintf("%v[%v,%v]", name, i, j))
+		}
+	}
+
+	// Input Checking
+	if name == "" {
+		return VarMatrix{}, fmt.Errorf("The name of a variable matrix must not be empty.")
+	}
+	if err := m.checkNewVariableNames(names...); err != nil {
+		return VarMatrix{}, err
+	}
+
+	// Algorithm
+	vm, err := m.AddVariableMatrix(rows, cols, lower, upper, vtype)
+	if err != nil {
+		return VarMatrix{}, err
+	}
+
+	stIndex := len(m.Variables) - rows*cols
+	for i, row := range vm.Elements {
+		for j := range row {
+			row[j].Name = names[i*cols+j]
+			m.Variables[stIndex+i*cols+j].Name = row[j].Name
+			m.namedVariables()[row[j].Name] = row[j]
+		}
+	}
+
+	return vm, nil
+}
+
+/*
+checkNewVariableNames
+Description:
+
+	Verifies that the names are not empty and are not used by any variable of the model (or
+	repeated in names).
+*/
+func (m *Model) checkNewVariableNames(names ...string) error {
+	// Input Checking
+	if len(names) == 0 {
+		return nil
+	}
+
+	// Constants
+	usedNames := m.namedVariables()
+	newNames := make(map[string]bool, len(names))
+
+	// Algorithm
+	for _, name := range names {
+		if name == "" {
+			return fmt.Errorf("The name of a variable must not be empty.")
+		}
+		if _, isUsed := usedNames[name]; isUsed || newNames[name] {
+			return fmt.Errorf("The model already has a variable named %q", name)
 		}
+		newNames[name] = true
+	}
+	return nil
+}
+
+/*
+namedVariables
+Description:
+
+	Returns the map from the names of the variables of the model to the variables. The map is
+	built the first time that it is needed (e.g. for a model created as a struct literal) and is
+	then kept up to date as variables are added, edited and removed.
+*/
+func (m *Model) namedVariables() map[string]Variable {
+	if m.varsByName == nil {
+		m.varsByName = make(map[string]Variable)
+		for _, tempVar := range m.Variables {
+			if tempVar.Name != "" {
+				m.varsByName[tempVar.Name] = tempVar
+			}
+		}
+	}
+	return m.varsByName
+}
+
+/*
+VariableByName
+Description:
+
+	Returns the variable of the model with the given name.
+*/
+func (m *Model) VariableByName(name string) (Variable, error) {
+	if tempVar, found := m.namedVariables()[name]; found {
+		return tempVar, nil
+	}
+	return Variable{}, fmt.Errorf("The model does not have a variable named %q", name)
+}
+
+/*
+newVariableIDs
+Description:
+
+	Reserves num consecutive IDs for new variables and returns the first one. IDs are not reused
+	after a variable is removed.
+*/
+func (m *Model) newVariableIDs(num int) uint64 {
+	// Variables which were not added by the model's methods (e.g. a model created as a
+	// struct literal) may already use some IDs.
+	if m.nextVarID < uint64(len(m.Variables)) {
+		for _, tempVar := range m.Variables {
+			if tempVar.ID >= m.nextVarID {
+				m.nextVarID = tempVar.ID + 1
+			}
+		}
+	}
+
+	stID := m.nextVarID
+	m.nextVarID += uint64(num)
+	return stID
+}
+
+/*
+checkVariableSettings
+Description:
+
+	Verifies that the bounds and the type of a new variable are valid.
+*/
+func checkVariableSettings(lower, upper float64, vtype VarType) error {
+	if math.IsNaN(lower) || math.IsNaN(upper) {
+		return fmt.Errorf("The bounds of a variable can not be NaN; received [%v, %v]", lower, upper)
+	}
+	if lower > upper {
+		return fmt.Errorf("The lower bound of a variable (%v) must not be greater than its upper bound (%v)", lower, upper)
+	}
+	if math.IsInf(lower, 1) || math.IsInf(upper, -1) {
+		return fmt.Errorf("The bounds of a variable must allow a finite value; received [%v, %v]", lower, upper)
+	}
+	switch vtype {
+	case Continuous, Integer, Binary:
 	default:
-		logrus.Info(
-			fmt.Sprintf("Unexpected input to AddConstr %v of type %T.", optionalErrorArgument, optionalErrorArgument),
-		)
-		return
+		return fmt.Errorf("Unexpected variable type %q", vtype)
+	}
+	return nil
+}
+
+// AddConstr adds a the given constraint to the model and returns its handle,
+// which can be used to edit or remove the constraint later. The constraint can
+// be a ScalarConstraint, VectorConstraint or MatrixConstraint (or a pointer to
+// one of them); vector and matrix constraints are expanded into one scalar
+// constraint per element when the model is given to a solver. The error
+// returned while computing the constraint can be passed as an extra argument,
+// so that comparisons can be used directly:
+//
+//	handle, err := m.AddConstr(x.LessEq(y))
+//
+// An error is returned (and the constraint is not added) if that error is not
+// nil or if the constraint is not valid.
+func (m *Model) AddConstr(constr Constraint, extras ...interface{}) (ConstrHandle, error) {
+	// Input Processing
+	if err := checkOptionalError("AddConstr", extras); err != nil {
+		return 0, err
+	}
+
+	constr, err := m.checkConstr(constr)
+	if err != nil {
+		return 0, err
+	}
+	if err := m.checkNewConstraintNames(constraintNames(constr)...); err != nil {
+		return 0, err
 	}
 
 	// Algorithm
+	return m.appendConstr(constr), nil
+}
+
+/*
+checkConstr
+Description:
+
+	Verifies that the constraint can be added to the model and returns it as a ScalarConstraint,
+	VectorConstraint or MatrixConstraint (pointers are dereferenced).
+*/
+func (m *Model) checkConstr(constr Constraint) (Constraint, error) {
+	switch constrIn := constr.(type) {
+	case ScalarConstraint:
+		if constrIn.LeftHandSide == nil || constrIn.RightHandSide == nil {
+			return nil, fmt.Errorf("The scalar constraint %v is missing one of its sides.", constrIn)
+		}
+		return constrIn, nil
+	case *ScalarConstraint:
+		if constrIn == nil {
+			return nil, fmt.Errorf("The constraint given to AddConstr is nil.")
+		}
+		return m.checkConstr(*constrIn)
+	case VectorConstraint:
+		if err := constrIn.Check(); err != nil {
+			return nil, fmt.Errorf("The vector constraint is not valid: %v", err)
+		}
+		return constrIn, nil
+	case *VectorConstraint:
+		if constrIn == nil {
+			return nil, fmt.Errorf("The constraint given to AddConstr is nil.")
+		}
+		return m.checkConstr(*constrIn)
+	case MatrixConstraint:
+		if err := constrIn.Check(); err != nil {
+			return nil, fmt.Errorf("The matrix constraint is not valid: %v", err)
+		}
+		return constrIn, nil
+	case *MatrixConstraint:
+		if constrIn == nil {
+			return nil, fmt.Errorf("The constraint given to AddConstr is nil.")
+		}
+		return m.checkConstr(*constrIn)
+	}
+	return nil, fmt.Errorf("Unexpected type of constraint given to AddConstr: %T (%v)", constr, constr)
+}
+
+/*
+appendConstr
+Description:
+
+	Appends a (checked) constraint to the model and returns its new handle.
+*/
+func (m *Model) appendConstr(constr Constraint) ConstrHandle {
+	handle := m.nextConstrHandle
+	m.nextConstrHandle++
+
+	if names := constraintNames(constr); len(names) > 0 {
+		usedNames := m.namedConstrs()
+		for _, name := range names {
+			usedNames[name] = handle
+		}
+	}
+
 	m.constrs = append(m.constrs, constr)
+	m.constrHandles = append(m.constrHandles, handle)
+	return handle
+}
+
+/*
+AddNamedConstr
+Description:
+
+	Adds the constraint to the model with the given name and returns its handle. The rows of a
+	named vector constraint are named name[0], name[1], ... and the elements of a named matrix
+	constraint are named name[i,j]. An error is returned (and the constraint is not added) if
+	the name is empty or already used by another constraint of the model.
+*/
+func (m *Model) AddNamedConstr(name string, constr Constraint, extras ...interface{}) (ConstrHandle, error) {
+	// Input Checking
+	if name == "" {
+		return 0, fmt.Errorf("The name of a constraint must not be empty.")
+	}
+
+	// Algorithm
+	switch constrIn := constr.(type) {
+	case ScalarConstraint:
+		constrIn.Name = name
+		return m.AddConstr(constrIn, extras...)
+	case *ScalarConstraint:
+		if constrIn != nil {
+			return m.AddNamedConstr(name, *constrIn, extras...)
+		}
+	case VectorConstraint:
+		constrIn.Name = name
+		return m.AddConstr(constrIn, extras...)
+	case *VectorConstraint:
+		if constrIn != nil {
+			return m.AddNamedConstr(name, *constrIn, extras...)
+		}
+	case MatrixConstraint:
+		constrIn.Name = name
+		return m.AddConstr(constrIn, extras...)
+	case *MatrixConstraint:
+		if constrIn != nil {
+			return m.AddNamedConstr(name, *constrIn, extras...)
+		}
+	}
+
+	return m.AddConstr(constr, extras...) // Returns the appropriate error
+}
+
+/*
+constraintNames
+Description:
+
+	Returns the names of a constraint; for a named vector (or matrix) constraint, these are the
+	name of the constraint and the names of each of its rows (or elements).
+*/
+func constraintNames(constr Constraint) []string {
+	switch constrIn := constr.(type) {
+	case ScalarConstraint:
+		if constrIn.Name != "" {
+			return []string{constrIn.Name}
+		}
+	case VectorConstraint:
+		if constrIn.Name != "" {
+			names := []string{constrIn.Name}
+			for rowIndex := 0; rowIndex < constrIn.Len(); rowIndex++ {
+				names = append(names, constrIn.AtVec(rowIndex).Name)
+			}
+			return names
+		}
+	case MatrixConstraint:
+		if constrIn.Name != "" {
+			names := []string{constrIn.Name}
+			for _, element := range constraintRows(constrIn) {
+				names = append(names, element.Name)
+			}
+			return names
+		}
+	}
+	return nil
+}
+
+/*
+checkNewConstraintNames
+Description:
+
+	Verifies that none of the names are used by a constraint of the model. Empty names (unnamed
+	constraints) are always accepted.
+*/
+func (m *Model) checkNewConstraintNames(names ...string) error {
+	// Input Checking
+	if len(names) == 0 {
+		return nil
+	}
+
+	// Algorithm
+	usedNames := m.namedConstrs()
+	for _, name := range names {
+		if _, isUsed := usedNames[name]; name != "" && isUsed {
+			return fmt.Errorf("The model already has a constraint named %q", name)
+		}
+	}
+	return nil
+}
+
+/*
+namedConstrs
+Description:
+
+	Returns the map from the names of the constraints of the model (and of the rows or elements
+	of its named vector and matrix constraints) to the handles of the constraints. The map is
+	built the first time that it is needed and is then kept up to date as constraints are added
+	and removed.
+*/
+func (m *Model) namedConstrs() map[string]ConstrHandle {
+	if m.constrsByName == nil {
+		m.constrsByName = make(map[string]ConstrHandle)
+		for constrIndex, constr := range m.constrs {
+			for _, name := range constraintNames(constr) {
+				m.constrsByName[name] = m.constrHandles[constrIndex]
+			}
+		}
+	}
+	return m.constrsByName
+}
+
+/*
+ConstraintByName
+Description:
+
+	Returns the constraint of the model with the given name. The rows of a named vector
+	constraint can be found using the name of the row (e.g. name[2]) and the elements of a named
+	matrix constraint using the name of the element (e.g. name[1,2]).
+*/
+func (m *Model) ConstraintByName(name string) (Constraint, error) {
+	// Input Checking
+	if name == "" {
+		return nil, fmt.Errorf("The name of a constraint must not be empty.")
+	}
+
+	handle, found := m.namedConstrs()[name]
+	if !found {
+		return nil, fmt.Errorf("The model does not have a constraint named %q", name)
+	}
+	constrIndex, err := m.constrIndex(handle)
+	if err != nil {
+		return nil, err
+	}
+
+	// Algorithm
+	switch constrIn := m.constrs[constrIndex].(type) {
+	case VectorConstraint:
+		if constrIn.Name == name {
+			return constrIn, nil
+		}
+		for rowIndex := 0; rowIndex < constrIn.Len(); rowIndex++ {
+			if row := constrIn.AtVec(rowIndex); row.Name == name {
+				return row, nil
+			}
+		}
+	case MatrixConstraint:
+		if constrIn.Name == name {
+			return constrIn, nil
+		}
+		for _, element := range constraintRows(constrIn) {
+			if element.Name == name {
+				return element, nil
+			}
+		}
+	default:
+		return constrIn, nil
+	}
+	return nil, fmt.Errorf("The model does not have a constraint named %q", name)
+}
+
+/*
+scalarConstraints
+Description:
+
+	Returns the constraints of the model with each vector (or matrix) constraint expanded into
+	one scalar constraint per row (or element).
+*/
+func (m *Model) scalarConstraints() []ScalarConstraint {
+	var constrs []ScalarConstraint
+	for _, constr := range m.constrs {
+		constrs = append(constrs, constraintRows(constr)...)
+	}
+	return constrs
+}
+
+/*
+constraintRows
+Description:
+
+	Returns the scalar constraints which make up a constraint of the model (one per row for a
+	vector constraint and one per element, in row-major order, for a matrix constraint).
+*/
+func constraintRows(constr Constraint) []ScalarConstraint {
+	switch constrIn := constr.(type) {
+	case ScalarConstraint:
+		return []ScalarConstraint{constrIn}
+	case VectorConstraint:
+		rows, _ := constrIn.ScalarConstraints() // Vector constraints are checked in AddConstr
+		return rows
+	case MatrixConstraint:
+		elements, _ := constrIn.ScalarConstraints() // Matrix constraints are checked in AddConstr
+		return elements
+	}
+	return nil
 }
 
 // SetObjective sets the objective of the model given an expression and
-// objective sense.
-func (m *Model) SetObjective(e ScalarExpression, sense ObjSense) {
+// objective sense. As in AddConstr, the error returned while computing the
+// expression can be passed as an extra argument:
+//
+//	obj, err := x.Plus(y)
+//	err = m.SetObjective(obj, optim.SenseMinimize, err)
+//
+// An error is returned (and the objective is not changed) if that error is not
+// nil or if the objective is not valid.
+func (m *Model) SetObjective(e ScalarExpression, sense ObjSense, extras ...interface{}) error {
+	// Input Checking
+	if err := checkOptionalError("SetObjective", extras); err != nil {
+		return err
+	}
+	if e == nil {
+		return fmt.Errorf("The objective expression is nil.")
+	}
+	if sense != SenseMinimize && sense != SenseMaximize {
+		return fmt.Errorf("Unexpected objective sense %v; expected SenseMinimize or SenseMaximize", sense)
+	}
+
+	// Algorithm
 	m.obj = NewObjective(e, sense)
+	return nil
 }
 
 // Optimize optimizes the model using the given solver type and returns the
-// solution or an error.
+// solution or an error. If the solver stops without proving optimality (for
+// example, because of a time limit), then the solution it returned is given
+// along with the error.
 func (m *Model) Optimize(solver Solver) (*Solution, error) {
 	// Variables
 	var err error
@@ -197,16 +747,64 @@ func (m *Model) Optimize(solver Solver) (*Solution, error) {
 	// 	types.WriteByte(byte(v.Vtype))
 	// }
 
-	solver.ShowLog(m.showLog)
+	// A solver which is kept alive must be able to drop the model that it holds.
+	sessionSolver, isSessionSolver := solver.(SessionSolver)
+	if m.keepSolverAlive && !isSessionSolver {
+		return nil, fmt.Errorf("The solver of type %T can not be kept alive, because it does not implement the SessionSolver interface.", solver)
+	}
+
+	// A kept alive IncrementalSolver which already holds the model only receives the changes.
+	incrementalSolver, isIncrementalSolver := solver.(IncrementalSolver)
+	useSession := m.keepSolverAlive && m.session.holds(solver)
+	if !useSession {
+		m.session = nil
+	}
+
+	if m.keepSolverAlive && !useSession {
+		err = sessionSolver.ClearModel()
+		if err != nil {
+			return nil, fmt.Errorf("There was an error clearing the solver's previous model: %v", err)
+		}
+	}
+
+	if !m.keepSolverAlive {
+		defer solver.DeleteSolver()
+	}
+
+	err = solver.ShowLog(m.showLog)
+	if err != nil {
+		return nil, fmt.Errorf("There was an error setting the solver's log option: %v", err)
+	}
 
 	if m.timeLimit > 0 {
-		solver.SetTimeLimit(m.timeLimit.Seconds())
+		err = solver.SetTimeLimit(m.timeLimit.Seconds())
+		if err != nil {
+			return nil, fmt.Errorf("There was an error setting the solver's time limit: %v", err)
+		}
 	}
 
-	solver.AddVariables(m.Variables)
+	if useSession {
+		err = m.session.update(m)
+		if err != nil {
+			m.session = nil // The model is loaded again next time
+			return nil, fmt.Errorf("There was an error updating the model in the solver: %v", err)
+		}
+	} else {
+		err = solver.AddVariables(m.Variables)
+		if err != nil {
+			return nil, fmt.Errorf("There was an error adding the variables to the solver: %v", err)
+		}
 
-	for _, constr := range m.constrs {
-		solver.AddConstraint(constr)
+		for constrIndex, constr := range m.scalarConstraints() {
+			err = solver.AddConstraint(constr)
+			if err != nil {
+				return nil, fmt.Errorf("There was an error adding constraint %v to the solver: %v", constrIndex, err)
+			}
+		}
+
+		if m.keepSolverAlive && isIncrementalSolver {
+			m.session = newSolverSession(m, incrementalSolver)
+		}
 	}
 
 	if m.obj != nil {
@@ -220,14 +818,18 @@ func (m *Model) Optimize(solver Solver) (*Solution, error) {
 	}
 
 	mipSol, err := solver.Optimize()
-	defer solver.DeleteSolver()
+	if err != nil {
+		return nil, fmt.Errorf("There was an error optimizing the model: %v", err)
+	}
 
 	if mipSol.Status != OptimizationStatus_OPTIMAL {
 		errorMessage, err := mipSol.Status.ToMessage()
 		if err != nil {
 			return nil, fmt.Errorf("There was an issue converting optimization status to a message: %v", err)
 		}
-		return nil, fmt.Errorf(
+		// Return the solution along with the error, so that the best solution found so far
+		// (e.g. when a time limit is reached) can still be inspected.
+		return &mipSol, fmt.Errorf(
 			"[Code = %d] %s",
 			mipSol.Status,
 			errorMessage,
@@ -0,0 +1,516 @@
+package optim
+
+/*
+model_edit.go
+Description:
+	Defines the methods which edit a Model after it has been built: changing the bounds of
+	variables (or fixing them), changing the right hand side or a coefficient of a constraint and
+	removing variables and constraints. When the model is kept alive in an IncrementalSolver, the
+	edits are sent to the solver the next time the model is optimized instead of loading the
+	model again.
+*/
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+)
+
+// Type Definitions
+// ================
+
+/*
+solverSession
+Description:
+
+	Records what a kept alive IncrementalSolver holds: the variables in the order in which they
+	were given to it, the handle of the constraint that each of its rows came from and the
+	variables and constraints which were edited since then.
+*/
+type solverSession struct {
+	solver          IncrementalSolver
+	variables       []Variable
+	rowHandles      []ConstrHandle
+	editedVariables map[uint64]bool
+	editedConstrs   map[ConstrHandle]bool
+}
+
+// Functions
+// =========
+
+/*
+variablesByID
+Description:
+
+	Maps the ID of each variable of the model to the variable.
+*/
+func (m *Model) variablesByID() map[uint64]Variable {
+	vars := make(map[uint64]Variable, len(m.Variables))
+	for _, tempVar := range m.Variables {
+		vars[tempVar.ID] = tempVar
+	}
+	return vars
+}
+
+/*
+variableIndex
+Description:
+
+	Returns the index in m.Variables of the variable with the given ID.
+*/
+func (m *Model) variableIndex(id uint64) (int, error) {
+	for varIndex, tempVar := range m.Variables {
+		if tempVar.ID == id {
+			return varIndex, nil
+		}
+	}
+	return -1, fmt.Errorf("The variable with ID %v is not in the model", id)
+}
+
+/*
+constrIndex
+Description:
+
+	Returns the index in m.constrs of the constraint with the given handle. Handles are given out
+	in increasing order, so they can be searched with a binary search.
+*/
+func (m *Model) constrIndex(handle ConstrHandle) (int, error) {
+	constrIndex := sort.Search(len(m.constrHandles), func(i int) bool {
+		return m.constrHandles[i] >= handle
+	})
+	if constrIndex == len(m.constrHandles) || m.constrHandles[constrIndex] != handle {
+		return -1, fmt.Errorf("The model does not have a constraint with handle %v; it may have been removed", handle)
+	}
+	return constrIndex, nil
+}
+
+/*
+Constraint
+Description:
+
+	Returns the constraint of the model with the given handle.
+*/
+func (m *Model) Constraint(handle ConstrHandle) (Constraint, error) {
+	constrIndex, err := m.constrIndex(handle)
+	if err != nil {
+		return nil, err
+	}
+	return m.constrs[constrIndex], nil
+}
+
+/*
+SetVariableBounds
+Description:
+
+	Changes the bounds of a variable of the model. If the variable was fixed, then the new bounds
+	replace the ones it had before it was fixed.
+*/
+func (m *Model) SetVariableBounds(v Variable, lower, upper float64) error {
+	// Input Checking
+	varIndex, err := m.variableIndex(v.ID)
+	if err != nil {
+		return err
+	}
+	if err := checkVariableSettings(lower, upper, m.Variables[varIndex].Vtype); err != nil {
+		return err
+	}
+
+	// Algorithm
+	delete(m.fixedBounds, v.ID)
+	m.setBounds(varIndex, lower, upper)
+	return nil
+}
+
+/*
+FixVariable
+Description:
+
+	Fixes the variable to the given value by setting both of its bounds to it. The previous
+	bounds are restored by UnfixVariable.
+*/
+func (m *Model) FixVariable(v Variable, value float64) error {
+	// Input Checking
+	varIndex, err := m.variableIndex(v.ID)
+	if err != nil {
+		return err
+	}
+	if math.IsInf(value, 0) || math.IsNaN(value) {
+		return fmt.Errorf("A variable can only be fixed to a finite value; received %v", value)
+	}
+
+	// Algorithm
+	if _, isFixed := m.fixedBounds[v.ID]; !isFixed {
+		if m.fixedBounds == nil {
+			m.fixedBounds = make(map[uint64][2]float64)
+		}
+		m.fixedBounds[v.ID] = [2]float64{m.Variables[varIndex].Lower, m.Variables[varIndex].Upper}
+	}
+	m.setBounds(varIndex, value, value)
+	return nil
+}
+
+/*
+UnfixVariable
+Description:
+
+	Restores the bounds that a variable fixed by FixVariable had before it was fixed.
+*/
+func (m *Model) UnfixVariable(v Variable) error {
+	// Input Checking
+	varIndex, err := m.variableIndex(v.ID)
+	if err != nil {
+		return err
+	}
+	bounds, isFixed := m.fixedBounds[v.ID]
+	if !isFixed {
+		return fmt.Errorf("The variable %v is not fixed", m.Variables[varIndex].DisplayName())
+	}
+
+	// Algorithm
+	delete(m.fixedBounds, v.ID)
+	m.setBounds(varIndex, bounds[0], bounds[1])
+	return nil
+}
+
+/*
+setBounds
+Description:
+
+	Sets the bounds of the variable m.Variables[varIndex] and records the edit.
+*/
+func (m *Model) setBounds(varIndex int, lower, upper float64) {
+	m.Variables[varIndex].Lower = lower
+	m.Variables[varIndex].Upper = upper
+	if name := m.Variables[varIndex].Name; name != "" && m.varsByName != nil {
+		m.varsByName[name] = m.Variables[varIndex]
+	}
+	if m.session != nil {
+		m.session.editedVariables[m.Variables[varIndex].ID] = true
+	}
+}
+
+/*
+RemoveVariable
+Description:
+
+	Removes a variable from the model. The variable must not appear in the objective or in any
+	constraint; remove (or edit) those first. The IDs of the other variables do not change.
+*/
+func (m *Model) RemoveVariable(v Variable) error {
+	// Input Checking
+	varIndex, err := m.variableIndex(v.ID)
+	if err != nil {
+		return err
+	}
+	name := m.Variables[varIndex].DisplayName()
+
+	if m.obj != nil && containsID(m.obj.IDs(), v.ID) {
+		return fmt.Errorf("The variable %v can not be removed, since it appears in the objective", name)
+	}
+	for constrIndex, constr := range m.constrs {
+		if constraintUsesVariable(constr, v.ID) {
+			return fmt.Errorf(
+				"The variable %v can not be removed, since it appears in the constraint with handle %v",
+				name, m.constrHandles[constrIndex],
+			)
+		}
+	}
+
+	// Algorithm
+	delete(m.varsByName, m.Variables[varIndex].Name)
+	m.Variables = append(m.Variables[:varIndex], m.Variables[varIndex+1:]...)
+	delete(m.fixedBounds, v.ID)
+	return nil
+}
+
+/*
+constraintUsesVariable
+Description:
+
+	Determines whether or not the variable with the given ID appears in either side of the
+	constraint.
+*/
+func constraintUsesVariable(constr Constraint, id uint64) bool {
+	switch constrIn := constr.(type) {
+	case ScalarConstraint:
+		return containsID(constrIn.LeftHandSide.IDs(), id) || containsID(constrIn.RightHandSide.IDs(), id)
+	case VectorConstraint:
+		return containsID(constrIn.LeftHandSide.IDs(), id) || containsID(constrIn.RightHandSide.IDs(), id)
+	case MatrixConstraint:
+		return containsID(constrIn.LeftHandSide.IDs(), id) || containsID(constrIn.RightHandSide.IDs(), id)
+	}
+	return false
+}
+
+func containsID(ids []uint64, id uint64) bool {
+	for _, tempID := range ids {
+		if tempID == id {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+RemoveConstr
+Description:
+
+	Removes the constraint with the given handle from the model. The handles of the other
+	constraints do not change.
+*/
+func (m *Model) RemoveConstr(handle ConstrHandle) error {
+	// Input Checking
+	constrIndex, err := m.constrIndex(handle)
+	if err != nil {
+		return err
+	}
+
+	// Algorithm
+	for _, name := range constraintNames(m.constrs[constrIndex]) {
+		delete(m.constrsByName, name)
+	}
+	m.constrs = append(m.constrs[:constrIndex], m.constrs[constrIndex+1:]...)
+	m.constrHandles = append(m.constrHandles[:constrIndex], m.constrHandles[constrIndex+1:]...)
+	return nil
+}
+
+/*
+SetConstrRHS
+Description:
+
+	Changes the right hand side of a scalar constraint. The constraint is first written as
+		(terms with variables) sense rhs,
+	with every variable moved to the left hand side and every constant moved to the right hand
+	side, and then rhs is replaced.
+*/
+func (m *Model) SetConstrRHS(handle ConstrHandle, rhs float64) error {
+	// Input Checking
+	if math.IsNaN(rhs) {
+		return fmt.Errorf("The right hand side of a constraint can not be NaN.")
+	}
+
+	constrIndex, terms, err := m.scalarConstraintTerms(handle)
+	if err != nil {
+		return err
+	}
+
+	// Algorithm
+	terms.Constant = -rhs
+	return m.replaceScalarConstraint(constrIndex, terms)
+}
+
+/*
+SetConstrCoeff
+Description:
+
+	Changes the coefficient of the variable v in a scalar constraint, written as in SetConstrRHS.
+	A coefficient of zero removes the variable from the constraint.
+*/
+func (m *Model) SetConstrCoeff(handle ConstrHandle, v Variable, coeff float64) error {
+	// Input Checking
+	if math.IsInf(coeff, 0) || math.IsNaN(coeff) {
+		return fmt.Errorf("The coefficient of a variable must be finite; received %v", coeff)
+	}
+	if _, err := m.variableIndex(v.ID); err != nil {
+		return err
+	}
+
+	constrIndex, terms, err := m.scalarConstraintTerms(handle)
+	if err != nil {
+		return err
+	}
+
+	// Algorithm
+	found := false
+	for termIndex, term := range terms.Linear {
+		if term.ID == v.ID {
+			terms.Linear[termIndex].Coeff = coeff
+			found = true
+		}
+	}
+	if !found {
+		terms.addLinear(v.ID, coeff)
+	}
+	return m.replaceScalarConstraint(constrIndex, terms.WithoutZeros())
+}
+
+/*
+scalarConstraintTerms
+Description:
+
+	Returns the index and the terms of (LeftHandSide - RightHandSide) of the scalar constraint with
+	the given handle.
+*/
+func (m *Model) scalarConstraintTerms(handle ConstrHandle) (int, expressionTerms, error) {
+	constrIndex, err := m.constrIndex(handle)
+	if err != nil {
+		return -1, expressionTerms{}, err
+	}
+
+	sc, isScalar := m.constrs[constrIndex].(ScalarConstraint)
+	if !isScalar {
+		return -1, expressionTerms{}, fmt.Errorf(
+			"Only scalar constraints can be edited; the constraint with handle %v is a %T",
+			handle, m.constrs[constrIndex],
+		)
+	}
+
+	terms, err := constraintTermsOf(sc)
+	if err != nil {
+		return -1, expressionTerms{}, fmt.Errorf("There was an issue reading the constraint with handle %v: %v", handle, err)
+	}
+	return constrIndex, terms, nil
+}
+
+/*
+replaceScalarConstraint
+Description:
+
+	Replaces the scalar constraint m.constrs[constrIndex] with
+		(terms without the constant) sense -terms.Constant
+	keeping its sense and name, and records the edit.
+*/
+func (m *Model) replaceScalarConstraint(constrIndex int, terms expressionTerms) error {
+	// Constants
+	sc := m.constrs[constrIndex].(ScalarConstraint)
+	rhs := -terms.Constant
+	terms.Constant = 0
+
+	// Algorithm
+	m.constrs[constrIndex] = ScalarConstraint{
+		LeftHandSide:  terms.ToScalarExpression(m.variablesByID()),
+		RightHandSide: K(rhs),
+		Sense:         sc.Sense,
+		Name:          sc.Name,
+	}
+	if m.session != nil {
+		m.session.editedConstrs[m.constrHandles[constrIndex]] = true
+	}
+	return nil
+}
+
+/*
+newSolverSession
+Description:
+
+	Records that the solver holds the current variables and constraints of the model.
+*/
+func newSolverSession(m *Model, solver IncrementalSolver) *solverSession {
+	session := &solverSession{
+		solver:          solver,
+		variables:       append([]Variable{}, m.Variables...),
+		editedVariables: make(map[uint64]bool),
+		editedConstrs:   make(map[ConstrHandle]bool),
+	}
+	for constrIndex, constr := range m.constrs {
+		for range constraintRows(constr) {
+			session.rowHandles = append(session.rowHandles, m.constrHandles[constrIndex])
+		}
+	}
+	return session
+}
+
+/*
+holds
+Description:
+
+	Determines whether or not the session belongs to the given solver.
+*/
+func (session *solverSession) holds(solver Solver) bool {
+	if session == nil || !reflect.TypeOf(solver).Comparable() {
+		return false
+	}
+	return Solver(session.solver) == solver
+}
+
+/*
+update
+Description:
+
+	Sends the changes made to the model since the session was created to the solver: the
+	removed rows and variables are removed, the edited ones are changed and the new ones are
+	added. The rows keep the order of the constraints of the model, since new constraints are
+	always added at the end.
+*/
+func (session *solverSession) update(m *Model) error {
+	// Constants
+	solver := session.solver
+	currentVars := m.variablesByID()
+	currentConstrs := make(map[ConstrHandle]int, len(m.constrHandles))
+	for constrIndex, handle := range m.constrHandles {
+		currentConstrs[handle] = constrIndex
+	}
+
+	// Remove the rows of removed constraints (starting from the end, so the indices do not shift)
+	for rowIndex := len(session.rowHandles) - 1; rowIndex >= 0; rowIndex-- {
+		if _, found := currentConstrs[session.rowHandles[rowIndex]]; found {
+			continue
+		}
+		if err := solver.RemoveConstraint(rowIndex); err != nil {
+			return fmt.Errorf("There was an issue removing row %v from the solver: %v", rowIndex, err)
+		}
+		session.rowHandles = append(session.rowHandles[:rowIndex], session.rowHandles[rowIndex+1:]...)
+	}
+
+	// Remove and change variables
+	loadedVars := make(map[uint64]bool, len(session.variables))
+	for _, tempVar := range session.variables {
+		loadedVars[tempVar.ID] = true
+		currentVar, found := currentVars[tempVar.ID]
+		switch {
+		case !found:
+			if err := solver.RemoveVariable(tempVar); err != nil {
+				return fmt.Errorf("There was an issue removing the variable %v from the solver: %v", tempVar.DisplayName(), err)
+			}
+		case session.editedVariables[tempVar.ID]:
+			if err := solver.SetVariableBounds(currentVar); err != nil {
+				return fmt.Errorf("There was an issue changing the bounds of %v in the solver: %v", tempVar.DisplayName(), err)
+			}
+		}
+	}
+
+	var newVars []Variable
+	for _, tempVar := range m.Variables {
+		if !loadedVars[tempVar.ID] {
+			newVars = append(newVars, tempVar)
+		}
+	}
+	if len(newVars) > 0 {
+		if err := solver.AddVariables(newVars); err != nil {
+			return fmt.Errorf("There was an error adding the new variables to the solver: %v", err)
+		}
+	}
+
+	// Change the rows of edited constraints
+	loadedConstrs := make(map[ConstrHandle]bool)
+	for rowIndex := 0; rowIndex < len(session.rowHandles); {
+		handle := session.rowHandles[rowIndex]
+		loadedConstrs[handle] = true
+		rows := constraintRows(m.constrs[currentConstrs[handle]])
+		for rowOffset, row := range rows {
+			if session.editedConstrs[handle] {
+				if err := solver.SetConstraint(rowIndex+rowOffset, row); err != nil {
+					return fmt.Errorf("There was an issue changing row %v in the solver: %v", rowIndex+rowOffset, err)
+				}
+			}
+		}
+		rowIndex += len(rows)
+	}
+
+	// Add the new constraints
+	for constrIndex, handle := range m.constrHandles {
+		if loadedConstrs[handle] {
+			continue
+		}
+		for _, row := range constraintRows(m.constrs[constrIndex]) {
+			if err := solver.AddConstraint(row); err != nil {
+				return fmt.Errorf("There was an error adding the constraint with handle %v to the solver: %v", handle, err)
+			}
+		}
+	}
+
+	// The solver now holds the current model
+	*session = *newSolverSession(m, solver)
+	return nil
+}
@@ -0,0 +1,732 @@
+package optim
+
+/*
+mps_reader.go
+Description:
+	Defines the functions that read a model from a file in the fixed or free MPS format. Lines are
+	split on whitespace; lines which can not be read that way (e.g. fixed format lines with spaces
+	in their names or with an empty RHS set name) are read using the columns of the fixed format.
+*/
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/kwesiRutledge/gurobi.go/gurobi"
+)
+
+// Type Definitions
+// ================
+
+/*
+mpsRecord
+Description:
+
+	The fields of a data line of an MPS file, named after the fields of the fixed format:
+		Code Name1 Name2 Value1 Name3 Value2
+	Columns contains the column (starting at 1) where each field starts, for error messages.
+*/
+type mpsRecord struct {
+	Fields  [6]string
+	Columns [6]int
+}
+
+const (
+	mpsFieldCode = iota
+	mpsFieldName1
+	mpsFieldName2
+	mpsFieldValue1
+	mpsFieldName3
+	mpsFieldValue2
+)
+
+/*
+mpsParser
+Description:
+
+	Holds the problem read so far and the state needed while reading an MPS file.
+*/
+type mpsParser struct {
+	problem mpsProblem
+
+	section   string
+	line      int
+	inInteger bool
+
+	objRow      string
+	freeRows    map[string]bool
+	rowIndex    map[string]int
+	columnIndex map[string]int
+	lowerSet    []bool
+}
+
+// Functions
+// =========
+
+/*
+ReadMPS
+Description:
+
+	Reads a model written in the fixed or free MPS format from r. The ROWS, COLUMNS, RHS, RANGES,
+	BOUNDS, QUADOBJ and QMATRIX sections are supported, along with integer markers. Ranged rows
+	become two constraints (a lower and an upper one). Returns the model and a map from the column
+	names to the variables of the model. Errors in the file are reported as a ParseError.
+*/
+func ReadMPS(r io.Reader) (*Model, map[string]Variable, error) {
+	// Constants
+	p := &mpsParser{
+		problem:     mpsProblem{Sense: SenseMinimize},
+		freeRows:    make(map[string]bool),
+		rowIndex:    make(map[string]int),
+		columnIndex: make(map[string]int),
+	}
+
+	// Algorithm
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	ended := false
+	for scanner.Scan() && !ended {
+		p.line++
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		if len(strings.TrimSpace(line)) == 0 || line[0] == '*' {
+			continue
+		}
+
+		var err error
+		if line[0] != ' ' && line[0] != '\t' && isMPSSection(line) {
+			ended, err = p.parseSection(line)
+		} else {
+			err = p.parseData(line)
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("There was an issue reading the MPS file: %v", err)
+	}
+
+	return p.problem.toModel()
+}
+
+/*
+isMPSSection
+Description:
+
+	Determines whether or not a line (which starts in the first column) is a section header.
+*/
+func isMPSSection(line string) bool {
+	switch strings.ToUpper(strings.Fields(line)[0]) {
+	case "NAME", "OBJSENSE", "OBJSENCE", "ROWS", "COLUMNS", "RHS", "RANGES", "BOUNDS",
+		"QUADOBJ", "QMATRIX", "ENDATA", "SOS", "QCMATRIX", "INDICATORS", "OBJNAME":
+		return true
+	}
+	return false
+}
+
+/*
+errorAt
+Description:
+
+	Creates a ParseError on the current line.
+*/
+func (p *mpsParser) errorAt(column int, format string, args ...interface{}) error {
+	return ParseError{Line: p.line, Column: column, Msg: fmt.Sprintf(format, args...)}
+}
+
+/*
+parseSection
+Description:
+
+	Reads a section header. Returns true if the header is ENDATA.
+*/
+func (p *mpsParser) parseSection(line string) (bool, error) {
+	// Constants
+	fields := strings.Fields(line)
+	section := strings.ToUpper(fields[0])
+
+	// Algorithm
+	switch section {
+	case "NAME":
+		p.problem.Name = strings.TrimSpace(line[len(fields[0]):])
+	case "OBJSENSE", "OBJSENCE":
+		section = "OBJSENSE"
+		if len(fields) > 1 {
+			if err := p.setSense(fields[1], strings.Index(line, fields[1])+1); err != nil {
+				return false, err
+			}
+		}
+	case "ENDATA":
+		return true, nil
+	case "SOS", "QCMATRIX", "INDICATORS", "OBJNAME":
+		return false, p.errorAt(1, "the section %v is not supported", section)
+	}
+
+	if section != "COLUMNS" {
+		p.inInteger = false
+	}
+	p.section = section
+	return false, nil
+}
+
+/*
+setSense
+Description:
+
+	Sets the objective sense from the value in an OBJSENSE section.
+*/
+func (p *mpsParser) setSense(value string, column int) error {
+	switch strings.ToUpper(value) {
+	case "MAX", "MAXIMIZE", "MAXIMISE":
+		p.problem.Sense = SenseMaximize
+	case "MIN", "MINIMIZE", "MINIMISE":
+		p.problem.Sense = SenseMinimize
+	default:
+		return p.errorAt(column, "unexpected objective sense %q", value)
+	}
+	return nil
+}
+
+/*
+splitMPSLine
+Description:
+
+	Splits a line on whitespace, returning the fields and the columns where they start.
+*/
+func splitMPSLine(line string) ([]string, []int) {
+	var fields []string
+	var columns []int
+	for col := 0; col < len(line); {
+		if line[col] == ' ' || line[col] == '\t' {
+			col++
+			continue
+		}
+		end := col
+		for end < len(line) && line[end] != ' ' && line[end] != '\t' {
+			end++
+		}
+		fields = append(fields, line[col:end])
+		columns = append(columns, col+1)
+		col = end
+	}
+	return fields, columns
+}
+
+/*
+fixedMPSRecord
+Description:
+
+	Reads a line using the columns of the fixed MPS format (2-3, 5-12, 15-22, 25-36, 40-47 and
+	50-61).
+*/
+func fixedMPSRecord(line string) mpsRecord {
+	// Constants
+	starts := [6]int{1, 4, 14, 24, 39, 49}
+	ends := [6]int{3, 12, 22, 36, 47, 61}
+
+	// Algorithm
+	var record mpsRecord
+	for fieldIndex := range starts {
+		record.Columns[fieldIndex] = starts[fieldIndex] + 1
+		if starts[fieldIndex] >= len(line) {
+			continue
+		}
+		end := ends[fieldIndex]
+		if end > len(line) {
+			end = len(line)
+		}
+		record.Fields[fieldIndex] = strings.TrimSpace(line[starts[fieldIndex]:end])
+	}
+	return record
+}
+
+/*
+recordOf
+Description:
+
+	Splits a data line of the current section into a record. The whitespace separated fields are
+	assigned to the record according to their number; if their number does not fit the section,
+	the line is read using the fixed format columns.
+*/
+func (p *mpsParser) recordOf(line string) (mpsRecord, bool) {
+	// Constants
+	fields, columns := splitMPSLine(line)
+	var record mpsRecord
+	assign := func(positions ...int) (mpsRecord, bool) {
+		// Bounds without a value have one field less than positions.
+		for fieldIndex := 0; fieldIndex < len(fields) && fieldIndex < len(positions); fieldIndex++ {
+			position := positions[fieldIndex]
+			record.Fields[position] = fields[fieldIndex]
+			record.Columns[position] = columns[fieldIndex]
+		}
+		return record, true
+	}
+
+	// Algorithm
+	switch p.section {
+	case "ROWS":
+		if len(fields) == 2 {
+			return assign(mpsFieldCode, mpsFieldName1)
+		}
+	case "COLUMNS":
+		switch len(fields) {
+		case 3:
+			return assign(mpsFieldName1, mpsFieldName2, mpsFieldValue1)
+		case 5:
+			return assign(mpsFieldName1, mpsFieldName2, mpsFieldValue1, mpsFieldName3, mpsFieldValue2)
+		}
+	case "RHS", "RANGES":
+		switch len(fields) {
+		case 2:
+			return assign(mpsFieldName2, mpsFieldValue1)
+		case 3:
+			return assign(mpsFieldName1, mpsFieldName2, mpsFieldValue1)
+		case 4:
+			return assign(mpsFieldName2, mpsFieldValue1, mpsFieldName3, mpsFieldValue2)
+		case 5:
+			return assign(mpsFieldName1, mpsFieldName2, mpsFieldValue1, mpsFieldName3, mpsFieldValue2)
+		}
+	case "BOUNDS":
+		hasValue := true
+		if len(fields) > 0 {
+			switch strings.ToUpper(fields[0]) {
+			case "FR", "MI", "PL":
+				hasValue = false
+			case "BV":
+				hasValue = len(fields) == 4
+			}
+		}
+		switch {
+		case hasValue && len(fields) == 4, !hasValue && len(fields) == 3:
+			return assign(mpsFieldCode, mpsFieldName1, mpsFieldName2, mpsFieldValue1)
+		case hasValue && len(fields) == 3, !hasValue && len(fields) == 2:
+			return assign(mpsFieldCode, mpsFieldName2, mpsFieldValue1)
+		}
+	case "QUADOBJ", "QMATRIX":
+		if len(fields) == 3 {
+			return assign(mpsFieldName1, mpsFieldName2, mpsFieldValue1)
+		}
+	case "OBJSENSE":
+		if len(fields) == 1 {
+			return assign(mpsFieldName1)
+		}
+	}
+
+	if line[0] == ' ' && len(line) > 4 {
+		return fixedMPSRecord(line), true
+	}
+	return record, false
+}
+
+/*
+parseData
+Description:
+
+	Reads a data line of the current section.
+*/
+func (p *mpsParser) parseData(line string) error {
+	// Input Checking
+	if p.section == "" || p.section == "NAME" {
+		return p.errorAt(1, "expected a section header (e.g. ROWS), found %q", strings.TrimSpace(line))
+	}
+
+	record, ok := p.recordOf(line)
+	if !ok {
+		return p.errorAt(1, "unexpected number of fields in the %v section", p.section)
+	}
+
+	// Algorithm
+	switch p.section {
+	case "OBJSENSE":
+		return p.setSense(record.Fields[mpsFieldName1], record.Columns[mpsFieldName1])
+	case "ROWS":
+		return p.parseRow(record)
+	case "COLUMNS":
+		return p.parseColumn(record)
+	case "RHS", "RANGES":
+		return p.parseRHSOrRange(record)
+	case "BOUNDS":
+		return p.parseBound(record)
+	case "QUADOBJ", "QMATRIX":
+		return p.parseQuadratic(record)
+	}
+	return p.errorAt(1, "unexpected data in the %v section", p.section)
+}
+
+/*
+parseNumber
+Description:
+
+	Reads the numeric field with the given position from record.
+*/
+func (p *mpsParser) parseNumber(record mpsRecord, position int) (float64, error) {
+	value, err := strconv.ParseFloat(record.Fields[position], 64)
+	if err != nil {
+		return 0, p.errorAt(record.Columns[position], "invalid number %q", record.Fields[position])
+	}
+	return value, nil
+}
+
+/*
+parseRow
+Description:
+
+	Reads a line of the ROWS section. The first N row is the objective; other N rows are free
+	rows, which are ignored.
+*/
+func (p *mpsParser) parseRow(record mpsRecord) error {
+	// Constants
+	rowType := strings.ToUpper(record.Fields[mpsFieldCode])
+	name := record.Fields[mpsFieldName1]
+
+	// Input Checking
+	if _, found := p.rowIndex[name]; found || p.freeRows[name] || name == p.objRow && name != "" {
+		return p.errorAt(record.Columns[mpsFieldName1], "the row %q is defined twice", name)
+	}
+
+	// Algorithm
+	switch rowType {
+	case "N":
+		if p.objRow != "" {
+			p.freeRows[name] = true
+			return nil
+		}
+		p.objRow = name
+		return nil
+	case "E", "L", "G":
+		p.rowIndex[name] = len(p.problem.Rows)
+		p.problem.Rows = append(p.problem.Rows, mpsRow{Name: name, Type: rowType[0]})
+		return nil
+	}
+	return p.errorAt(record.Columns[mpsFieldCode], "unexpected row type %q", record.Fields[mpsFieldCode])
+}
+
+/*
+column
+Description:
+
+	Returns the index of the column with the given name, creating it if it does not exist yet
+	and create is true. New columns are integer if they appear between integer markers.
+*/
+func (p *mpsParser) column(record mpsRecord, position int, create bool) (int, error) {
+	name := record.Fields[position]
+	if colIndex, found := p.columnIndex[name]; found {
+		return colIndex, nil
+	}
+	if !create {
+		return 0, p.errorAt(record.Columns[position], "the column %q is not defined in the COLUMNS section", name)
+	}
+
+	vtype := Continuous
+	if p.inInteger {
+		vtype = Integer
+	}
+	p.columnIndex[name] = len(p.problem.Columns)
+	p.problem.Columns = append(p.problem.Columns, mpsColumn{Name: name, Vtype: vtype, Upper: gurobi.INFINITY})
+	p.lowerSet = append(p.lowerSet, false)
+	return len(p.problem.Columns) - 1, nil
+}
+
+/*
+parseColumn
+Description:
+
+	Reads a line of the COLUMNS section, which is either an integer marker or up to two
+	coefficients of a column.
+*/
+func (p *mpsParser) parseColumn(record mpsRecord) error {
+	// Integer markers
+	if strings.Trim(strings.ToUpper(record.Fields[mpsFieldName2]), "'") == "MARKER" {
+		marker := record.Fields[mpsFieldValue1]
+		if marker == "" {
+			marker = record.Fields[mpsFieldName3]
+		}
+		switch strings.Trim(strings.ToUpper(marker), "'") {
+		case "INTORG":
+			p.inInteger = true
+		case "INTEND":
+			p.inInteger = false
+		default:
+			return p.errorAt(record.Columns[mpsFieldName2], "unexpected marker %q", marker)
+		}
+		return nil
+	}
+
+	colIndex, err := p.column(record, mpsFieldName1, true)
+	if err != nil {
+		return err
+	}
+
+	for _, positions := range [][2]int{{mpsFieldName2, mpsFieldValue1}, {mpsFieldName3, mpsFieldValue2}} {
+		rowName := record.Fields[positions[0]]
+		if rowName == "" {
+			continue
+		}
+		value, err := p.parseNumber(record, positions[1])
+		if err != nil {
+			return err
+		}
+
+		_, isRow := p.rowIndex[rowName]
+		switch {
+		case rowName == p.objRow || isRow:
+			p.problem.Columns[colIndex].Entries = append(p.problem.Columns[colIndex].Entries, mpsEntry{rowName, value})
+		case !p.freeRows[rowName]:
+			return p.errorAt(record.Columns[positions[0]], "the row %q is not defined in the ROWS section", rowName)
+		}
+	}
+	return nil
+}
+
+/*
+parseRHSOrRange
+Description:
+
+	Reads a line of the RHS or RANGES section. A right hand side on the objective row is the
+	negated constant of the objective.
+*/
+func (p *mpsParser) parseRHSOrRange(record mpsRecord) error {
+	for _, positions := range [][2]int{{mpsFieldName2, mpsFieldValue1}, {mpsFieldName3, mpsFieldValue2}} {
+		rowName := record.Fields[positions[0]]
+		if rowName == "" {
+			continue
+		}
+		value, err := p.parseNumber(record, positions[1])
+		if err != nil {
+			return err
+		}
+
+		rowIndex, isRow := p.rowIndex[rowName]
+		switch {
+		case rowName == p.objRow && p.section == "RHS":
+			p.problem.ObjConstant = -value
+		case isRow && p.section == "RHS":
+			p.problem.Rows[rowIndex].RHS = value
+		case isRow:
+			p.problem.Rows[rowIndex].Range = value
+			p.problem.Rows[rowIndex].HasRange = true
+		case !p.freeRows[rowName]:
+			return p.errorAt(record.Columns[positions[0]], "the row %q is not a constraint", rowName)
+		}
+	}
+	return nil
+}
+
+/*
+parseBound
+Description:
+
+	Reads a line of the BOUNDS section. An upper bound below zero on a column without a lower
+	bound makes the lower bound -inf, as in most solvers.
+*/
+func (p *mpsParser) parseBound(record mpsRecord) error {
+	// Constants
+	boundType := strings.ToUpper(record.Fields[mpsFieldCode])
+
+	colIndex, err := p.column(record, mpsFieldName2, false)
+	if err != nil {
+		return err
+	}
+	column := &p.problem.Columns[colIndex]
+
+	var value float64
+	if record.Fields[mpsFieldValue1] != "" {
+		value, err = p.parseNumber(record, mpsFieldValue1)
+		if err != nil {
+			return err
+		}
+		value = mpsBoundValue(value)
+	}
+
+	// Algorithm
+	switch boundType {
+	case "UP", "UI":
+		column.Upper = value
+		if value < 0 && !p.lowerSet[colIndex] {
+			column.Lower = -gurobi.INFINITY
+		}
+	case "LO", "LI":
+		column.Lower = value
+		p.lowerSet[colIndex] = true
+	case "FX":
+		column.Lower, column.Upper = value, value
+		p.lowerSet[colIndex] = true
+	case "FR":
+		column.Lower, column.Upper = -gurobi.INFINITY, gurobi.INFINITY
+		p.lowerSet[colIndex] = true
+	case "MI":
+		column.Lower = -gurobi.INFINITY
+		p.lowerSet[colIndex] = true
+	case "PL":
+		column.Upper = gurobi.INFINITY
+	case "BV":
+		column.Vtype = Binary
+		column.Lower, column.Upper = 0, 1
+		p.lowerSet[colIndex] = true
+	default:
+		return p.errorAt(record.Columns[mpsFieldCode], "unsupported bound type %q", record.Fields[mpsFieldCode])
+	}
+
+	if (boundType == "UI" || boundType == "LI") && column.Vtype == Continuous {
+		column.Vtype = Integer
+	}
+	return nil
+}
+
+/*
+mpsBoundValue
+Description:
+
+	Converts values which are infinite (or at least 1e30 in magnitude) to the infinite bounds used
+	by the Model.
+*/
+func mpsBoundValue(value float64) float64 {
+	switch {
+	case math.IsInf(value, 1) || value >= 1e30:
+		return gurobi.INFINITY
+	case math.IsInf(value, -1) || value <= -1e30:
+		return -gurobi.INFINITY
+	}
+	return value
+}
+
+/*
+parseQuadratic
+Description:
+
+	Reads a line of the QUADOBJ or QMATRIX section. QUADOBJ only lists one triangle of the Hessian
+	H, while QMATRIX lists all of it, so off diagonal QMATRIX entries are halved.
+*/
+func (p *mpsParser) parseQuadratic(record mpsRecord) error {
+	// Constants
+	col1, err := p.column(record, mpsFieldName1, false)
+	if err != nil {
+		return err
+	}
+	col2, err := p.column(record, mpsFieldName2, false)
+	if err != nil {
+		return err
+	}
+	value, err := p.parseNumber(record, mpsFieldValue1)
+	if err != nil {
+		return err
+	}
+
+	// Algorithm
+	if p.section == "QMATRIX" && col1 != col2 {
+		value /= 2
+	}
+	p.problem.QuadObj = append(p.problem.QuadObj, mpsQuadraticEntry{
+		Col1:  p.problem.Columns[col1].Name,
+		Col2:  p.problem.Columns[col2].Name,
+		Value: value,
+	})
+	return nil
+}
+
+/*
+toModel
+Description:
+
+	Builds a Model from the problem. The variables and constraints keep the names of the columns
+	and rows. Ranged rows become a pair of constraints (named <row>_lo and <row>_hi)
+		lower <= a' x  and  a' x <= upper.
+*/
+func (problem mpsProblem) toModel() (*Model, map[string]Variable, error) {
+	// Constants
+	m := NewModel()
+	vars := make(map[string]Variable)
+	columnIndex := make(map[string]int)
+	rowIndex := make(map[string]int)
+	for index, row := range problem.Rows {
+		rowIndex[row.Name] = index
+	}
+
+	// Algorithm
+	for colIndex, column := range problem.Columns {
+		columnIndex[column.Name] = colIndex
+		newVar, err := m.AddNamedVariable(column.Name, column.Lower, column.Upper, column.Vtype)
+		if err != nil {
+			return nil, nil, fmt.Errorf("There was an issue creating the column %v: %v", column.Name, err)
+		}
+		vars[column.Name] = newVar
+	}
+	varsByID := m.variablesByID()
+
+	// Collect the terms of each row, using the column indices (which are the variable IDs)
+	objTerms := expressionTerms{Constant: problem.ObjConstant}
+	rowTerms := make([]expressionTerms, len(problem.Rows))
+	for colIndex, column := range problem.Columns {
+		for _, entry := range column.Entries {
+			if index, isRow := rowIndex[entry.Row]; isRow {
+				rowTerms[index].addLinear(uint64(colIndex), entry.Value)
+			} else {
+				objTerms.addLinear(uint64(colIndex), entry.Value)
+			}
+		}
+	}
+
+	// The objective contains 1/2 x' H x
+	for _, entry := range problem.QuadObj {
+		id1, id2 := uint64(columnIndex[entry.Col1]), uint64(columnIndex[entry.Col2])
+		if id1 == id2 {
+			objTerms.addQuadratic(id1, id2, entry.Value/2)
+		} else {
+			objTerms.addQuadratic(id1, id2, entry.Value)
+		}
+	}
+
+	if err := m.SetObjective(objTerms.WithoutZeros().ToScalarExpression(varsByID), problem.Sense); err != nil {
+		return nil, nil, fmt.Errorf("There was an issue setting the objective: %v", err)
+	}
+
+	for index, row := range problem.Rows {
+		lhs := rowTerms[index].ToScalarExpression(varsByID)
+
+		var constrs []ScalarConstraint
+		senses := map[byte]ConstrSense{'E': SenseEqual, 'L': SenseLessThanEqual, 'G': SenseGreaterThanEqual}
+		if !row.HasRange || row.Range == 0 {
+			constrs = append(
+				constrs,
+				ScalarConstraint{LeftHandSide: lhs, RightHandSide: K(row.RHS), Sense: senses[row.Type], Name: row.Name},
+			)
+		} else {
+			lower, upper := row.rangeBounds()
+			constrs = append(
+				constrs,
+				ScalarConstraint{LeftHandSide: lhs, RightHandSide: K(lower), Sense: SenseGreaterThanEqual, Name: row.Name + "_lo"},
+				ScalarConstraint{LeftHandSide: lhs, RightHandSide: K(upper), Sense: SenseLessThanEqual, Name: row.Name + "_hi"},
+			)
+		}
+
+		for _, constr := range constrs {
+			if _, err := m.AddConstr(constr); err != nil {
+				return nil, nil, fmt.Errorf("There was an issue adding the row %v: %v", row.Name, err)
+			}
+		}
+	}
+
+	return m, vars, nil
+}
+
+/*
+rangeBounds
+Description:
+
+	Returns the lower and upper bounds of a ranged row with right hand side b and range R:
+		E row: [b, b + |R|] if R >= 0 and [b - |R|, b] otherwise
+		L row: [b - |R|, b]
+		G row: [b, b + |R|]
+*/
+func (row mpsRow) rangeBounds() (float64, float64) {
+	absRange := math.Abs(row.Range)
+	switch {
+	case row.Type == 'L', row.Type == 'E' && row.Range < 0:
+		return row.RHS - absRange, row.RHS
+	}
+	return row.RHS, row.RHS + absRange
+}
@@ -0,0 +1,597 @@
+package optim
+
+/*
+mps_writer.go
+Description:
+	Defines the functions that write a Model to a file in the (fixed or free) MPS format.
+	Variables and constraints are named as in the LP writer (unnamed variables are x<ID> and
+	unnamed constraints are c<index>) and the objective row is named obj.
+*/
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Constants
+// =========
+
+const (
+	mpsObjectiveRow     = "obj"
+	mpsFixedNameLength  = 8  // Maximum length of a name in the fixed format.
+	mpsFixedValueLength = 12 // Maximum length of a number in the fixed format.
+)
+
+// Type Definitions
+// ================
+
+/*
+MPSOptions
+Description:
+
+	Options for writing MPS files. When Free is true, the free MPS format (whitespace separated
+	fields) is written; otherwise, the fixed format (fields in fixed columns) is written. Name is
+	the name of the problem written on the NAME line.
+*/
+type MPSOptions struct {
+	Free bool
+	Name string
+}
+
+/*
+mpsRow
+Description:
+
+	A row of an MPS file. Type is one of 'N' (objective), 'E', 'L' or 'G'. When HasRange is true,
+	the row is the ranged constraint described in the RANGES section.
+*/
+type mpsRow struct {
+	Name     string
+	Type     byte
+	RHS      float64
+	Range    float64
+	HasRange bool
+}
+
+/*
+mpsEntry
+Description:
+
+	A nonzero coefficient of a column in the row with the name Row.
+*/
+type mpsEntry struct {
+	Row   string
+	Value float64
+}
+
+/*
+mpsColumn
+Description:
+
+	A column (variable) of an MPS file along with its bounds, type and nonzero coefficients.
+*/
+type mpsColumn struct {
+	Name    string
+	Vtype   VarType
+	Lower   float64
+	Upper   float64
+	Entries []mpsEntry
+}
+
+/*
+mpsQuadraticEntry
+Description:
+
+	An entry of the QUADOBJ section. The objective contains the term 1/2 Value x_Col1 x_Col2 for
+	each entry, along with its symmetric counterpart when Col1 != Col2.
+*/
+type mpsQuadraticEntry struct {
+	Col1  string
+	Col2  string
+	Value float64
+}
+
+/*
+mpsProblem
+Description:
+
+	The contents of an MPS file. ObjConstant is the constant term of the objective, which is
+	written as the (negated) right hand side of the objective row.
+*/
+type mpsProblem struct {
+	Name        string
+	Sense       ObjSense
+	ObjConstant float64
+	Rows        []mpsRow
+	Columns     []mpsColumn
+	QuadObj     []mpsQuadraticEntry
+}
+
+/*
+mpsLineWriter
+Description:
+
+	Writes the lines of an MPS file in either the fixed or the free format.
+*/
+type mpsLineWriter struct {
+	w    *bufio.Writer
+	free bool
+}
+
+// Functions
+// =========
+
+/*
+WriteMPS
+Description:
+
+	Writes the model to w in the MPS format. The file contains the ROWS, COLUMNS, RHS, RANGES and
+	BOUNDS sections, integer and binary columns surrounded by MARKER INTORG/INTEND lines and a
+	QUADOBJ section when the objective is quadratic. Variables that appear on the right hand side
+	of a constraint are moved to the left hand side. A pair of constraints l <= a'x and a'x <= u
+	with the same left hand side is written as one ranged row, named after the first constraint
+	of the pair. Quadratic constraints are not supported.
+*/
+func (m *Model) WriteMPS(w io.Writer, opts MPSOptions) error {
+	// Input Processing
+	problem, err := m.toMPSProblem(opts.Name)
+	if err != nil {
+		return err
+	}
+
+	// Algorithm
+	return problem.write(w, opts.Free)
+}
+
+/*
+toMPSProblem
+Description:
+
+	Collects the rows and columns of the model.
+*/
+func (m *Model) toMPSProblem(name string) (mpsProblem, error) {
+	// Input Checking
+	names, err := m.fileNames(mpsCheckName)
+	if err != nil {
+		return mpsProblem{}, err
+	}
+
+	// Constants
+	problem := mpsProblem{Name: name, Sense: SenseMinimize}
+	if problem.Name == "" {
+		problem.Name = "goop2"
+	}
+
+	columnIndex := make(map[uint64]int)
+	for varIndex, tempVar := range m.Variables {
+		columnIndex[tempVar.ID] = varIndex
+		problem.Columns = append(problem.Columns, mpsColumn{
+			Name:  names.Variables[tempVar.ID],
+			Vtype: tempVar.Vtype,
+			Lower: tempVar.Lower,
+			Upper: tempVar.Upper,
+		})
+	}
+
+	addEntry := func(id uint64, row string, value float64) error {
+		colIndex, found := columnIndex[id]
+		if !found {
+			return fmt.Errorf("The variable with ID %v is not in the model", id)
+		}
+		problem.Columns[colIndex].Entries = append(problem.Columns[colIndex].Entries, mpsEntry{row, value})
+		return nil
+	}
+
+	// Objective
+	problem.Rows = append(problem.Rows, mpsRow{Name: mpsObjectiveRow, Type: 'N'})
+	if m.obj != nil {
+		objTerms, err := termsOf(m.obj.ScalarExpression)
+		if err != nil {
+			return problem, fmt.Errorf("There was an issue writing the objective: %v", err)
+		}
+		objTerms = objTerms.WithoutZeros()
+		problem.Sense = m.obj.Sense
+		problem.ObjConstant = objTerms.Constant
+
+		for _, term := range objTerms.Linear {
+			if err := addEntry(term.ID, mpsObjectiveRow, term.Coeff); err != nil {
+				return problem, fmt.Errorf("There was an issue writing the objective: %v", err)
+			}
+		}
+
+		// QUADOBJ holds H = Q + Q', since the objective is 1/2 x' H x + c' x
+		for _, term := range objTerms.Quadratic {
+			value := term.Coeff
+			if term.ID1 == term.ID2 {
+				value *= 2
+			}
+			problem.QuadObj = append(problem.QuadObj, mpsQuadraticEntry{
+				Col1:  names.Variables[term.ID1],
+				Col2:  names.Variables[term.ID2],
+				Value: value,
+			})
+		}
+	}
+
+	// Constraints
+	var rows []mpsRow
+	var rowTerms [][]LinearTerm
+	for constrIndex, constr := range m.scalarConstraints() {
+		terms, err := constraintTermsOf(constr)
+		if err != nil {
+			return problem, fmt.Errorf("There was an issue writing constraint %v: %v", constrIndex, err)
+		}
+		if len(terms.Quadratic) > 0 {
+			return problem, fmt.Errorf("Constraint %v is quadratic; quadratic constraints can not be written to MPS files", constrIndex)
+		}
+
+		if names.Constraints[constrIndex] == mpsObjectiveRow {
+			return problem, fmt.Errorf("Constraint %v can not be named %q, which is the name of the objective row", constrIndex, mpsObjectiveRow)
+		}
+		row := mpsRow{Name: names.Constraints[constrIndex], RHS: -terms.Constant}
+		switch constr.Sense {
+		case SenseEqual:
+			row.Type = 'E'
+		case SenseLessThanEqual:
+			row.Type = 'L'
+		case SenseGreaterThanEqual:
+			row.Type = 'G'
+		default:
+			return problem, fmt.Errorf("Constraint %v has an unexpected sense %v", constrIndex, constr.Sense)
+		}
+		rows = append(rows, row)
+		rowTerms = append(rowTerms, terms.Linear)
+	}
+
+	rows, rowTerms = mpsRangedRows(rows, rowTerms)
+	for rowIndex, row := range rows {
+		problem.Rows = append(problem.Rows, row)
+		for _, term := range rowTerms[rowIndex] {
+			if err := addEntry(term.ID, row.Name, term.Coeff); err != nil {
+				return problem, fmt.Errorf("There was an issue writing row %v: %v", row.Name, err)
+			}
+		}
+	}
+
+	return problem, nil
+}
+
+/*
+mpsRangedRows
+Description:
+
+	Combines each pair of rows l <= a'x (type G) and a'x <= u (type L) with the same nonzero
+	coefficients a into the ranged row l <= a'x <= u, which is written as a G row with right hand
+	side l and range u - l. The ranged row takes the name and position of the first row of the
+	pair. Pairs whose bounds cross (u < l) are kept as two rows.
+*/
+func mpsRangedRows(rows []mpsRow, rowTerms [][]LinearTerm) ([]mpsRow, [][]LinearTerm) {
+	// Constants
+	var rowsOut []mpsRow
+	var termsOut [][]LinearTerm
+	unpaired := make(map[string]int) // The index in rowsOut of an unpaired row with the given key
+
+	// Algorithm
+	for rowIndex, row := range rows {
+		if row.Type != 'L' && row.Type != 'G' {
+			rowsOut = append(rowsOut, row)
+			termsOut = append(termsOut, rowTerms[rowIndex])
+			continue
+		}
+
+		key := mpsRowKey(rowTerms[rowIndex])
+		if pairIndex, found := unpaired[key]; found && rowsOut[pairIndex].Type != row.Type {
+			lower, upper := rowsOut[pairIndex].RHS, row.RHS
+			if row.Type == 'G' {
+				lower, upper = upper, lower
+			}
+			if lower <= upper {
+				rowsOut[pairIndex] = mpsRow{
+					Name:     rowsOut[pairIndex].Name,
+					Type:     'G',
+					RHS:      lower,
+					Range:    upper - lower,
+					HasRange: true,
+				}
+				delete(unpaired, key)
+				continue
+			}
+		}
+
+		if _, found := unpaired[key]; !found {
+			unpaired[key] = len(rowsOut)
+		}
+		rowsOut = append(rowsOut, row)
+		termsOut = append(termsOut, rowTerms[rowIndex])
+	}
+
+	return rowsOut, termsOut
+}
+
+/*
+mpsRowKey
+Description:
+
+	Returns a string which identifies the nonzero coefficients of a row, regardless of the order
+	of its terms.
+*/
+func mpsRowKey(terms []LinearTerm) string {
+	// Constants
+	coeffs := make(map[uint64]float64)
+	var ids []uint64
+
+	// Algorithm
+	for _, term := range terms {
+		if _, found := coeffs[term.ID]; !found {
+			ids = append(ids, term.ID)
+		}
+		coeffs[term.ID] += term.Coeff
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	var key strings.Builder
+	for _, id := range ids {
+		if coeffs[id] != 0 {
+			fmt.Fprintf(&key, "%v:%v;", id, strconv.FormatFloat(coeffs[id], 'g', -1, 64))
+		}
+	}
+	return key.String()
+}
+
+/*
+write
+Description:
+
+	Writes the MPS file describing the problem.
+*/
+func (problem mpsProblem) write(w io.Writer, free bool) error {
+	// Constants
+	mw := &mpsLineWriter{w: bufio.NewWriter(w), free: free}
+
+	// Input Checking
+	if !free {
+		if err := problem.checkFixedNames(); err != nil {
+			return err
+		}
+	}
+
+	// Algorithm
+	mw.writeSection("NAME", problem.Name)
+
+	if problem.Sense == SenseMaximize {
+		mw.writeSection("OBJSENSE", "")
+		mw.writeFields("", "MAX")
+	}
+
+	mw.writeSection("ROWS", "")
+	for _, row := range problem.Rows {
+		mw.writeFields(string(row.Type), row.Name)
+	}
+
+	// Columns, with integer columns between markers
+	mw.writeSection("COLUMNS", "")
+	inIntegerBlock, markerCount := false, 0
+	for _, column := range problem.Columns {
+		isInteger := column.Vtype == Integer || column.Vtype == Binary
+		if isInteger != inIntegerBlock {
+			marker := "'INTEND'"
+			if isInteger {
+				marker = "'INTORG'"
+				markerCount++
+			}
+			mw.writeFields("", fmt.Sprintf("MARKER%v", markerCount), "'MARKER'", "", marker)
+			inIntegerBlock = isInteger
+		}
+
+		if len(column.Entries) == 0 {
+			// Write a zero objective coefficient so that the column is still declared.
+			mw.writeFields("", column.Name, mpsObjectiveRow, mpsNumber(0, mw.free))
+		}
+		for _, entry := range column.Entries {
+			mw.writeFields("", column.Name, entry.Row, mpsNumber(entry.Value, mw.free))
+		}
+	}
+	if inIntegerBlock {
+		mw.writeFields("", fmt.Sprintf("MARKER%v", markerCount), "'MARKER'", "", "'INTEND'")
+	}
+
+	// Right hand sides. The right hand side of the objective row is the negated constant.
+	mw.writeSection("RHS", "")
+	if problem.ObjConstant != 0 {
+		mw.writeFields("", "RHS", mpsObjectiveRow, mpsNumber(-problem.ObjConstant, mw.free))
+	}
+	for _, row := range problem.Rows {
+		if row.Type != 'N' && row.RHS != 0 {
+			mw.writeFields("", "RHS", row.Name, mpsNumber(row.RHS, mw.free))
+		}
+	}
+
+	hasRanges := false
+	for _, row := range problem.Rows {
+		hasRanges = hasRanges || row.HasRange
+	}
+	if hasRanges {
+		mw.writeSection("RANGES", "")
+		for _, row := range problem.Rows {
+			if row.HasRange {
+				mw.writeFields("", "RNG", row.Name, mpsNumber(row.Range, mw.free))
+			}
+		}
+	}
+
+	mw.writeSection("BOUNDS", "")
+	for _, column := range problem.Columns {
+		for _, bound := range mpsBounds(column, mw.free) {
+			mw.writeFields(bound[0], "BND", column.Name, bound[1])
+		}
+	}
+
+	if len(problem.QuadObj) > 0 {
+		mw.writeSection("QUADOBJ", "")
+		for _, entry := range problem.QuadObj {
+			mw.writeFields("", entry.Col1, entry.Col2, mpsNumber(entry.Value, mw.free))
+		}
+	}
+
+	mw.writeSection("ENDATA", "")
+
+	return mw.w.Flush()
+}
+
+/*
+mpsCheckName
+Description:
+
+	Verifies that the name can be written in a (free) MPS file, where names are separated by
+	spaces.
+*/
+func mpsCheckName(name string) error {
+	if name == "" || strings.IndexFunc(name, unicode.IsSpace) >= 0 {
+		return fmt.Errorf("The name %q can not be written in an MPS file; names must not be empty or contain spaces", name)
+	}
+	return nil
+}
+
+/*
+checkFixedNames
+Description:
+
+	Verifies that every name fits in the fixed MPS format.
+*/
+func (problem mpsProblem) checkFixedNames() error {
+	names := []string{}
+	for _, row := range problem.Rows {
+		names = append(names, row.Name)
+	}
+	for _, column := range problem.Columns {
+		names = append(names, column.Name)
+	}
+
+	for _, name := range names {
+		if len(name) > mpsFixedNameLength {
+			return fmt.Errorf(
+				"The name %q is longer than %v characters and can not be written in the fixed MPS format; use the free format instead",
+				name, mpsFixedNameLength,
+			)
+		}
+	}
+	return nil
+}
+
+/*
+mpsBounds
+Description:
+
+	Returns the bound lines (type and value) of the BOUNDS section for a column. Binary columns are
+	written with BV; other columns only get the bounds that differ from the default 0 <= x < +inf.
+	Integer columns always get explicit bounds, since some readers give them a default upper bound
+	of 1.
+*/
+func mpsBounds(column mpsColumn, free bool) [][2]string {
+	// Constants
+	lowerIsInf := math.IsInf(column.Lower, -1) || column.Lower <= -1e30
+	upperIsInf := math.IsInf(column.Upper, 1) || column.Upper >= 1e30
+
+	// Algorithm
+	switch {
+	case column.Vtype == Binary && column.Lower == 0 && column.Upper == 1:
+		return [][2]string{{"BV", ""}}
+	case lowerIsInf && upperIsInf:
+		return [][2]string{{"FR", ""}}
+	case !lowerIsInf && column.Lower == column.Upper:
+		return [][2]string{{"FX", mpsNumber(column.Lower, free)}}
+	}
+
+	var bounds [][2]string
+	switch {
+	case lowerIsInf:
+		bounds = append(bounds, [2]string{"MI", ""})
+	case column.Lower != 0 || column.Vtype != Continuous || (!upperIsInf && column.Upper < 0):
+		bounds = append(bounds, [2]string{"LO", mpsNumber(column.Lower, free)})
+	}
+
+	switch {
+	case !upperIsInf:
+		bounds = append(bounds, [2]string{"UP", mpsNumber(column.Upper, free)})
+	case column.Vtype != Continuous:
+		bounds = append(bounds, [2]string{"PL", ""})
+	}
+	return bounds
+}
+
+/*
+mpsNumber
+Description:
+
+	Formats a number for an MPS file. In the free format the shortest representation that reads
+	back as the same float64 is used. In the fixed format the number is shortened to at most 12
+	characters (the width of a numeric field), keeping as many digits as possible.
+*/
+func mpsNumber(value float64, free bool) string {
+	if value == 0 {
+		return "0"
+	}
+
+	formatted := strconv.FormatFloat(value, 'g', -1, 64)
+	if free {
+		return formatted
+	}
+	for precision := 12; len(formatted) > mpsFixedValueLength && precision > 0; precision-- {
+		formatted = strconv.FormatFloat(value, 'g', precision, 64)
+	}
+	return formatted
+}
+
+/*
+writeSection
+Description:
+
+	Writes a section header, which starts in the first column.
+*/
+func (mw *mpsLineWriter) writeSection(section, value string) {
+	if value != "" {
+		section = fmt.Sprintf("%-14s%v", section, value)
+	}
+	mw.w.WriteString(section + "\n")
+}
+
+/*
+writeFields
+Description:
+
+	Writes a data line with the fields code, name1, name2, value1 and (optionally) name3. In the
+	fixed format these are placed in columns 2, 5, 15, 25 and 40; in the free format they are
+	separated by spaces.
+*/
+func (mw *mpsLineWriter) writeFields(code, name1 string, rest ...string) {
+	fields := append([]string{code, name1}, rest...)
+
+	var line string
+	if mw.free {
+		var nonEmpty []string
+		for _, field := range fields {
+			if field != "" {
+				nonEmpty = append(nonEmpty, field)
+			}
+		}
+		line = " " + strings.Join(nonEmpty, " ")
+		if code == "" {
+			line = "   " + line
+		}
+	} else {
+		widths := []int{3, 10, 10, 15, 0}
+		line = " "
+		for fieldIndex, field := range fields {
+			line += fmt.Sprintf("%-*s", widths[fieldIndex], field)
+		}
+		line = strings.TrimRight(line, " ")
+	}
+
+	mw.w.WriteString(line + "\n")
+}
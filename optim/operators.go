@@ -66,9 +66,9 @@ func Comparison(lhs, rhs interface{}, sense ConstrSense) (Constraint, error) {
 		lhsAsScalarExpression, _ := lhs.(ScalarExpression)
 		rhsAsScalarExpression, _ := rhs.(ScalarExpression)
 		return ScalarConstraint{
-			lhsAsScalarExpression,
-			rhsAsScalarExpression,
-			sense,
+			LeftHandSide:  lhsAsScalarExpression,
+			RightHandSide: rhsAsScalarExpression,
+			Sense:         sense,
 		}, nil
 	case VectorExpression:
 		lhsAsVecExpr, _ := lhs.(VectorExpression)
@@ -83,39 +83,39 @@ func Comparison(lhs, rhs interface{}, sense ConstrSense) (Constraint, error) {
 Multiply
 Description:
 
-	Defines the multiplication between two objects.
+	Defines the multiplication between two objects (and then between the product and each of the
+	extras). The terms can be any scalar, vector or matrix expression, or a float64, mat.VecDense
+	or mat.Dense. A scalar scales every element of the other term, two vectors of the same length
+	give their inner product and all other products follow the rules of matrix multiplication
+	with vectors treated as columns (e.g. a KMatrix times a VarVector is a VectorLinearExpr).
+	Products of degree more than two (e.g. a Variable times a ScalarQuadraticExpression) return
+	an error.
+
+Usage:
+
+	xSquared, err := Multiply(x, x)
 */
-func Multiply(term1, term2 interface{}) (Expression, error) {
-	// Constants
+func Multiply(term1, term2 interface{}, extras ...interface{}) (Expression, error) {
+	// Input Processing
+	product, err := toProductFactor(term1)
+	if err != nil {
+		return nil, fmt.Errorf("There was an issue with the first term of the product: %v", err)
+	}
 
 	// Algorithm
-	switch term1.(type) {
-	case float64:
-		// Convert lhs to K
-		term1AsFloat64, _ := term1.(float64)
-		term1AsK := K(term1AsFloat64)
-
-		// Create constraint
-		return Multiply(term1AsK, term2)
-	case mat.VecDense:
-		// Convert lhs to KVector.
-		term1AsVecDense, _ := term1.(mat.VecDense)
-		term1AsKVector := KVector(term1AsVecDense)
+	for termIndex, term := range append([]interface{}{term2}, extras...) {
+		factor, err := toProductFactor(term)
+		if err != nil {
+			return nil, fmt.Errorf("There was an issue with term %v of the product: %v", termIndex+2, err)
+		}
 
-		// Create constraint
-		return term1AsKVector.Multiply(term2)
-	//case ScalarExpression:
-	//	term1AsScalarExpression, _ := term1.(ScalarExpression)
-	//
-	//	// Create Constraint
-	//	return term1AsScalarExpression.Multiply(term2)
-	//
-	//case VectorExpression:
-	//	lhsAsVecExpr, _ := lhs.(VectorExpression)
-	//	return lhsAsVecExpr.Comparison(rhs, sense)
-	default:
-		return nil, fmt.Errorf("Multiply of %T term with %T term is not yet defined!", term1, term2)
+		product, err = product.Times(factor)
+		if err != nil {
+			return nil, fmt.Errorf("Multiply of %T term with %T term is not defined: %v", term1, term, err)
+		}
 	}
+
+	return product.ToExpression()
 }
 
 // Dot returns the dot product of a vector of variables and slice of floats.
@@ -127,16 +127,18 @@ func Dot(vs []Variable, coeffs []float64) ScalarExpression {
 		}).Panic("Number of vars and coeffs mismatch")
 	}
 
-	newExpr := NewExpr(0)
+	b := NewLinearExprBuilder(len(vs))
 	for i := range vs {
-		newExpr.Plus(vs[i].Mult(coeffs[i]))
+		b.AddTerm(coeffs[i], vs[i])
 	}
 
-	return newExpr
+	return b.Build()
 }
 
 // Sum returns the sum of the given expressions. It creates a new empty
-// expression and adds to it the given expressions.
+// expression and adds to it the given expressions. Sums of constants, variables
+// and linear expressions are accumulated with a LinearExprBuilder, so they take
+// linear time in the total number of terms.
 func Sum(exprs ...interface{}) (Expression, error) {
 	// Constants
 
@@ -152,6 +154,10 @@ func Sum(exprs ...interface{}) (Expression, error) {
 		return ToExpression(exprs[0])
 	}
 
+	if sle, ok := sumLinear(exprs); ok {
+		return sle, nil
+	}
+
 	// Check whether or not the second argument is an error or not.
 	var (
 		e1        interface{}
@@ -219,3 +225,118 @@ func Sum(exprs ...interface{}) (Expression, error) {
 		return e0, fmt.Errorf("Unexpected type input to Sum: %T", e0)
 	}
 }
+
+/*
+sumLinear
+Description:
+
+	Adds the terms of Sum with a LinearExprBuilder when every term is a K, Variable or
+	ScalarLinearExpr (possibly separated by nil errors) and at least one of them has variables.
+	Returns false if Sum should fall back to adding the terms one at a time.
+*/
+func sumLinear(exprs []interface{}) (ScalarLinearExpr, bool) {
+	// Constants
+	var b LinearExprBuilder
+	numTerms := 0
+
+	// Algorithm
+	for exprIndex, expr := range exprs {
+		switch exprIn := expr.(type) {
+		case K, Variable, ScalarLinearExpr:
+			if err := b.Add(exprIn.(ScalarExpression)); err != nil {
+				return ScalarLinearExpr{}, false
+			}
+			numTerms++
+		case nil:
+			if exprIndex == 0 {
+				return ScalarLinearExpr{}, false
+			}
+		case error:
+			// Errors are reported by the general algorithm
+			return ScalarLinearExpr{}, false
+		default:
+			return ScalarLinearExpr{}, false
+		}
+	}
+
+	return b.Build(), numTerms > 1 && b.NumVars() > 0
+}
+
+/*
+Minus
+Description:
+
+	Returns the difference term1 - term2 of two scalar, vector or matrix expressions (or a float64,
+	mat.VecDense or mat.Dense).
+
+Usage:
+
+	diff, err := Minus(x, y)
+*/
+func Minus(term1, term2 interface{}) (Expression, error) {
+	// Input Processing
+	e1, err := ToExpression(term1)
+	if err != nil {
+		return nil, fmt.Errorf("There was an issue with the first term of the difference: %v", err)
+	}
+
+	// Algorithm
+	switch e1In := e1.(type) {
+	case ScalarExpression:
+		return e1In.Minus(term2)
+	case VectorExpression:
+		return e1In.Minus(term2)
+	case MatrixExpression:
+		nR, nC := e1In.Dims()
+		e2, err := toMatrixExpression(term2, nR, nC)
+		if err != nil {
+			return nil, fmt.Errorf("There was an issue with the second term of the difference: %v", err)
+		}
+		negE2, err := e2.Mult(-1)
+		if err != nil {
+			return nil, err
+		}
+		return e1In.Plus(negE2)
+	default:
+		return nil, fmt.Errorf("Minus is not defined for terms of type %T and %T!", term1, term2)
+	}
+}
+
+/*
+checkOptionalError
+Description:
+
+	Checks the extras given to an operation which accepts the error returned while computing its
+	input (e.g. x.Minus(y.Mult(2))). At most one extra is allowed, and it must be a nil error.
+*/
+func checkOptionalError(operationName string, extras []interface{}) error {
+	if len(extras) > 1 {
+		return fmt.Errorf("%v accepts at most one extra argument (an error); received %v", operationName, len(extras))
+	}
+
+	if len(extras) == 1 {
+		switch optionalErrorArgument := extras[0].(type) {
+		case nil:
+			// A nil error was given.
+		case error:
+			return fmt.Errorf("There was an error computing the input to %v: %v", operationName, optionalErrorArgument)
+		default:
+			return fmt.Errorf("Unexpected extra input to %v %v of type %T; expected an error.", operationName, optionalErrorArgument, optionalErrorArgument)
+		}
+	}
+
+	return nil
+}
+
+/*
+checkDivisor
+Description:
+
+	Returns an error if an expression can not be divided by the constant c.
+*/
+func checkDivisor(c float64) error {
+	if c == 0 {
+		return fmt.Errorf("Cannot divide an expression by zero!")
+	}
+	return nil
+}
@@ -0,0 +1,368 @@
+package optim
+
+/*
+product.go
+Description:
+	Defines the product of two expressions computed by the Multiply operator. Every term is
+	converted to a grid of expressionTerms so that one routine handles all pairs of scalars,
+	vectors and matrices.
+*/
+
+import (
+	"fmt"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// Type Definitions
+// ================
+
+/*
+productShape
+Description:
+
+	Describes whether a term of a product is a scalar, a vector or a matrix.
+*/
+type productShape int
+
+const (
+	productScalar productShape = iota
+	productVector
+	productMatrix
+)
+
+/*
+productFactor
+Description:
+
+	A term of a product written as a grid of expressionTerms. Scalars are stored as a 1 x 1 grid
+	and vectors as a single column. Vars maps the ID of each variable in the grid to the variable.
+*/
+type productFactor struct {
+	Shape    productShape
+	Elements [][]expressionTerms
+	Vars     map[uint64]Variable
+}
+
+// Functions
+// =========
+
+/*
+toProductFactor
+Description:
+
+	Converts the term of a product to a productFactor. float64, mat.VecDense and mat.Dense are
+	treated as K, KVector and KMatrix respectively.
+*/
+func toProductFactor(term interface{}) (productFactor, error) {
+	// Constants
+	pf := productFactor{Vars: make(map[uint64]Variable)}
+
+	// Input Checking
+	if checker, ok := term.(interface{ Check() error }); ok {
+		if err := checker.Check(); err != nil {
+			return pf, err
+		}
+	}
+
+	// Algorithm
+	switch termIn := term.(type) {
+	case float64:
+		return toProductFactor(K(termIn))
+	case mat.VecDense:
+		return toProductFactor(KVector(termIn))
+	case mat.Dense:
+		return toProductFactor(KMatrix(termIn))
+	case *mat.Dense:
+		return toProductFactor(KMatrix(*termIn))
+	case ScalarExpression:
+		pf.Shape = productScalar
+		if err := pf.appendRow(termIn); err != nil {
+			return pf, err
+		}
+	case VectorExpression:
+		pf.Shape = productVector
+		for eltIndex := 0; eltIndex < termIn.Len(); eltIndex++ {
+			if err := pf.appendRow(termIn.AtVec(eltIndex)); err != nil {
+				return pf, err
+			}
+		}
+	case MatrixExpression:
+		pf.Shape = productMatrix
+		nR, nC := termIn.Dims()
+		for rowIndex := 0; rowIndex < nR; rowIndex++ {
+			row := make([]ScalarExpression, nC)
+			for colIndex := range row {
+				row[colIndex] = termIn.At(rowIndex, colIndex)
+			}
+			if err := pf.appendRow(row...); err != nil {
+				return pf, err
+			}
+		}
+	default:
+		return pf, fmt.Errorf("Multiply is not defined for terms of type %T!", term)
+	}
+
+	if nR, nC := pf.Dims(); nR == 0 || nC == 0 {
+		return pf, fmt.Errorf("Cannot multiply the empty %T %v.", term, term)
+	}
+
+	return pf, nil
+}
+
+/*
+appendRow
+Description:
+
+	Appends a row containing the terms of each of the given scalar expressions to the grid.
+*/
+func (pf *productFactor) appendRow(row ...ScalarExpression) error {
+	termsRow := make([]expressionTerms, len(row))
+	for eltIndex, se := range row {
+		et, err := termsOf(se)
+		if err != nil {
+			return err
+		}
+		termsRow[eltIndex] = et
+
+		for _, tempVar := range se.Variables() {
+			pf.Vars[tempVar.ID] = tempVar
+		}
+	}
+	pf.Elements = append(pf.Elements, termsRow)
+
+	return nil
+}
+
+/*
+Dims
+Description:
+
+	Returns the number of rows and columns of the grid.
+*/
+func (pf productFactor) Dims() (int, int) {
+	if len(pf.Elements) == 0 {
+		return 0, 0
+	}
+	return len(pf.Elements), len(pf.Elements[0])
+}
+
+/*
+Degree
+Description:
+
+	Returns the largest degree of the elements of the grid.
+*/
+func (pf productFactor) Degree() int {
+	degree := 0
+	for _, row := range pf.Elements {
+		for _, et := range row {
+			if et.Degree() > degree {
+				degree = et.Degree()
+			}
+		}
+	}
+	return degree
+}
+
+/*
+Times
+Description:
+
+	Returns the product pf * other. A scalar scales every element of the other term. Two vectors of
+	the same length give their inner product. Otherwise the terms are multiplied as matrices, with
+	vectors treated as columns, and the product is a vector if other is a vector.
+*/
+func (pf productFactor) Times(other productFactor) (productFactor, error) {
+	// Input Checking
+	if degree := pf.Degree() + other.Degree(); degree > 2 {
+		return pf, fmt.Errorf(
+			"The product of an expression of degree %v and an expression of degree %v has degree %v; only products of degree two or less are supported.",
+			pf.Degree(), other.Degree(), degree,
+		)
+	}
+
+	// Constants
+	product := productFactor{Vars: make(map[uint64]Variable)}
+	for _, vars := range []map[uint64]Variable{pf.Vars, other.Vars} {
+		for id, tempVar := range vars {
+			product.Vars[id] = tempVar
+		}
+	}
+
+	// Algorithm
+	switch {
+	case pf.Shape == productScalar:
+		product.Shape = other.Shape
+		product.Elements = scaleTermsGrid(pf.Elements[0][0], other.Elements)
+	case other.Shape == productScalar:
+		product.Shape = pf.Shape
+		product.Elements = scaleTermsGrid(other.Elements[0][0], pf.Elements)
+	case pf.Shape == productVector && other.Shape == productVector:
+		// Inner Product
+		if len(pf.Elements) != len(other.Elements) {
+			return pf, fmt.Errorf(
+				"Cannot compute the inner product of vectors of length %v and %v.",
+				len(pf.Elements), len(other.Elements),
+			)
+		}
+
+		var sum expressionTerms
+		for eltIndex := range pf.Elements {
+			sum = sum.Plus(pf.Elements[eltIndex][0].Times(other.Elements[eltIndex][0]))
+		}
+		product.Shape = productScalar
+		product.Elements = [][]expressionTerms{{sum}}
+	default:
+		nR, nInner := pf.Dims()
+		nOtherInner, nC := other.Dims()
+		if nInner != nOtherInner {
+			return pf, fmt.Errorf(
+				"Cannot multiply a %v x %v expression by a %v x %v expression.",
+				nR, nInner, nOtherInner, nC,
+			)
+		}
+
+		product.Shape = productMatrix
+		if other.Shape == productVector {
+			product.Shape = productVector
+		}
+		product.Elements = make([][]expressionTerms, nR)
+		for rowIndex := range product.Elements {
+			product.Elements[rowIndex] = make([]expressionTerms, nC)
+			for colIndex := range product.Elements[rowIndex] {
+				var sum expressionTerms
+				for k := 0; k < nInner; k++ {
+					sum = sum.Plus(pf.Elements[rowIndex][k].Times(other.Elements[k][colIndex]))
+				}
+				product.Elements[rowIndex][colIndex] = sum
+			}
+		}
+	}
+
+	return product, nil
+}
+
+/*
+scaleTermsGrid
+Description:
+
+	Multiplies every element of grid by the terms scale.
+*/
+func scaleTermsGrid(scale expressionTerms, grid [][]expressionTerms) [][]expressionTerms {
+	scaled := make([][]expressionTerms, len(grid))
+	for rowIndex, row := range grid {
+		scaled[rowIndex] = make([]expressionTerms, len(row))
+		for colIndex, et := range row {
+			scaled[rowIndex][colIndex] = scale.Times(et)
+		}
+	}
+	return scaled
+}
+
+/*
+variables
+Description:
+
+	Returns the variables of the grid in the order in which they first appear (row by row) and the
+	position of each variable ID in that order.
+*/
+func (pf productFactor) variables() (VarVector, map[uint64]int) {
+	positionOf := make(map[uint64]int)
+	var elements []Variable
+	addElement := func(id uint64) {
+		if _, found := positionOf[id]; !found {
+			positionOf[id] = len(elements)
+			elements = append(elements, pf.Vars[id])
+		}
+	}
+
+	for _, row := range pf.Elements {
+		for _, et := range row {
+			for _, term := range et.Linear {
+				addElement(term.ID)
+			}
+			for _, term := range et.Quadratic {
+				addElement(term.ID1)
+				addElement(term.ID2)
+			}
+		}
+	}
+
+	return VarVector{Elements: elements}, positionOf
+}
+
+/*
+ToExpression
+Description:
+
+	Builds the expression described by the grid. Scalars become a K, ScalarLinearExpr or
+	ScalarQuadraticExpression, vectors a KVector, VectorLinearExpr or VectorQuadraticExpression and
+	matrices a KMatrix or MatrixLinearExpr, depending on the degree of the grid. There is no
+	quadratic matrix expression, so matrices of degree two return an error.
+*/
+func (pf productFactor) ToExpression() (Expression, error) {
+	// Constants
+	nR, nC := pf.Dims()
+	x, positionOf := pf.variables()
+
+	// Algorithm
+	switch pf.Shape {
+	case productScalar:
+		return pf.Elements[0][0].ToScalarExpression(pf.Vars), nil
+
+	case productVector:
+		C := mat.NewVecDense(nR, nil)
+		for eltIndex, row := range pf.Elements {
+			C.SetVec(eltIndex, row[0].Constant)
+		}
+
+		switch pf.Degree() {
+		case 0:
+			return KVector(*C), nil
+		case 1:
+			L := mat.NewDense(nR, x.Len(), nil)
+			for eltIndex, row := range pf.Elements {
+				LRow := row[0].linearCoefficients(positionOf, x.Len())
+				L.SetRow(eltIndex, LRow.RawVector().Data)
+			}
+			return VectorLinearExpr{X: x, L: *L, C: *C}, nil
+		default:
+			vqe := VectorQuadraticExpression{
+				Q: make([]mat.Dense, nR),
+				L: *mat.NewDense(nR, x.Len(), nil),
+				C: *C,
+				X: x,
+			}
+			for eltIndex, row := range pf.Elements {
+				vqe.Q[eltIndex] = *row[0].quadraticCoefficients(positionOf, x.Len())
+				vqe.L.SetRow(eltIndex, row[0].linearCoefficients(positionOf, x.Len()).RawVector().Data)
+			}
+			return vqe, nil
+		}
+
+	default:
+		C := mat.NewDense(nR, nC, nil)
+		for rowIndex, row := range pf.Elements {
+			for colIndex, et := range row {
+				C.Set(rowIndex, colIndex, et.Constant)
+			}
+		}
+
+		switch pf.Degree() {
+		case 0:
+			return KMatrix(*C), nil
+		case 1:
+			L := mat.NewDense(nR*nC, x.Len(), nil)
+			for rowIndex, row := range pf.Elements {
+				for colIndex, et := range row {
+					LRow := et.linearCoefficients(positionOf, x.Len())
+					L.SetRow(rowIndex*nC+colIndex, LRow.RawVector().Data)
+				}
+			}
+			return MatrixLinearExpr{X: x, L: *L, C: *C}, nil
+		default:
+			return nil, fmt.Errorf("The product is a %v x %v matrix of quadratic expressions, which is not currently supported.", nR, nC)
+		}
+	}
+}
@@ -7,6 +7,7 @@ type ScalarConstraint struct {
 	LeftHandSide  ScalarExpression
 	RightHandSide ScalarExpression
 	Sense         ConstrSense
+	Name          string // Optional
 }
 
 // ConstrSense represents if the constraint x <= y, x >= y, or x == y. For easy
@@ -20,3 +21,20 @@ const (
 	SenseLessThanEqual                = '<'
 	SenseGreaterThanEqual             = '>'
 )
+
+/*
+Reverse
+Description:
+
+	Returns the sense of the constraint after its two sides have been swapped (i.e. x <= y is the
+	same as y >= x).
+*/
+func (cs ConstrSense) Reverse() ConstrSense {
+	switch cs {
+	case SenseLessThanEqual:
+		return SenseGreaterThanEqual
+	case SenseGreaterThanEqual:
+		return SenseLessThanEqual
+	}
+	return cs
+}
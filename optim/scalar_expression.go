@@ -1,5 +1,7 @@
 package optim
 
+import "fmt"
+
 // ScalarExpression represents a linear general expression of the form
 // c0 * x0 + c1 * x1 + ... + cn * xn + k where ci are coefficients and xi are
 // variables and k is a constant. This is a base interface that is implemented
@@ -28,6 +30,21 @@ type ScalarExpression interface {
 	// resulting expression
 	Mult(c float64) (ScalarExpression, error)
 
+	// Minus subtracts another expression from the current expression and
+	// returns the resulting expression
+	Minus(e interface{}, extras ...interface{}) (ScalarExpression, error)
+
+	// Neg returns the negation of the current expression
+	Neg() ScalarExpression
+
+	// Div divides the current expression by the constant c and returns the
+	// resulting expression
+	Div(c float64) (ScalarExpression, error)
+
+	// Equals returns true if the other expression has the same coefficients
+	// (and constant) as the current expression, up to the tolerance tol
+	Equals(other interface{}, tol float64) bool
+
 	// LessEq returns a less than or equal to (<=) constraint between the
 	// current expression and another
 	LessEq(e ScalarExpression) (ScalarConstraint, error)
@@ -46,7 +63,7 @@ type ScalarExpression interface {
 
 	//Multiply
 	// Multiplies the given scalar expression with another expression
-	//Multiply(term1 interface{}, extras...) (Expression, error)
+	Multiply(term1 interface{}, extras ...interface{}) (Expression, error)
 }
 
 // NewExpr returns a new expression with a single additive constant value, c,
@@ -72,3 +89,70 @@ func getCoeffsPtr(e ScalarExpression) *float64 {
 
 	return nil
 }
+
+/*
+scalarMinus
+Description:
+
+	Returns lhs - e, where e is a float64 or any ScalarExpression. The error returned while
+	computing e can be given in extras, so that the Minus methods can be called as
+	x.Minus(y.Mult(2)).
+*/
+func scalarMinus(lhs ScalarExpression, e interface{}, extras []interface{}) (ScalarExpression, error) {
+	// Input Processing
+	if err := checkOptionalError("Minus", extras); err != nil {
+		return lhs, err
+	}
+
+	var eAsSE ScalarExpression
+	switch eIn := e.(type) {
+	case float64:
+		eAsSE = K(eIn)
+	case ScalarExpression:
+		eAsSE = eIn
+	default:
+		return lhs, fmt.Errorf("Unexpected type (%T) given to Minus for the scalar expression %v.", e, lhs)
+	}
+
+	// Algorithm
+	return lhs.Plus(eAsSE.Neg())
+}
+
+/*
+scalarEquals
+Description:
+
+	Returns true if other is a float64 or scalar expression whose coefficients (and constant)
+	match those of se up to tol. The types of the two expressions do not need to match, so the
+	variable x equals the linear expression 1 x + 0.
+*/
+func scalarEquals(se ScalarExpression, other interface{}, tol float64) bool {
+	// Input Processing
+	var otherAsSE ScalarExpression
+	switch otherIn := other.(type) {
+	case float64:
+		otherAsSE = K(otherIn)
+	case ScalarExpression:
+		otherAsSE = otherIn
+	default:
+		return false
+	}
+
+	for _, tempSE := range []ScalarExpression{se, otherAsSE} {
+		if checker, ok := tempSE.(interface{ Check() error }); ok && checker.Check() != nil {
+			return false
+		}
+	}
+
+	// Algorithm
+	seTerms, err := termsOf(se)
+	if err != nil {
+		return false
+	}
+	otherTerms, err := termsOf(otherAsSE)
+	if err != nil {
+		return false
+	}
+
+	return seTerms.EqualsWithin(otherTerms, tol)
+}
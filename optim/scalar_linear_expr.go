@@ -121,6 +121,10 @@ func (sle ScalarLinearExpr) Plus(e interface{}, extras ...interface{}) (ScalarEx
 		//return newQExprAligned, nil
 		return quadraticEIn.Plus(sle)
 
+	case SparseScalarLinearExpr, SparseScalarQuadraticExpression:
+		// Sums with sparse expressions stay sparse
+		return e.(ScalarExpression).Plus(sle)
+
 	default:
 		fmt.Println("Unexpected type given to Plus().")
 
@@ -131,10 +135,116 @@ func (sle ScalarLinearExpr) Plus(e interface{}, extras ...interface{}) (ScalarEx
 // Mult multiplies the current expression to another and returns the
 // resulting expression
 func (sle ScalarLinearExpr) Mult(c float64) (ScalarExpression, error) {
-	sle.L.ScaleVec(c, &sle.L)
-	sle.C *= c
+	// Create a new L so that the result does not share data with sle
+	sleOut := ScalarLinearExpr{X: sle.X, C: sle.C * c}
+	if sle.L.Len() > 0 {
+		sleOut.L.ScaleVec(c, &sle.L)
+	}
+
+	return sleOut, nil
+}
 
-	return sle, nil
+/*
+Minus
+Description:
+
+	Returns the difference between the linear expression and e, which can be a float64 or any
+	scalar expression. The error returned while computing e can be passed as an extra argument:
+		diff, err := sle.Minus(y.Mult(2))
+*/
+func (sle ScalarLinearExpr) Minus(e interface{}, extras ...interface{}) (ScalarExpression, error) {
+	return scalarMinus(sle, e, extras)
+}
+
+/*
+Neg
+Description:
+
+	Returns the linear expression with every coefficient (and the constant) negated.
+*/
+func (sle ScalarLinearExpr) Neg() ScalarExpression {
+	negated, _ := sle.Mult(-1.0)
+	return negated
+}
+
+/*
+Div
+Description:
+
+	Divides the linear expression by the constant c. Division by zero returns an error.
+*/
+func (sle ScalarLinearExpr) Div(c float64) (ScalarExpression, error) {
+	// Input Checking
+	if err := checkDivisor(c); err != nil {
+		return sle, err
+	}
+
+	// Algorithm
+	return sle.Mult(1.0 / c)
+}
+
+/*
+Equals
+Description:
+
+	Returns true if other has the same coefficients and constant as the linear expression, up
+	to tol. Duplicate variables and zero coefficients do not affect the comparison.
+*/
+func (sle ScalarLinearExpr) Equals(other interface{}, tol float64) bool {
+	return scalarEquals(sle, other, tol)
+}
+
+/*
+Simplify
+Description:
+
+	Returns the canonical form of the linear expression: the coefficients of duplicate variables
+	are added together, variables with a zero coefficient are dropped and the remaining variables
+	are sorted by ID. Linear expressions which describe the same function have the same canonical
+	form.
+*/
+func (sle ScalarLinearExpr) Simplify() ScalarLinearExpr {
+	// Constants
+	terms, _ := termsOf(sle)
+	terms = terms.WithoutZeros()
+	x, positionOf := terms.sortedVariables(sle.X.Elements)
+
+	// Algorithm
+	sleOut := ScalarLinearExpr{X: x, C: terms.Constant}
+	if x.Len() > 0 {
+		sleOut.L = *terms.linearCoefficients(positionOf, x.Len())
+	}
+
+	return sleOut
+}
+
+/*
+ToSparse
+Description:
+
+	Returns the SparseScalarLinearExpr with the same nonzero terms. The coefficients of repeated
+	variables are added together.
+*/
+func (sle ScalarLinearExpr) ToSparse() SparseScalarLinearExpr {
+	terms, _ := termsOf(sle)
+	terms = terms.WithoutZeros()
+
+	return SparseScalarLinearExpr{
+		X:      VarVector{UniqueVars(sle.X.Elements)},
+		Linear: terms.Linear,
+		C:      terms.Constant,
+	}
+}
+
+/*
+Multiply
+Description:
+
+	Returns the product of the linear expression with term1 (and then with each of the extras).
+	The product of two linear expressions is a ScalarQuadraticExpression.
+*/
+func (sle ScalarLinearExpr) Multiply(term1 interface{}, extras ...interface{}) (Expression, error) {
+	return Multiply(sle, term1, extras...)
 }
 
 // LessEq returns a less than or equal to (<=) constraint between the
@@ -166,7 +276,7 @@ Usage:
 	constr, err := e.Comparison(expr1,SenseGreaterThanEqual)
 */
 func (sle ScalarLinearExpr) Comparison(rhs ScalarExpression, sense ConstrSense) (ScalarConstraint, error) {
-	return ScalarConstraint{sle, rhs, sense}, nil
+	return ScalarConstraint{LeftHandSide: sle, RightHandSide: rhs, Sense: sense}, nil
 }
 
 /*
@@ -183,41 +293,25 @@ func (sle ScalarLinearExpr) RewriteInTermsOf(newX VarVector) (ScalarLinearExpr,
 	// Create new Linear Express
 	var newLE ScalarLinearExpr = ScalarLinearExpr{
 		X: newX,
+		C: sle.C,
 	}
 
 	// Find length of X indices
 	dimX := newX.Len()
-
-	// Create L matrix of appropriate dimension
-	var newLfloat []float64
-	for rowIndex := 0; rowIndex < dimX; rowIndex++ {
-		newLfloat = append(newLfloat, 0.0)
-	}
-	newL := mat.NewVecDense(dimX, newLfloat)
+	positionOf := newX.positionsOf()
 
 	// Populate L
+	newL := make([]float64, dimX)
 	for oi1Index, oldElt1 := range sle.X.Elements {
-		// Identify what term is associated with the pair (oldIndex1, oldIndex2)
-		oldLterm := sle.L.AtVec(oi1Index)
-
-		// Get the new indices corresponding to oi1 and oi2
-		ni1Index, err := FindInSlice(oldElt1, newX.Elements)
-		if err != nil {
+		ni1Index, found := positionOf[oldElt1.ID]
+		if !found {
 			return newLE, fmt.Errorf("The element %v was found in the old X indices, but it does not exist in the new ones!", oldElt1)
 		}
-		//newElt1 := newX.Elements[ni1Index]
-
-		// Plug the old Linearterm into newLinear expression
-		offset := ZerosVector(dimX)
-		offset.SetVec(ni1Index, oldLterm)
-
-		newL.AddVec(newL, &offset)
+		newL[ni1Index] += sle.L.AtVec(oi1Index)
+	}
+	if dimX > 0 {
+		newLE.L = *mat.NewVecDense(dimX, newL)
 	}
-	newLE.L = *newL
-
-	// Populate C
-	newLE.C = sle.C
 
 	return newLE, nil
-
 }
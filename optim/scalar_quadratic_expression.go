@@ -270,6 +270,9 @@ func (qe ScalarQuadraticExpression) Plus(e interface{}, extras ...interface{}) (
 		// Add constants together
 		newQExprAligned.C += linearEIn.C
 		return newQExprAligned, nil
+	case SparseScalarLinearExpr, SparseScalarQuadraticExpression:
+		// Sums with sparse expressions stay sparse
+		return e.(ScalarExpression).Plus(qe)
 	default:
 		return ScalarQuadraticExpression{}, fmt.Errorf("Unexpected type (%T) given as argument to Plus: %v.", e, e)
 	}
@@ -297,9 +300,115 @@ func (qe ScalarQuadraticExpression) Mult(c float64) (ScalarExpression, error) {
 	newQE.L.ScaleVec(c, &qe.L)
 
 	// Update through the constant
-	qe.C *= c
+	newQE.C = qe.C * c
 
-	return qe, nil
+	return newQE, nil
+}
+
+/*
+Minus
+Description:
+
+	Returns the difference between the quadratic expression and e, which can be a float64 or any
+	scalar expression. The error returned while computing e can be passed as an extra argument:
+		diff, err := qe.Minus(y.Mult(2))
+*/
+func (qe ScalarQuadraticExpression) Minus(e interface{}, extras ...interface{}) (ScalarExpression, error) {
+	return scalarMinus(qe, e, extras)
+}
+
+/*
+Neg
+Description:
+
+	Returns the quadratic expression with every coefficient (and the constant) negated.
+*/
+func (qe ScalarQuadraticExpression) Neg() ScalarExpression {
+	negated, _ := qe.Mult(-1.0)
+	return negated
+}
+
+/*
+Div
+Description:
+
+	Divides the quadratic expression by the constant c. Division by zero returns an error.
+*/
+func (qe ScalarQuadraticExpression) Div(c float64) (ScalarExpression, error) {
+	// Input Checking
+	if err := checkDivisor(c); err != nil {
+		return qe, err
+	}
+
+	// Algorithm
+	return qe.Mult(1.0 / c)
+}
+
+/*
+Equals
+Description:
+
+	Returns true if other describes the same quadratic function as qe up to tol, e.g. Q and
+	its transpose give equal expressions.
+*/
+func (qe ScalarQuadraticExpression) Equals(other interface{}, tol float64) bool {
+	return scalarEquals(qe, other, tol)
+}
+
+/*
+Simplify
+Description:
+
+	Returns the canonical form of the quadratic expression: the coefficients of duplicate
+	variables are added together, terms with a zero coefficient are dropped, Q is made symmetric
+	and the remaining variables are sorted by ID. Quadratic expressions which describe the same
+	function have the same canonical form.
+*/
+func (qe ScalarQuadraticExpression) Simplify() ScalarQuadraticExpression {
+	// Constants
+	terms, _ := termsOf(qe)
+	terms = terms.WithoutZeros()
+	x, positionOf := terms.sortedVariables(qe.X.Elements)
+
+	// Algorithm
+	qeOut := ScalarQuadraticExpression{X: x, C: terms.Constant}
+	if x.Len() > 0 {
+		qeOut.Q = *terms.quadraticCoefficients(positionOf, x.Len())
+		qeOut.L = *terms.linearCoefficients(positionOf, x.Len())
+	}
+
+	return qeOut
+}
+
+/*
+ToSparse
+Description:
+
+	Returns the SparseScalarQuadraticExpression with the same nonzero terms. Repeated variables
+	are merged and Q[i][j] and Q[j][i] are combined into a single cross term.
+*/
+func (qe ScalarQuadraticExpression) ToSparse() SparseScalarQuadraticExpression {
+	terms, _ := termsOf(qe)
+	terms = terms.WithoutZeros()
+
+	return SparseScalarQuadraticExpression{
+		X:         VarVector{UniqueVars(qe.X.Elements)},
+		Quadratic: terms.Quadratic,
+		Linear:    terms.Linear,
+		C:         terms.Constant,
+	}
+}
+
+/*
+Multiply
+Description:
+
+	Returns the product of the quadratic expression with term1 (and then with each of the
+	extras). Only products with constants keep the degree at two, so any other term returns an
+	error.
+*/
+func (qe ScalarQuadraticExpression) Multiply(term1 interface{}, extras ...interface{}) (Expression, error) {
+	return Multiply(qe, term1, extras...)
 }
 
 /*
@@ -347,7 +456,7 @@ Usage:
 	constr, err := qe.Comparison(expr1,SenseGreaterThanEqual)
 */
 func (qe ScalarQuadraticExpression) Comparison(rhs ScalarExpression, sense ConstrSense) (ScalarConstraint, error) {
-	return ScalarConstraint{qe, rhs, sense}, nil
+	return ScalarConstraint{LeftHandSide: qe, RightHandSide: rhs, Sense: sense}, nil
 }
 
 /*
@@ -366,66 +475,33 @@ func (qe ScalarQuadraticExpression) RewriteInTermsOf(newX VarVector) (ScalarQuad
 
 	// Find length of X indices
 	dimX := newX.Len()
-
-	// Create Q matrix of appropriate dimension.
-	newQ := ZerosMatrix(dimX, dimX)
+	positionOf := newX.positionsOf()
 
 	// Create expression
 	var newQE ScalarQuadraticExpression = ScalarQuadraticExpression{
-		Q: newQ,
+		Q: ZerosMatrix(dimX, dimX),
 		X: newX,
 		L: ZerosVector(dimX),
-		C: 0.0,
+		C: qe.C,
 	}
 
-	// Populate Q
-	for oi1Index, oldElt1 := range qe.X.Elements {
-		for oi2Index, oldElt2 := range qe.X.Elements {
-			// Identify what term is associated with the pair (oldIndex1, oldIndex2)
-			oldQterm := qe.Q.At(oi1Index, oi2Index)
-
-			// Get the new indices corresponding to oi1 and oi2
-			ni1Index, err := FindInSlice(oldElt1, newX.Elements)
-			if err != nil {
-				return newQE, fmt.Errorf("The element %v was found in the old X indices, but it does not exist in the new ones!", oldElt1)
-			}
-			//newElt1 := newX.Elements[ni1Index]
-
-			ni2Index, err := FindInSlice(oldElt2, newX.Elements)
-			if err != nil {
-				return newQE, fmt.Errorf("The element %v was found in the old X indices, but it does not exist in the new ones!", oldElt2)
-			}
-			//newElt2 := newX.Elements[ni2Index]
-
-			// Plug the oldQterm into newQ
-			newQE.Q.Set(ni1Index, ni2Index, oldQterm)
+	// Get the new index of each old element
+	newIndices := make([]int, qe.X.Len())
+	for oldIndex, oldElt := range qe.X.Elements {
+		newIndex, found := positionOf[oldElt.ID]
+		if !found {
+			return newQE, fmt.Errorf("The element %v was found in the old X indices, but it does not exist in the new ones!", oldElt)
 		}
+		newIndices[oldIndex] = newIndex
 	}
 
-	// Create L matrix of appropriate dimension
-	newL := ZerosVector(dimX)
-
-	// Populate L
-	for oi1Index, oldElt1 := range qe.X.Elements {
-		// Identify what term is associated with the pair (oldIndex1, oldIndex2)
-		oldLterm := qe.L.AtVec(oi1Index)
-
-		// Get the new indices corresponding to oi1 and oi2
-		ni1Index, err := FindInSlice(oldElt1, newX.Elements)
-		if err != nil {
-			return newQE, fmt.Errorf("The element %v was found in the old X, but it does not exist in the new ones!", oldElt1)
+	// Populate Q and L
+	for oi1Index, ni1Index := range newIndices {
+		for oi2Index, ni2Index := range newIndices {
+			newQE.Q.Set(ni1Index, ni2Index, newQE.Q.At(ni1Index, ni2Index)+qe.Q.At(oi1Index, oi2Index))
 		}
-		//newIndex1 := newXIndices[ni1Index]
-
-		// Plug the oldQterm into newQ
-		offset := ZerosVector(dimX)
-		offset.SetVec(ni1Index, oldLterm)
-		(&newL).AddVec(&newL, &offset)
+		newQE.L.SetVec(ni1Index, newQE.L.AtVec(ni1Index)+qe.L.AtVec(oi1Index))
 	}
-	newQE.L = newL
-
-	// Populate C
-	newQE.C = qe.C
 
 	return newQE, nil
 
@@ -0,0 +1,287 @@
+package optim
+
+/*
+sol_file.go
+Description:
+	Defines the functions that write a solution to a file and read it back, either in a simple
+	text format with one "<name> <value>" line per variable or as JSON (which also records the
+	status, objective and solver metadata). Variables are identified by the names used in the LP
+	and MPS files, so a solution can be read back into the model that it was written for and used
+	e.g. as a MIP start.
+*/
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Constants
+// =========
+
+// SolutionJSONVersion is the version of the JSON schema written by Solution.WriteJSON.
+const SolutionJSONVersion = 1
+
+// solObjectivePrefix starts the comment line which holds the objective in a .sol file.
+const solObjectivePrefix = "# Objective value ="
+
+// Type Definitions
+// ================
+
+/*
+jsonSolutionValue
+Description:
+
+	The value of a single variable in the JSON form of a solution.
+*/
+type jsonSolutionValue struct {
+	Name  string    `json:"name"`
+	Value jsonFloat `json:"value"`
+}
+
+/*
+jsonSolution
+Description:
+
+	The JSON form of a solution.
+*/
+type jsonSolution struct {
+	Version        int                 `json:"version"`
+	Status         OptimizationStatus  `json:"status"`
+	Objective      jsonFloat           `json:"objective"`
+	Gap            jsonFloat           `json:"gap"`
+	BestBound      jsonFloat           `json:"bestBound"`
+	Iterations     int                 `json:"iterations"`
+	PrimalResidual jsonFloat           `json:"primalResidual"`
+	DualResidual   jsonFloat           `json:"dualResidual"`
+	Values         []jsonSolutionValue `json:"values"`
+}
+
+// Functions
+// =========
+
+/*
+WriteSol
+Description:
+
+	Writes the values of the solution to w in the .sol format:
+		# Solution written by goop2
+		# Objective value = 8
+		x0 0
+		x1 4
+	Only the variables of m which have a value in the solution are written, in the order of
+	m.Variables.
+*/
+func (s *Solution) WriteSol(w io.Writer, m *Model) error {
+	// Input Checking
+	if m == nil {
+		return fmt.Errorf("A model is needed to name the variables of the solution.")
+	}
+
+	// Algorithm
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "# Solution written by goop2")
+	fmt.Fprintf(bw, "%v %v\n", solObjectivePrefix, solNumber(s.Objective))
+	for _, tempVar := range m.Variables {
+		value, found := s.Values[tempVar.ID]
+		if !found {
+			continue
+		}
+		name := tempVar.DisplayName()
+		if strings.HasPrefix(name, "#") || strings.IndexFunc(name, unicode.IsSpace) >= 0 {
+			return fmt.Errorf("The name %q of variable %v can not be written in a .sol file", name, tempVar.ID)
+		}
+		fmt.Fprintf(bw, "%v %v\n", name, solNumber(value))
+	}
+
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("There was an issue writing the solution: %v", err)
+	}
+	return nil
+}
+
+/*
+ReadSol
+Description:
+
+	Reads a solution in the .sol format (see WriteSol) for the model m. Lines starting with # are
+	comments, except for the objective line written by WriteSol. Each other line must hold the
+	name of one of the variables of m and its value. Errors are returned as a ParseError.
+*/
+func ReadSol(r io.Reader, m *Model) (*Solution, error) {
+	// Input Checking
+	if m == nil {
+		return nil, fmt.Errorf("A model is needed to identify the variables of the solution.")
+	}
+
+	// Constants
+	ids := solVariableIDs(m)
+	sol := &Solution{Values: make(map[uint64]float64)}
+	scanner := bufio.NewScanner(r)
+
+	// Algorithm
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, "#") {
+			if strings.HasPrefix(trimmed, solObjectivePrefix) {
+				objText := strings.TrimSpace(strings.TrimPrefix(trimmed, solObjectivePrefix))
+				objective, err := strconv.ParseFloat(objText, 64)
+				if err != nil {
+					return nil, ParseError{lineNumber, strings.Index(line, objText) + 1, fmt.Sprintf("invalid objective value %q", objText)}
+				}
+				sol.Objective = objective
+			}
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, ParseError{lineNumber, 1, fmt.Sprintf("expected a name and a value; received %v fields", len(fields))}
+		}
+
+		nameColumn := strings.Index(line, fields[0]) + 1
+		valueColumn := nameColumn + len(fields[0]) + strings.Index(line[nameColumn-1+len(fields[0]):], fields[1])
+
+		id, found := ids[fields[0]]
+		if !found {
+			return nil, ParseError{lineNumber, nameColumn, fmt.Sprintf("unknown variable %q", fields[0])}
+		}
+		if _, isDuplicate := sol.Values[id]; isDuplicate {
+			return nil, ParseError{lineNumber, nameColumn, fmt.Sprintf("variable %q appears more than once", fields[0])}
+		}
+
+		value, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil {
+			return nil, ParseError{lineNumber, valueColumn, fmt.Sprintf("invalid value %q", fields[1])}
+		}
+		sol.Values[id] = value
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("There was an issue reading the solution: %v", err)
+	}
+	return sol, nil
+}
+
+/*
+WriteJSON
+Description:
+
+	Writes the solution, its status, objective and solver metadata to w as indented JSON. The
+	values are listed by variable name in the order of m.Variables.
+*/
+func (s *Solution) WriteJSON(w io.Writer, m *Model) error {
+	// Input Checking
+	if m == nil {
+		return fmt.Errorf("A model is needed to name the variables of the solution.")
+	}
+
+	// Algorithm
+	solJSON := jsonSolution{
+		Version:        SolutionJSONVersion,
+		Status:         s.Status,
+		Objective:      jsonFloat(s.Objective),
+		Gap:            jsonFloat(s.Gap),
+		BestBound:      jsonFloat(s.BestBound),
+		Iterations:     s.Iterations,
+		PrimalResidual: jsonFloat(s.PrimalResidual),
+		DualResidual:   jsonFloat(s.DualResidual),
+		Values:         []jsonSolutionValue{},
+	}
+	for _, tempVar := range m.Variables {
+		if value, found := s.Values[tempVar.ID]; found {
+			solJSON.Values = append(solJSON.Values, jsonSolutionValue{tempVar.DisplayName(), jsonFloat(value)})
+		}
+	}
+
+	data, err := json.MarshalIndent(solJSON, "", "  ")
+	if err != nil {
+		return fmt.Errorf("There was an issue converting the solution to JSON: %v", err)
+	}
+	if _, err := w.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("There was an issue writing the solution: %v", err)
+	}
+	return nil
+}
+
+/*
+ReadSolutionJSON
+Description:
+
+	Reads a solution written by Solution.WriteJSON for the model m.
+*/
+func ReadSolutionJSON(r io.Reader, m *Model) (*Solution, error) {
+	// Input Checking
+	if m == nil {
+		return nil, fmt.Errorf("A model is needed to identify the variables of the solution.")
+	}
+
+	// Algorithm
+	var solJSON jsonSolution
+	if err := json.NewDecoder(r).Decode(&solJSON); err != nil {
+		return nil, fmt.Errorf("There was an issue reading the solution: %v", err)
+	}
+	if solJSON.Version != SolutionJSONVersion {
+		return nil, fmt.Errorf("Unsupported solution JSON version %v; expected %v", solJSON.Version, SolutionJSONVersion)
+	}
+
+	ids := solVariableIDs(m)
+	sol := &Solution{
+		Values:         make(map[uint64]float64, len(solJSON.Values)),
+		Objective:      float64(solJSON.Objective),
+		Status:         solJSON.Status,
+		Gap:            float64(solJSON.Gap),
+		BestBound:      float64(solJSON.BestBound),
+		Iterations:     solJSON.Iterations,
+		PrimalResidual: float64(solJSON.PrimalResidual),
+		DualResidual:   float64(solJSON.DualResidual),
+	}
+	for _, value := range solJSON.Values {
+		id, found := ids[value.Name]
+		if !found {
+			return nil, fmt.Errorf("The solution refers to the unknown variable %q", value.Name)
+		}
+		if _, isDuplicate := sol.Values[id]; isDuplicate {
+			return nil, fmt.Errorf("The variable %q appears more than once in the solution", value.Name)
+		}
+		sol.Values[id] = float64(value.Value)
+	}
+
+	return sol, nil
+}
+
+/*
+solVariableIDs
+Description:
+
+	Maps the names of the variables of m (as written by WriteSol) to their IDs.
+*/
+func solVariableIDs(m *Model) map[string]uint64 {
+	ids := make(map[string]uint64, len(m.Variables))
+	for _, tempVar := range m.Variables {
+		ids[tempVar.DisplayName()] = tempVar.ID
+	}
+	return ids
+}
+
+/*
+solNumber
+Description:
+
+	Formats a value so that it is read back exactly by strconv.ParseFloat.
+*/
+func solNumber(value float64) string {
+	if value == 0 {
+		return "0" // Avoids writing -0
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
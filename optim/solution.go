@@ -20,7 +20,22 @@ type Solution struct {
 	// The optimality gap returned from the solver. For many solvers, this is
 	// the gap between the best possible solution with integer relaxation and
 	// the best integer solution found so far.
-	// Gap float64
+	Gap float64
+
+	// The best bound on the objective that the solver was able to prove. For
+	// mixed integer programs, this is the best objective value of the integer
+	// relaxations which have not yet been explored.
+	BestBound float64
+
+	// The number of iterations used by iterative solvers (e.g. first-order or
+	// interior point methods) before they stopped.
+	Iterations int
+
+	// The final primal and dual residuals (in the infinity norm) reported by
+	// iterative solvers. They measure how far the solution is from satisfying
+	// the constraints and the optimality conditions, respectively.
+	PrimalResidual float64
+	DualResidual   float64
 }
 
 type OptimizationStatus int
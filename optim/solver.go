@@ -16,3 +16,35 @@ type Solver interface {
 	Optimize() (Solution, error)
 	DeleteSolver() error
 }
+
+/*
+SessionSolver
+Description:
+
+	A Solver which can keep its internal state (for example, a factorized basis) between calls to
+	Optimize. ClearModel removes the variables, constraints and objective from the solver, but keeps
+	any information that can be used to warm start the next solve.
+*/
+type SessionSolver interface {
+	Solver
+	ClearModel() error
+}
+
+/*
+IncrementalSolver
+Description:
+
+	A SessionSolver which can be given the changes made to a model since it was last loaded,
+	instead of the whole model. When a model which is kept alive (see Model.KeepSolverAlive) is
+	optimized with the same IncrementalSolver again, Model.Optimize removes and changes the
+	variables and constraints that were edited and only adds the new ones. Constraints are
+	identified by their (scalar) row index in the solver, which shifts when an earlier row is
+	removed.
+*/
+type IncrementalSolver interface {
+	SessionSolver
+	SetVariableBounds(varIn Variable) error
+	RemoveVariable(varIn Variable) error
+	SetConstraint(index int, constrIn ScalarConstraint) error
+	RemoveConstraint(index int) error
+}
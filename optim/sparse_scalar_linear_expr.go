@@ -0,0 +1,319 @@
+package optim
+
+/*
+sparse_scalar_linear_expr.go
+Description:
+	Defines SparseScalarLinearExpr, a linear expression which only stores its nonzero terms. It is
+	meant for expressions over many variables where most coefficients are zero.
+*/
+
+import (
+	"fmt"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// Type Definitions
+// ================
+
+/*
+SparseScalarLinearExpr
+Description:
+
+	The linear expression
+		sum_k Linear[k].Coeff x_{Linear[k].ID} + C
+	stored in coordinate form. X contains each variable of the expression once; variables of X
+	without a term have a zero coefficient.
+*/
+type SparseScalarLinearExpr struct {
+	X      VarVector    // The variables of the expression
+	Linear []LinearTerm // The nonzero linear terms
+	C      float64      // Constant Term
+}
+
+// Functions
+// =========
+
+/*
+sparsePositions
+Description:
+
+	Returns the position of each variable of x, or an error if a variable appears more than once.
+*/
+func sparsePositions(x VarVector) (map[uint64]int, error) {
+	positionOf := make(map[uint64]int)
+	for eltIndex, tempVar := range x.Elements {
+		if _, found := positionOf[tempVar.ID]; found {
+			return positionOf, fmt.Errorf("The variable %v appears more than once in the sparse expression's X.", tempVar)
+		}
+		positionOf[tempVar.ID] = eltIndex
+	}
+	return positionOf, nil
+}
+
+/*
+checkLinearTerms
+Description:
+
+	Verifies that every term refers to a variable of the sparse expression.
+*/
+func checkLinearTerms(terms []LinearTerm, positionOf map[uint64]int) error {
+	for _, term := range terms {
+		if _, found := positionOf[term.ID]; !found {
+			return fmt.Errorf("The linear term %v refers to a variable which is not in the sparse expression's X.", term)
+		}
+	}
+	return nil
+}
+
+/*
+scaleLinearTerms
+Description:
+
+	Returns a copy of the terms with every coefficient multiplied by c.
+*/
+func scaleLinearTerms(terms []LinearTerm, c float64) []LinearTerm {
+	if len(terms) == 0 {
+		return nil
+	}
+	scaled := make([]LinearTerm, len(terms))
+	for termIndex, term := range terms {
+		scaled[termIndex] = LinearTerm{ID: term.ID, Coeff: c * term.Coeff}
+	}
+	return scaled
+}
+
+/*
+sparsePlus
+Description:
+
+	Returns the sum of the sparse expression se and e, which can be a float64 or any scalar
+	expression. The sum is sparse; it is a SparseScalarQuadraticExpression if either term is
+	quadratic and a SparseScalarLinearExpr otherwise.
+*/
+func sparsePlus(se ScalarExpression, e interface{}) (ScalarExpression, error) {
+	// Input Checking
+	var eAsSE ScalarExpression
+	switch eIn := e.(type) {
+	case float64:
+		eAsSE = K(eIn)
+	case ScalarExpression:
+		eAsSE = eIn
+	default:
+		return se, fmt.Errorf("Unexpected type (%T) given as argument to Plus: %v.", e, e)
+	}
+
+	seTerms, err := SparseTermsOf(se)
+	if err != nil {
+		return se, fmt.Errorf("There was an issue reading the sparse expression: %v", err)
+	}
+	eTerms, err := SparseTermsOf(eAsSE)
+	if err != nil {
+		return se, fmt.Errorf("There was an issue reading the argument of Plus: %v", err)
+	}
+
+	// Algorithm
+	sum := expressionTerms{
+		Linear: seTerms.Linear, Quadratic: seTerms.Quadratic, Constant: seTerms.Constant,
+	}.Plus(expressionTerms{
+		Linear: eTerms.Linear, Quadratic: eTerms.Quadratic, Constant: eTerms.Constant,
+	})
+
+	return sum.toSparseScalarExpression(append(se.Variables(), eAsSE.Variables()...)), nil
+}
+
+// Member Functions
+// ================
+
+/*
+Check
+Description:
+
+	Verifies that each variable appears in X only once and that every term refers to a variable
+	of X.
+*/
+func (sle SparseScalarLinearExpr) Check() error {
+	positionOf, err := sparsePositions(sle.X)
+	if err != nil {
+		return err
+	}
+	return checkLinearTerms(sle.Linear, positionOf)
+}
+
+/*
+Variables
+Description:
+
+	Returns a slice containing all unique variables in the expression.
+*/
+func (sle SparseScalarLinearExpr) Variables() []Variable {
+	return UniqueVars(sle.X.Elements)
+}
+
+/*
+NumVars
+Description:
+
+	Returns the number of variables in the expression.
+*/
+func (sle SparseScalarLinearExpr) NumVars() int {
+	return sle.X.Len()
+}
+
+/*
+IDs
+Description:
+
+	Returns the ids of the variables in the expression, in the order of X.
+*/
+func (sle SparseScalarLinearExpr) IDs() []uint64 {
+	return sle.X.IDs()
+}
+
+/*
+Coeffs
+Description:
+
+	Returns the coefficient of each variable of X (in the order of IDs()), including zeros.
+*/
+func (sle SparseScalarLinearExpr) Coeffs() []float64 {
+	positionOf, _ := sparsePositions(sle.X)
+	coeffsOut := make([]float64, sle.X.Len())
+	for _, term := range sle.Linear {
+		coeffsOut[positionOf[term.ID]] += term.Coeff
+	}
+	return coeffsOut
+}
+
+/*
+Constant
+Description:
+
+	Returns the constant term of the expression.
+*/
+func (sle SparseScalarLinearExpr) Constant() float64 {
+	return sle.C
+}
+
+/*
+Plus
+Description:
+
+	Adds the sparse expression to e, which can be a float64 or any scalar expression. The sum is
+	a sparse expression.
+*/
+func (sle SparseScalarLinearExpr) Plus(e interface{}, extras ...interface{}) (ScalarExpression, error) {
+	return sparsePlus(sle, e)
+}
+
+/*
+Mult
+Description:
+
+	Multiplies the expression by the constant c. The result does not share data with sle.
+*/
+func (sle SparseScalarLinearExpr) Mult(c float64) (ScalarExpression, error) {
+	return SparseScalarLinearExpr{X: sle.X, Linear: scaleLinearTerms(sle.Linear, c), C: c * sle.C}, nil
+}
+
+/*
+Minus
+Description:
+
+	Returns the difference between the sparse expression and e, which can be a float64 or any
+	scalar expression.
+*/
+func (sle SparseScalarLinearExpr) Minus(e interface{}, extras ...interface{}) (ScalarExpression, error) {
+	return scalarMinus(sle, e, extras)
+}
+
+/*
+Neg
+Description:
+
+	Returns the expression with every coefficient (and the constant) negated.
+*/
+func (sle SparseScalarLinearExpr) Neg() ScalarExpression {
+	negated, _ := sle.Mult(-1.0)
+	return negated
+}
+
+/*
+Div
+Description:
+
+	Divides the expression by the constant c. Division by zero returns an error.
+*/
+func (sle SparseScalarLinearExpr) Div(c float64) (ScalarExpression, error) {
+	// Input Checking
+	if err := checkDivisor(c); err != nil {
+		return sle, err
+	}
+
+	// Algorithm
+	return sle.Mult(1.0 / c)
+}
+
+/*
+Equals
+Description:
+
+	Returns true if other has the same coefficients and constant as the expression, up to tol.
+	Dense and sparse expressions can be compared with each other.
+*/
+func (sle SparseScalarLinearExpr) Equals(other interface{}, tol float64) bool {
+	return scalarEquals(sle, other, tol)
+}
+
+/*
+Multiply
+Description:
+
+	Returns the product of the expression with term1 (and then with each of the extras).
+	Products are computed with dense expressions.
+*/
+func (sle SparseScalarLinearExpr) Multiply(term1 interface{}, extras ...interface{}) (Expression, error) {
+	return Multiply(sle, term1, extras...)
+}
+
+/*
+ToDense
+Description:
+
+	Returns the ScalarLinearExpr with the same variables and coefficients.
+*/
+func (sle SparseScalarLinearExpr) ToDense() ScalarLinearExpr {
+	sleOut := ScalarLinearExpr{X: sle.X, C: sle.C}
+	if sle.X.Len() > 0 {
+		sleOut.L = *mat.NewVecDense(sle.X.Len(), sle.Coeffs())
+	}
+	return sleOut
+}
+
+// LessEq returns a less than or equal to (<=) constraint between the
+// current expression and another
+func (sle SparseScalarLinearExpr) LessEq(other ScalarExpression) (ScalarConstraint, error) {
+	return sle.Comparison(other, SenseLessThanEqual)
+}
+
+// GreaterEq returns a greater than or equal to (>=) constraint between the
+// current expression and another
+func (sle SparseScalarLinearExpr) GreaterEq(other ScalarExpression) (ScalarConstraint, error) {
+	return sle.Comparison(other, SenseGreaterThanEqual)
+}
+
+// Eq returns an equality (==) constraint between the current expression
+// and another
+func (sle SparseScalarLinearExpr) Eq(other ScalarExpression) (ScalarConstraint, error) {
+	return sle.Comparison(other, SenseEqual)
+}
+
+/*
+Comparison
+Description:
+
+	This method compares the receiver with expression rhs in the sense provided by sense.
+*/
+func (sle SparseScalarLinearExpr) Comparison(rhs ScalarExpression, sense ConstrSense) (ScalarConstraint, error) {
+	return ScalarConstraint{LeftHandSide: sle, RightHandSide: rhs, Sense: sense}, nil
+}
@@ -0,0 +1,294 @@
+package optim
+
+/*
+sparse_scalar_quadratic_expression.go
+Description:
+	Defines SparseScalarQuadraticExpression, a quadratic expression which only stores its nonzero
+	terms. A quadratic over n variables with k nonzero terms needs O(k) memory instead of the
+	n x n matrix of a ScalarQuadraticExpression.
+*/
+
+import (
+	"fmt"
+)
+
+// Type Definitions
+// ================
+
+/*
+SparseScalarQuadraticExpression
+Description:
+
+	The quadratic expression
+		sum_k Quadratic[k].Coeff x_{Quadratic[k].ID1} x_{Quadratic[k].ID2}
+			+ sum_k Linear[k].Coeff x_{Linear[k].ID} + C
+	stored in coordinate form. Each quadratic term holds the total coefficient of its product of
+	variables, so the cross term 2 x y is a single term with Coeff 2. X contains each variable of
+	the expression once.
+*/
+type SparseScalarQuadraticExpression struct {
+	X         VarVector       // The variables of the expression
+	Quadratic []QuadraticTerm // The nonzero quadratic terms
+	Linear    []LinearTerm    // The nonzero linear terms
+	C         float64         // Constant Term
+}
+
+// Member Functions
+// ================
+
+/*
+Check
+Description:
+
+	Verifies that each variable appears in X only once and that every term refers to variables
+	of X.
+*/
+func (qe SparseScalarQuadraticExpression) Check() error {
+	positionOf, err := sparsePositions(qe.X)
+	if err != nil {
+		return err
+	}
+
+	for _, term := range qe.Quadratic {
+		_, found1 := positionOf[term.ID1]
+		_, found2 := positionOf[term.ID2]
+		if !found1 || !found2 {
+			return fmt.Errorf("The quadratic term %v refers to a variable which is not in the sparse expression's X.", term)
+		}
+	}
+
+	return checkLinearTerms(qe.Linear, positionOf)
+}
+
+/*
+Variables
+Description:
+
+	Returns a slice containing all unique variables in the expression.
+*/
+func (qe SparseScalarQuadraticExpression) Variables() []Variable {
+	return UniqueVars(qe.X.Elements)
+}
+
+/*
+NumVars
+Description:
+
+	Returns the number of variables in the expression.
+*/
+func (qe SparseScalarQuadraticExpression) NumVars() int {
+	return qe.X.Len()
+}
+
+/*
+IDs
+Description:
+
+	Returns the ids of the variables in the expression, in the order of X.
+*/
+func (qe SparseScalarQuadraticExpression) IDs() []uint64 {
+	return qe.X.IDs()
+}
+
+/*
+Coeffs
+Description:
+
+	Returns the coefficients in the same order as ScalarQuadraticExpression.Coeffs: one for each
+	pair x[i]*x[j] with i <= j, followed by one for each x[i]. The output has one entry for every
+	pair of variables, so sparse solvers should use SparseTermsOf instead.
+*/
+func (qe SparseScalarQuadraticExpression) Coeffs() []float64 {
+	// Constants
+	positionOf, _ := sparsePositions(qe.X)
+	n := qe.X.Len()
+
+	quadraticCoeffs := make(map[[2]int]float64)
+	for _, term := range qe.Quadratic {
+		i, j := positionOf[term.ID1], positionOf[term.ID2]
+		if j < i {
+			i, j = j, i
+		}
+		quadraticCoeffs[[2]int{i, j}] += term.Coeff
+	}
+
+	// Algorithm
+	var coefficientList []float64
+	for i := 0; i < n; i++ {
+		for j := i; j < n; j++ {
+			coefficientList = append(coefficientList, quadraticCoeffs[[2]int{i, j}])
+		}
+	}
+
+	linearCoeffs := make([]float64, n)
+	for _, term := range qe.Linear {
+		linearCoeffs[positionOf[term.ID]] += term.Coeff
+	}
+
+	return append(coefficientList, linearCoeffs...)
+}
+
+/*
+Constant
+Description:
+
+	Returns the constant term of the expression.
+*/
+func (qe SparseScalarQuadraticExpression) Constant() float64 {
+	return qe.C
+}
+
+/*
+Plus
+Description:
+
+	Adds the sparse expression to e, which can be a float64 or any scalar expression. The sum is
+	a sparse expression.
+*/
+func (qe SparseScalarQuadraticExpression) Plus(e interface{}, extras ...interface{}) (ScalarExpression, error) {
+	return sparsePlus(qe, e)
+}
+
+/*
+Mult
+Description:
+
+	Multiplies the expression by the constant c. The result does not share data with qe.
+*/
+func (qe SparseScalarQuadraticExpression) Mult(c float64) (ScalarExpression, error) {
+	qeOut := SparseScalarQuadraticExpression{
+		X:      qe.X,
+		Linear: scaleLinearTerms(qe.Linear, c),
+		C:      c * qe.C,
+	}
+	for _, term := range qe.Quadratic {
+		qeOut.Quadratic = append(qeOut.Quadratic, QuadraticTerm{ID1: term.ID1, ID2: term.ID2, Coeff: c * term.Coeff})
+	}
+
+	return qeOut, nil
+}
+
+/*
+Minus
+Description:
+
+	Returns the difference between the sparse expression and e, which can be a float64 or any
+	scalar expression.
+*/
+func (qe SparseScalarQuadraticExpression) Minus(e interface{}, extras ...interface{}) (ScalarExpression, error) {
+	return scalarMinus(qe, e, extras)
+}
+
+/*
+Neg
+Description:
+
+	Returns the expression with every coefficient (and the constant) negated.
+*/
+func (qe SparseScalarQuadraticExpression) Neg() ScalarExpression {
+	negated, _ := qe.Mult(-1.0)
+	return negated
+}
+
+/*
+Div
+Description:
+
+	Divides the expression by the constant c. Division by zero returns an error.
+*/
+func (qe SparseScalarQuadraticExpression) Div(c float64) (ScalarExpression, error) {
+	// Input Checking
+	if err := checkDivisor(c); err != nil {
+		return qe, err
+	}
+
+	// Algorithm
+	return qe.Mult(1.0 / c)
+}
+
+/*
+Equals
+Description:
+
+	Returns true if other describes the same quadratic function as qe up to tol. Dense and
+	sparse expressions can be compared with each other.
+*/
+func (qe SparseScalarQuadraticExpression) Equals(other interface{}, tol float64) bool {
+	return scalarEquals(qe, other, tol)
+}
+
+/*
+Multiply
+Description:
+
+	Returns the product of the expression with term1 (and then with each of the extras). Only
+	products with constants keep the degree at two, so any other term returns an error.
+*/
+func (qe SparseScalarQuadraticExpression) Multiply(term1 interface{}, extras ...interface{}) (Expression, error) {
+	return Multiply(qe, term1, extras...)
+}
+
+/*
+ToDense
+Description:
+
+	Returns the ScalarQuadraticExpression with the same variables and coefficients. Cross terms
+	are split evenly between Q[i][j] and Q[j][i], so Q is symmetric.
+*/
+func (qe SparseScalarQuadraticExpression) ToDense() ScalarQuadraticExpression {
+	// Constants
+	terms, _ := termsOf(qe)
+	positionOf, _ := sparsePositions(qe.X)
+
+	// Algorithm
+	qeOut := ScalarQuadraticExpression{X: qe.X, C: qe.C}
+	if qe.X.Len() > 0 {
+		qeOut.Q = *terms.quadraticCoefficients(positionOf, qe.X.Len())
+		qeOut.L = *terms.linearCoefficients(positionOf, qe.X.Len())
+	}
+
+	return qeOut
+}
+
+/*
+LessEq
+Description:
+
+	LessEq returns a less than or equal to (<=) constraint between the
+	current expression and another
+*/
+func (qe SparseScalarQuadraticExpression) LessEq(other ScalarExpression) (ScalarConstraint, error) {
+	return qe.Comparison(other, SenseLessThanEqual)
+}
+
+/*
+GreaterEq
+Description:
+
+	GreaterEq returns a greater than or equal to (>=) constraint between the
+	current expression and another
+*/
+func (qe SparseScalarQuadraticExpression) GreaterEq(other ScalarExpression) (ScalarConstraint, error) {
+	return qe.Comparison(other, SenseGreaterThanEqual)
+}
+
+/*
+Eq
+Description:
+
+	Eq returns an equality (==) constraint between the current expression
+	and another
+*/
+func (qe SparseScalarQuadraticExpression) Eq(other ScalarExpression) (ScalarConstraint, error) {
+	return qe.Comparison(other, SenseEqual)
+}
+
+/*
+Comparison
+Description:
+
+	This method compares the receiver with expression rhs in the sense provided by sense.
+*/
+func (qe SparseScalarQuadraticExpression) Comparison(rhs ScalarExpression, sense ConstrSense) (ScalarConstraint, error) {
+	return ScalarConstraint{LeftHandSide: qe, RightHandSide: rhs, Sense: sense}, nil
+}
@@ -0,0 +1,409 @@
+package optim
+
+/*
+sparse_vector_linear_expression.go
+Description:
+	Defines SparseVectorLinearExpr, a vector of linear expressions stored as compressed sparse
+	rows. Large constraint blocks A x <= b where each row only involves a few variables can be
+	built without allocating the dense matrix A.
+*/
+
+import (
+	"fmt"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// Type Definitions
+// ================
+
+/*
+SparseVectorLinearExpr
+Description:
+
+	The vector of linear expressions whose i-th element is
+		sum_k Rows[i][k].Coeff x_{Rows[i][k].ID} + C[i]
+	X contains each variable of the expression once. Rows must have one entry for each element
+	of C; an empty row is the constant C[i].
+*/
+type SparseVectorLinearExpr struct {
+	X    VarVector      // The variables of the expression
+	Rows [][]LinearTerm // The nonzero linear terms of each element
+	C    mat.VecDense   // Constant Terms
+}
+
+// Functions
+// =========
+
+/*
+newSparseVectorLinearExpr
+Description:
+
+	Builds the sparse vector expression whose elements have the given terms. The variables are
+	taken from pool, which must contain every variable of the terms, and sorted by ID.
+*/
+func newSparseVectorLinearExpr(rows []expressionTerms, pool []Variable) SparseVectorLinearExpr {
+	// Constants
+	var allTerms expressionTerms
+	svleOut := SparseVectorLinearExpr{Rows: make([][]LinearTerm, len(rows))}
+	C := make([]float64, len(rows))
+
+	// Algorithm
+	for rowIndex, row := range rows {
+		nonzero := row.WithoutZeros()
+		svleOut.Rows[rowIndex] = nonzero.Linear
+		C[rowIndex] = nonzero.Constant
+		for _, term := range nonzero.Linear {
+			allTerms.addLinear(term.ID, 1.0)
+		}
+	}
+	svleOut.X, _ = allTerms.sortedVariables(pool)
+	if len(rows) > 0 {
+		svleOut.C = *mat.NewVecDense(len(rows), C)
+	}
+
+	return svleOut
+}
+
+// Member Functions
+// ================
+
+/*
+Check
+Description:
+
+	Verifies that each variable appears in X only once, that every term refers to a variable of
+	X and that there is one row for each element of C.
+*/
+func (svle SparseVectorLinearExpr) Check() error {
+	// Check the dimensions
+	if len(svle.Rows) != svle.C.Len() {
+		return fmt.Errorf("The number of rows (%v) does not match the length of C (%v).", len(svle.Rows), svle.C.Len())
+	}
+
+	// Check the terms
+	positionOf, err := sparsePositions(svle.X)
+	if err != nil {
+		return err
+	}
+	for rowIndex, row := range svle.Rows {
+		if err := checkLinearTerms(row, positionOf); err != nil {
+			return fmt.Errorf("Row %v is not valid: %v", rowIndex, err)
+		}
+	}
+
+	return nil
+}
+
+/*
+IDs
+Description:
+
+	Returns the ids of the variables in the expression, in the order of X.
+*/
+func (svle SparseVectorLinearExpr) IDs() []uint64 {
+	return svle.X.IDs()
+}
+
+/*
+NumVars
+Description:
+
+	Returns the number of variables in the expression.
+*/
+func (svle SparseVectorLinearExpr) NumVars() int {
+	return svle.X.Len()
+}
+
+/*
+Len
+Description:
+
+	Returns the number of elements in the expression.
+*/
+func (svle SparseVectorLinearExpr) Len() int {
+	return len(svle.Rows)
+}
+
+/*
+LinearCoeff
+Description:
+
+	Returns the dense matrix L of coefficients such that the expression is L x + C. This allocates
+	a Len() x NumVars() matrix, so it should only be used for small expressions.
+*/
+func (svle SparseVectorLinearExpr) LinearCoeff() mat.Dense {
+	return svle.ToDense().LinearCoeff()
+}
+
+/*
+Constant
+Description:
+
+	Returns the vector of constant terms.
+*/
+func (svle SparseVectorLinearExpr) Constant() mat.VecDense {
+	return svle.C
+}
+
+/*
+AtVec
+Description:
+
+	Returns element idx of the vector as a SparseScalarLinearExpr over the variables of that row.
+	The returned expression does not share data with svle.
+*/
+func (svle SparseVectorLinearExpr) AtVec(idx int) ScalarExpression {
+	// Constants
+	positionOf, _ := sparsePositions(svle.X)
+	row := svle.Rows[idx]
+
+	// Algorithm
+	sleOut := SparseScalarLinearExpr{
+		Linear: scaleLinearTerms(row, 1.0),
+		C:      svle.C.AtVec(idx),
+	}
+	for _, term := range row {
+		sleOut.X.Elements = append(sleOut.X.Elements, svle.X.Elements[positionOf[term.ID]])
+	}
+	sleOut.X = VarVector{UniqueVars(sleOut.X.Elements)}
+
+	return sleOut
+}
+
+/*
+Plus
+Description:
+
+	Adds the sparse expression to e, which can be a KVector (or mat.VecDense), VarVector,
+	VectorLinearExpr, SparseVectorLinearExpr or VectorQuadraticExpression of the same length.
+	Sums with linear expressions are sparse; sums with quadratic expressions are dense.
+*/
+func (svle SparseVectorLinearExpr) Plus(e interface{}, extras ...interface{}) (VectorExpression, error) {
+	// Input Processing
+	var eAsVE VectorExpression
+	switch eIn := e.(type) {
+	case mat.VecDense:
+		eAsVE = KVector(eIn)
+	case VectorQuadraticExpression:
+		return eIn.Plus(svle.ToDense())
+	case KVector, VarVector, VectorLinearExpr, SparseVectorLinearExpr:
+		eAsVE = eIn.(VectorExpression)
+	default:
+		return svle, fmt.Errorf("Unexpected type (%T) given as argument to Plus: %v.", e, e)
+	}
+
+	// Input Checking
+	if err := svle.Check(); err != nil {
+		return svle, err
+	}
+	if checker, ok := eAsVE.(interface{ Check() error }); ok {
+		if err := checker.Check(); err != nil {
+			return svle, err
+		}
+	}
+	if eAsVE.Len() != svle.Len() {
+		return svle, fmt.Errorf(
+			"The length of input %T (%v) did not match the length of the SparseVectorLinearExpr (%v).",
+			e, eAsVE.Len(), svle.Len(),
+		)
+	}
+
+	// Algorithm
+	pool := svle.X.Elements
+	rows := make([]expressionTerms, svle.Len())
+	for rowIndex := range rows {
+		other := eAsVE.AtVec(rowIndex)
+		otherTerms, err := termsOf(other)
+		if err != nil {
+			return svle, err
+		}
+		rowTerms := expressionTerms{Linear: svle.Rows[rowIndex], Constant: svle.C.AtVec(rowIndex)}
+		rows[rowIndex] = rowTerms.Plus(otherTerms)
+		pool = append(pool, other.Variables()...)
+	}
+
+	return newSparseVectorLinearExpr(rows, pool), nil
+}
+
+/*
+Mult
+Description:
+
+	Multiplies every element of the expression by the constant c. The result does not share data
+	with svle.
+*/
+func (svle SparseVectorLinearExpr) Mult(c float64) (VectorExpression, error) {
+	// Input Checking
+	if err := svle.Check(); err != nil {
+		return svle, err
+	}
+
+	// Algorithm
+	svleOut := SparseVectorLinearExpr{X: svle.X, Rows: make([][]LinearTerm, len(svle.Rows))}
+	for rowIndex, row := range svle.Rows {
+		svleOut.Rows[rowIndex] = scaleLinearTerms(row, c)
+	}
+	if svle.C.Len() > 0 {
+		svleOut.C.ScaleVec(c, &svle.C)
+	}
+
+	return svleOut, nil
+}
+
+/*
+Minus
+Description:
+
+	Returns the difference between the sparse expression and e, which can be a mat.VecDense or
+	any vector expression of the same length.
+*/
+func (svle SparseVectorLinearExpr) Minus(e interface{}, extras ...interface{}) (VectorExpression, error) {
+	return vectorMinus(svle, e, extras)
+}
+
+/*
+Neg
+Description:
+
+	Returns the expression with every coefficient (and constant) negated.
+*/
+func (svle SparseVectorLinearExpr) Neg() VectorExpression {
+	negated, _ := svle.Mult(-1.0)
+	return negated
+}
+
+/*
+Div
+Description:
+
+	Divides every element of the expression by the constant c. Division by zero returns an error.
+*/
+func (svle SparseVectorLinearExpr) Div(c float64) (VectorExpression, error) {
+	// Input Checking
+	if err := checkDivisor(c); err != nil {
+		return svle, err
+	}
+
+	// Algorithm
+	return svle.Mult(1.0 / c)
+}
+
+/*
+Equals
+Description:
+
+	Returns true if other is a vector of the same length whose elements each equal the matching
+	element of svle up to tol. Dense and sparse expressions can be compared with each other.
+*/
+func (svle SparseVectorLinearExpr) Equals(other interface{}, tol float64) bool {
+	return vectorEquals(svle, other, tol)
+}
+
+/*
+Multiply
+Description:
+
+	Returns the product of the expression with term1 (and then with each of the extras).
+	Products are computed with dense expressions.
+*/
+func (svle SparseVectorLinearExpr) Multiply(term1 interface{}, extras ...interface{}) (Expression, error) {
+	return Multiply(svle, term1, extras...)
+}
+
+/*
+ToDense
+Description:
+
+	Returns the VectorLinearExpr with the same variables and coefficients.
+*/
+func (svle SparseVectorLinearExpr) ToDense() VectorLinearExpr {
+	// Constants
+	positionOf, _ := sparsePositions(svle.X)
+	vleOut := VectorLinearExpr{X: svle.X}
+	if svle.C.Len() > 0 {
+		vleOut.C = *mat.VecDenseCopyOf(&svle.C)
+	}
+
+	// Algorithm
+	if len(svle.Rows) > 0 && svle.X.Len() > 0 {
+		L := mat.NewDense(len(svle.Rows), svle.X.Len(), nil)
+		for rowIndex, row := range svle.Rows {
+			for _, term := range row {
+				colIndex := positionOf[term.ID]
+				L.Set(rowIndex, colIndex, L.At(rowIndex, colIndex)+term.Coeff)
+			}
+		}
+		vleOut.L = *L
+	}
+
+	return vleOut
+}
+
+/*
+LessEq
+Description:
+
+	Returns a less than or equal to (<=) constraint between the expression and rhs.
+*/
+func (svle SparseVectorLinearExpr) LessEq(rhs interface{}) (VectorConstraint, error) {
+	return svle.Comparison(rhs, SenseLessThanEqual)
+}
+
+/*
+GreaterEq
+Description:
+
+	Returns a greater than or equal to (>=) constraint between the expression and rhs.
+*/
+func (svle SparseVectorLinearExpr) GreaterEq(rhs interface{}) (VectorConstraint, error) {
+	return svle.Comparison(rhs, SenseGreaterThanEqual)
+}
+
+/*
+Eq
+Description:
+
+	Returns an equality (==) constraint between the expression and rhs.
+*/
+func (svle SparseVectorLinearExpr) Eq(rhs interface{}) (VectorConstraint, error) {
+	return svle.Comparison(rhs, SenseEqual)
+}
+
+/*
+Comparison
+Description:
+
+	Compares the expression with rhs, which can be a KVector (or mat.VecDense), VarVector,
+	VectorLinearExpr, SparseVectorLinearExpr or VectorQuadraticExpression of the same length.
+*/
+func (svle SparseVectorLinearExpr) Comparison(rhs interface{}, sense ConstrSense) (VectorConstraint, error) {
+	// Check Input
+	if err := svle.Check(); err != nil {
+		return VectorConstraint{}, fmt.Errorf(
+			"There was an issue in the provided sparse vector linear expression %v: %v",
+			svle, err,
+		)
+	}
+
+	var rhsAsVE VectorExpression
+	switch rhsIn := rhs.(type) {
+	case mat.VecDense:
+		rhsAsVE = KVector(rhsIn)
+	case KVector, VarVector, VectorLinearExpr, SparseVectorLinearExpr, VectorQuadraticExpression:
+		rhsAsVE = rhsIn.(VectorExpression)
+	default:
+		return VectorConstraint{}, fmt.Errorf("The Comparison() method for SparseVectorLinearExpr is not implemented yet for type %T!", rhs)
+	}
+
+	// Algorithm
+	if rhsAsVE.Len() != svle.Len() {
+		return VectorConstraint{}, fmt.Errorf(
+			"The two vector inputs to Comparison() must have the same dimension, but #1 has dimension %v and #2 has dimension %v!",
+			svle.Len(),
+			rhsAsVE.Len(),
+		)
+	}
+
+	return VectorConstraint{LeftHandSide: svle, RightHandSide: rhsAsVE, Sense: sense}, nil
+}
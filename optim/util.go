@@ -5,34 +5,30 @@ import (
 	"gonum.org/v1/gonum/mat"
 )
 
-// SumVars returns the sum of the given variables. It creates a new empty
-// expression and adds to it the given variables.
+// SumVars returns the sum of the given variables as a ScalarLinearExpr. It is
+// built with a LinearExprBuilder, so it takes linear time.
 func SumVars(vs ...Variable) ScalarExpression {
-	newExpr := NewExpr(0)
+	b := NewLinearExprBuilder(len(vs))
 	for _, v := range vs {
-		newExpr.Plus(v)
+		b.AddTerm(1.0, v)
 	}
-	return newExpr
+	return b.Build()
 }
 
 // SumRow returns the sum of all the variables in a single specified row of
 // a variable matrix.
 func SumRow(vs [][]Variable, row int) ScalarExpression {
-	newExpr := NewExpr(0)
-	for col := 0; col < len(vs[0]); col++ {
-		newExpr.Plus(vs[row][col])
-	}
-	return newExpr
+	return SumVars(vs[row]...)
 }
 
 // SumCol returns the sum of all variables in a single specified column of
 // a variable matrix.
 func SumCol(vs [][]Variable, col int) ScalarExpression {
-	newExpr := NewExpr(0)
+	b := NewLinearExprBuilder(len(vs))
 	for row := 0; row < len(vs); row++ {
-		newExpr.Plus(vs[row][col])
+		b.AddTerm(1.0, vs[row][col])
 	}
-	return newExpr
+	return b.Build()
 }
 
 /*
@@ -123,21 +119,14 @@ Description:
 func Unique(listIn []uint64) []uint64 {
 	// Create unique list
 	var uniqueList []uint64
+	seen := make(map[uint64]bool, len(listIn))
 
-	// For each int in the list, determine if it previously existed in the list.
-	for listIndex, tempElt := range listIn {
-		// Don't do any checks if this is the first element.
-		if listIndex == 0 {
-			uniqueList = append(uniqueList, tempElt)
-			continue
-		}
-
-		// check to see if the current element exists in the uniqueList.
-		if foundIndex, _ := FindInSlice(tempElt, uniqueList); foundIndex == -1 {
-			// tempElt does not exist in uniqueList already. Add it.
+	// Keep each element the first time that it appears in the list.
+	for _, tempElt := range listIn {
+		if !seen[tempElt] {
+			seen[tempElt] = true
 			uniqueList = append(uniqueList, tempElt)
 		}
-		// Otherwise, don't add it.
 	}
 
 	return uniqueList
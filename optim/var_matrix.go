@@ -0,0 +1,303 @@
+package optim
+
+/*
+var_matrix.go
+Description:
+	The VarMatrix type represents a matrix of optimization variables, as created by
+	Model.AddVariableMatrix.
+*/
+
+import (
+	"fmt"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+/*
+VarMatrix
+Description:
+
+	Represents a matrix of variables in an optimization problem. Elements[i][j] is the variable in
+	row i and column j; every row must have the same length.
+*/
+type VarMatrix struct {
+	Elements [][]Variable
+}
+
+// =========
+// Functions
+// =========
+
+/*
+Check
+Description:
+
+	Verifies that every row of the matrix has the same length.
+*/
+func (vm VarMatrix) Check() error {
+	_, nC := vm.Dims()
+	for rowIndex, row := range vm.Elements {
+		if len(row) != nC {
+			return fmt.Errorf("Row %v of the variable matrix has length %v; expected %v", rowIndex, len(row), nC)
+		}
+	}
+	return nil
+}
+
+/*
+Dims
+Description:
+
+	Returns the number of rows and columns of the matrix. This mirrors the gonum API for matrices.
+*/
+func (vm VarMatrix) Dims() (int, int) {
+	if len(vm.Elements) == 0 {
+		return 0, 0
+	}
+	return len(vm.Elements), len(vm.Elements[0])
+}
+
+/*
+At
+Description:
+
+	Mirrors the gonum api for matrices. This extracts the variable in row i and column j.
+*/
+func (vm VarMatrix) At(i, j int) ScalarExpression {
+	return vm.Elements[i][j]
+}
+
+/*
+IDs
+Description:
+
+	Returns the unique indices of the variables in the matrix.
+*/
+func (vm VarMatrix) IDs() []uint64 {
+	return vm.Vars().IDs()
+}
+
+/*
+NumVars
+Description:
+
+	The number of unique variables inside the variable matrix.
+*/
+func (vm VarMatrix) NumVars() int {
+	return len(vm.IDs())
+}
+
+/*
+Vars
+Description:
+
+	Returns the unique variables of the matrix, in row-major order.
+*/
+func (vm VarMatrix) Vars() VarVector {
+	var vars []Variable
+	for _, row := range vm.Elements {
+		vars = append(vars, row...)
+	}
+	return VarVector{UniqueVars(vars)}
+}
+
+/*
+Row
+Description:
+
+	Returns row i of the matrix as a VarVector.
+*/
+func (vm VarMatrix) Row(i int) VarVector {
+	return VarVector{append([]Variable(nil), vm.Elements[i]...)}
+}
+
+/*
+Col
+Description:
+
+	Returns column j of the matrix as a VarVector.
+*/
+func (vm VarMatrix) Col(j int) VarVector {
+	col := make([]Variable, len(vm.Elements))
+	for rowIndex, row := range vm.Elements {
+		col[rowIndex] = row[j]
+	}
+	return VarVector{col}
+}
+
+/*
+T
+Description:
+
+	Returns the transpose of the matrix (a VarMatrix).
+*/
+func (vm VarMatrix) T() MatrixExpression {
+	// Constants
+	nR, nC := vm.Dims()
+
+	// Algorithm
+	transposed := make([][]Variable, nC)
+	for colIndex := range transposed {
+		transposed[colIndex] = make([]Variable, nR)
+		for rowIndex := 0; rowIndex < nR; rowIndex++ {
+			transposed[colIndex][rowIndex] = vm.Elements[rowIndex][colIndex]
+		}
+	}
+
+	return VarMatrix{transposed}
+}
+
+/*
+Trace
+Description:
+
+	Returns the sum of the variables on the diagonal of a square matrix.
+*/
+func (vm VarMatrix) Trace() (ScalarExpression, error) {
+	// Input Checking
+	if err := checkSquare(vm); err != nil {
+		return nil, err
+	}
+
+	mle, err := vm.ToMatrixLinearExpr()
+	if err != nil {
+		return nil, err
+	}
+
+	// Algorithm
+	return mle.Trace()
+}
+
+/*
+ToMatrixLinearExpr
+Description:
+
+	Writes the matrix of variables as a MatrixLinearExpr (with a zero constant).
+*/
+func (vm VarMatrix) ToMatrixLinearExpr() (MatrixLinearExpr, error) {
+	// Input Checking
+	if err := vm.Check(); err != nil {
+		return MatrixLinearExpr{}, err
+	}
+
+	nR, nC := vm.Dims()
+	if nR == 0 || nC == 0 {
+		return MatrixLinearExpr{}, fmt.Errorf("An empty (%v x %v) variable matrix can not be written as a linear expression.", nR, nC)
+	}
+
+	// Algorithm
+	x := vm.Vars()
+	L := mat.NewDense(nR*nC, x.Len(), nil)
+	for rowIndex, row := range vm.Elements {
+		for colIndex, tempVar := range row {
+			xIndex, _ := FindInSlice(tempVar, x.Elements)
+			L.Set(rowIndex*nC+colIndex, xIndex, 1.0)
+		}
+	}
+
+	return MatrixLinearExpr{X: x, L: *L, C: *mat.NewDense(nR, nC, nil)}, nil
+}
+
+/*
+Plus
+Description:
+
+	This member function computes the addition of the receiver matrix of variables with the
+	incoming matrix expression e (or a constant, which is added to every element).
+*/
+func (vm VarMatrix) Plus(e interface{}, extras ...interface{}) (MatrixExpression, error) {
+	// Algorithm
+	mle, err := vm.ToMatrixLinearExpr()
+	if err != nil {
+		return vm, err
+	}
+
+	return mle.Plus(e, extras...)
+}
+
+/*
+Mult
+Description:
+
+	Returns the expression which multiplies every variable of the matrix by c.
+*/
+func (vm VarMatrix) Mult(c float64) (MatrixExpression, error) {
+	// Algorithm
+	mle, err := vm.ToMatrixLinearExpr()
+	if err != nil {
+		return vm, err
+	}
+
+	return mle.Mult(c)
+}
+
+/*
+Equals
+Description:
+
+	Returns true if each element of other is equal to the matching variable of vm up to tol.
+*/
+func (vm VarMatrix) Equals(other interface{}, tol float64) bool {
+	return matrixEquals(vm, other, tol)
+}
+
+/*
+Multiply
+Description:
+
+	Returns the matrix product of the matrix of variables with term1 (and then with each of
+	the extras).
+*/
+func (vm VarMatrix) Multiply(term1 interface{}, extras ...interface{}) (Expression, error) {
+	return Multiply(vm, term1, extras...)
+}
+
+/*
+LessEq
+Description:
+
+	This method creates a less than or equal to matrix constraint using the receiver as the left hand side and the
+	input rhs as the right hand side if it is valid.
+*/
+func (vm VarMatrix) LessEq(rhs interface{}) (MatrixConstraint, error) {
+	return vm.Comparison(rhs, SenseLessThanEqual)
+}
+
+/*
+GreaterEq
+Description:
+
+	This method creates a greater than or equal to matrix constraint using the receiver as the left hand side and the
+	input rhs as the right hand side if it is valid.
+*/
+func (vm VarMatrix) GreaterEq(rhs interface{}) (MatrixConstraint, error) {
+	return vm.Comparison(rhs, SenseGreaterThanEqual)
+}
+
+/*
+Eq
+Description:
+
+	This method creates an equal to matrix constraint using the receiver as the left hand side and the
+	input rhs as the right hand side if it is valid.
+*/
+func (vm VarMatrix) Eq(rhs interface{}) (MatrixConstraint, error) {
+	return vm.Comparison(rhs, SenseEqual)
+}
+
+/*
+Comparison
+Description:
+
+	This method creates an element-wise constraint of type sense between the receiver (as left
+	hand side) and rhs (as right hand side) if both are valid and have the same dimensions.
+*/
+func (vm VarMatrix) Comparison(rhs interface{}, sense ConstrSense) (MatrixConstraint, error) {
+	// Input Checking
+	if err := vm.Check(); err != nil {
+		return MatrixConstraint{}, err
+	}
+
+	// Algorithm
+	return matrixComparison(vm, rhs, sense)
+}
@@ -76,6 +76,22 @@ func (vv VarVector) IDs() []uint64 {
 
 }
 
+/*
+positionsOf
+Description:
+
+	Maps the ID of each variable in the vector to the position where it first appears.
+*/
+func (vv VarVector) positionsOf() map[uint64]int {
+	positionOf := make(map[uint64]int, vv.Len())
+	for eltIndex, elt := range vv.Elements {
+		if _, found := positionOf[elt.ID]; !found {
+			positionOf[elt.ID] = eltIndex
+		}
+	}
+	return positionOf
+}
+
 /*
 NumVars
 Description:
@@ -169,22 +185,101 @@ func (vv VarVector) Plus(e interface{}, extras ...interface{}) (VectorExpression
 
 		return eAsVLE.Plus(vv)
 
+	case VectorQuadraticExpression:
+		// Cast expression
+		eAsVQE, _ := e.(VectorQuadraticExpression)
+
+		return eAsVQE.Plus(vv)
+
+	case SparseVectorLinearExpr:
+		// Sums with sparse expressions stay sparse
+		return e.(SparseVectorLinearExpr).Plus(vv)
+
 	default:
 		errString := fmt.Sprintf("Unrecognized expression type %T for addition of VarVector vv.Plus(%v)!", e, e)
 		return VarVector{}, fmt.Errorf(errString)
 	}
-	return vv, fmt.Errorf("The Plus() method for VarVector is not implemented yet!")
 }
 
 /*
 Mult
 Description:
 
-	This member function computest the multiplication of the receiver vector var with some
-	incoming vector expression (may result in quadratic?).
+	This member function computes the multiplication of the receiver vector var with the constant
+	c. The result is the VectorLinearExpr c * I * vv.
 */
 func (vv VarVector) Mult(c float64) (VectorExpression, error) {
-	return vv, fmt.Errorf("The Mult() method for VarVector is not implemented yet!")
+	// Constants
+	scaledIdentity := Identity(vv.Len())
+	scaledIdentity.Scale(c, &scaledIdentity)
+
+	// Algorithm
+	return VectorLinearExpr{
+		L: scaledIdentity,
+		X: vv,
+		C: ZerosVector(vv.Len()),
+	}, nil
+}
+
+/*
+Minus
+Description:
+
+	Returns the difference between the vector of variables and e, which can be a mat.VecDense or any
+	vector expression of the same length. The error returned while computing e can be passed as an
+	extra argument:
+		diff, err := vv.Minus(y.Mult(2))
+*/
+func (vv VarVector) Minus(e interface{}, extras ...interface{}) (VectorExpression, error) {
+	return vectorMinus(vv, e, extras)
+}
+
+/*
+Neg
+Description:
+
+	Returns the vector linear expression -vv.
+*/
+func (vv VarVector) Neg() VectorExpression {
+	negated, _ := vv.Mult(-1.0)
+	return negated
+}
+
+/*
+Div
+Description:
+
+	Divides the vector of variables by the constant c. Division by zero returns an error.
+*/
+func (vv VarVector) Div(c float64) (VectorExpression, error) {
+	// Input Checking
+	if err := checkDivisor(c); err != nil {
+		return vv, err
+	}
+
+	// Algorithm
+	return vv.Mult(1.0 / c)
+}
+
+/*
+Equals
+Description:
+
+	Returns true if each element of other is equal to the matching variable of vv up to tol.
+*/
+func (vv VarVector) Equals(other interface{}, tol float64) bool {
+	return vectorEquals(vv, other, tol)
+}
+
+/*
+Multiply
+Description:
+
+	Returns the product of the vector of variables with term1 (and then with each of the
+	extras). A VarVector times a vector of the same length is their inner product.
+*/
+func (vv VarVector) Multiply(term1 interface{}, extras ...interface{}) (Expression, error) {
+	return Multiply(vv, term1, extras...)
 }
 
 /*
@@ -250,7 +345,7 @@ func (vv VarVector) Comparison(rhs interface{}, sense ConstrSense) (VectorConstr
 					rhsAsKVector.Len(),
 				)
 		}
-		return VectorConstraint{vv, rhsAsKVector, sense}, nil
+		return VectorConstraint{LeftHandSide: vv, RightHandSide: rhsAsKVector, Sense: sense}, nil
 	case mat.VecDense:
 		// Cast Type
 		rhsAsVecDense, _ := rhs.(mat.VecDense)
@@ -273,14 +368,40 @@ func (vv VarVector) Comparison(rhs interface{}, sense ConstrSense) (VectorConstr
 				)
 		}
 		// Do Computation
-		return VectorConstraint{vv, rhsAsVV, sense}, nil
+		return VectorConstraint{LeftHandSide: vv, RightHandSide: rhsAsVV, Sense: sense}, nil
 
 	case VectorLinearExpr:
 		// Cast type
 		rhsAsVLE, _ := rhs.(VectorLinearExpr)
 
-		// Do computation
-		return rhsAsVLE.Comparison(vv, sense)
+		// Do computation (vv <= vle is the same as vle >= vv)
+		constr, err := rhsAsVLE.Comparison(vv, sense.Reverse())
+		if err != nil {
+			return constr, err
+		}
+		return VectorConstraint{LeftHandSide: vv, RightHandSide: rhsAsVLE, Sense: sense}, nil
+
+	case VectorQuadraticExpression:
+		// Cast type
+		rhsAsVQE, _ := rhs.(VectorQuadraticExpression)
+
+		// Do computation (vv <= vqe is the same as vqe >= vv)
+		constr, err := rhsAsVQE.Comparison(vv, sense.Reverse())
+		if err != nil {
+			return constr, err
+		}
+		return VectorConstraint{LeftHandSide: vv, RightHandSide: rhsAsVQE, Sense: sense}, nil
+
+	case SparseVectorLinearExpr:
+		// Cast type
+		rhsAsSVLE, _ := rhs.(SparseVectorLinearExpr)
+
+		// Do computation (vv <= svle is the same as svle >= vv)
+		constr, err := rhsAsSVLE.Comparison(vv, sense.Reverse())
+		if err != nil {
+			return constr, err
+		}
+		return VectorConstraint{LeftHandSide: vv, RightHandSide: rhsAsSVLE, Sense: sense}, nil
 
 	default:
 		return VectorConstraint{}, fmt.Errorf("The Eq() method for VarVector is not implemented yet for type %T!", rhs)
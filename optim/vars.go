@@ -6,12 +6,27 @@ import (
 )
 
 // Var represnts a variable in a optimization problem. The variable is
-// identified with an uint64.
+// identified with an uint64. The optional Name is used by the solvers and in
+// files; unnamed variables are called x<ID>.
 type Variable struct {
 	ID    uint64
 	Lower float64
 	Upper float64
 	Vtype VarType
+	Name  string
+}
+
+/*
+DisplayName
+Description:
+
+	Returns the name of the variable, or x<ID> if the variable has no name.
+*/
+func (v Variable) DisplayName() string {
+	if v.Name != "" {
+		return v.Name
+	}
+	return fmt.Sprintf("x%v", v.ID)
 }
 
 /*
@@ -121,6 +136,10 @@ func (v Variable) Plus(e interface{}, extras ...interface{}) (ScalarExpression,
 
 		return e2, nil
 
+	case SparseScalarLinearExpr, SparseScalarQuadraticExpression:
+		// Sums with sparse expressions stay sparse
+		return e.(ScalarExpression).Plus(v)
+
 	default:
 		return v, fmt.Errorf("There was an unexpected type (%T) given to Variable.Plus()!", e)
 	}
@@ -148,6 +167,70 @@ func (v Variable) Mult(m float64) (ScalarExpression, error) {
 	// }
 }
 
+/*
+Minus
+Description:
+
+	Returns the difference between the variable and e, which can be a float64 or any
+	scalar expression. The error returned while computing e can be passed as an extra argument:
+		diff, err := v.Minus(y.Mult(2))
+*/
+func (v Variable) Minus(e interface{}, extras ...interface{}) (ScalarExpression, error) {
+	return scalarMinus(v, e, extras)
+}
+
+/*
+Neg
+Description:
+
+	Returns the linear expression -v.
+*/
+func (v Variable) Neg() ScalarExpression {
+	return ScalarLinearExpr{
+		X: VarVector{[]Variable{v}},
+		L: *mat.NewVecDense(1, []float64{-1.0}),
+		C: 0,
+	}
+}
+
+/*
+Div
+Description:
+
+	Divides the variable by the constant c. Division by zero returns an error.
+*/
+func (v Variable) Div(c float64) (ScalarExpression, error) {
+	// Input Checking
+	if err := checkDivisor(c); err != nil {
+		return v, err
+	}
+
+	// Algorithm
+	return v.Mult(1.0 / c)
+}
+
+/*
+Equals
+Description:
+
+	Returns true if other is the variable v (e.g. v itself or the linear expression 1 v + 0),
+	up to tol.
+*/
+func (v Variable) Equals(other interface{}, tol float64) bool {
+	return scalarEquals(v, other, tol)
+}
+
+/*
+Multiply
+Description:
+
+	Returns the product of the variable v with term1 (and then with each of the extras). For
+	example, the product of two variables is a ScalarQuadraticExpression.
+*/
+func (v Variable) Multiply(term1 interface{}, extras ...interface{}) (Expression, error) {
+	return Multiply(v, term1, extras...)
+}
+
 // LessEq returns a less than or equal to (<=) constraint between the
 // current expression and another
 func (v Variable) LessEq(other ScalarExpression) (ScalarConstraint, error) {
@@ -180,7 +263,7 @@ func (v Variable) Comparison(rhs ScalarExpression, sense ConstrSense) (ScalarCon
 	// Constants
 
 	// Algorithm
-	return ScalarConstraint{v, rhs, sense}, nil
+	return ScalarConstraint{LeftHandSide: v, RightHandSide: rhs, Sense: sense}, nil
 }
 
 /*
@@ -229,8 +312,10 @@ func UniqueVars(varsIn []Variable) []Variable {
 
 	// Algorithm
 	var varsOut []Variable
+	seen := make(map[uint64]bool, len(varsIn))
 	for _, v := range varsIn {
-		if vIndex, _ := FindInSlice(v, varsOut); vIndex == -1 { // If v is not yet in varsOut, then add it
+		if !seen[v.ID] { // If v is not yet in varsOut, then add it
+			seen[v.ID] = true
 			varsOut = append(varsOut, v)
 		}
 	}
@@ -152,6 +152,17 @@ func (kv KVector) Plus(e interface{}, extras ...interface{}) (VectorExpression,
 		// Return result
 		return eAsVLE.Plus(kv)
 
+	case VectorQuadraticExpression:
+		// Cast Type
+		eAsVQE, _ := e.(VectorQuadraticExpression)
+
+		// Return result
+		return eAsVQE.Plus(kv)
+
+	case SparseVectorLinearExpr:
+		// Sums with sparse expressions stay sparse
+		return e.(SparseVectorLinearExpr).Plus(kv)
+
 	default:
 		errString := fmt.Sprintf("Unrecognized expression type %T for addition of KVector kv.Plus(%v)!", e, e)
 		return KVector{}, fmt.Errorf(errString)
@@ -174,6 +185,57 @@ func (kv KVector) Mult(val float64) (VectorExpression, error) {
 	return KVector(result), nil
 }
 
+/*
+Minus
+Description:
+
+	Returns the difference between the vector constant and e, which can be a mat.VecDense or any
+	vector expression of the same length. The error returned while computing e can be passed as an
+	extra argument:
+		diff, err := kv.Minus(y.Mult(2))
+*/
+func (kv KVector) Minus(e interface{}, extras ...interface{}) (VectorExpression, error) {
+	return vectorMinus(kv, e, extras)
+}
+
+/*
+Neg
+Description:
+
+	Returns the vector constant -kv.
+*/
+func (kv KVector) Neg() VectorExpression {
+	negated, _ := kv.Mult(-1.0)
+	return negated
+}
+
+/*
+Div
+Description:
+
+	Divides the vector constant by the constant c. Division by zero returns an error.
+*/
+func (kv KVector) Div(c float64) (VectorExpression, error) {
+	// Input Checking
+	if err := checkDivisor(c); err != nil {
+		return kv, err
+	}
+
+	// Algorithm
+	return kv.Mult(1.0 / c)
+}
+
+/*
+Equals
+Description:
+
+	Returns true if other is a constant vector (or a vector expression with no non-zero
+	coefficients) whose elements match those of kv up to tol.
+*/
+func (kv KVector) Equals(other interface{}, tol float64) bool {
+	return vectorEquals(kv, other, tol)
+}
+
 /*
 LessEq
 Description:
@@ -230,14 +292,38 @@ func (kv KVector) Comparison(rhs interface{}, sense ConstrSense) (VectorConstrai
 		// Cast type
 		rhsAsVV, _ := rhs.(VarVector)
 
-		// Return constraint
-		return rhsAsVV.Comparison(kv, sense)
+		// Check dimensions (kv <= vv is the same as vv >= kv)
+		if _, err := rhsAsVV.Comparison(kv, sense.Reverse()); err != nil {
+			return VectorConstraint{}, err
+		}
+		return VectorConstraint{LeftHandSide: kv, RightHandSide: rhsAsVV, Sense: sense}, nil
 	case VectorLinearExpr:
 		// Cast Type
 		rhsAsVLE, _ := rhs.(VectorLinearExpr)
 
-		// Return constraint
-		return rhsAsVLE.Comparison(kv, sense)
+		// Check dimensions (kv <= vle is the same as vle >= kv)
+		if _, err := rhsAsVLE.Comparison(kv, sense.Reverse()); err != nil {
+			return VectorConstraint{}, err
+		}
+		return VectorConstraint{LeftHandSide: kv, RightHandSide: rhsAsVLE, Sense: sense}, nil
+	case VectorQuadraticExpression:
+		// Cast Type
+		rhsAsVQE, _ := rhs.(VectorQuadraticExpression)
+
+		// Check dimensions (kv <= vqe is the same as vqe >= kv)
+		if _, err := rhsAsVQE.Comparison(kv, sense.Reverse()); err != nil {
+			return VectorConstraint{}, err
+		}
+		return VectorConstraint{LeftHandSide: kv, RightHandSide: rhsAsVQE, Sense: sense}, nil
+	case SparseVectorLinearExpr:
+		// Cast Type
+		rhsAsSVLE, _ := rhs.(SparseVectorLinearExpr)
+
+		// Check dimensions (kv <= svle is the same as svle >= kv)
+		if _, err := rhsAsSVLE.Comparison(kv, sense.Reverse()); err != nil {
+			return VectorConstraint{}, err
+		}
+		return VectorConstraint{LeftHandSide: kv, RightHandSide: rhsAsSVLE, Sense: sense}, nil
 	default:
 		// Return an error
 		return VectorConstraint{}, fmt.Errorf("The input to KVector's '%v' comparison (%v) has unexpected type: %T", sense, rhs, rhs)
@@ -249,9 +335,9 @@ func (kv KVector) Comparison(rhs interface{}, sense ConstrSense) (VectorConstrai
 Multiply
 Description:
 
-	This method is used to compute the multiplication of the input vector constant with another term.
+	This method is used to compute the multiplication of the input vector constant with another term
+	(and then with each of the extras).
 */
 func (kv KVector) Multiply(term1 interface{}, extras ...interface{}) (Expression, error) {
-	// TODO: Implement this!
-	return K(0), fmt.Errorf("The Multiply() method for KVector has not been implemented yet!")
+	return Multiply(kv, term1, extras...)
 }
@@ -3,15 +3,109 @@ package optim
 /*
 vector_constraint.go
 Description:
-
+	Defines the VectorConstraint, which compares two vector expressions of the same length
+	element by element.
 */
 
+import "fmt"
+
 type VectorConstraint struct {
 	LeftHandSide  VectorExpression
 	RightHandSide VectorExpression
 	Sense         ConstrSense
+	Name          string // Optional. The rows are named Name[i].
 }
 
 /*
+Check
+Description:
+
+	Verifies that both sides of the constraint are well-defined and have the same length.
+*/
+func (vc VectorConstraint) Check() error {
+	// Check that both sides exist
+	if vc.LeftHandSide == nil || vc.RightHandSide == nil {
+		return fmt.Errorf("The vector constraint %v is missing one of its sides.", vc)
+	}
+
+	// Check each side
+	for sideIndex, side := range []VectorExpression{vc.LeftHandSide, vc.RightHandSide} {
+		var err error
+		switch sideIn := side.(type) {
+		case VectorLinearExpr:
+			err = sideIn.Check()
+		case VectorQuadraticExpression:
+			err = sideIn.Check()
+		case SparseVectorLinearExpr:
+			err = sideIn.Check()
+		}
+		if err != nil {
+			return fmt.Errorf("Side #%v of the vector constraint is not valid: %v", sideIndex+1, err)
+		}
+	}
+
+	// Check dimensions
+	if vc.LeftHandSide.Len() != vc.RightHandSide.Len() {
+		return fmt.Errorf(
+			"The left hand side's dimension (%v) and the right hand side's dimension (%v) do not match!",
+			vc.LeftHandSide.Len(),
+			vc.RightHandSide.Len(),
+		)
+	}
+
+	return nil
+}
+
+/*
+Len
+Description:
+
+	Returns the number of scalar constraints in the vector constraint.
+*/
+func (vc VectorConstraint) Len() int {
+	return vc.LeftHandSide.Len()
+}
 
- */
+/*
+AtVec
+Description:
+
+	Returns the scalar constraint given by the idx-th element of each side. If the vector
+	constraint is named, then the row is named Name[idx].
+*/
+func (vc VectorConstraint) AtVec(idx int) ScalarConstraint {
+	// Constants
+	name := ""
+	if vc.Name != "" {
+		name = fmt.Sprintf("%v[%v]", vc.Name, idx)
+	}
+
+	// Algorithm
+	return ScalarConstraint{
+		LeftHandSide:  vc.LeftHandSide.AtVec(idx),
+		RightHandSide: vc.RightHandSide.AtVec(idx),
+		Sense:         vc.Sense,
+		Name:          name,
+	}
+}
+
+/*
+ScalarConstraints
+Description:
+
+	Expands the vector constraint into one scalar constraint per element.
+*/
+func (vc VectorConstraint) ScalarConstraints() ([]ScalarConstraint, error) {
+	// Input Checking
+	if err := vc.Check(); err != nil {
+		return nil, err
+	}
+
+	// Algorithm
+	constrs := make([]ScalarConstraint, vc.Len())
+	for rowIndex := range constrs {
+		constrs[rowIndex] = vc.AtVec(rowIndex)
+	}
+
+	return constrs, nil
+}
@@ -6,7 +6,11 @@ Description:
 	An improvement/successor to the scalar expr interface.
 */
 
-import "gonum.org/v1/gonum/mat"
+import (
+	"fmt"
+
+	"gonum.org/v1/gonum/mat"
+)
 
 /*
 VectorExpression
@@ -16,7 +20,7 @@ Description:
 	vector of represents a linear general expression of the form
 		c0 * x0 + c1 * x1 + ... + cn * xn + k where ci are coefficients and xi are
 	variables and k is a constant. This is a base interface that is implemented
-	by single variables, constants, and general linear expressions.
+	by vectors of variables, constants, and general linear and quadratic expressions.
 */
 type VectorExpression interface {
 	// NumVars returns the number of variables in the expression
@@ -39,6 +43,26 @@ type VectorExpression interface {
 	// resulting expression
 	Mult(c float64) (VectorExpression, error)
 
+	// Minus subtracts another expression from the current expression and
+	// returns the resulting expression
+	Minus(e interface{}, extras ...interface{}) (VectorExpression, error)
+
+	// Neg returns the negation of the current expression
+	Neg() VectorExpression
+
+	// Div divides every element of the current expression by the constant c
+	// and returns the resulting expression
+	Div(c float64) (VectorExpression, error)
+
+	// Equals returns true if each element of the other expression has the same
+	// coefficients as the matching element of the current expression, up to
+	// the tolerance tol
+	Equals(other interface{}, tol float64) bool
+
+	// Multiply multiplies the current expression with another expression (a
+	// scalar, vector or matrix) and returns the resulting expression
+	Multiply(term1 interface{}, extras ...interface{}) (Expression, error)
+
 	// LessEq returns a less than or equal to (<=) constraint between the
 	// current expression and another
 	LessEq(rhs interface{}) (VectorConstraint, error)
@@ -91,3 +115,70 @@ func NewVectorExpression(c mat.VecDense) VectorLinearExpr {
 //
 //	return nil
 //}
+
+/*
+vectorMinus
+Description:
+
+	Returns lhs - e, where e is a mat.VecDense or any VectorExpression of the same length as lhs.
+	The error returned while computing e can be given in extras, so that the Minus methods can be
+	called as x.Minus(y.Mult(2)).
+*/
+func vectorMinus(lhs VectorExpression, e interface{}, extras []interface{}) (VectorExpression, error) {
+	// Input Processing
+	if err := checkOptionalError("Minus", extras); err != nil {
+		return lhs, err
+	}
+
+	var eAsVE VectorExpression
+	switch eIn := e.(type) {
+	case mat.VecDense:
+		eAsVE = KVector(eIn)
+	case VectorExpression:
+		eAsVE = eIn
+	default:
+		return lhs, fmt.Errorf("Unexpected type (%T) given to Minus for a vector expression of length %v.", e, lhs.Len())
+	}
+
+	// Algorithm
+	return lhs.Plus(eAsVE.Neg())
+}
+
+/*
+vectorEquals
+Description:
+
+	Returns true if other is a mat.VecDense or vector expression of the same length as ve whose
+	elements are each equal (see scalarEquals) to the matching element of ve up to tol.
+*/
+func vectorEquals(ve VectorExpression, other interface{}, tol float64) bool {
+	// Input Processing
+	var otherAsVE VectorExpression
+	switch otherIn := other.(type) {
+	case mat.VecDense:
+		otherAsVE = KVector(otherIn)
+	case VectorExpression:
+		otherAsVE = otherIn
+	default:
+		return false
+	}
+
+	for _, tempVE := range []VectorExpression{ve, otherAsVE} {
+		if checker, ok := tempVE.(interface{ Check() error }); ok && checker.Check() != nil {
+			return false
+		}
+	}
+
+	if ve.Len() != otherAsVE.Len() {
+		return false
+	}
+
+	// Algorithm
+	for eltIndex := 0; eltIndex < ve.Len(); eltIndex++ {
+		if !scalarEquals(ve.AtVec(eltIndex), otherAsVE.AtVec(eltIndex), tol) {
+			return false
+		}
+	}
+
+	return true
+}
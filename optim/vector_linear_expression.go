@@ -119,7 +119,104 @@ Description:
 	Returns an expression which scales every dimension of the vector linear expression by the input.
 */
 func (vle VectorLinearExpr) Mult(c float64) (VectorExpression, error) {
-	return vle, fmt.Errorf("The multiplication method has not yet been implemented!")
+	// Input Checking
+	if err := vle.Check(); err != nil {
+		return vle, err
+	}
+
+	// Algorithm
+	var vleOut VectorLinearExpr = VectorLinearExpr{X: vle.X}
+	vleOut.L.Scale(c, &vle.L)
+	vleOut.C.ScaleVec(c, &vle.C)
+
+	return vleOut, nil
+}
+
+/*
+Minus
+Description:
+
+	Returns the difference between the vector linear expression and e, which can be a mat.VecDense or any
+	vector expression of the same length. The error returned while computing e can be passed as an
+	extra argument:
+		diff, err := vle.Minus(y.Mult(2))
+*/
+func (vle VectorLinearExpr) Minus(e interface{}, extras ...interface{}) (VectorExpression, error) {
+	return vectorMinus(vle, e, extras)
+}
+
+/*
+Neg
+Description:
+
+	Returns the vector linear expression with every coefficient (and constant) negated. An invalid
+	expression is returned unchanged.
+*/
+func (vle VectorLinearExpr) Neg() VectorExpression {
+	negated, _ := vle.Mult(-1.0)
+	return negated
+}
+
+/*
+Div
+Description:
+
+	Divides the vector linear expression by the constant c. Division by zero returns an error.
+*/
+func (vle VectorLinearExpr) Div(c float64) (VectorExpression, error) {
+	// Input Checking
+	if err := checkDivisor(c); err != nil {
+		return vle, err
+	}
+
+	// Algorithm
+	return vle.Mult(1.0 / c)
+}
+
+/*
+Equals
+Description:
+
+	Returns true if each element of other has the same coefficients and constant as the
+	matching element of vle, up to tol.
+*/
+func (vle VectorLinearExpr) Equals(other interface{}, tol float64) bool {
+	return vectorEquals(vle, other, tol)
+}
+
+/*
+Multiply
+Description:
+
+	Returns the product of the vector linear expression with term1 (and then with each of the
+	extras).
+*/
+func (vle VectorLinearExpr) Multiply(term1 interface{}, extras ...interface{}) (Expression, error) {
+	return Multiply(vle, term1, extras...)
+}
+
+/*
+ToSparse
+Description:
+
+	Returns the SparseVectorLinearExpr with the same nonzero coefficients. The coefficients of
+	repeated variables are added together.
+*/
+func (vle VectorLinearExpr) ToSparse() SparseVectorLinearExpr {
+	// Constants
+	rows := make([]expressionTerms, vle.Len())
+
+	// Algorithm
+	for rowIndex := range rows {
+		for eltIndex, tempVar := range vle.X.Elements {
+			rows[rowIndex].addLinear(tempVar.ID, vle.L.At(rowIndex, eltIndex))
+		}
+		rows[rowIndex].Constant = vle.C.AtVec(rowIndex)
+	}
+	svleOut := newSparseVectorLinearExpr(rows, vle.X.Elements)
+	svleOut.X = VarVector{UniqueVars(vle.X.Elements)}
+
+	return svleOut
 }
 
 /*
@@ -218,6 +315,17 @@ func (vle VectorLinearExpr) Plus(e interface{}, extras ...interface{}) (VectorEx
 		}
 
 		return vleOut, nil
+
+	case VectorQuadraticExpression:
+		// Cast expression
+		eAsVQE, _ := e.(VectorQuadraticExpression)
+
+		return eAsVQE.Plus(vle)
+
+	case SparseVectorLinearExpr:
+		// Sums with sparse expressions stay sparse
+		return e.(SparseVectorLinearExpr).Plus(vle)
+
 	default:
 		return vle, fmt.Errorf("The addition method has not yet been implemented!")
 	}
@@ -310,10 +418,10 @@ func (vle VectorLinearExpr) Comparison(rhs interface{}, sense ConstrSense) (Vect
 					rhsAsKVector.Len(),
 				)
 		}
-		return VectorConstraint{vle, rhsAsKVector, sense}, nil
+		return VectorConstraint{LeftHandSide: vle, RightHandSide: rhsAsKVector, Sense: sense}, nil
 	case mat.VecDense:
 		rhsAsVecDense, _ := rhs.(mat.VecDense)
-		return vle.Eq(KVector(rhsAsVecDense))
+		return vle.Comparison(KVector(rhsAsVecDense), sense)
 	case VectorLinearExpr:
 		rhsAsVLE, _ := rhs.(VectorLinearExpr)
 		// Check length of input and output.
@@ -325,7 +433,7 @@ func (vle VectorLinearExpr) Comparison(rhs interface{}, sense ConstrSense) (Vect
 					rhsAsVLE.Len(),
 				)
 		}
-		return VectorConstraint{vle, rhsAsVLE, sense}, nil
+		return VectorConstraint{LeftHandSide: vle, RightHandSide: rhsAsVLE, Sense: sense}, nil
 	case VarVector:
 		rhsAsVV, _ := rhs.(VarVector)
 		// Check length of input and output.
@@ -337,7 +445,31 @@ func (vle VectorLinearExpr) Comparison(rhs interface{}, sense ConstrSense) (Vect
 					rhsAsVV.Len(),
 				)
 		}
-		return VectorConstraint{vle, rhsAsVV, sense}, nil
+		return VectorConstraint{LeftHandSide: vle, RightHandSide: rhsAsVV, Sense: sense}, nil
+	case VectorQuadraticExpression:
+		rhsAsVQE, _ := rhs.(VectorQuadraticExpression)
+		// Check length of input and output.
+		if rhsAsVQE.Len() != vle.Len() {
+			return VectorConstraint{},
+				fmt.Errorf(
+					"The two vector inputs to Eq() must have the same dimension, but #1 has dimension %v and #2 has dimension %v!",
+					vle.Len(),
+					rhsAsVQE.Len(),
+				)
+		}
+		return VectorConstraint{LeftHandSide: vle, RightHandSide: rhsAsVQE, Sense: sense}, nil
+	case SparseVectorLinearExpr:
+		rhsAsSVLE, _ := rhs.(SparseVectorLinearExpr)
+		// Check length of input and output.
+		if rhsAsSVLE.Len() != vle.Len() {
+			return VectorConstraint{},
+				fmt.Errorf(
+					"The two vector inputs to Eq() must have the same dimension, but #1 has dimension %v and #2 has dimension %v!",
+					vle.Len(),
+					rhsAsSVLE.Len(),
+				)
+		}
+		return VectorConstraint{LeftHandSide: vle, RightHandSide: rhsAsSVLE, Sense: sense}, nil
 
 	default:
 		return VectorConstraint{}, fmt.Errorf("The comparison of vector linear expression %v with object of type %T is not currently supported.", vle, rhs)
@@ -391,12 +523,11 @@ Description:
 */
 func (vle VectorLinearExpr) AtVec(idx int) ScalarExpression {
 	// Constants
-	Li := vle.L.RowView(idx)
-	LiAsVecDense := Li.(*mat.VecDense)
+	Li := mat.VecDenseCopyOf(vle.L.RowView(idx)) // Copied so that the new expression does not share data with L
 
 	// Cast
 	sleOut := ScalarLinearExpr{
-		L: *LiAsVecDense,
+		L: *Li,
 		X: vle.X,
 		C: vle.C.AtVec(idx),
 	}
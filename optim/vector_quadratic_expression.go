@@ -0,0 +1,474 @@
+package optim
+
+/*
+vector_quadratic_expression.go
+Description:
+	Defines the VectorQuadraticExpression, a vector whose elements are quadratic expressions of the
+	same vector of variables (e.g. the squared distance of each agent to its goal).
+*/
+
+import (
+	"fmt"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// Type Definitions
+// ================
+
+/*
+VectorQuadraticExpression
+Description:
+
+	A vector of quadratic expressions of optimization variables. Element i of the vector is
+		x' * Q[i] * x + L[i] * x + C[i]
+	where L[i] is row i of L.
+*/
+type VectorQuadraticExpression struct {
+	Q []mat.Dense  // Quadratic Terms (one matrix per element)
+	L mat.Dense    // Linear Terms (one row per element)
+	C mat.VecDense // Constant Terms
+	X VarVector
+}
+
+// Member Functions
+// ================
+
+/*
+NewVectorQuadraticExpression
+Description:
+
+	Returns the vector quadratic expression defined by QIn, LIn, CIn and xIn, or an error if their
+	dimensions do not match.
+*/
+func NewVectorQuadraticExpression(QIn []mat.Dense, LIn mat.Dense, CIn mat.VecDense, xIn VarVector) (VectorQuadraticExpression, error) {
+	// Input Checking
+	tempExpr := VectorQuadraticExpression{
+		Q: QIn,
+		L: LIn,
+		C: CIn,
+		X: xIn,
+	}
+
+	if err := tempExpr.Check(); err != nil {
+		return tempExpr, err
+	}
+
+	// Algorithm
+	return tempExpr, nil
+}
+
+/*
+Check
+Description:
+
+	Checks that there is one quadratic term per element, that each of them is a square matrix
+	matching the dimension of X and that L has one row per element and one column per variable.
+*/
+func (vqe VectorQuadraticExpression) Check() error {
+	// Constants
+	xLen := vqe.X.Len()
+	nL, mL := vqe.L.Dims()
+
+	// Check the number of elements
+	if len(vqe.Q) != vqe.Len() {
+		return fmt.Errorf("The number of quadratic terms (%v) does not match the length of C (%v).", len(vqe.Q), vqe.Len())
+	}
+	if nL != vqe.Len() {
+		return fmt.Errorf("Dimension of L (%v x %v) and C (length %v) do not match!", nL, mL, vqe.Len())
+	}
+
+	// Check the number of variables
+	if mL != xLen {
+		return fmt.Errorf("Dimensions of L (%v x %v) and x (length %v) do not match appropriately.", nL, mL, xLen)
+	}
+	for qIndex, tempQ := range vqe.Q {
+		if nQ, mQ := tempQ.Dims(); nQ != xLen || mQ != xLen {
+			return fmt.Errorf("The quadratic term of element %v is %v x %v; expected %v x %v to match x.", qIndex, nQ, mQ, xLen, xLen)
+		}
+	}
+
+	// If all other checks passed, then the VectorQuadraticExpression seems valid.
+	return nil
+}
+
+/*
+Len
+Description:
+
+	The number of elements of the vector.
+*/
+func (vqe VectorQuadraticExpression) Len() int {
+	return vqe.C.Len()
+}
+
+/*
+IDs
+Description:
+
+	Returns the goop2 ID of each variable in the current vector quadratic expression.
+*/
+func (vqe VectorQuadraticExpression) IDs() []uint64 {
+	return vqe.X.IDs()
+}
+
+/*
+NumVars
+Description:
+
+	Returns the number of unique variables in the current vector quadratic expression.
+*/
+func (vqe VectorQuadraticExpression) NumVars() int {
+	return len(vqe.IDs())
+}
+
+/*
+LinearCoeff
+Description:
+
+	Returns the matrix of linear coefficients (row i holds the linear term of element i).
+*/
+func (vqe VectorQuadraticExpression) LinearCoeff() mat.Dense {
+	return vqe.L
+}
+
+/*
+Constant
+Description:
+
+	Returns the vector of constant terms.
+*/
+func (vqe VectorQuadraticExpression) Constant() mat.VecDense {
+	return vqe.C
+}
+
+/*
+AtVec
+Description:
+
+	Returns element idx of the vector as a ScalarQuadraticExpression. The returned expression does
+	not share data with vqe.
+*/
+func (vqe VectorQuadraticExpression) AtVec(idx int) ScalarExpression {
+	return ScalarQuadraticExpression{
+		Q: *mat.DenseCopyOf(&vqe.Q[idx]),
+		L: *mat.VecDenseCopyOf(vqe.L.RowView(idx)),
+		C: vqe.C.AtVec(idx),
+		X: vqe.X,
+	}
+}
+
+/*
+Plus
+Description:
+
+	Returns an expression which adds the expression e to the vector quadratic expression. e can be
+	a KVector (or mat.VecDense), VarVector, VectorLinearExpr, SparseVectorLinearExpr or
+	VectorQuadraticExpression of the same length.
+*/
+func (vqe VectorQuadraticExpression) Plus(e interface{}, extras ...interface{}) (VectorExpression, error) {
+	// Input Checking
+	if err := vqe.Check(); err != nil {
+		return vqe, err
+	}
+
+	// Algorithm
+	switch eIn := e.(type) {
+	case KVector:
+		// Check Length
+		if eIn.Len() != vqe.Len() {
+			return vqe, fmt.Errorf(
+				"The length of input KVector (%v) did not match the length of the VectorQuadraticExpression (%v).",
+				eIn.Len(),
+				vqe.Len(),
+			)
+		}
+
+		// Add to the constant
+		vqeOut := vqe.RewriteInTermsOf(vqe.X)
+		eAsVec := mat.VecDense(eIn)
+		vqeOut.C.AddVec(&vqeOut.C, &eAsVec)
+
+		return vqeOut, nil
+
+	case mat.VecDense:
+		return vqe.Plus(KVector(eIn))
+
+	case VarVector:
+		eAsVLE := VectorLinearExpr{
+			L: Identity(eIn.Len()),
+			X: eIn,
+			C: ZerosVector(eIn.Len()),
+		}
+
+		return vqe.Plus(eAsVLE)
+
+	case VectorLinearExpr:
+		// Check Length
+		if eIn.Len() != vqe.Len() {
+			return vqe, fmt.Errorf(
+				"The length of input VectorLinearExpr (%v) did not match the length of the VectorQuadraticExpression (%v).",
+				eIn.Len(),
+				vqe.Len(),
+			)
+		}
+
+		// A linear expression is a quadratic expression with no quadratic terms
+		eAsVQE := VectorQuadraticExpression{
+			Q: make([]mat.Dense, eIn.Len()),
+			L: eIn.L,
+			C: eIn.C,
+			X: eIn.X,
+		}
+		for qIndex := range eAsVQE.Q {
+			eAsVQE.Q[qIndex] = *mat.NewDense(eIn.X.Len(), eIn.X.Len(), nil)
+		}
+
+		return vqe.Plus(eAsVQE)
+
+	case VectorQuadraticExpression:
+		// Check Input
+		if err := eIn.Check(); err != nil {
+			return vqe, err
+		}
+		if eIn.Len() != vqe.Len() {
+			return vqe, fmt.Errorf(
+				"The length of input VectorQuadraticExpression (%v) did not match the length of the VectorQuadraticExpression (%v).",
+				eIn.Len(),
+				vqe.Len(),
+			)
+		}
+
+		// Rewrite both expressions in terms of all of their variables
+		uniqueVV := VarVector{UniqueVars(append(append([]Variable(nil), vqe.X.Elements...), eIn.X.Elements...))}
+		vqeOut := vqe.RewriteInTermsOf(uniqueVV)
+		eRewritten := eIn.RewriteInTermsOf(uniqueVV)
+
+		for qIndex := range vqeOut.Q {
+			vqeOut.Q[qIndex].Add(&vqeOut.Q[qIndex], &eRewritten.Q[qIndex])
+		}
+		vqeOut.L.Add(&vqeOut.L, &eRewritten.L)
+		vqeOut.C.AddVec(&vqeOut.C, &eRewritten.C)
+
+		return vqeOut, nil
+
+	case SparseVectorLinearExpr:
+		return vqe.Plus(eIn.ToDense())
+
+	default:
+		return vqe, fmt.Errorf("Unrecognized expression type %T for addition of VectorQuadraticExpression vqe.Plus(%v)!", e, e)
+	}
+}
+
+/*
+Mult
+Description:
+
+	Returns an expression which scales every element of the vector quadratic expression by c.
+*/
+func (vqe VectorQuadraticExpression) Mult(c float64) (VectorExpression, error) {
+	// Input Checking
+	if err := vqe.Check(); err != nil {
+		return vqe, err
+	}
+
+	// Algorithm
+	vqeOut := vqe.RewriteInTermsOf(vqe.X)
+	for qIndex := range vqeOut.Q {
+		vqeOut.Q[qIndex].Scale(c, &vqeOut.Q[qIndex])
+	}
+	vqeOut.L.Scale(c, &vqeOut.L)
+	vqeOut.C.ScaleVec(c, &vqeOut.C)
+
+	return vqeOut, nil
+}
+
+/*
+Minus
+Description:
+
+	Returns the difference between the vector quadratic expression and e, which can be a mat.VecDense or any
+	vector expression of the same length. The error returned while computing e can be passed as an
+	extra argument:
+		diff, err := vqe.Minus(y.Mult(2))
+*/
+func (vqe VectorQuadraticExpression) Minus(e interface{}, extras ...interface{}) (VectorExpression, error) {
+	return vectorMinus(vqe, e, extras)
+}
+
+/*
+Neg
+Description:
+
+	Returns the vector quadratic expression with every coefficient (and constant) negated. An
+	invalid expression is returned unchanged.
+*/
+func (vqe VectorQuadraticExpression) Neg() VectorExpression {
+	negated, _ := vqe.Mult(-1.0)
+	return negated
+}
+
+/*
+Div
+Description:
+
+	Divides the vector quadratic expression by the constant c. Division by zero returns an error.
+*/
+func (vqe VectorQuadraticExpression) Div(c float64) (VectorExpression, error) {
+	// Input Checking
+	if err := checkDivisor(c); err != nil {
+		return vqe, err
+	}
+
+	// Algorithm
+	return vqe.Mult(1.0 / c)
+}
+
+/*
+Equals
+Description:
+
+	Returns true if each element of other describes the same quadratic function as the
+	matching element of vqe, up to tol.
+*/
+func (vqe VectorQuadraticExpression) Equals(other interface{}, tol float64) bool {
+	return vectorEquals(vqe, other, tol)
+}
+
+/*
+Multiply
+Description:
+
+	Returns the product of the vector quadratic expression with term1 (and then with each of
+	the extras). Only products with constants keep the degree at two.
+*/
+func (vqe VectorQuadraticExpression) Multiply(term1 interface{}, extras ...interface{}) (Expression, error) {
+	return Multiply(vqe, term1, extras...)
+}
+
+/*
+RewriteInTermsOf
+Description:
+
+	Rewrites the vector quadratic expression in terms of a new set of variables vv. The returned
+	expression does not share data with vqe.
+
+Assumes:
+
+	vv contains all unique variables.
+	All elements of vqe.X are in vv.
+*/
+func (vqe VectorQuadraticExpression) RewriteInTermsOf(vv VarVector) VectorQuadraticExpression {
+	// Constants
+	dimX := vv.Len()
+
+	// Find the new index of each old variable
+	newIndices := make([]int, vqe.X.Len())
+	for xIndex, tempVar := range vqe.X.Elements {
+		newIndices[xIndex], _ = FindInSlice(tempVar, vv.Elements)
+	}
+
+	// Create new expression (variables which appear more than once in vqe.X have their
+	// coefficients added)
+	vqeOut := VectorQuadraticExpression{
+		Q: make([]mat.Dense, vqe.Len()),
+		L: *mat.NewDense(vqe.Len(), dimX, nil),
+		C: *mat.VecDenseCopyOf(&vqe.C),
+		X: vv,
+	}
+	for qIndex := range vqe.Q {
+		newQ := mat.NewDense(dimX, dimX, nil)
+		for oldI, newI := range newIndices {
+			for oldJ, newJ := range newIndices {
+				newQ.Set(newI, newJ, newQ.At(newI, newJ)+vqe.Q[qIndex].At(oldI, oldJ))
+			}
+		}
+		vqeOut.Q[qIndex] = *newQ
+
+		for oldI, newI := range newIndices {
+			vqeOut.L.Set(qIndex, newI, vqeOut.L.At(qIndex, newI)+vqe.L.At(qIndex, oldI))
+		}
+	}
+
+	return vqeOut
+}
+
+/*
+LessEq
+Description:
+
+	Creates a VectorConstraint that declares vqe is less than or equal to the right hand side rhs.
+*/
+func (vqe VectorQuadraticExpression) LessEq(rhs interface{}) (VectorConstraint, error) {
+	return vqe.Comparison(rhs, SenseLessThanEqual)
+}
+
+/*
+GreaterEq
+Description:
+
+	Creates a VectorConstraint that declares vqe is greater than or equal to the right hand side
+	rhs.
+*/
+func (vqe VectorQuadraticExpression) GreaterEq(rhs interface{}) (VectorConstraint, error) {
+	return vqe.Comparison(rhs, SenseGreaterThanEqual)
+}
+
+/*
+Eq
+Description:
+
+	Creates a VectorConstraint that declares vqe is equal to the right hand side rhs.
+*/
+func (vqe VectorQuadraticExpression) Eq(rhs interface{}) (VectorConstraint, error) {
+	return vqe.Comparison(rhs, SenseEqual)
+}
+
+/*
+Comparison
+Description:
+
+	Compares the vector quadratic expression with the vector expression rhs in the sense given
+	by sense. Each row of the resulting constraint is a quadratic constraint.
+*/
+func (vqe VectorQuadraticExpression) Comparison(rhs interface{}, sense ConstrSense) (VectorConstraint, error) {
+	// Input Checking
+	if err := vqe.Check(); err != nil {
+		return VectorConstraint{}, fmt.Errorf(
+			"There was an issue in the provided vector quadratic expression %v: %v",
+			vqe, err,
+		)
+	}
+
+	// Algorithm
+	var rhsAsVE VectorExpression
+	switch rhsIn := rhs.(type) {
+	case KVector:
+		rhsAsVE = rhsIn
+	case mat.VecDense:
+		rhsAsVE = KVector(rhsIn)
+	case VarVector:
+		rhsAsVE = rhsIn
+	case VectorLinearExpr:
+		rhsAsVE = rhsIn
+	case VectorQuadraticExpression:
+		rhsAsVE = rhsIn
+	case SparseVectorLinearExpr:
+		rhsAsVE = rhsIn
+	default:
+		return VectorConstraint{}, fmt.Errorf("The comparison of vector quadratic expression %v with object of type %T is not currently supported.", vqe, rhs)
+	}
+
+	// Check length of input and output.
+	if rhsAsVE.Len() != vqe.Len() {
+		return VectorConstraint{},
+			fmt.Errorf(
+				"The two inputs to comparison '%v' must have the same dimension, but #1 has dimension %v and #2 has dimension %v!",
+				sense,
+				vqe.Len(),
+				rhsAsVE.Len(),
+			)
+	}
+
+	return VectorConstraint{LeftHandSide: vqe, RightHandSide: rhsAsVE, Sense: sense}, nil
+}
@@ -0,0 +1,1001 @@
+package solvers
+
+/*
+admmsolver.go
+Description:
+	Defines a pure-Go, first-order solver for convex quadratic programs based on the alternating
+	direction method of multipliers (ADMM), following the algorithm used by OSQP:
+
+		B. Stellato, G. Banjac, P. Goulart, A. Bemporad and S. Boyd. "OSQP: an operator splitting
+		solver for quadratic programs." Mathematical Programming Computation, 2020.
+
+	The solver handles problems of the form
+
+		minimize   1/2 x' P x + q' x
+		subject to l <= A x <= u
+
+	where every constraint (including variable bounds) is a row of A. It warm-starts well and is
+	meant to be used with loose tolerances in real-time loops.
+
+	P and A are only stored through their nonzero entries and the linear system of each iteration
+	is solved with the conjugate gradient method (OSQP's "indirect" linear system solver), so the
+	memory and the work per iteration grow with the number of nonzeros of P and A rather than
+	with the square (or cube) of the number of variables.
+*/
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"github.com/kwesiRutledge/goop2/optim"
+)
+
+// Type Definitions
+// ================
+
+type ADMMSolver struct {
+	problemStore
+
+	RangeConstraints []ADMMRangeConstraint
+
+	// Settings
+	Rho                 float64 // Initial ADMM step size
+	Sigma               float64 // Regularization of the x update
+	Alpha               float64 // Relaxation parameter, in (0, 2)
+	EpsAbs              float64 // Absolute tolerance on the residuals
+	EpsRel              float64 // Relative tolerance on the residuals
+	EpsPrimalInfeasible float64 // Tolerance used when detecting primal infeasibility
+	EpsDualInfeasible   float64 // Tolerance used when detecting dual infeasibility (unboundedness)
+	MaxIterations       int
+	AdaptiveRho         bool // Whether or not Rho is updated to balance the residuals
+	AdaptiveRhoInterval int  // Number of iterations between updates of Rho
+
+	// Warm Starting
+	WarmStart   *ADMMIterate // Iterate that the next call to Optimize() starts from (if not nil)
+	LastIterate *ADMMIterate // Final iterate of the last call to Optimize()
+
+	showLog   bool
+	timeLimit float64
+}
+
+/*
+ADMMRangeConstraint
+Description:
+
+	Represents the ranged linear constraint
+		Lower <= Expression <= Upper
+	Either of the bounds may be infinite.
+*/
+type ADMMRangeConstraint struct {
+	Expression optim.ScalarExpression
+	Lower      float64
+	Upper      float64
+}
+
+/*
+ADMMIterate
+Description:
+
+	Stores a primal-dual point of the ADMMSolver. The dual variable of each constraint is positive
+	when its upper bound is active and negative when its lower bound is active.
+
+	Primal contains the value of each variable, keyed by Variable.ID.
+	Dual contains the multipliers of the bounds of each variable, keyed by Variable.ID.
+	ConstraintDual contains the multipliers of Constraints followed by those of RangeConstraints.
+*/
+type ADMMIterate struct {
+	Primal         map[uint64]float64
+	Dual           map[uint64]float64
+	ConstraintDual []float64
+}
+
+/*
+admmProblem
+Description:
+
+	The data of the problem solved by the ADMMSolver.
+		minimize   1/2 x' P x + q' x
+		subject to l <= A x <= u
+	BoundRows maps the index of each variable to the row of A containing its bounds (or -1 when the
+	variable has no finite bounds). The objective of the original problem is
+	Offset + (1/2 x' P x + q' x) when it is minimized and Offset - (1/2 x' P x + q' x) when it
+	is maximized.
+*/
+type admmProblem struct {
+	P         sparseMatrix
+	Q         []float64
+	A         sparseMatrix
+	L         []float64
+	U         []float64
+	BoundRows []int
+	Sense     optim.ObjSense
+	Offset    float64
+}
+
+/*
+admmLinearSystem
+Description:
+
+	The positive definite matrix
+		K = P + sigma I + A' diag(Rho) A
+	of the linear system solved in each ADMM iteration. K is never formed; its products with
+	vectors use the sparse matrices P and A. Diag holds the diagonal of K, which is used as the
+	preconditioner of the conjugate gradient method.
+*/
+type admmLinearSystem struct {
+	Prob  admmProblem
+	Sigma float64
+	Rho   []float64
+	Diag  []float64
+}
+
+// Constants
+// =========
+
+const (
+	admmRhoMin        = 1e-6
+	admmRhoMax        = 1e6
+	admmRhoEqualScale = 1e3
+
+	admmCGTolerance        = 1e-10 // Relative tolerance of the conjugate gradient method
+	admmCGMinIterations    = 100   // Minimum iteration limit of the conjugate gradient method
+	admmDenseConvexityVars = 500   // Largest quadratic objective checked for convexity with eigenvalues
+)
+
+// Functions
+// =========
+
+/*
+NewADMMSolver
+Description:
+
+	Create a new, empty ADMMSolver object with settings similar to OSQP's defaults.
+*/
+func NewADMMSolver() *ADMMSolver {
+	return &ADMMSolver{
+		problemStore:        newProblemStore("ADMMSolver", admmObjective, optim.Continuous),
+		Rho:                 0.1,
+		Sigma:               1e-6,
+		Alpha:               1.6,
+		EpsAbs:              1e-3,
+		EpsRel:              1e-3,
+		EpsPrimalInfeasible: 1e-4,
+		EpsDualInfeasible:   1e-4,
+		MaxIterations:       4000,
+		AdaptiveRho:         true,
+		AdaptiveRhoInterval: 25,
+	}
+}
+
+/*
+ShowLog
+Description:
+
+	Decides whether or not to print the progress of the solver to the terminal.
+*/
+func (as *ADMMSolver) ShowLog(tf bool) error {
+	as.showLog = tf
+	return nil
+}
+
+/*
+SetTimeLimit
+Description:
+
+	Sets the time limit of the current model in the ADMMSolver.
+
+Input:
+
+	limitInS = Value of time limit in seconds (float)
+*/
+func (as *ADMMSolver) SetTimeLimit(limitInS float64) error {
+	if limitInS < 0 {
+		return fmt.Errorf("The time limit must be nonnegative; received %v", limitInS)
+	}
+	as.timeLimit = limitInS
+	return nil
+}
+
+/*
+AddRangeConstraint
+Description:
+
+	Adds the ranged linear constraint lower <= expr <= upper to the problem. Either bound may be
+	infinite.
+*/
+func (as *ADMMSolver) AddRangeConstraint(expr optim.ScalarExpression, lower, upper float64) error {
+	// Input Checking
+	if _, _, _, err := linearTerms(expr); err != nil {
+		return fmt.Errorf("The ADMMSolver could not use the range constraint: %v", err)
+	}
+
+	if lower > upper {
+		return fmt.Errorf("The lower bound of the range constraint (%v) is greater than its upper bound (%v).", lower, upper)
+	}
+
+	// Algorithm
+	as.RangeConstraints = append(
+		as.RangeConstraints,
+		ADMMRangeConstraint{Expression: expr, Lower: lower, Upper: upper},
+	)
+
+	return nil
+}
+
+/*
+admmObjective
+Description:
+
+	Checks that the objective can be used by the ADMMSolver. The objective may be linear or a convex
+	(when minimized) or concave (when maximized) quadratic expression. Convexity is verified with
+	the eigenvalues of the quadratic part when it involves at most 500 variables. Larger
+	objectives are only checked through the signs of their squared terms, as computing the
+	eigenvalues would need a dense matrix; a nonconvex objective is then reported by Optimize when
+	its linear system is found not to be positive definite.
+*/
+func admmObjective(objIn optim.Objective) error {
+	terms, err := optim.SparseTermsOf(objIn.ScalarExpression)
+	if err != nil {
+		return fmt.Errorf("expected a linear or quadratic expression, but received expression of type %T: %v", objIn.ScalarExpression, err)
+	}
+
+	quadraticVars := make(map[uint64]bool)
+	for _, term := range terms.Quadratic {
+		quadraticVars[term.ID1], quadraticVars[term.ID2] = true, true
+	}
+
+	if len(quadraticVars) > admmDenseConvexityVars {
+		return checkSquaredTerms(terms.Quadratic, objIn.Sense)
+	}
+	_, Q, _, _, _, _ := quadraticTerms(objIn.ScalarExpression)
+	return checkConvexity(Q, objIn.Sense, 1e-10)
+}
+
+/*
+checkSquaredTerms
+Description:
+
+	Verifies the necessary condition for convexity (when minimizing) or concavity (when
+	maximizing) that every squared term of the objective has a nonnegative (or nonpositive)
+	coefficient.
+*/
+func checkSquaredTerms(terms []optim.QuadraticTerm, sense optim.ObjSense) error {
+	for _, term := range terms {
+		if term.ID1 != term.ID2 {
+			continue
+		}
+		if sense == optim.SenseMaximize && term.Coeff > 0 {
+			return fmt.Errorf("the quadratic objective is not concave (the squared term of the variable with ID %v has the coefficient %v), so it can not be maximized by a convex solver", term.ID1, term.Coeff)
+		}
+		if sense != optim.SenseMaximize && term.Coeff < 0 {
+			return fmt.Errorf("the quadratic objective is not convex (the squared term of the variable with ID %v has the coefficient %v), so it can not be minimized by a convex solver", term.ID1, term.Coeff)
+		}
+	}
+	return nil
+}
+
+/*
+SetWarmStart
+Description:
+
+	Sets the primal-dual point that the next call to Optimize() starts from. Any variable or
+	constraint missing from the iterate starts at zero. To warm start from the result of the last
+	call to Optimize(), use as.SetWarmStart(*as.LastIterate).
+*/
+func (as *ADMMSolver) SetWarmStart(iterate ADMMIterate) error {
+	as.WarmStart = &iterate
+	return nil
+}
+
+/*
+Optimize
+Description:
+
+	Runs the ADMM iterations until the primal and dual residuals are within tolerance, a
+	certificate of infeasibility (or unboundedness) is found, or the iteration or time limit is
+	reached. The number of iterations and the final residuals are reported in the solution.
+*/
+func (as *ADMMSolver) Optimize() (optim.Solution, error) {
+	// Input Checking
+	if len(as.Variables) == 0 {
+		return optim.Solution{}, fmt.Errorf("There are no variables in the ADMMSolver.")
+	}
+
+	if as.Alpha <= 0 || as.Alpha >= 2 {
+		return optim.Solution{}, fmt.Errorf("The relaxation parameter Alpha must be in (0, 2); received %v", as.Alpha)
+	}
+
+	if as.Rho <= 0 || as.Sigma <= 0 {
+		return optim.Solution{}, fmt.Errorf("The step sizes Rho (%v) and Sigma (%v) must be positive.", as.Rho, as.Sigma)
+	}
+
+	// Constants
+	startTime := time.Now()
+
+	// Create problem
+	prob, err := as.newADMMProblem()
+	if err != nil {
+		return optim.Solution{}, fmt.Errorf("There was an issue creating the ADMM problem: %v", err)
+	}
+
+	n, m := len(as.Variables), prob.A.NumRows()
+
+	// Initialize iterate
+	x, y := as.initialIterate(prob)
+	z := prob.Project(prob.A.MulVec(x))
+
+	rho := as.Rho
+	rhoVec := prob.RhoVector(rho)
+	system := prob.newLinearSystem(as.Sigma, rhoVec)
+
+	// Iterate
+	var (
+		status     optim.OptimizationStatus = optim.OptimizationStatus_ITERATION_LIMIT
+		iterations int
+		rPrim      float64
+		rDual      float64
+	)
+	xTilde := make([]float64, n)
+	rhs := make([]float64, n)
+	for iterations = 1; iterations <= as.MaxIterations; iterations++ {
+		// Solve the linear system
+		//	(P + sigma I + A' R A) xTilde = sigma x - q + A' (R z - y)
+		w := make([]float64, m)
+		for i := 0; i < m; i++ {
+			w[i] = rhoVec[i]*z[i] - y[i]
+		}
+		ATw := prob.A.MulTransVec(w)
+		for j := 0; j < n; j++ {
+			rhs[j] = as.Sigma*x[j] - prob.Q[j] + ATw[j]
+		}
+		if err := system.Solve(xTilde, rhs); err != nil {
+			return optim.Solution{}, fmt.Errorf("There was an issue solving the ADMM linear system: %v", err)
+		}
+		zTilde := prob.A.MulVec(xTilde)
+
+		// Relaxed updates of x, z and y
+		xPrev := append([]float64{}, x...)
+		yPrev := append([]float64{}, y...)
+		for j := 0; j < n; j++ {
+			x[j] = as.Alpha*xTilde[j] + (1-as.Alpha)*x[j]
+		}
+		for i := 0; i < m; i++ {
+			zRelaxed := as.Alpha*zTilde[i] + (1-as.Alpha)*z[i]
+			zNext := math.Min(math.Max(zRelaxed+y[i]/rhoVec[i], prob.L[i]), prob.U[i])
+			y[i] += rhoVec[i] * (zRelaxed - zNext)
+			z[i] = zNext
+		}
+
+		// Compute residuals
+		Ax := prob.A.MulVec(x)
+		Px := prob.P.MulVec(x)
+		ATy := prob.A.MulTransVec(y)
+
+		rPrim = 0.0
+		for i := 0; i < m; i++ {
+			rPrim = math.Max(rPrim, math.Abs(Ax[i]-z[i]))
+		}
+		rDual = 0.0
+		for j := 0; j < n; j++ {
+			rDual = math.Max(rDual, math.Abs(Px[j]+prob.Q[j]+ATy[j]))
+		}
+
+		primScale := math.Max(infNorm(Ax), infNorm(z))
+		dualScale := math.Max(math.Max(infNorm(Px), infNorm(ATy)), infNorm(prob.Q))
+
+		// Check termination
+		if rPrim <= as.EpsAbs+as.EpsRel*primScale && rDual <= as.EpsAbs+as.EpsRel*dualScale {
+			status = optim.OptimizationStatus_OPTIMAL
+			break
+		}
+
+		deltaY := make([]float64, m)
+		for i := 0; i < m; i++ {
+			deltaY[i] = y[i] - yPrev[i]
+		}
+		if prob.IsPrimalInfeasible(deltaY, as.EpsPrimalInfeasible) {
+			status = optim.OptimizationStatus_INFEASIBLE
+			break
+		}
+
+		deltaX := make([]float64, n)
+		for j := 0; j < n; j++ {
+			deltaX[j] = x[j] - xPrev[j]
+		}
+		if prob.IsDualInfeasible(deltaX, as.EpsDualInfeasible) {
+			status = optim.OptimizationStatus_UNBOUNDED
+			break
+		}
+
+		if as.timeLimit > 0 && time.Since(startTime).Seconds() > as.timeLimit {
+			status = optim.OptimizationStatus_TIME_LIMIT
+			break
+		}
+
+		// Update rho
+		if as.AdaptiveRho && as.AdaptiveRhoInterval > 0 && iterations%as.AdaptiveRhoInterval == 0 {
+			tiny := 1e-10
+			ratio := (rPrim / math.Max(primScale, tiny)) / math.Max(rDual/math.Max(dualScale, tiny), tiny)
+			newRho := math.Min(math.Max(rho*math.Sqrt(ratio), admmRhoMin), admmRhoMax)
+			if newRho > 5*rho || newRho < rho/5 {
+				rho = newRho
+				rhoVec = prob.RhoVector(rho)
+				system = prob.newLinearSystem(as.Sigma, rhoVec)
+			}
+		}
+	}
+	if iterations > as.MaxIterations {
+		iterations = as.MaxIterations
+	}
+
+	// Save the final iterate
+	as.LastIterate = as.toIterate(prob, x, y)
+
+	// Create solution
+	tempSolution := optim.Solution{
+		Status:         status,
+		Iterations:     iterations,
+		PrimalResidual: rPrim,
+		DualResidual:   rDual,
+	}
+	if status == optim.OptimizationStatus_OPTIMAL ||
+		status == optim.OptimizationStatus_ITERATION_LIMIT ||
+		status == optim.OptimizationStatus_TIME_LIMIT {
+		tempSolution.Values = make(map[uint64]float64)
+		for varIndex, tempVar := range as.Variables {
+			tempSolution.Values[tempVar.ID] = x[varIndex]
+		}
+		tempSolution.Objective = prob.ObjectiveValue(x)
+	}
+
+	if as.showLog {
+		statusMessage, _ := status.ToMessage()
+		log.Printf(
+			"ADMMSolver: %v iterations (rho = %v). %v Objective = %v, primal residual = %v, dual residual = %v",
+			iterations, rho, statusMessage, tempSolution.Objective, rPrim, rDual,
+		)
+	}
+
+	return tempSolution, nil
+}
+
+/*
+ClearModel
+Description:
+
+	Removes all variables, constraints (including the range constraints) and the objective from
+	the solver. The warm start and the last iterate are kept, so that they can be reused by the
+	next problem.
+*/
+func (as *ADMMSolver) ClearModel() error {
+	as.clearProblem()
+	as.RangeConstraints = nil
+
+	return nil
+}
+
+/*
+DeleteSolver
+Description:
+
+	Removes all variables, constraints and the objective from the solver. The warm start and the
+	last iterate are kept, so that they can be reused by the next problem.
+*/
+func (as *ADMMSolver) DeleteSolver() error {
+	return as.ClearModel()
+}
+
+/*
+newADMMProblem
+Description:
+
+	Converts the variables, constraints and objective of the solver into the form
+		minimize   1/2 x' P x + q' x
+		subject to l <= A x <= u
+	The rows of A are: the scalar constraints, the range constraints and then one row for each
+	variable with a finite bound. P and A are built from the sparse terms of the expressions, so
+	no dense matrix is created.
+*/
+func (as *ADMMSolver) newADMMProblem() (admmProblem, error) {
+	// Constants
+	n := len(as.Variables)
+	inf := math.Inf(1)
+
+	idToIndex := make(map[uint64]int, n)
+	for varIndex, tempVar := range as.Variables {
+		if _, found := idToIndex[tempVar.ID]; found {
+			return admmProblem{}, fmt.Errorf("The variable with ID %v was added to the solver more than once.", tempVar.ID)
+		}
+		idToIndex[tempVar.ID] = varIndex
+	}
+
+	prob := admmProblem{
+		P:         sparseMatrix{NumCols: n},
+		Q:         make([]float64, n),
+		A:         sparseMatrix{NumCols: n},
+		BoundRows: make([]int, n),
+		Sense:     optim.SenseMinimize,
+	}
+
+	// Objective
+	if err := prob.setObjective(as.Objective, idToIndex); err != nil {
+		return prob, fmt.Errorf("There was an issue converting the objective: %v", err)
+	}
+
+	// Scalar constraints
+	for constrIndex, constr := range as.Constraints {
+		row, rhs, err := admmRow(constr.LeftHandSide, constr.RightHandSide, idToIndex)
+		if err != nil {
+			return prob, fmt.Errorf("There was an issue converting constraint #%v: %v", constrIndex, err)
+		}
+
+		lower, upper := -inf, inf
+		switch constr.Sense {
+		case optim.SenseEqual:
+			lower, upper = rhs, rhs
+		case optim.SenseLessThanEqual:
+			upper = rhs
+		case optim.SenseGreaterThanEqual:
+			lower = rhs
+		}
+		prob.addRow(row, lower, upper)
+	}
+
+	// Range constraints
+	for constrIndex, rangeConstr := range as.RangeConstraints {
+		// Move the constant of the expression into the bounds.
+		row, rhs, err := admmRow(rangeConstr.Expression, optim.K(0.0), idToIndex)
+		if err != nil {
+			return prob, fmt.Errorf("There was an issue converting range constraint #%v: %v", constrIndex, err)
+		}
+		prob.addRow(row, rangeConstr.Lower+rhs, rangeConstr.Upper+rhs)
+	}
+
+	// Variable bounds
+	for varIndex, tempVar := range as.Variables {
+		lower, upper := tempVar.Lower, tempVar.Upper
+		if isInfiniteBound(lower) && isInfiniteBound(upper) {
+			prob.BoundRows[varIndex] = -1
+			continue
+		}
+		prob.BoundRows[varIndex] = prob.A.NumRows()
+		prob.addRow(sparseVector{Indices: []int{varIndex}, Values: []float64{1.0}}, lower, upper)
+	}
+
+	return prob, nil
+}
+
+/*
+admmRow
+Description:
+
+	Converts the linear constraint lhs (sense) rhs into the sparse row a and the right hand side b
+	of a' x (sense) b by moving all variables to the left hand side and all constants to the
+	right hand side.
+*/
+func admmRow(lhs, rhs optim.ScalarExpression, idToIndex map[uint64]int) (sparseVector, float64, error) {
+	// Input Processing
+	lhsIDs, lhsCoeffs, lhsConstant, err := linearTerms(lhs)
+	if err != nil {
+		return sparseVector{}, 0.0, fmt.Errorf("left hand side: %v", err)
+	}
+	rhsIDs, rhsCoeffs, rhsConstant, err := linearTerms(rhs)
+	if err != nil {
+		return sparseVector{}, 0.0, fmt.Errorf("right hand side: %v", err)
+	}
+
+	// Algorithm
+	indices := make([]int, 0, len(lhsIDs)+len(rhsIDs))
+	for _, id := range append(lhsIDs, rhsIDs...) {
+		varIndex, found := idToIndex[id]
+		if !found {
+			return sparseVector{}, 0.0, fmt.Errorf("the variable with ID %v was never added to the solver", id)
+		}
+		indices = append(indices, varIndex)
+	}
+
+	values := lhsCoeffs
+	for _, coeff := range rhsCoeffs {
+		values = append(values, -coeff)
+	}
+
+	return newSparseVectorFromEntries(indices, values), rhsConstant - lhsConstant, nil
+}
+
+/*
+setObjective
+Description:
+
+	Fills P, q, Sense and Offset from the objective obj (a nil objective is the objective 0). A
+	term c x_i x_j of the objective adds c to P[i][j] and to P[j][i], and a term c x_i^2 adds 2c
+	to P[i][i], so that the objective is 1/2 x' P x + q' x + Offset. Both are negated when the
+	objective is maximized.
+*/
+func (prob *admmProblem) setObjective(obj *optim.Objective, idToIndex map[uint64]int) error {
+	// Constants
+	n := len(prob.Q)
+	rowIndices := make([][]int, n)
+	rowValues := make([][]float64, n)
+
+	if obj == nil {
+		prob.P.Rows = make([]sparseVector, n)
+		return nil
+	}
+
+	// Algorithm
+	terms, err := optim.SparseTermsOf(obj.ScalarExpression)
+	if err != nil {
+		return fmt.Errorf("expected a linear or quadratic expression, but received expression of type %T: %v", obj.ScalarExpression, err)
+	}
+
+	sign := 1.0
+	if obj.Sense == optim.SenseMaximize {
+		sign = -1.0
+	}
+	prob.Sense = obj.Sense
+	prob.Offset = terms.Constant
+
+	indexOf := func(id uint64) (int, error) {
+		varIndex, found := idToIndex[id]
+		if !found {
+			return 0, fmt.Errorf("The objective contains a variable with ID %v which was never added to the solver.", id)
+		}
+		return varIndex, nil
+	}
+
+	for _, term := range terms.Linear {
+		varIndex, err := indexOf(term.ID)
+		if err != nil {
+			return err
+		}
+		prob.Q[varIndex] += sign * term.Coeff
+	}
+
+	for _, term := range terms.Quadratic {
+		i, err := indexOf(term.ID1)
+		if err != nil {
+			return err
+		}
+		j, err := indexOf(term.ID2)
+		if err != nil {
+			return err
+		}
+		if i == j {
+			rowIndices[i] = append(rowIndices[i], i)
+			rowValues[i] = append(rowValues[i], 2*sign*term.Coeff)
+			continue
+		}
+		rowIndices[i] = append(rowIndices[i], j)
+		rowValues[i] = append(rowValues[i], sign*term.Coeff)
+		rowIndices[j] = append(rowIndices[j], i)
+		rowValues[j] = append(rowValues[j], sign*term.Coeff)
+	}
+
+	for i := 0; i < n; i++ {
+		prob.P.Rows = append(prob.P.Rows, newSparseVectorFromEntries(rowIndices[i], rowValues[i]))
+	}
+
+	return nil
+}
+
+/*
+initialIterate
+Description:
+
+	Creates the starting point (x, y) of the iterations out of the warm start (if one was given).
+*/
+func (as *ADMMSolver) initialIterate(prob admmProblem) ([]float64, []float64) {
+	// Constants
+	n, m := len(as.Variables), prob.A.NumRows()
+	x := make([]float64, n)
+	y := make([]float64, m)
+
+	if as.WarmStart == nil {
+		return x, y
+	}
+
+	// Algorithm
+	for varIndex, tempVar := range as.Variables {
+		x[varIndex] = as.WarmStart.Primal[tempVar.ID]
+		if boundRow := prob.BoundRows[varIndex]; boundRow >= 0 {
+			y[boundRow] = as.WarmStart.Dual[tempVar.ID]
+		}
+	}
+
+	nConstraints := len(as.Constraints) + len(as.RangeConstraints)
+	for constrIndex := 0; constrIndex < nConstraints && constrIndex < len(as.WarmStart.ConstraintDual); constrIndex++ {
+		y[constrIndex] = as.WarmStart.ConstraintDual[constrIndex]
+	}
+
+	return x, y
+}
+
+/*
+toIterate
+Description:
+
+	Converts the vectors x and y used by the iterations into an ADMMIterate.
+*/
+func (as *ADMMSolver) toIterate(prob admmProblem, x, y []float64) *ADMMIterate {
+	// Constants
+	nConstraints := len(as.Constraints) + len(as.RangeConstraints)
+
+	// Algorithm
+	iterate := ADMMIterate{
+		Primal:         make(map[uint64]float64),
+		Dual:           make(map[uint64]float64),
+		ConstraintDual: append([]float64{}, y[:nConstraints]...),
+	}
+	for varIndex, tempVar := range as.Variables {
+		iterate.Primal[tempVar.ID] = x[varIndex]
+		if boundRow := prob.BoundRows[varIndex]; boundRow >= 0 {
+			iterate.Dual[tempVar.ID] = y[boundRow]
+		} else {
+			iterate.Dual[tempVar.ID] = 0.0
+		}
+	}
+
+	return &iterate
+}
+
+/*
+addRow
+Description:
+
+	Appends the constraint lower <= row' x <= upper to the problem.
+*/
+func (prob *admmProblem) addRow(row sparseVector, lower, upper float64) {
+	if isInfiniteBound(lower) {
+		lower = math.Inf(-1)
+	}
+	if isInfiniteBound(upper) {
+		upper = math.Inf(1)
+	}
+
+	prob.A.Rows = append(prob.A.Rows, row)
+	prob.L = append(prob.L, lower)
+	prob.U = append(prob.U, upper)
+}
+
+/*
+Project
+Description:
+
+	Projects the vector v onto the box [l, u].
+*/
+func (prob admmProblem) Project(v []float64) []float64 {
+	out := make([]float64, len(v))
+	for i, vi := range v {
+		out[i] = math.Min(math.Max(vi, prob.L[i]), prob.U[i])
+	}
+	return out
+}
+
+/*
+RhoVector
+Description:
+
+	Returns the step size used for each constraint. As in OSQP, equality constraints use a much
+	larger step size and constraints without finite bounds use a tiny one.
+*/
+func (prob admmProblem) RhoVector(rho float64) []float64 {
+	rhoVec := make([]float64, prob.A.NumRows())
+	for i := range rhoVec {
+		switch {
+		case math.IsInf(prob.L[i], -1) && math.IsInf(prob.U[i], 1):
+			rhoVec[i] = admmRhoMin
+		case prob.L[i] == prob.U[i]:
+			rhoVec[i] = admmRhoEqualScale * rho
+		default:
+			rhoVec[i] = rho
+		}
+	}
+	return rhoVec
+}
+
+/*
+ObjectiveValue
+Description:
+
+	Evaluates the objective of the original problem (in its original sense) at x.
+*/
+func (prob admmProblem) ObjectiveValue(x []float64) float64 {
+	value := 0.5*dot(x, prob.P.MulVec(x)) + dot(prob.Q, x)
+	if prob.Sense == optim.SenseMaximize {
+		value = -value
+	}
+	return prob.Offset + value
+}
+
+/*
+newLinearSystem
+Description:
+
+	Creates the linear system with the matrix
+		P + sigma I + A' diag(rhoVec) A
+	which is positive definite whenever P is positive semidefinite and sigma > 0. Only the
+	diagonal of the matrix is computed.
+*/
+func (prob admmProblem) newLinearSystem(sigma float64, rhoVec []float64) admmLinearSystem {
+	// Constants
+	n := prob.A.NumCols
+	system := admmLinearSystem{Prob: prob, Sigma: sigma, Rho: rhoVec, Diag: make([]float64, n)}
+
+	// Algorithm
+	for i, row := range prob.P.Rows {
+		for k, j := range row.Indices {
+			if j == i {
+				system.Diag[i] += row.Values[k]
+			}
+		}
+		system.Diag[i] += sigma
+	}
+
+	for rowIndex, row := range prob.A.Rows {
+		for k, j := range row.Indices {
+			system.Diag[j] += rhoVec[rowIndex] * row.Values[k] * row.Values[k]
+		}
+	}
+
+	return system
+}
+
+/*
+MulVec
+Description:
+
+	Computes the product of the matrix of the linear system with the vector x.
+*/
+func (system admmLinearSystem) MulVec(x []float64) []float64 {
+	// Constants
+	Ax := system.Prob.A.MulVec(x)
+	for i := range Ax {
+		Ax[i] *= system.Rho[i]
+	}
+
+	// Algorithm
+	out := system.Prob.P.MulVec(x)
+	for j, ATRAxj := range system.Prob.A.MulTransVec(Ax) {
+		out[j] += system.Sigma*x[j] + ATRAxj
+	}
+	return out
+}
+
+/*
+Solve
+Description:
+
+	Solves the linear system with the right hand side b using the conjugate gradient method,
+	preconditioned by the diagonal of the matrix. x holds the starting point (typically the
+	solution of the previous ADMM iteration) and is overwritten with the solution. An error is
+	returned if the matrix is found not to be positive definite.
+*/
+func (system admmLinearSystem) Solve(x, b []float64) error {
+	// Constants
+	n := len(b)
+	maxIterations := 10 * n
+	if maxIterations < admmCGMinIterations {
+		maxIterations = admmCGMinIterations
+	}
+	tolerance := admmCGTolerance * math.Sqrt(dot(b, b))
+
+	// Input Checking
+	for _, diagJ := range system.Diag {
+		if diagJ <= 0 {
+			return fmt.Errorf("the matrix P + sigma I + A' R A is not positive definite (is the objective convex?)")
+		}
+	}
+
+	// Algorithm
+	r := system.MulVec(x)
+	for j := range r {
+		r[j] = b[j] - r[j]
+	}
+	z := make([]float64, n)
+	for j := range z {
+		z[j] = r[j] / system.Diag[j]
+	}
+	p := append([]float64{}, z...)
+	rz := dot(r, z)
+
+	for iteration := 0; iteration < maxIterations && math.Sqrt(dot(r, r)) > tolerance; iteration++ {
+		Kp := system.MulVec(p)
+		curvature := dot(p, Kp)
+		if curvature <= 0 {
+			return fmt.Errorf("the matrix P + sigma I + A' R A is not positive definite (is the objective convex?)")
+		}
+
+		stepSize := rz / curvature
+		for j := range x {
+			x[j] += stepSize * p[j]
+			r[j] -= stepSize * Kp[j]
+			z[j] = r[j] / system.Diag[j]
+		}
+
+		rzNext := dot(r, z)
+		for j := range p {
+			p[j] = z[j] + (rzNext/rz)*p[j]
+		}
+		rz = rzNext
+	}
+
+	return nil
+}
+
+/*
+IsPrimalInfeasible
+Description:
+
+	Checks whether the change in the dual variables deltaY is a certificate of primal
+	infeasibility, i.e. whether
+		A' deltaY ~ 0 and u' max(deltaY, 0) + l' min(deltaY, 0) < 0.
+*/
+func (prob admmProblem) IsPrimalInfeasible(deltaY []float64, eps float64) bool {
+	// Constants
+	normDeltaY := infNorm(deltaY)
+	if normDeltaY <= eps {
+		return false
+	}
+
+	// Algorithm
+	if infNorm(prob.A.MulTransVec(deltaY)) > eps*normDeltaY {
+		return false
+	}
+
+	support := 0.0
+	for i, dyi := range deltaY {
+		switch {
+		case dyi > eps*normDeltaY:
+			if math.IsInf(prob.U[i], 1) {
+				return false
+			}
+			support += prob.U[i] * dyi
+		case dyi < -eps*normDeltaY:
+			if math.IsInf(prob.L[i], -1) {
+				return false
+			}
+			support += prob.L[i] * dyi
+		}
+	}
+
+	return support < -eps*normDeltaY
+}
+
+/*
+IsDualInfeasible
+Description:
+
+	Checks whether the change in the primal variables deltaX is a certificate of dual
+	infeasibility (i.e. an unbounded direction), i.e. whether
+		P deltaX ~ 0, q' deltaX < 0 and A deltaX stays within the recession cone of [l, u].
+*/
+func (prob admmProblem) IsDualInfeasible(deltaX []float64, eps float64) bool {
+	// Constants
+	normDeltaX := infNorm(deltaX)
+	if normDeltaX <= eps {
+		return false
+	}
+	threshold := eps * normDeltaX
+
+	// Algorithm
+	if infNorm(prob.P.MulVec(deltaX)) > threshold {
+		return false
+	}
+
+	if dot(prob.Q, deltaX) >= -threshold {
+		return false
+	}
+
+	for i, ai := range prob.A.MulVec(deltaX) {
+		if !math.IsInf(prob.U[i], 1) && ai > threshold {
+			return false
+		}
+		if !math.IsInf(prob.L[i], -1) && ai < -threshold {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,309 @@
+package solvers
+
+/*
+branchandboundsolver.go
+Description:
+	Defines a pure-Go solver for mixed integer linear programs. The solver uses a best-bound
+	branch-and-bound search whose LP relaxations are solved with gonum's simplex method (see
+	gonumlpsolver.go).
+*/
+
+import (
+	"container/heap"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"github.com/kwesiRutledge/goop2/optim"
+)
+
+// Type Definition
+
+type BranchAndBoundSolver struct {
+	problemStore
+
+	IntegralityTolerance float64 // How far a value may be from an integer while still being considered integral
+	RelativeGap          float64 // The search stops once the relative gap falls below this value
+	LPTolerance          float64 // Tolerance given to gonum's simplex method
+	NodeCount            int     // Number of nodes explored in the last call to Optimize()
+	showLog              bool
+	timeLimit            float64
+}
+
+/*
+bbNode
+Description:
+
+	A node of the branch-and-bound tree. Bound is the objective value (of the minimization form
+	of the problem) of the parent's relaxation and is used to order the search.
+*/
+type bbNode struct {
+	Lower []float64
+	Upper []float64
+	Bound float64
+	Depth int
+}
+
+// bbNodeQueue implements heap.Interface for a min-heap of nodes ordered by their bound.
+type bbNodeQueue []bbNode
+
+func (q bbNodeQueue) Len() int { return len(q) }
+func (q bbNodeQueue) Less(i, j int) bool {
+	if q[i].Bound == q[j].Bound {
+		return q[i].Depth > q[j].Depth
+	}
+	return q[i].Bound < q[j].Bound
+}
+func (q bbNodeQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *bbNodeQueue) Push(x interface{}) { *q = append(*q, x.(bbNode)) }
+func (q *bbNodeQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	node := old[n-1]
+	*q = old[:n-1]
+	return node
+}
+
+// Functions
+
+/*
+NewBranchAndBoundSolver
+Description:
+
+	Create a new, empty BranchAndBoundSolver object with default tolerances.
+*/
+func NewBranchAndBoundSolver() *BranchAndBoundSolver {
+	return &BranchAndBoundSolver{
+		problemStore:         newProblemStore("BranchAndBoundSolver", linearObjective, optim.Continuous, optim.Binary, optim.Integer),
+		IntegralityTolerance: 1e-6,
+		RelativeGap:          1e-4,
+		LPTolerance:          1e-10,
+	}
+}
+
+/*
+ShowLog
+Description:
+
+	Decides whether or not to print the progress of the search to the terminal.
+*/
+func (bbs *BranchAndBoundSolver) ShowLog(tf bool) error {
+	bbs.showLog = tf
+	return nil
+}
+
+/*
+SetTimeLimit
+Description:
+
+	Sets the time limit of the search. A limit of zero means that there is no limit.
+
+Input:
+
+	limitInS = Value of time limit in seconds (float)
+*/
+func (bbs *BranchAndBoundSolver) SetTimeLimit(limitInS float64) error {
+	if limitInS < 0 {
+		return fmt.Errorf("The time limit must be nonnegative; received %v", limitInS)
+	}
+	bbs.timeLimit = limitInS
+	return nil
+}
+
+/*
+Optimize
+Description:
+
+	Runs the branch-and-bound search. The returned solution contains the best integer feasible
+	solution found (the incumbent), the best bound on the objective and the relative gap between
+	the two. If the time limit is reached before the search completes, then the status
+	OptimizationStatus_TIME_LIMIT is returned along with the incumbent (if one was found).
+*/
+func (bbs *BranchAndBoundSolver) Optimize() (optim.Solution, error) {
+	// Constants
+	startTime := time.Now()
+
+	// Create linear program
+	linProg, err := newLinearProgram(bbs.Variables, bbs.Constraints, bbs.Objective)
+	if err != nil {
+		return optim.Solution{}, fmt.Errorf("There was an issue creating the linear program: %v", err)
+	}
+	senseFactor := 1.0
+	if linProg.Sense == optim.SenseMaximize {
+		senseFactor = -1.0
+	}
+
+	// Tighten the bounds of the integer variables
+	var integerIndices []int
+	rootLower := make([]float64, len(linProg.Variables))
+	rootUpper := make([]float64, len(linProg.Variables))
+	copy(rootLower, linProg.Lower)
+	copy(rootUpper, linProg.Upper)
+	for varIndex, tempVar := range linProg.Variables {
+		switch tempVar.Vtype {
+		case optim.Binary:
+			rootLower[varIndex] = math.Max(rootLower[varIndex], 0.0)
+			rootUpper[varIndex] = math.Min(rootUpper[varIndex], 1.0)
+		case optim.Integer:
+		default:
+			continue
+		}
+		integerIndices = append(integerIndices, varIndex)
+		if !isInfiniteBound(rootLower[varIndex]) {
+			rootLower[varIndex] = math.Ceil(rootLower[varIndex] - bbs.IntegralityTolerance)
+		}
+		if !isInfiniteBound(rootUpper[varIndex]) {
+			rootUpper[varIndex] = math.Floor(rootUpper[varIndex] + bbs.IntegralityTolerance)
+		}
+	}
+
+	// Search
+	var incumbent []float64
+	incumbentValue := math.Inf(1) // In the minimization form of the problem
+	queue := &bbNodeQueue{}
+	var status optim.OptimizationStatus = optim.OptimizationStatus_OPTIMAL
+	heap.Push(queue, bbNode{Lower: rootLower, Upper: rootUpper, Bound: math.Inf(-1)})
+	bbs.NodeCount = 0
+
+	for queue.Len() > 0 {
+		// Check the stopping criteria
+		if bbs.timeLimit > 0 && time.Since(startTime).Seconds() >= bbs.timeLimit {
+			status = optim.OptimizationStatus_TIME_LIMIT
+			break
+		}
+		if incumbent != nil && relativeGap(incumbentValue, (*queue)[0].Bound) <= bbs.RelativeGap {
+			break
+		}
+
+		node := heap.Pop(queue).(bbNode)
+		if node.Bound >= incumbentValue {
+			continue // Prune by bound
+		}
+		bbs.NodeCount++
+
+		// Solve the relaxation at this node
+		nodeProg := linProg
+		nodeProg.Lower = node.Lower
+		nodeProg.Upper = node.Upper
+		if !nodeProg.HasConsistentBounds() {
+			continue
+		}
+
+		nodeStatus, x, err := solveWithGonumSimplex(nodeProg, bbs.LPTolerance)
+		if err != nil {
+			return optim.Solution{Status: nodeStatus}, fmt.Errorf("There was an issue solving the relaxation at node %v: %v", bbs.NodeCount, err)
+		}
+
+		switch nodeStatus {
+		case optim.OptimizationStatus_INFEASIBLE:
+			if bbs.NodeCount == 1 {
+				return optim.Solution{Status: optim.OptimizationStatus_INFEASIBLE}, nil
+			}
+			continue // Prune by infeasibility
+		case optim.OptimizationStatus_UNBOUNDED:
+			if len(integerIndices) == 0 {
+				return optim.Solution{Status: optim.OptimizationStatus_UNBOUNDED}, nil
+			}
+			// An unbounded relaxation does not tell us whether or not the integer problem is feasible.
+			return optim.Solution{Status: optim.OptimizationStatus_INF_OR_UNBD}, nil
+		}
+
+		nodeValue := senseFactor * linProg.ObjectiveValue(x)
+		if nodeValue >= incumbentValue {
+			continue // Prune by bound
+		}
+
+		// Find the most fractional integer variable
+		branchIndex, mostFractional := -1, 0.0
+		for _, varIndex := range integerIndices {
+			fractionalPart := x[varIndex] - math.Floor(x[varIndex])
+			distance := math.Min(fractionalPart, 1.0-fractionalPart)
+			if distance > bbs.IntegralityTolerance && distance > mostFractional {
+				branchIndex, mostFractional = varIndex, distance
+			}
+		}
+
+		if branchIndex == -1 {
+			// The relaxation's solution is integral. Save it as the new incumbent.
+			for _, varIndex := range integerIndices {
+				x[varIndex] = math.Round(x[varIndex])
+			}
+			incumbent, incumbentValue = x, nodeValue
+			if bbs.showLog {
+				log.Printf("BranchAndBoundSolver: node %v found incumbent with objective %v", bbs.NodeCount, senseFactor*incumbentValue)
+			}
+			continue
+		}
+
+		// Branch
+		downUpper := make([]float64, len(node.Upper))
+		copy(downUpper, node.Upper)
+		downUpper[branchIndex] = math.Floor(x[branchIndex])
+
+		upLower := make([]float64, len(node.Lower))
+		copy(upLower, node.Lower)
+		upLower[branchIndex] = math.Ceil(x[branchIndex])
+
+		heap.Push(queue, bbNode{Lower: node.Lower, Upper: downUpper, Bound: nodeValue, Depth: node.Depth + 1})
+		heap.Push(queue, bbNode{Lower: upLower, Upper: node.Upper, Bound: nodeValue, Depth: node.Depth + 1})
+	}
+
+	// Compute the best bound
+	bestBound := incumbentValue
+	for _, node := range *queue {
+		if node.Bound < bestBound {
+			bestBound = node.Bound
+		}
+	}
+
+	// Create Solution
+	if incumbent == nil && status == optim.OptimizationStatus_OPTIMAL {
+		// The whole tree was explored without finding an integer solution.
+		return optim.Solution{Status: optim.OptimizationStatus_INFEASIBLE}, nil
+	}
+
+	tempSolution := optim.Solution{
+		Status:    status,
+		BestBound: senseFactor * bestBound,
+		Gap:       relativeGap(incumbentValue, bestBound),
+	}
+	if incumbent != nil {
+		tempSolution.Values = make(map[uint64]float64)
+		for varIndex, tempVar := range linProg.Variables {
+			tempSolution.Values[tempVar.ID] = incumbent[varIndex]
+		}
+		tempSolution.Objective = senseFactor * incumbentValue
+	}
+
+	if bbs.showLog {
+		statusMessage, _ := status.ToMessage()
+		log.Printf(
+			"BranchAndBoundSolver: explored %v nodes in %v. %v Objective = %v, Bound = %v, Gap = %v",
+			bbs.NodeCount, time.Since(startTime), statusMessage,
+			tempSolution.Objective, tempSolution.BestBound, tempSolution.Gap,
+		)
+	}
+
+	return tempSolution, nil
+}
+
+/*
+relativeGap
+Description:
+
+	Computes the relative gap between the objective of an incumbent and a bound in the same way
+	as Gurobi: |bound - incumbent| / |incumbent|.
+*/
+func relativeGap(incumbentValue, bound float64) float64 {
+	if math.IsInf(incumbentValue, 0) || math.IsInf(bound, 0) {
+		return math.Inf(1)
+	}
+	if incumbentValue == bound {
+		return 0.0
+	}
+	if incumbentValue == 0.0 {
+		return math.Inf(1)
+	}
+	return math.Abs(bound-incumbentValue) / math.Abs(incumbentValue)
+}
@@ -0,0 +1,1065 @@
+package solvers
+
+/*
+dualsimplexsolver.go
+Description:
+	Defines a pure-Go bounded dual simplex solver for linear programs. The solver keeps its basis
+	(and the inverse of the basis matrix) between calls to Optimize, so that re-solving a problem
+	whose right hand sides, bounds or objective changed only takes a few pivots.
+
+	Each constraint i receives a logical variable r_i equal to its activity, so that the problem
+	that is solved is
+		minimize   c' x
+		subject to A x - r = 0
+		           lower <= x <= upper, rowLower <= r <= rowUpper.
+	Variables with an infinite bound on the side required by their reduced cost temporarily
+	receive an artificial bound, which is enlarged (or removed) once the dual simplex finishes.
+*/
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"github.com/kwesiRutledge/goop2/optim"
+	"gonum.org/v1/gonum/mat"
+)
+
+// Type Definitions
+// ================
+
+type DualSimplexSolver struct {
+	problemStore
+
+	// Settings
+	PrimalTolerance    float64 // Tolerance on the bounds of the basic variables
+	DualTolerance      float64 // Tolerance on the signs of the reduced costs
+	PivotTolerance     float64 // Smallest magnitude of an acceptable pivot element
+	MaxIterations      int
+	RefactorFrequency  int     // Number of pivots between recomputations of the basis inverse
+	ArtificialBound    float64 // Initial magnitude of the artificial bounds
+	MaxArtificialBound float64 // Magnitude of the artificial bounds after which the problem is declared unbounded
+
+	// Results of the last solve
+	Iterations int
+
+	warmStart *optim.Basis
+	state     *dualSimplexState
+	showLog   bool
+	timeLimit float64
+}
+
+/*
+dualSimplexState
+Description:
+
+	The data and the current basis of the bounded dual simplex method. The first NumVars columns
+	correspond to the variables of the problem and the remaining columns correspond to the
+	logical variables of the rows.
+*/
+type dualSimplexState struct {
+	NumRows int
+	NumVars int
+	Cols    [][]float64
+	Cost    []float64
+	Lower   []float64
+	Upper   []float64
+
+	Value      []float64
+	Status     []optim.BasisStatus
+	Basic      []int // The column in each position of the basis
+	BInv       [][]float64
+	D          []float64 // Reduced costs
+	Artificial []bool    // Whether or not a nonbasic column sits at an artificial bound
+	ArtBound   float64
+}
+
+// Functions
+// =========
+
+/*
+NewDualSimplexSolver
+Description:
+
+	Create a new, empty DualSimplexSolver object.
+*/
+func NewDualSimplexSolver() *DualSimplexSolver {
+	return &DualSimplexSolver{
+		problemStore:       newProblemStore("DualSimplexSolver", linearObjective, optim.Continuous),
+		PrimalTolerance:    1e-9,
+		DualTolerance:      1e-9,
+		PivotTolerance:     1e-9,
+		MaxIterations:      10000,
+		RefactorFrequency:  100,
+		ArtificialBound:    1e6,
+		MaxArtificialBound: 1e9,
+	}
+}
+
+/*
+ShowLog
+Description:
+
+	Decides whether or not to print a short summary of each solve to the terminal.
+*/
+func (dss *DualSimplexSolver) ShowLog(tf bool) error {
+	dss.showLog = tf
+	return nil
+}
+
+/*
+SetTimeLimit
+Description:
+
+	Sets the time limit of the current model in the DualSimplexSolver.
+
+Input:
+
+	limitInS = Value of time limit in seconds (float)
+*/
+func (dss *DualSimplexSolver) SetTimeLimit(limitInS float64) error {
+	if limitInS < 0 {
+		return fmt.Errorf("The time limit must be nonnegative; received %v", limitInS)
+	}
+	dss.timeLimit = limitInS
+	return nil
+}
+
+/*
+SetBasis
+Description:
+
+	Sets the basis that the next call to Optimize() starts from. A basis which does not fit the
+	problem being solved (e.g. because it has the wrong number of basic variables or is singular)
+	is ignored.
+*/
+func (dss *DualSimplexSolver) SetBasis(basisIn optim.Basis) error {
+	dss.warmStart = &basisIn
+	return nil
+}
+
+/*
+GetBasis
+Description:
+
+	Returns the final basis of the last call to Optimize().
+*/
+func (dss *DualSimplexSolver) GetBasis() (optim.Basis, error) {
+	// Input Checking
+	if dss.state == nil {
+		return optim.Basis{}, fmt.Errorf("The DualSimplexSolver has not solved a problem yet, so it does not have a basis.")
+	}
+
+	if dss.state.NumVars != len(dss.Variables) {
+		return optim.Basis{}, fmt.Errorf(
+			"The last basis has %v variables, but the solver currently has %v variables.",
+			dss.state.NumVars, len(dss.Variables),
+		)
+	}
+
+	// Algorithm
+	basisOut := optim.Basis{
+		Variables:   make(map[uint64]optim.BasisStatus),
+		Constraints: make([]optim.BasisStatus, dss.state.NumRows),
+	}
+	for varIndex, tempVar := range dss.Variables {
+		basisOut.Variables[tempVar.ID] = dss.state.Status[varIndex]
+	}
+	copy(basisOut.Constraints, dss.state.Status[dss.state.NumVars:])
+
+	return basisOut, nil
+}
+
+/*
+Optimize
+Description:
+
+	Solves the linear program with the bounded dual simplex method. The solve starts from the
+	basis given to SetBasis() or, if there is none, from the basis of the previous solve; if
+	the constraint matrix did not change since the previous solve, then the inverse of that basis
+	is reused as well.
+*/
+func (dss *DualSimplexSolver) Optimize() (optim.Solution, error) {
+	// Input Checking
+	if len(dss.Variables) == 0 {
+		return optim.Solution{}, fmt.Errorf("There are no variables in the DualSimplexSolver.")
+	}
+
+	// Constants
+	var deadline time.Time
+	if dss.timeLimit > 0 {
+		deadline = time.Now().Add(time.Duration(dss.timeLimit * float64(time.Second)))
+	}
+
+	// Create linear program
+	linProg, err := newLinearProgram(dss.Variables, dss.Constraints, dss.Objective)
+	if err != nil {
+		return optim.Solution{}, fmt.Errorf("There was an issue creating the linear program: %v", err)
+	}
+
+	if !linProg.HasConsistentBounds() {
+		return optim.Solution{Status: optim.OptimizationStatus_INFEASIBLE}, nil
+	}
+
+	state := newDualSimplexState(linProg, dss.ArtificialBound)
+
+	// Choose the starting basis
+	err = dss.initializeBasis(state)
+	if err != nil {
+		return optim.Solution{}, fmt.Errorf("There was an issue initializing the basis: %v", err)
+	}
+
+	// Solve
+	status, iterations := dss.iterate(state, deadline)
+	dss.Iterations = iterations
+	dss.state = state
+	dss.warmStart = nil
+
+	tempSolution := optim.Solution{Status: status, Iterations: iterations}
+	if status == optim.OptimizationStatus_OPTIMAL {
+		x := state.Value[:state.NumVars]
+		tempSolution.Values = make(map[uint64]float64)
+		for varIndex, tempVar := range linProg.Variables {
+			tempSolution.Values[tempVar.ID] = x[varIndex]
+		}
+		tempSolution.Objective = linProg.ObjectiveValue(x)
+	}
+
+	if dss.showLog {
+		statusMessage, _ := status.ToMessage()
+		log.Printf(
+			"DualSimplexSolver: %v variables, %v constraints, %v iterations. %v Objective = %v",
+			state.NumVars, state.NumRows, iterations, statusMessage, tempSolution.Objective,
+		)
+	}
+
+	return tempSolution, nil
+}
+
+/*
+ClearModel
+Description:
+
+	Removes all variables, constraints and the objective from the solver, but keeps the basis of
+	the last solve so that it can be used to warm start the next one.
+*/
+func (dss *DualSimplexSolver) ClearModel() error {
+	dss.clearProblem()
+
+	return nil
+}
+
+/*
+SetVariableBounds
+Description:
+
+	Changes the bounds of a variable of the linear program. The basis of the last solve is kept,
+	so the next solve starts from it.
+*/
+func (dss *DualSimplexSolver) SetVariableBounds(varIn optim.Variable) error {
+	varIndex, err := dss.variableIndex(varIn.ID)
+	if err != nil {
+		return err
+	}
+	dss.Variables[varIndex].Lower = varIn.Lower
+	dss.Variables[varIndex].Upper = varIn.Upper
+
+	return nil
+}
+
+/*
+RemoveVariable
+Description:
+
+	Removes a variable from the linear program. Since the shape of the problem changes, the next
+	solve starts from the basis made of the logical variables.
+*/
+func (dss *DualSimplexSolver) RemoveVariable(varIn optim.Variable) error {
+	varIndex, err := dss.variableIndex(varIn.ID)
+	if err != nil {
+		return err
+	}
+	dss.removeVariable(varIndex)
+
+	return nil
+}
+
+/*
+SetConstraint
+Description:
+
+	Replaces the constraint at the given index. If only its right hand side changed, then the next
+	solve reuses the basis (and basis inverse) of the last one.
+*/
+func (dss *DualSimplexSolver) SetConstraint(index int, constrIn optim.ScalarConstraint) error {
+	if index < 0 || index >= len(dss.Constraints) {
+		return fmt.Errorf("The DualSimplexSolver does not have a constraint with index %v.", index)
+	}
+	dss.Constraints[index] = constrIn
+
+	return nil
+}
+
+/*
+RemoveConstraint
+Description:
+
+	Removes the constraint at the given index. Since the shape of the problem changes, the next
+	solve starts from the basis made of the logical variables.
+*/
+func (dss *DualSimplexSolver) RemoveConstraint(index int) error {
+	if index < 0 || index >= len(dss.Constraints) {
+		return fmt.Errorf("The DualSimplexSolver does not have a constraint with index %v.", index)
+	}
+	dss.Constraints = append(dss.Constraints[:index], dss.Constraints[index+1:]...)
+
+	return nil
+}
+
+/*
+variableIndex
+Description:
+
+	Returns the index of the variable with the given ID in dss.Variables.
+*/
+func (dss *DualSimplexSolver) variableIndex(id uint64) (int, error) {
+	for varIndex, tempVar := range dss.Variables {
+		if tempVar.ID == id {
+			return varIndex, nil
+		}
+	}
+	return -1, fmt.Errorf("The variable with ID %v is not in the DualSimplexSolver.", id)
+}
+
+/*
+DeleteSolver
+Description:
+
+	Removes all variables, constraints, the objective and the saved basis from the solver.
+*/
+func (dss *DualSimplexSolver) DeleteSolver() error {
+	dss.ClearModel()
+	dss.warmStart = nil
+	dss.state = nil
+
+	return nil
+}
+
+/*
+initializeBasis
+Description:
+
+	Chooses the starting basis of the state. In order of preference, this is: the basis given to
+	SetBasis(), the basis (and basis inverse) of the last solve, or the basis made of the
+	logical variables.
+*/
+func (dss *DualSimplexSolver) initializeBasis(state *dualSimplexState) error {
+	// Constants
+	previous := dss.state
+	sameShape := previous != nil && previous.NumVars == state.NumVars && previous.NumRows == state.NumRows
+
+	// Algorithm
+	initialized := false
+	switch {
+	case dss.warmStart != nil:
+		if basic, ok := dss.basicColumnsOf(*dss.warmStart, state); ok {
+			copy(state.Status, basic)
+			initialized = state.ComputeBInv() == nil
+		}
+	case sameShape:
+		copy(state.Status, previous.Status)
+		copy(state.Basic, previous.Basic)
+		if state.HasSameMatrix(previous) {
+			state.BInv = previous.BInv
+			initialized = true
+		} else {
+			initialized = state.ComputeBInv() == nil
+		}
+	}
+
+	if !initialized {
+		state.UseLogicalBasis()
+		if err := state.ComputeBInv(); err != nil {
+			return err
+		}
+	}
+
+	state.ComputeDuals()
+	state.PlaceNonbasic(dss.DualTolerance)
+	state.ComputeBasicValues()
+
+	return nil
+}
+
+/*
+basicColumnsOf
+Description:
+
+	Converts the basis basisIn into the statuses of the columns of state. The basic columns are
+	also stored in state.Basic. Returns false if basisIn does not contain exactly one basic
+	column per row.
+*/
+func (dss *DualSimplexSolver) basicColumnsOf(basisIn optim.Basis, state *dualSimplexState) ([]optim.BasisStatus, bool) {
+	// Input Checking
+	if len(basisIn.Constraints) != state.NumRows {
+		return nil, false
+	}
+
+	// Algorithm
+	statuses := make([]optim.BasisStatus, state.NumVars+state.NumRows)
+	for varIndex, tempVar := range dss.Variables {
+		status, found := basisIn.Variables[tempVar.ID]
+		if !found {
+			status = optim.BasisStatus_AT_LOWER
+		}
+		statuses[varIndex] = status
+	}
+	copy(statuses[state.NumVars:], basisIn.Constraints)
+
+	var basic []int
+	for col, status := range statuses {
+		if status == optim.BasisStatus_BASIC {
+			basic = append(basic, col)
+		}
+	}
+	if len(basic) != state.NumRows {
+		return nil, false
+	}
+	copy(state.Basic, basic)
+
+	return statuses, true
+}
+
+/*
+iterate
+Description:
+
+	Runs the bounded dual simplex method from the (dual feasible) starting point in state.
+*/
+func (dss *DualSimplexSolver) iterate(state *dualSimplexState, deadline time.Time) (optim.OptimizationStatus, int) {
+	// Constants
+	primalTol, dualTol, pivotTol := dss.PrimalTolerance, dss.DualTolerance, dss.PivotTolerance
+
+	// Algorithm
+	pivotsSinceRefactor := 0
+	for iterations := 0; ; iterations++ {
+		if iterations >= dss.MaxIterations {
+			return optim.OptimizationStatus_ITERATION_LIMIT, iterations
+		}
+		if !deadline.IsZero() && time.Now().After(deadline) {
+			return optim.OptimizationStatus_TIME_LIMIT, iterations
+		}
+
+		// Refactor the basis periodically to limit the growth of numerical errors.
+		if pivotsSinceRefactor >= dss.RefactorFrequency {
+			if err := state.ComputeBInv(); err != nil {
+				return optim.OptimizationStatus_NUMERIC, iterations
+			}
+			state.ComputeBasicValues()
+			state.ComputeDuals()
+			pivotsSinceRefactor = 0
+		}
+
+		// Choose the leaving variable
+		leavingPos := state.ChooseLeaving(primalTol)
+		if leavingPos < 0 {
+			// The problem with artificial bounds has been solved.
+			done, status := state.FinishArtificial(dss.MaxArtificialBound, dualTol, pivotTol)
+			if done {
+				return status, iterations
+			}
+			continue
+		}
+
+		// Choose the entering variable
+		leaving := state.Basic[leavingPos]
+		direction := 1.0 // The leaving variable decreases to its upper bound.
+		target := state.Upper[leaving]
+		if state.Value[leaving] < state.Lower[leaving] {
+			direction = -1.0 // The leaving variable increases to its lower bound.
+			target = state.Lower[leaving]
+		}
+
+		alphaRow := state.PivotRow(leavingPos)
+		entering := state.ChooseEntering(alphaRow, direction, dualTol, pivotTol)
+		if entering < 0 {
+			// The dual is unbounded, unless the artificial bounds prevent a move.
+			if state.BlockedByArtificial(alphaRow, direction, pivotTol) && state.ArtBound < dss.MaxArtificialBound {
+				state.EnlargeArtificialBounds()
+				continue
+			}
+			return optim.OptimizationStatus_INFEASIBLE, iterations
+		}
+
+		state.Pivot(leavingPos, entering, target, alphaRow)
+		pivotsSinceRefactor++
+	}
+}
+
+/*
+newDualSimplexState
+Description:
+
+	Creates the columns, costs and bounds of the bounded dual simplex method for linProg.
+*/
+func newDualSimplexState(linProg linearProgram, artificialBound float64) *dualSimplexState {
+	// Constants
+	n, m := len(linProg.Variables), len(linProg.Rows)
+	inf := math.Inf(1)
+
+	// Algorithm
+	state := &dualSimplexState{
+		NumRows:    m,
+		NumVars:    n,
+		Cols:       make([][]float64, n+m),
+		Cost:       make([]float64, n+m),
+		Lower:      make([]float64, n+m),
+		Upper:      make([]float64, n+m),
+		Value:      make([]float64, n+m),
+		Status:     make([]optim.BasisStatus, n+m),
+		Basic:      make([]int, m),
+		D:          make([]float64, n+m),
+		Artificial: make([]bool, n+m),
+		ArtBound:   artificialBound,
+	}
+
+	copy(state.Cost, linProg.MinimizationCost())
+	for j := 0; j < n; j++ {
+		state.Cols[j] = make([]float64, m)
+		for i, row := range linProg.Rows {
+			state.Cols[j][i] = row.Coeffs[j]
+		}
+		state.Lower[j], state.Upper[j] = linProg.Lower[j], linProg.Upper[j]
+		if isInfiniteBound(state.Lower[j]) {
+			state.Lower[j] = -inf
+		}
+		if isInfiniteBound(state.Upper[j]) {
+			state.Upper[j] = inf
+		}
+	}
+
+	for i, row := range linProg.Rows {
+		col := make([]float64, m)
+		col[i] = -1.0
+		state.Cols[n+i] = col
+
+		state.Lower[n+i], state.Upper[n+i] = -inf, inf
+		switch row.Sense {
+		case optim.SenseEqual:
+			state.Lower[n+i], state.Upper[n+i] = row.RHS, row.RHS
+		case optim.SenseLessThanEqual:
+			state.Upper[n+i] = row.RHS
+		case optim.SenseGreaterThanEqual:
+			state.Lower[n+i] = row.RHS
+		}
+	}
+
+	for col := range state.Status {
+		state.Status[col] = optim.BasisStatus_AT_LOWER
+	}
+
+	return state
+}
+
+/*
+UseLogicalBasis
+Description:
+
+	Makes the logical variables of the rows basic.
+*/
+func (state *dualSimplexState) UseLogicalBasis() {
+	for col := range state.Status {
+		if col < state.NumVars {
+			state.Status[col] = optim.BasisStatus_AT_LOWER
+		} else {
+			state.Status[col] = optim.BasisStatus_BASIC
+			state.Basic[col-state.NumVars] = col
+		}
+	}
+}
+
+/*
+HasSameMatrix
+Description:
+
+	Determines whether or not the constraint matrix of state is the same as that of other.
+*/
+func (state *dualSimplexState) HasSameMatrix(other *dualSimplexState) bool {
+	for col := range state.Cols {
+		for i := range state.Cols[col] {
+			if state.Cols[col][i] != other.Cols[col][i] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+/*
+ComputeBInv
+Description:
+
+	Computes the inverse of the basis matrix from scratch.
+*/
+func (state *dualSimplexState) ComputeBInv() error {
+	// Constants
+	m := state.NumRows
+	if m == 0 {
+		state.BInv = nil
+		return nil
+	}
+
+	// Algorithm
+	B := mat.NewDense(m, m, nil)
+	for pos, col := range state.Basic {
+		for i := 0; i < m; i++ {
+			B.Set(i, pos, state.Cols[col][i])
+		}
+	}
+
+	var inverse mat.Dense
+	if err := inverse.Inverse(B); err != nil {
+		return fmt.Errorf("the basis matrix is singular: %v", err)
+	}
+
+	state.BInv = make([][]float64, m)
+	for i := 0; i < m; i++ {
+		state.BInv[i] = mat.Row(nil, i, &inverse)
+	}
+
+	return nil
+}
+
+/*
+ComputeDuals
+Description:
+
+	Computes the reduced costs of all columns with respect to the current basis.
+*/
+func (state *dualSimplexState) ComputeDuals() {
+	// Compute y' = c_B' B^{-1}
+	y := make([]float64, state.NumRows)
+	for pos, col := range state.Basic {
+		if state.Cost[col] == 0 {
+			continue
+		}
+		for i := range y {
+			y[i] += state.Cost[col] * state.BInv[pos][i]
+		}
+	}
+
+	for col := range state.D {
+		if state.Status[col] == optim.BasisStatus_BASIC {
+			state.D[col] = 0.0
+			continue
+		}
+		state.D[col] = state.Cost[col] - dot(y, state.Cols[col])
+	}
+}
+
+/*
+PlaceNonbasic
+Description:
+
+	Moves every nonbasic column to the bound which makes its reduced cost dual feasible. Columns
+	with an infinite bound on that side are placed at an artificial bound.
+*/
+func (state *dualSimplexState) PlaceNonbasic(dualTol float64) {
+	for col, status := range state.Status {
+		if status == optim.BasisStatus_BASIC {
+			continue
+		}
+
+		lower, upper := state.Lower[col], state.Upper[col]
+		d := state.D[col]
+		state.Artificial[col] = false
+		switch {
+		case lower == upper:
+			state.setNonbasic(col, optim.BasisStatus_AT_LOWER, lower)
+		case d > dualTol:
+			if math.IsInf(lower, -1) {
+				state.setNonbasic(col, optim.BasisStatus_AT_LOWER, state.artificialLower(col))
+				state.Artificial[col] = true
+			} else {
+				state.setNonbasic(col, optim.BasisStatus_AT_LOWER, lower)
+			}
+		case d < -dualTol:
+			if math.IsInf(upper, 1) {
+				state.setNonbasic(col, optim.BasisStatus_AT_UPPER, state.artificialUpper(col))
+				state.Artificial[col] = true
+			} else {
+				state.setNonbasic(col, optim.BasisStatus_AT_UPPER, upper)
+			}
+		case status == optim.BasisStatus_AT_UPPER && !math.IsInf(upper, 1):
+			state.setNonbasic(col, optim.BasisStatus_AT_UPPER, upper)
+		case !math.IsInf(lower, -1):
+			state.setNonbasic(col, optim.BasisStatus_AT_LOWER, lower)
+		case !math.IsInf(upper, 1):
+			state.setNonbasic(col, optim.BasisStatus_AT_UPPER, upper)
+		default:
+			// A free column with a zero reduced cost can stay at zero.
+			state.setNonbasic(col, optim.BasisStatus_SUPERBASIC, 0.0)
+		}
+	}
+}
+
+func (state *dualSimplexState) setNonbasic(col int, status optim.BasisStatus, value float64) {
+	state.Status[col] = status
+	state.Value[col] = value
+}
+
+func (state *dualSimplexState) artificialLower(col int) float64 {
+	return math.Min(state.Upper[col], 0.0) - state.ArtBound
+}
+
+func (state *dualSimplexState) artificialUpper(col int) float64 {
+	return math.Max(state.Lower[col], 0.0) + state.ArtBound
+}
+
+/*
+ComputeBasicValues
+Description:
+
+	Computes the values of the basic columns from the values of the nonbasic columns, so that
+	the sum of Cols[j] * Value[j] over all columns is zero.
+*/
+func (state *dualSimplexState) ComputeBasicValues() {
+	rhs := make([]float64, state.NumRows)
+	for col, status := range state.Status {
+		if status == optim.BasisStatus_BASIC || state.Value[col] == 0 {
+			continue
+		}
+		for i, aij := range state.Cols[col] {
+			rhs[i] -= aij * state.Value[col]
+		}
+	}
+
+	for pos, col := range state.Basic {
+		state.Value[col] = dot(state.BInv[pos], rhs)
+	}
+}
+
+/*
+ChooseLeaving
+Description:
+
+	Returns the position in the basis of the basic column which violates its bounds the most, or
+	-1 if every basic column is within its bounds.
+*/
+func (state *dualSimplexState) ChooseLeaving(primalTol float64) int {
+	leavingPos, maxViolation := -1, 0.0
+	for pos, col := range state.Basic {
+		violation := 0.0
+		value := state.Value[col]
+		if value < state.Lower[col]-primalTol*(1+math.Abs(state.Lower[col])) {
+			violation = state.Lower[col] - value
+		} else if value > state.Upper[col]+primalTol*(1+math.Abs(state.Upper[col])) {
+			violation = value - state.Upper[col]
+		}
+		if violation > maxViolation {
+			leavingPos, maxViolation = pos, violation
+		}
+	}
+	return leavingPos
+}
+
+/*
+PivotRow
+Description:
+
+	Computes the row of B^{-1} [Cols] corresponding to the basis position pos.
+*/
+func (state *dualSimplexState) PivotRow(pos int) []float64 {
+	alphaRow := make([]float64, len(state.Cols))
+	for col, status := range state.Status {
+		if status == optim.BasisStatus_BASIC {
+			continue
+		}
+		alphaRow[col] = dot(state.BInv[pos], state.Cols[col])
+	}
+	return alphaRow
+}
+
+/*
+ChooseEntering
+Description:
+
+	Performs the (two pass) ratio test of the dual simplex method. When the leaving column moves
+	in direction (+1 when it decreases to its upper bound, -1 when it increases to its lower
+	bound), the entering column must be able to move in the direction which makes that change,
+	and it is chosen so that all reduced costs stay dual feasible. Returns -1 if no column can
+	enter the basis.
+*/
+func (state *dualSimplexState) ChooseEntering(alphaRow []float64, direction, dualTol, pivotTol float64) int {
+	// eligible determines whether or not col can move in the direction that the leaving
+	// variable needs.
+	eligible := func(col int) bool {
+		status := state.Status[col]
+		alpha := direction * alphaRow[col]
+		if status == optim.BasisStatus_BASIC || state.Lower[col] == state.Upper[col] || math.Abs(alpha) <= pivotTol {
+			return false
+		}
+		switch status {
+		case optim.BasisStatus_AT_LOWER:
+			return alpha > 0
+		case optim.BasisStatus_AT_UPPER:
+			return alpha < 0
+		}
+		return true
+	}
+
+	// First pass: find the largest step with relaxed bounds.
+	maxRatio := math.Inf(1)
+	for col := range state.Status {
+		if eligible(col) {
+			maxRatio = math.Min(maxRatio, (math.Abs(state.D[col])+dualTol)/math.Abs(alphaRow[col]))
+		}
+	}
+	if math.IsInf(maxRatio, 1) {
+		return -1
+	}
+
+	// Second pass: choose the largest pivot element among the columns within that step.
+	entering, largestAlpha := -1, 0.0
+	for col := range state.Status {
+		if !eligible(col) {
+			continue
+		}
+		if math.Abs(state.D[col])/math.Abs(alphaRow[col]) <= maxRatio && math.Abs(alphaRow[col]) > largestAlpha {
+			entering, largestAlpha = col, math.Abs(alphaRow[col])
+		}
+	}
+
+	return entering
+}
+
+/*
+BlockedByArtificial
+Description:
+
+	Determines whether or not a column sitting at an artificial bound would have been able to
+	enter the basis if its artificial bound was not present.
+*/
+func (state *dualSimplexState) BlockedByArtificial(alphaRow []float64, direction, pivotTol float64) bool {
+	for col, isArtificial := range state.Artificial {
+		alpha := direction * alphaRow[col]
+		if !isArtificial || math.Abs(alpha) <= pivotTol {
+			continue
+		}
+		if state.Status[col] == optim.BasisStatus_AT_UPPER && alpha > 0 {
+			return true
+		}
+		if state.Status[col] == optim.BasisStatus_AT_LOWER && alpha < 0 {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+EnlargeArtificialBounds
+Description:
+
+	Multiplies the magnitude of the artificial bounds by 1000 and updates the values of the
+	columns that sit at them.
+*/
+func (state *dualSimplexState) EnlargeArtificialBounds() {
+	state.ArtBound *= 1000
+	for col, isArtificial := range state.Artificial {
+		if !isArtificial {
+			continue
+		}
+		if state.Status[col] == optim.BasisStatus_AT_LOWER {
+			state.Value[col] = state.artificialLower(col)
+		} else {
+			state.Value[col] = state.artificialUpper(col)
+		}
+	}
+	state.ComputeBasicValues()
+}
+
+/*
+Pivot
+Description:
+
+	Replaces the basic column in position leavingPos (which moves to target) with the column
+	entering, and updates the values, reduced costs and basis inverse.
+*/
+func (state *dualSimplexState) Pivot(leavingPos, entering int, target float64, alphaRow []float64) {
+	// Constants
+	leaving := state.Basic[leavingPos]
+	alphaCol := state.basisSolve(state.Cols[entering])
+	pivot := alphaCol[leavingPos]
+
+	// Update values
+	deltaEntering := -(target - state.Value[leaving]) / pivot
+	for pos, col := range state.Basic {
+		state.Value[col] -= alphaCol[pos] * deltaEntering
+	}
+	state.Value[entering] += deltaEntering
+	state.Value[leaving] = target
+
+	// Update reduced costs
+	theta := state.D[entering] / alphaRow[entering]
+	for col, status := range state.Status {
+		if status != optim.BasisStatus_BASIC {
+			state.D[col] -= theta * alphaRow[col]
+		}
+	}
+	state.D[leaving] = -theta
+	state.D[entering] = 0.0
+
+	// Update basis
+	state.swap(leavingPos, entering, alphaCol)
+	if target == state.Lower[leaving] {
+		state.Status[leaving] = optim.BasisStatus_AT_LOWER
+	} else {
+		state.Status[leaving] = optim.BasisStatus_AT_UPPER
+	}
+}
+
+/*
+swap
+Description:
+
+	Updates the basis header and the basis inverse when column entering (with B^{-1} column
+	alphaCol) replaces the basic column in position pos.
+*/
+func (state *dualSimplexState) swap(pos, entering int, alphaCol []float64) {
+	// Constants
+	pivot := alphaCol[pos]
+
+	// Update the inverse with a Gauss-Jordan step
+	pivotRow := state.BInv[pos]
+	for k := range pivotRow {
+		pivotRow[k] /= pivot
+	}
+	for i := range state.BInv {
+		if i == pos || alphaCol[i] == 0 {
+			continue
+		}
+		for k := range state.BInv[i] {
+			state.BInv[i][k] -= alphaCol[i] * pivotRow[k]
+		}
+	}
+
+	state.Status[entering] = optim.BasisStatus_BASIC
+	state.Artificial[entering] = false
+	state.Basic[pos] = entering
+}
+
+func (state *dualSimplexState) basisSolve(v []float64) []float64 {
+	out := make([]float64, state.NumRows)
+	for i := range out {
+		out[i] = dot(state.BInv[i], v)
+	}
+	return out
+}
+
+/*
+FinishArtificial
+Description:
+
+	Handles the columns that sit at an artificial bound once the problem with artificial bounds
+	has been solved. Columns with a nonzero reduced cost indicate that the objective keeps
+	improving past the artificial bound, so the bounds are enlarged (or the problem is declared
+	unbounded). Columns with a zero reduced cost are moved back towards zero with primal steps
+	that do not change the objective. Returns true when the solve is complete.
+*/
+func (state *dualSimplexState) FinishArtificial(maxArtBound, dualTol, pivotTol float64) (bool, optim.OptimizationStatus) {
+	// Check for columns whose reduced costs push them past their artificial bounds
+	for col, isArtificial := range state.Artificial {
+		if isArtificial && math.Abs(state.D[col]) > dualTol {
+			if state.ArtBound >= maxArtBound {
+				return true, optim.OptimizationStatus_UNBOUNDED
+			}
+			state.EnlargeArtificialBounds()
+			return false, optim.OptimizationStatus_INPROGRESS
+		}
+	}
+
+	// Move the remaining columns off of their artificial bounds
+	for col, isArtificial := range state.Artificial {
+		if isArtificial {
+			state.moveOffArtificial(col, pivotTol)
+		}
+	}
+
+	return true, optim.OptimizationStatus_OPTIMAL
+}
+
+/*
+moveOffArtificial
+Description:
+
+	Moves the nonbasic column col (which has a zero reduced cost) from its artificial bound
+	towards zero (or the nearest of its real bounds). If a basic column reaches one of its bounds
+	first, then col enters the basis in its place.
+*/
+func (state *dualSimplexState) moveOffArtificial(col int, pivotTol float64) {
+	// Constants
+	target := math.Min(math.Max(0.0, state.Lower[col]), state.Upper[col])
+	step := target - state.Value[col]
+	direction := math.Copysign(1.0, step)
+	alphaCol := state.basisSolve(state.Cols[col])
+
+	// Ratio test over the basic columns
+	maxStep, blockingPos, blockingBound := math.Abs(step), -1, 0.0
+	for pos, basicCol := range state.Basic {
+		rate := -alphaCol[pos] * direction // Change of the basic column per unit step
+		if math.Abs(rate) <= pivotTol {
+			continue
+		}
+		bound := state.Upper[basicCol]
+		if rate < 0 {
+			bound = state.Lower[basicCol]
+		}
+		if math.IsInf(bound, 0) {
+			continue
+		}
+		if limit := math.Max((bound-state.Value[basicCol])/rate, 0.0); limit < maxStep {
+			maxStep, blockingPos, blockingBound = limit, pos, bound
+		}
+	}
+
+	// Take the step
+	delta := direction * maxStep
+	for pos, basicCol := range state.Basic {
+		state.Value[basicCol] -= alphaCol[pos] * delta
+	}
+	state.Value[col] += delta
+	state.Artificial[col] = false
+
+	if blockingPos < 0 {
+		switch state.Value[col] {
+		case state.Lower[col]:
+			state.Status[col] = optim.BasisStatus_AT_LOWER
+		case state.Upper[col]:
+			state.Status[col] = optim.BasisStatus_AT_UPPER
+		default:
+			state.Status[col] = optim.BasisStatus_SUPERBASIC
+		}
+		return
+	}
+
+	// The blocking column leaves the basis at its bound.
+	leaving := state.Basic[blockingPos]
+	alphaRow := state.PivotRow(blockingPos)
+	theta := state.D[col] / alphaCol[blockingPos]
+	for other, status := range state.Status {
+		if status != optim.BasisStatus_BASIC {
+			state.D[other] -= theta * alphaRow[other]
+		}
+	}
+	state.D[leaving] = -theta
+	state.D[col] = 0.0
+
+	state.swap(blockingPos, col, alphaCol)
+	state.Value[leaving] = blockingBound
+	if blockingBound == state.Lower[leaving] {
+		state.Status[leaving] = optim.BasisStatus_AT_LOWER
+	} else {
+		state.Status[leaving] = optim.BasisStatus_AT_UPPER
+	}
+}
@@ -0,0 +1,694 @@
+package solvers
+
+/*
+execsolver.go
+Description:
+	Defines a solver which runs an external command-line solver (e.g. CBC, HiGHS or GLPK). The
+	model is written to a temporary LP or MPS file, the executable is run with a templated list
+	of arguments and the solution file that it writes is parsed back into an optim.Solution.
+*/
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/kwesiRutledge/goop2/optim"
+)
+
+// Type Definitions
+// ================
+
+/*
+ExecFileFormat
+Description:
+
+	The format of the model file given to the external solver.
+*/
+type ExecFileFormat string
+
+const (
+	ExecFileFormat_LP      ExecFileFormat = "lp"
+	ExecFileFormat_MPS     ExecFileFormat = "mps"
+	ExecFileFormat_FREEMPS ExecFileFormat = "freemps"
+)
+
+/*
+ExecArguments
+Description:
+
+	The values available to the argument templates of an ExecSolver. TimeLimit is in seconds and is
+	zero when no time limit was set.
+*/
+type ExecArguments struct {
+	ModelFile    string
+	SolutionFile string
+	TimeLimit    float64
+	ShowLog      bool
+}
+
+/*
+ExecSolutionParser
+Description:
+
+	Reads the solution file written by an external solver. vars contains the variables of the
+	model in the order in which they were written to the model file.
+*/
+type ExecSolutionParser func(r io.Reader, vars []optim.Variable) (optim.Solution, error)
+
+/*
+ExecSolver
+Description:
+
+	A solver which runs the executable Command with the arguments Args. Each argument is a
+	text/template which is executed with an ExecArguments value; arguments which are empty after
+	executing their template are dropped, so that optional arguments can be written as e.g.
+		{{if .TimeLimit}}--time_limit={{.TimeLimit}}{{end}}
+	The model is written in the format FileFormat and the solution file is read with
+	ParseSolution. The output of the last run is saved in Output.
+*/
+type ExecSolver struct {
+	Command       string
+	Args          []string
+	FileFormat    ExecFileFormat
+	ParseSolution ExecSolutionParser
+	WorkDir       string // Directory for the temporary files (os.TempDir() if empty)
+	KeepFiles     bool   // Whether or not to keep the temporary files after Optimize returns
+	Output        []byte
+
+	model     *optim.Model
+	objective *optim.Objective
+	showLog   bool
+	timeLimit float64
+}
+
+// Functions
+// =========
+
+/*
+NewExecSolver
+Description:
+
+	Creates a new ExecSolver which runs command with the given argument templates.
+*/
+func NewExecSolver(command string, args []string, format ExecFileFormat, parser ExecSolutionParser) *ExecSolver {
+	return &ExecSolver{
+		Command:       command,
+		Args:          args,
+		FileFormat:    format,
+		ParseSolution: parser,
+		model:         optim.NewModel(),
+	}
+}
+
+/*
+NewCBCSolver
+Description:
+
+	Creates an ExecSolver which runs the COIN-OR CBC executable at path.
+*/
+func NewCBCSolver(path string) *ExecSolver {
+	return NewExecSolver(
+		path,
+		[]string{
+			"{{.ModelFile}}",
+			"{{if .TimeLimit}}-sec{{end}}", "{{if .TimeLimit}}{{.TimeLimit}}{{end}}",
+			"-solve", "-solu", "{{.SolutionFile}}",
+		},
+		ExecFileFormat_MPS,
+		ParseCBCSolution,
+	)
+}
+
+/*
+NewHiGHSSolver
+Description:
+
+	Creates an ExecSolver which runs the HiGHS executable at path.
+*/
+func NewHiGHSSolver(path string) *ExecSolver {
+	return NewExecSolver(
+		path,
+		[]string{
+			"--model_file", "{{.ModelFile}}",
+			"--solution_file", "{{.SolutionFile}}",
+			"{{if .TimeLimit}}--time_limit={{.TimeLimit}}{{end}}",
+		},
+		ExecFileFormat_FREEMPS,
+		ParseHiGHSSolution,
+	)
+}
+
+/*
+NewGLPKSolver
+Description:
+
+	Creates an ExecSolver which runs the GLPK executable glpsol at path.
+*/
+func NewGLPKSolver(path string) *ExecSolver {
+	return NewExecSolver(
+		path,
+		[]string{
+			"--freemps", "{{.ModelFile}}",
+			"-w", "{{.SolutionFile}}",
+			"{{if .TimeLimit}}--tmlim{{end}}", "{{if .TimeLimit}}{{printf \"%.0f\" .TimeLimit}}{{end}}",
+		},
+		ExecFileFormat_FREEMPS,
+		ParseGLPKSolution,
+	)
+}
+
+/*
+ShowLog
+Description:
+
+	Decides whether or not to print the output of the external solver.
+*/
+func (es *ExecSolver) ShowLog(tf bool) error {
+	es.showLog = tf
+	return nil
+}
+
+/*
+SetTimeLimit
+Description:
+
+	Saves the time limit (in seconds), which is given to the argument templates.
+*/
+func (es *ExecSolver) SetTimeLimit(limitInS float64) error {
+	if limitInS < 0 {
+		return fmt.Errorf("The time limit must be nonnegative; received %v", limitInS)
+	}
+	es.timeLimit = limitInS
+	return nil
+}
+
+/*
+AddVariable
+Description:
+
+	Adds a single variable to the model written for the external solver.
+*/
+func (es *ExecSolver) AddVariable(varIn optim.Variable) error {
+	es.ensureModel()
+	es.model.Variables = append(es.model.Variables, varIn)
+	return nil
+}
+
+/*
+AddVariables
+Description:
+
+	Adds a set of variables to the model written for the external solver.
+*/
+func (es *ExecSolver) AddVariables(varSliceIn []optim.Variable) error {
+	es.ensureModel()
+	es.model.Variables = append(es.model.Variables, varSliceIn...)
+	return nil
+}
+
+/*
+AddConstraint
+Description:
+
+	Adds a single scalar or vector constraint to the model written for the external solver.
+*/
+func (es *ExecSolver) AddConstraint(constrIn optim.Constraint) error {
+	// Input Checking
+	if !optim.IsConstraint(constrIn) {
+		return fmt.Errorf("The input to AddConstraint is not recognized as a constraint!")
+	}
+
+	// Algorithm
+	es.ensureModel()
+	_, err := es.model.AddConstr(constrIn)
+	return err
+}
+
+/*
+SetObjective
+Description:
+
+	Sets the objective of the model written for the external solver.
+*/
+func (es *ExecSolver) SetObjective(objIn optim.Objective) error {
+	es.ensureModel()
+	if err := es.model.SetObjective(objIn.ScalarExpression, objIn.Sense); err != nil {
+		return err
+	}
+	es.objective = &objIn
+	return nil
+}
+
+/*
+ensureModel
+Description:
+
+	Creates the model if the solver was not created with NewExecSolver.
+*/
+func (es *ExecSolver) ensureModel() {
+	if es.model == nil {
+		es.model = optim.NewModel()
+	}
+}
+
+/*
+Optimize
+Description:
+
+	Writes the model to a temporary file, runs the external solver and reads its solution file.
+	An error is returned if the solver can not be run, exits with an error or does not write a
+	readable solution file.
+*/
+func (es *ExecSolver) Optimize() (optim.Solution, error) {
+	// Input Checking
+	es.ensureModel()
+	if es.ParseSolution == nil {
+		return optim.Solution{}, fmt.Errorf("The ExecSolver has no ParseSolution function.")
+	}
+
+	// Write the model
+	dir, err := os.MkdirTemp(es.WorkDir, "goop2-exec-")
+	if err != nil {
+		return optim.Solution{}, fmt.Errorf("There was an issue creating a temporary directory: %v", err)
+	}
+	if !es.KeepFiles {
+		defer os.RemoveAll(dir)
+	}
+
+	args := ExecArguments{
+		ModelFile:    filepath.Join(dir, "model."+es.fileExtension()),
+		SolutionFile: filepath.Join(dir, "model.sol"),
+		TimeLimit:    es.timeLimit,
+		ShowLog:      es.showLog,
+	}
+	if err := es.writeModel(args.ModelFile); err != nil {
+		return optim.Solution{}, err
+	}
+
+	// Run the solver
+	cmdArgs, err := es.expandArgs(args)
+	if err != nil {
+		return optim.Solution{}, err
+	}
+
+	cmd := exec.Command(es.Command, cmdArgs...)
+	cmd.Dir = dir
+	es.Output, err = cmd.CombinedOutput()
+	if es.showLog {
+		log.Printf("ExecSolver: %v %v\n%s", es.Command, strings.Join(cmdArgs, " "), es.Output)
+	}
+	if err != nil {
+		return optim.Solution{}, fmt.Errorf("There was an issue running %v: %v\n%s", es.Command, err, es.Output)
+	}
+
+	// Read the solution
+	solFile, err := os.Open(args.SolutionFile)
+	if err != nil {
+		return optim.Solution{}, fmt.Errorf("There was an issue opening the solution file: %v", err)
+	}
+	defer solFile.Close()
+
+	sol, err := es.ParseSolution(solFile, es.model.Variables)
+	if err != nil {
+		return sol, fmt.Errorf("There was an issue reading the solution file: %v", err)
+	}
+
+	// Compute the objective from the values, so that constant terms are always included.
+	if sol.Values != nil {
+		if qp, err := newQuadraticProgram(es.model.Variables, nil, es.objective); err == nil {
+			x := make([]float64, len(qp.Variables))
+			for varIndex, tempVar := range qp.Variables {
+				x[varIndex] = sol.Values[tempVar.ID]
+			}
+			sol.Objective = qp.ObjectiveValue(x)
+		}
+	}
+
+	return sol, nil
+}
+
+/*
+fileExtension
+Description:
+
+	Returns the extension of the model file.
+*/
+func (es *ExecSolver) fileExtension() string {
+	if es.FileFormat == ExecFileFormat_LP {
+		return "lp"
+	}
+	return "mps"
+}
+
+/*
+writeModel
+Description:
+
+	Writes the model to the file at path in the solver's format.
+*/
+func (es *ExecSolver) writeModel(path string) error {
+	modelFile, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("There was an issue creating the model file: %v", err)
+	}
+	defer modelFile.Close()
+
+	switch es.FileFormat {
+	case ExecFileFormat_LP:
+		err = es.model.WriteLP(modelFile)
+	case ExecFileFormat_MPS, ExecFileFormat_FREEMPS:
+		err = es.model.WriteMPS(modelFile, optim.MPSOptions{Free: es.FileFormat == ExecFileFormat_FREEMPS})
+	default:
+		return fmt.Errorf("Unexpected file format %q", es.FileFormat)
+	}
+	if err != nil {
+		return fmt.Errorf("There was an issue writing the model file: %v", err)
+	}
+	return modelFile.Close()
+}
+
+/*
+expandArgs
+Description:
+
+	Executes the argument templates, dropping the arguments which are empty.
+*/
+func (es *ExecSolver) expandArgs(args ExecArguments) ([]string, error) {
+	var cmdArgs []string
+	for argIndex, argTemplate := range es.Args {
+		tmpl, err := template.New(fmt.Sprintf("arg%v", argIndex)).Parse(argTemplate)
+		if err != nil {
+			return nil, fmt.Errorf("There was an issue parsing argument %v (%q): %v", argIndex, argTemplate, err)
+		}
+
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, args); err != nil {
+			return nil, fmt.Errorf("There was an issue executing argument %v (%q): %v", argIndex, argTemplate, err)
+		}
+		if buf.Len() > 0 {
+			cmdArgs = append(cmdArgs, buf.String())
+		}
+	}
+	return cmdArgs, nil
+}
+
+/*
+DeleteSolver
+Description:
+
+	Clears the model.
+*/
+func (es *ExecSolver) DeleteSolver() error {
+	es.model = optim.NewModel()
+	es.objective = nil
+	return nil
+}
+
+/*
+execVariableIDs
+Description:
+
+	Maps the names that Model.WriteLP and Model.WriteMPS give to the variables (their names, or
+	x<ID> for unnamed variables) to their IDs.
+*/
+func execVariableIDs(vars []optim.Variable) map[string]uint64 {
+	ids := make(map[string]uint64, len(vars))
+	for _, tempVar := range vars {
+		ids[tempVar.DisplayName()] = tempVar.ID
+	}
+	return ids
+}
+
+/*
+ParseCBCSolution
+Description:
+
+	Reads a solution file written by CBC's solu command. The first line holds the status and the
+	objective, e.g.
+		Optimal - objective value 2.8
+	and each following line holds the index, name, value and reduced cost of a column. Columns
+	which are not listed are zero.
+*/
+func ParseCBCSolution(r io.Reader, vars []optim.Variable) (optim.Solution, error) {
+	// Constants
+	scanner := bufio.NewScanner(r)
+	ids := execVariableIDs(vars)
+
+	// Status line
+	if !scanner.Scan() {
+		return optim.Solution{}, fmt.Errorf("The CBC solution file is empty.")
+	}
+	statusLine := strings.TrimSpace(scanner.Text())
+	lowerStatus := strings.ToLower(statusLine)
+
+	var status optim.OptimizationStatus = optim.OptimizationStatus_NUMERIC
+	switch {
+	case strings.HasPrefix(lowerStatus, "optimal"):
+		status = optim.OptimizationStatus_OPTIMAL
+	case strings.Contains(lowerStatus, "infeasible"):
+		status = optim.OptimizationStatus_INFEASIBLE
+	case strings.Contains(lowerStatus, "unbounded"):
+		status = optim.OptimizationStatus_UNBOUNDED
+	case strings.Contains(lowerStatus, "stopped on time"):
+		status = optim.OptimizationStatus_TIME_LIMIT
+	case strings.Contains(lowerStatus, "stopped on iterations"):
+		status = optim.OptimizationStatus_ITERATION_LIMIT
+	case strings.Contains(lowerStatus, "stopped"):
+		status = optim.OptimizationStatus_INTERRUPTED
+	}
+
+	sol := optim.Solution{Status: status, Values: make(map[uint64]float64)}
+	if index := strings.LastIndex(lowerStatus, "objective value"); index >= 0 {
+		objText := strings.TrimSpace(statusLine[index+len("objective value"):])
+		if objective, err := strconv.ParseFloat(objText, 64); err == nil {
+			sol.Objective = objective
+		}
+	}
+
+	// Values
+	for _, tempVar := range vars {
+		sol.Values[tempVar.ID] = 0.0
+	}
+	for lineNumber := 2; scanner.Scan(); lineNumber++ {
+		fields := strings.Fields(strings.TrimPrefix(strings.TrimSpace(scanner.Text()), "**"))
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) < 3 {
+			return sol, fmt.Errorf("Line %v of the CBC solution file has %v fields; expected at least 3", lineNumber, len(fields))
+		}
+
+		id, found := ids[fields[1]]
+		if !found {
+			return sol, fmt.Errorf("Line %v of the CBC solution file refers to the unknown column %q", lineNumber, fields[1])
+		}
+		value, err := strconv.ParseFloat(fields[2], 64)
+		if err != nil {
+			return sol, fmt.Errorf("Line %v of the CBC solution file has an invalid value %q", lineNumber, fields[2])
+		}
+		sol.Values[id] = value
+	}
+
+	return sol, scanner.Err()
+}
+
+/*
+ParseHiGHSSolution
+Description:
+
+	Reads a solution file written by HiGHS (with --solution_file). The file contains the model
+	status after the line "Model status", the objective on a line starting with "Objective" and
+	the values of the columns after the line "# Columns <n>".
+*/
+func ParseHiGHSSolution(r io.Reader, vars []optim.Variable) (optim.Solution, error) {
+	// Constants
+	scanner := bufio.NewScanner(r)
+	ids := execVariableIDs(vars)
+	sol := optim.Solution{Status: optim.OptimizationStatus_NUMERIC}
+
+	// Algorithm
+	foundStatus := false
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "Model status" && !foundStatus:
+			if !scanner.Scan() {
+				return sol, fmt.Errorf("The HiGHS solution file ends after \"Model status\".")
+			}
+			lineNumber++
+			sol.Status = highsStatus(strings.TrimSpace(scanner.Text()))
+			foundStatus = true
+		case strings.HasPrefix(line, "Objective") && sol.Values == nil:
+			fields := strings.Fields(line)
+			if len(fields) >= 2 {
+				if objective, err := strconv.ParseFloat(fields[len(fields)-1], 64); err == nil {
+					sol.Objective = objective
+				}
+			}
+		case strings.HasPrefix(line, "# Columns") && sol.Values == nil:
+			fields := strings.Fields(line)
+			numColumns, err := strconv.Atoi(fields[len(fields)-1])
+			if err != nil {
+				return sol, fmt.Errorf("Line %v of the HiGHS solution file has an invalid number of columns", lineNumber)
+			}
+
+			sol.Values = make(map[uint64]float64)
+			for colIndex := 0; colIndex < numColumns; colIndex++ {
+				if !scanner.Scan() {
+					return sol, fmt.Errorf("The HiGHS solution file ends before the values of all %v columns.", numColumns)
+				}
+				lineNumber++
+				valueFields := strings.Fields(scanner.Text())
+				if len(valueFields) < 2 {
+					return sol, fmt.Errorf("Line %v of the HiGHS solution file has %v fields; expected 2", lineNumber, len(valueFields))
+				}
+
+				id, found := ids[valueFields[0]]
+				if !found {
+					return sol, fmt.Errorf("Line %v of the HiGHS solution file refers to the unknown column %q", lineNumber, valueFields[0])
+				}
+				value, err := strconv.ParseFloat(valueFields[1], 64)
+				if err != nil {
+					return sol, fmt.Errorf("Line %v of the HiGHS solution file has an invalid value %q", lineNumber, valueFields[1])
+				}
+				sol.Values[id] = value
+			}
+		}
+	}
+
+	if !foundStatus {
+		return sol, fmt.Errorf("The HiGHS solution file does not contain a model status.")
+	}
+	return sol, scanner.Err()
+}
+
+/*
+highsStatus
+Description:
+
+	Converts a HiGHS model status to an OptimizationStatus.
+*/
+func highsStatus(text string) optim.OptimizationStatus {
+	switch strings.ToLower(text) {
+	case "optimal":
+		return optim.OptimizationStatus_OPTIMAL
+	case "infeasible":
+		return optim.OptimizationStatus_INFEASIBLE
+	case "unbounded":
+		return optim.OptimizationStatus_UNBOUNDED
+	case "primal infeasible or unbounded":
+		return optim.OptimizationStatus_INF_OR_UNBD
+	case "time limit reached":
+		return optim.OptimizationStatus_TIME_LIMIT
+	case "iteration limit reached":
+		return optim.OptimizationStatus_ITERATION_LIMIT
+	case "solution limit reached":
+		return optim.OptimizationStatus_SOLUTION_LIMIT
+	case "interrupted by user":
+		return optim.OptimizationStatus_INTERRUPTED
+	}
+	return optim.OptimizationStatus_NUMERIC
+}
+
+/*
+ParseGLPKSolution
+Description:
+
+	Reads a solution file written by glpsol with -w (GLPK's raw format). The line
+		s bas <rows> <cols> <primal status> <dual status> <objective>
+	(or s mip <rows> <cols> <status> <objective> for MIPs) holds the status and the line
+		j <col> ... <value> ...
+	holds the value of the column with the given (1-based) index. Columns are numbered in the
+	order in which they appear in the model file, which is the order of vars.
+*/
+func ParseGLPKSolution(r io.Reader, vars []optim.Variable) (optim.Solution, error) {
+	// Constants
+	scanner := bufio.NewScanner(r)
+	sol := optim.Solution{Status: optim.OptimizationStatus_NUMERIC}
+	isMIP, foundStatus := false, false
+
+	// Algorithm
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch fields[0] {
+		case "s":
+			if len(fields) < 6 {
+				return sol, fmt.Errorf("Line %v of the GLPK solution file has too few fields", lineNumber)
+			}
+			isMIP = fields[1] == "mip"
+			if isMIP {
+				sol.Status = glpkStatus(fields[4], "f")
+			} else if len(fields) >= 7 {
+				sol.Status = glpkStatus(fields[4], fields[5])
+			}
+			if objective, err := strconv.ParseFloat(fields[len(fields)-1], 64); err == nil {
+				sol.Objective = objective
+			}
+			sol.Values = make(map[uint64]float64)
+			foundStatus = true
+		case "j":
+			if !foundStatus {
+				return sol, fmt.Errorf("Line %v of the GLPK solution file comes before the status line", lineNumber)
+			}
+
+			// j <col> <value> for MIPs and j <col> <status> <value> <dual> otherwise
+			valueIndex := 3
+			if isMIP {
+				valueIndex = 2
+			}
+			if len(fields) <= valueIndex {
+				return sol, fmt.Errorf("Line %v of the GLPK solution file has too few fields", lineNumber)
+			}
+
+			colIndex, err := strconv.Atoi(fields[1])
+			if err != nil || colIndex < 1 || colIndex > len(vars) {
+				return sol, fmt.Errorf("Line %v of the GLPK solution file has an invalid column index %q", lineNumber, fields[1])
+			}
+			value, err := strconv.ParseFloat(fields[valueIndex], 64)
+			if err != nil {
+				return sol, fmt.Errorf("Line %v of the GLPK solution file has an invalid value %q", lineNumber, fields[valueIndex])
+			}
+			sol.Values[vars[colIndex-1].ID] = value
+		}
+	}
+
+	if !foundStatus {
+		return sol, fmt.Errorf("The GLPK solution file does not contain a status line.")
+	}
+	return sol, scanner.Err()
+}
+
+/*
+glpkStatus
+Description:
+
+	Converts GLPK's primal and dual solution statuses to an OptimizationStatus. For MIPs the
+	primal status is o (optimal), f (feasible), n (no feasible solution) or u (undefined); for
+	LPs both statuses are f (feasible), i (infeasible), n (no feasible solution) or u (undefined).
+*/
+func glpkStatus(primal, dual string) optim.OptimizationStatus {
+	switch {
+	case primal == "o", primal == "f" && dual == "f":
+		return optim.OptimizationStatus_OPTIMAL
+	case primal == "n":
+		return optim.OptimizationStatus_INFEASIBLE
+	case primal == "f" && dual == "n":
+		return optim.OptimizationStatus_UNBOUNDED
+	case primal == "f":
+		return optim.OptimizationStatus_SUBOPTIMAL
+	}
+	return optim.OptimizationStatus_NUMERIC
+}
@@ -0,0 +1,340 @@
+package solvers
+
+/*
+gonumlpsolver.go
+Description:
+	Defines a pure-Go solver for linear programs that is built on the simplex method in gonum's
+	optimize/convex/lp package. It does not require a license of any kind, so it can be used in
+	places (like CI) where Gurobi is not available.
+*/
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+
+	"github.com/kwesiRutledge/goop2/optim"
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/optimize/convex/lp"
+)
+
+// Type Definition
+
+type GonumLPSolver struct {
+	problemStore
+
+	Tolerance float64 // Tolerance given to gonum's simplex method
+	showLog   bool
+	timeLimit float64
+}
+
+// Functions
+
+/*
+NewGonumLPSolver
+Description:
+
+	Create a new, empty GonumLPSolver object.
+*/
+func NewGonumLPSolver() *GonumLPSolver {
+	return &GonumLPSolver{
+		problemStore: newProblemStore("GonumLPSolver", linearObjective, optim.Continuous),
+		Tolerance:    1e-10,
+	}
+}
+
+/*
+ShowLog
+Description:
+
+	Decides whether or not to print a short summary of each solve to the terminal.
+*/
+func (gls *GonumLPSolver) ShowLog(tf bool) error {
+	gls.showLog = tf
+	return nil
+}
+
+/*
+SetTimeLimit
+Description:
+
+	Saves the time limit for the solver. gonum's simplex method can not be interrupted, so
+	this value is currently only recorded.
+
+Input:
+
+	limitInS = Value of time limit in seconds (float)
+*/
+func (gls *GonumLPSolver) SetTimeLimit(limitInS float64) error {
+	if limitInS < 0 {
+		return fmt.Errorf("The time limit must be nonnegative; received %v", limitInS)
+	}
+	gls.timeLimit = limitInS
+	return nil
+}
+
+/*
+Optimize
+Description:
+
+	Converts the stored problem into standard form and solves it with gonum's simplex method.
+	Infeasible and unbounded problems are reported through the status of the returned solution.
+*/
+func (gls *GonumLPSolver) Optimize() (optim.Solution, error) {
+	// Create linear program
+	linProg, err := newLinearProgram(gls.Variables, gls.Constraints, gls.Objective)
+	if err != nil {
+		return optim.Solution{}, fmt.Errorf("There was an issue creating the linear program: %v", err)
+	}
+
+	if !linProg.HasConsistentBounds() {
+		return optim.Solution{Status: optim.OptimizationStatus_INFEASIBLE}, nil
+	}
+
+	// Solve
+	status, x, err := solveWithGonumSimplex(linProg, gls.Tolerance)
+	if err != nil {
+		return optim.Solution{Status: status}, err
+	}
+
+	tempSolution := optim.Solution{Status: status}
+	if status == optim.OptimizationStatus_OPTIMAL {
+		tempSolution.Values = make(map[uint64]float64)
+		for varIndex, tempVar := range linProg.Variables {
+			tempSolution.Values[tempVar.ID] = x[varIndex]
+		}
+		tempSolution.Objective = linProg.ObjectiveValue(x)
+	}
+
+	if gls.showLog {
+		statusMessage, _ := status.ToMessage()
+		log.Printf(
+			"GonumLPSolver: %v variables, %v constraints. %v Objective = %v",
+			len(linProg.Variables), len(linProg.Rows), statusMessage, tempSolution.Objective,
+		)
+	}
+
+	return tempSolution, nil
+}
+
+/*
+solveWithGonumSimplex
+Description:
+
+	Solves the linear program linProg with gonum's simplex method and returns the status of the
+	solve along with the optimal point (in the variables of linProg) when one exists.
+
+	gonum's simplex requires a constraint matrix with full row rank and no zero rows or
+	columns, so those are removed from the standard form before calling it.
+*/
+func solveWithGonumSimplex(linProg linearProgram, tol float64) (optim.OptimizationStatus, []float64, error) {
+	// Constants
+	sf := linProg.ToStandardForm()
+	numCols := len(sf.C)
+
+	// Remove columns which do not appear in any row.
+	var (
+		keptCols      []int
+		unboundedCols bool
+	)
+	for j := 0; j < numCols; j++ {
+		isZero := true
+		for _, row := range sf.A {
+			if row[j] != 0 {
+				isZero = false
+				break
+			}
+		}
+		if !isZero {
+			keptCols = append(keptCols, j)
+		} else if sf.C[j] < 0 {
+			unboundedCols = true
+		}
+	}
+
+	// Remove redundant (and detect inconsistent) rows.
+	reducedA, reducedB, consistent := independentRows(sf.A, sf.B, keptCols, tol)
+	if !consistent {
+		return optim.OptimizationStatus_INFEASIBLE, nil, nil
+	}
+
+	xStd := make([]float64, numCols)
+	if len(reducedA) > 0 {
+		// Assemble the reduced problem
+		cReduced := make([]float64, len(keptCols))
+		for k, j := range keptCols {
+			cReduced[k] = sf.C[j]
+		}
+		aReduced := mat.NewDense(len(reducedA), len(keptCols), nil)
+		for i, row := range reducedA {
+			aReduced.SetRow(i, row)
+		}
+
+		_, xReduced, err := lp.Simplex(cReduced, aReduced, reducedB, tol, nil)
+		if errors.Is(err, lp.ErrInfeasible) {
+			// gonum's phase 1 reports some feasible problems (e.g. with equality rows whose
+			// right hand side is zero) as infeasible, so infeasibility is confirmed separately.
+			xReduced, err = simplexFromArtificialBasis(cReduced, reducedA, reducedB, tol)
+		}
+		switch {
+		case errors.Is(err, lp.ErrInfeasible):
+			return optim.OptimizationStatus_INFEASIBLE, nil, nil
+		case errors.Is(err, lp.ErrUnbounded):
+			return optim.OptimizationStatus_UNBOUNDED, nil, nil
+		case err != nil:
+			return optim.OptimizationStatus_NUMERIC, nil, fmt.Errorf("There was an issue running gonum's simplex method: %v", err)
+		}
+
+		for k, j := range keptCols {
+			xStd[j] = xReduced[k]
+		}
+	} else {
+		// With no rows, every kept column is unconstrained apart from its sign.
+		for _, j := range keptCols {
+			if sf.C[j] < 0 {
+				unboundedCols = true
+			}
+		}
+	}
+
+	if unboundedCols {
+		return optim.OptimizationStatus_UNBOUNDED, nil, nil
+	}
+
+	return optim.OptimizationStatus_OPTIMAL, sf.Recover(xStd), nil
+}
+
+/*
+simplexFromArtificialBasis
+Description:
+
+	Solves the standard form problem
+		minimize c' x  s.t.  A x = b, x >= 0
+	with gonum's simplex method started from a basis of artificial variables a, one for each row,
+	whose columns are sign(b_i) e_i so that x = 0, a = |b| is a basic feasible solution. Phase 1
+	minimizes the sum of the artificial variables; if it can not be driven to zero, the problem
+	is infeasible and lp.ErrInfeasible is returned. Otherwise, phase 2 minimizes
+	c' x + M 1' a with a large penalty M, starting from the same basis.
+*/
+func simplexFromArtificialBasis(c []float64, A [][]float64, b []float64, tol float64) ([]float64, error) {
+	// Constants
+	n, m := len(c), len(A)
+	feasTol := math.Max(tol, 1e-9) * (1 + infNorm(b))
+	penalty := 1e6 * (1 + infNorm(c))
+
+	withArtificials := mat.NewDense(m, n+m, nil)
+	artificialBasis := make([]int, m)
+	for i, row := range A {
+		for j, coeff := range row {
+			withArtificials.Set(i, j, coeff)
+		}
+		if b[i] < 0 {
+			withArtificials.Set(i, n+i, -1.0)
+		} else {
+			withArtificials.Set(i, n+i, 1.0)
+		}
+		artificialBasis[i] = n + i
+	}
+
+	// Phase 1
+	phase1Cost := make([]float64, n+m)
+	for i := 0; i < m; i++ {
+		phase1Cost[n+i] = 1.0
+	}
+	infeasibility, _, err := lp.Simplex(phase1Cost, withArtificials, b, tol, artificialBasis)
+	if err != nil {
+		return nil, err
+	}
+	if infeasibility > feasTol {
+		return nil, lp.ErrInfeasible
+	}
+
+	// Phase 2
+	phase2Cost := append(append([]float64{}, c...), phase1Cost[n:]...)
+	for i := 0; i < m; i++ {
+		phase2Cost[n+i] = penalty
+	}
+	_, x, err := lp.Simplex(phase2Cost, withArtificials, b, tol, artificialBasis)
+	if err != nil {
+		return nil, err
+	}
+	if infNorm(x[n:]) > feasTol {
+		return nil, fmt.Errorf("the artificial variables of the feasible problem could not be driven to zero")
+	}
+
+	return x[:n], nil
+}
+
+/*
+independentRows
+Description:
+
+	Performs Gaussian elimination on the rows of [A b] (restricted to the columns in cols) to
+	find a linearly independent subset of them. If a row of A is a combination of the other rows,
+	but its entry in b is not, then the system A x = b has no solution and consistent is false.
+*/
+func independentRows(A [][]float64, b []float64, cols []int, tol float64) (rowsOut [][]float64, bOut []float64, consistent bool) {
+	// Constants
+	numCols := len(cols)
+	elimTol := math.Max(tol, 1e-9)
+
+	// Algorithm
+	var (
+		reduced   [][]float64 // Rows in echelon form
+		reducedB  []float64
+		pivotCols []int
+	)
+	for i, row := range A {
+		// Restrict row to the columns in cols
+		restricted := make([]float64, numCols)
+		for k, j := range cols {
+			restricted[k] = row[j]
+		}
+		work := make([]float64, numCols)
+		copy(work, restricted)
+		workB := b[i]
+
+		// Eliminate using previous pivots
+		for p, pivotRow := range reduced {
+			factor := work[pivotCols[p]]
+			if factor == 0 {
+				continue
+			}
+			for k := range work {
+				work[k] -= factor * pivotRow[k]
+			}
+			workB -= factor * reducedB[p]
+		}
+
+		// Find the largest remaining entry
+		pivot, pivotValue := -1, 0.0
+		scale := 0.0
+		for k := range restricted {
+			scale = math.Max(scale, math.Abs(restricted[k]))
+			if math.Abs(work[k]) > math.Abs(pivotValue) {
+				pivot, pivotValue = k, work[k]
+			}
+		}
+
+		if pivot == -1 || math.Abs(pivotValue) <= elimTol*math.Max(scale, 1.0) {
+			// This row is dependent on the previous ones.
+			if math.Abs(workB) > elimTol*math.Max(math.Abs(b[i]), 1.0) {
+				return nil, nil, false
+			}
+			continue
+		}
+
+		for k := range work {
+			work[k] /= pivotValue
+		}
+		reduced = append(reduced, work)
+		reducedB = append(reducedB, workB/pivotValue)
+		pivotCols = append(pivotCols, pivot)
+
+		rowsOut = append(rowsOut, restricted)
+		bOut = append(bOut, b[i])
+	}
+
+	return rowsOut, bOut, true
+}
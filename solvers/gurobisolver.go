@@ -203,9 +203,7 @@ func (gs *GurobiSolver) AddVariable(varIn optim.Variable) error {
 	}
 
 	// Add Variable to Current Model
-	_, err = gs.CurrentModel.AddVar(int8(vType), 0.0, varIn.Lower, varIn.Upper, fmt.Sprintf("x%v", varIn.ID), []*gurobi.Constr{}, []float64{})
-
-	fmt.Printf("%v: L=%v, U=%v, name=%v\n", int8(vType), varIn.Lower, varIn.Upper, fmt.Sprintf("x%v", varIn.ID))
+	_, err = gs.CurrentModel.AddVar(int8(vType), 0.0, varIn.Lower, varIn.Upper, varIn.DisplayName(), []*gurobi.Constr{}, []float64{})
 
 	// Update Map from GoopID to Gurobi Idx
 	gs.GoopIDToGurobiIndexMap[varIn.ID] = int32(len(gs.CurrentModel.Variables) - 1)
@@ -253,26 +251,33 @@ func (gs *GurobiSolver) AddConstraint(constrIn optim.Constraint) error {
 	case optim.ScalarConstraint:
 		// Cast
 		constrAsSC, _ := constrIn.(optim.ScalarConstraint)
-		// Identify the variables in the left hand side of this constraint
-		var tempVarSlice []*gurobi.Var
-		for _, tempGoopID := range constrAsSC.LeftHandSide.IDs() {
-			tempGurobiIdx := gs.GoopIDToGurobiIndexMap[tempGoopID]
-
-			// Locate the gurobi variable in the current model that has matching ID
-			for _, tempGurobiVar := range gs.CurrentModel.Variables {
-				if tempGurobiIdx == tempGurobiVar.Index {
-					tempVarSlice = append(tempVarSlice, &tempGurobiVar)
-				}
-			}
+		// Collect the nonzero terms of the left hand side
+		lhsTerms, err := optim.SparseTermsOf(constrAsSC.LeftHandSide)
+		if err != nil {
+			return fmt.Errorf("There was an issue reading the left hand side of the constraint: %v", err)
+		}
+		var (
+			tempVarSlice []*gurobi.Var
+			tempCoeffs   []float64
+		)
+		for _, term := range lhsTerms.Linear {
+			tempVarSlice = append(tempVarSlice, gs.gurobiVar(term.ID))
+			tempCoeffs = append(tempCoeffs, term.Coeff)
+		}
+
+		// Unnamed constraints are numbered
+		constrName := constrAsSC.Name
+		if constrName == "" {
+			constrName = fmt.Sprintf("goop Constraint #%v", len(gs.CurrentModel.Constraints))
 		}
 
 		// Call Gurobi library's AddConstr() function
-		_, err := gs.CurrentModel.AddConstr(
+		_, err = gs.CurrentModel.AddConstr(
 			tempVarSlice,
-			constrAsSC.LeftHandSide.Coeffs(),
+			tempCoeffs,
 			int8(constrAsSC.Sense),
-			constrAsSC.RightHandSide.Constant(),
-			fmt.Sprintf("goop Constraint #%v", len(gs.CurrentModel.Constraints)),
+			constrAsSC.RightHandSide.Constant()-lhsTerms.Constant,
+			constrName,
 		)
 		if err != nil {
 			return fmt.Errorf("There was an issue with adding the constraint to the gurobi model: %v", err)
@@ -292,28 +297,21 @@ Description:
 	This algorithm should set the objective based on the value of the expression provided as input to this function.
 */
 func (gs *GurobiSolver) SetObjective(objIn optim.Objective) error {
+	// Collect the nonzero terms of the objective
+	terms, err := optim.SparseTermsOf(objIn.ScalarExpression)
+	if err != nil {
+		return fmt.Errorf("Unexpected objective type given to gurobisolver's SetObjective(): %v", err)
+	}
 
-	objExpression := objIn.ScalarExpression
-
-	// Handle this differently for different types of expression inputs
-	switch objExpression.(type) {
-	case optim.ScalarLinearExpr:
+	// Linear objectives
+	if len(terms.Quadratic) == 0 {
 		gurobiLE := &gurobi.LinExpr{}
-		for varIndex, goopIndex := range objExpression.IDs() {
-			gurobiIndex := gs.GoopIDToGurobiIndexMap[goopIndex]
-
-			// Add each linear term to the expression.
-			tempGurobiVar := gurobi.Var{
-				Model: gs.CurrentModel,
-				Index: gurobiIndex,
-			}
-			gurobiLE = gurobiLE.AddTerm(&tempGurobiVar, objExpression.Coeffs()[varIndex])
+		for _, term := range terms.Linear {
+			gurobiLE = gurobiLE.AddTerm(gs.gurobiVar(term.ID), term.Coeff)
 		}
 
 		// Add a constant term to the expression
-		gurobiLE = gurobiLE.AddConstant(objExpression.Constant())
-
-		fmt.Println(gurobiLE)
+		gurobiLE = gurobiLE.AddConstant(terms.Constant)
 
 		// Add linear expression to the objective.
 		err := gs.CurrentModel.SetLinearObjective(gurobiLE, int32(objIn.Sense))
@@ -322,59 +320,38 @@ func (gs *GurobiSolver) SetObjective(objIn optim.Objective) error {
 		}
 
 		return nil
+	}
 
-	case optim.ScalarQuadraticExpression:
-		objExpressionAsQE := objExpression.(optim.ScalarQuadraticExpression)
-		gurobiQE := &gurobi.QuadExpr{}
-
-		// Create quadratic part of quadratic expression
-		for varIndex1, goopIndex1 := range objExpression.IDs() {
-			gurobiIndex1 := gs.GoopIDToGurobiIndexMap[goopIndex1]
-
-			for varIndex2, goopIndex2 := range objExpression.IDs() {
-				gurobiIndex2 := gs.GoopIDToGurobiIndexMap[goopIndex2]
-
-				// Add each linear term to the expression.
-				tempGurobiVar1 := gurobi.Var{
-					Model: gs.CurrentModel,
-					Index: gurobiIndex1,
-				}
-				tempGurobiVar2 := gurobi.Var{
-					Model: gs.CurrentModel,
-					Index: gurobiIndex2,
-				}
-
-				gurobiQE = gurobiQE.AddQTerm(&tempGurobiVar1, &tempGurobiVar2, objExpressionAsQE.Q.At(varIndex1, varIndex2))
-			}
-		}
-
-		// Create linear part of quadratic expression
-		for varIndex, goopIndex := range objExpression.IDs() {
-			gurobiIndex := gs.GoopIDToGurobiIndexMap[goopIndex]
-
-			// Add each linear term to the expression.
-			tempGurobiVar := gurobi.Var{
-				Model: gs.CurrentModel,
-				Index: gurobiIndex,
-			}
-			gurobiQE = gurobiQE.AddTerm(&tempGurobiVar, objExpressionAsQE.L.AtVec(varIndex))
-		}
+	// Quadratic objectives are given to gurobi one nonzero term at a time
+	gurobiQE := &gurobi.QuadExpr{}
+	for _, term := range terms.Quadratic {
+		gurobiQE = gurobiQE.AddQTerm(gs.gurobiVar(term.ID1), gs.gurobiVar(term.ID2), term.Coeff)
+	}
+	for _, term := range terms.Linear {
+		gurobiQE = gurobiQE.AddTerm(gs.gurobiVar(term.ID), term.Coeff)
+	}
 
-		// Create offset
-		gurobiQE = gurobiQE.AddConstant(objExpressionAsQE.C)
+	// Create offset
+	gurobiQE = gurobiQE.AddConstant(terms.Constant)
 
-		// Return
-		fmt.Println(gurobiQE)
+	err = gs.CurrentModel.SetQuadraticObjective(gurobiQE, int32(objIn.Sense))
+	if err != nil {
+		return fmt.Errorf("There was an issue setting the quadratic objective with SetQuadraticObjective(): %v", err)
+	}
 
-		err := gs.CurrentModel.SetQuadraticObjective(gurobiQE, int32(objIn.Sense))
-		if err != nil {
-			return fmt.Errorf("There was an issue setting the quadratic objective with SetQuadraticObjective(): %v", err)
-		}
+	return nil
+}
 
-		return nil
+/*
+gurobiVar
+Description:
 
-	default:
-		return fmt.Errorf("Unexpected objective type given to gurobisolver's SetObjective(): %T", objExpression)
+	Returns the gurobi variable which corresponds to the goop variable with ID goopID.
+*/
+func (gs *GurobiSolver) gurobiVar(goopID uint64) *gurobi.Var {
+	return &gurobi.Var{
+		Model: gs.CurrentModel,
+		Index: gs.GoopIDToGurobiIndexMap[goopID],
 	}
 }
 
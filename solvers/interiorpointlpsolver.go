@@ -0,0 +1,433 @@
+package solvers
+
+/*
+interiorpointlpsolver.go
+Description:
+	Defines a pure-Go interior point solver for linear programs. The solver applies Mehrotra's
+	predictor-corrector method to the homogeneous self-dual embedding of the standard form
+	problem, which lets it detect infeasible and unbounded problems without a separate phase:
+
+		Y. Ye, M. Todd and S. Mizuno. "An O(sqrt(n)L)-iteration homogeneous and self-dual linear
+		programming algorithm." Mathematics of Operations Research, 1994.
+*/
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"github.com/kwesiRutledge/goop2/optim"
+	"gonum.org/v1/gonum/mat"
+)
+
+// Type Definition
+
+type InteriorPointLPSolver struct {
+	problemStore
+
+	Tolerance     float64 // Relative tolerance on the residuals and duality gap
+	MaxIterations int
+	showLog       bool
+	timeLimit     float64
+}
+
+/*
+hsdeResult
+Description:
+
+	The outcome of running the interior point method on the homogeneous self-dual embedding of a
+	standard form problem. X is the (scaled) primal solution when Status is optimal.
+*/
+type hsdeResult struct {
+	Status         optim.OptimizationStatus
+	X              []float64
+	Iterations     int
+	PrimalResidual float64
+	DualResidual   float64
+}
+
+// Functions
+
+/*
+NewInteriorPointLPSolver
+Description:
+
+	Create a new, empty InteriorPointLPSolver object.
+*/
+func NewInteriorPointLPSolver() *InteriorPointLPSolver {
+	return &InteriorPointLPSolver{
+		problemStore:  newProblemStore("InteriorPointLPSolver", linearObjective, optim.Continuous),
+		Tolerance:     1e-8,
+		MaxIterations: 200,
+	}
+}
+
+/*
+ShowLog
+Description:
+
+	Decides whether or not to print a short summary of each solve to the terminal.
+*/
+func (ipls *InteriorPointLPSolver) ShowLog(tf bool) error {
+	ipls.showLog = tf
+	return nil
+}
+
+/*
+SetTimeLimit
+Description:
+
+	Sets the time limit of the current model in the InteriorPointLPSolver.
+
+Input:
+
+	limitInS = Value of time limit in seconds (float)
+*/
+func (ipls *InteriorPointLPSolver) SetTimeLimit(limitInS float64) error {
+	if limitInS < 0 {
+		return fmt.Errorf("The time limit must be nonnegative; received %v", limitInS)
+	}
+	ipls.timeLimit = limitInS
+	return nil
+}
+
+/*
+Optimize
+Description:
+
+	Converts the stored problem into standard form and solves it with the interior point method.
+	Infeasible and unbounded problems are reported through the status of the returned solution.
+*/
+func (ipls *InteriorPointLPSolver) Optimize() (optim.Solution, error) {
+	// Create linear program
+	linProg, err := newLinearProgram(ipls.Variables, ipls.Constraints, ipls.Objective)
+	if err != nil {
+		return optim.Solution{}, fmt.Errorf("There was an issue creating the linear program: %v", err)
+	}
+
+	if !linProg.HasConsistentBounds() {
+		return optim.Solution{Status: optim.OptimizationStatus_INFEASIBLE}, nil
+	}
+
+	// Remove redundant (and detect inconsistent) rows, so that A D A' is nonsingular.
+	sf := linProg.ToStandardForm()
+	allCols := make([]int, len(sf.C))
+	for j := range allCols {
+		allCols[j] = j
+	}
+	reducedA, reducedB, consistent := independentRows(sf.A, sf.B, allCols, 1e-12)
+	if !consistent {
+		return optim.Solution{Status: optim.OptimizationStatus_INFEASIBLE}, nil
+	}
+
+	// Solve
+	var deadline time.Time
+	if ipls.timeLimit > 0 {
+		deadline = time.Now().Add(time.Duration(ipls.timeLimit * float64(time.Second)))
+	}
+	result, err := solveHSDE(sf.C, reducedA, reducedB, ipls.Tolerance, ipls.MaxIterations, deadline)
+	if err != nil {
+		return optim.Solution{Status: result.Status}, err
+	}
+
+	tempSolution := optim.Solution{
+		Status:         result.Status,
+		Iterations:     result.Iterations,
+		PrimalResidual: result.PrimalResidual,
+		DualResidual:   result.DualResidual,
+	}
+	if result.X != nil {
+		x := sf.Recover(result.X)
+		tempSolution.Values = make(map[uint64]float64)
+		for varIndex, tempVar := range linProg.Variables {
+			tempSolution.Values[tempVar.ID] = x[varIndex]
+		}
+		tempSolution.Objective = linProg.ObjectiveValue(x)
+	}
+
+	if ipls.showLog {
+		statusMessage, _ := tempSolution.Status.ToMessage()
+		log.Printf(
+			"InteriorPointLPSolver: %v variables, %v constraints, %v iterations. %v Objective = %v",
+			len(linProg.Variables), len(linProg.Rows), result.Iterations, statusMessage, tempSolution.Objective,
+		)
+	}
+
+	return tempSolution, nil
+}
+
+/*
+solveHSDE
+Description:
+
+	Solves the standard form linear program
+		minimize c' x  subject to  A x = b, x >= 0
+	(where A has full row rank) by applying Mehrotra's predictor-corrector method to its
+	homogeneous self-dual embedding
+		A x - b tau                 = 0
+		A' y + s - c tau            = 0
+		c' x - b' y + kappa         = 0
+		x, s, tau, kappa >= 0.
+	When the iterates converge with tau > 0, x / tau is optimal. When tau goes to zero instead,
+	the iterates contain a certificate of primal infeasibility (b' y > 0) or of dual
+	infeasibility (c' x < 0). In the latter case, a feasibility problem is solved to decide
+	whether the problem is unbounded or infeasible.
+*/
+func solveHSDE(c []float64, A [][]float64, b []float64, tol float64, maxIter int, deadline time.Time) (hsdeResult, error) {
+	// Constants
+	n, m := len(c), len(A)
+	stepScale := 0.99
+	normB, normC := infNorm(b), infNorm(c)
+
+	// Initial point
+	x, s := make([]float64, n), make([]float64, n)
+	for j := 0; j < n; j++ {
+		x[j], s[j] = 1.0, 1.0
+	}
+	y := make([]float64, m)
+	tau, kappa := 1.0, 1.0
+
+	mulA := func(v []float64) []float64 {
+		out := make([]float64, m)
+		for i := 0; i < m; i++ {
+			out[i] = dot(A[i], v)
+		}
+		return out
+	}
+	mulAT := func(v []float64) []float64 {
+		out := make([]float64, n)
+		for i := 0; i < m; i++ {
+			for j := 0; j < n; j++ {
+				out[j] += A[i][j] * v[i]
+			}
+		}
+		return out
+	}
+
+	result := hsdeResult{Status: optim.OptimizationStatus_ITERATION_LIMIT}
+	for iter := 0; iter < maxIter; iter++ {
+		result.Iterations = iter
+
+		// Compute residuals
+		Ax, ATy := mulA(x), mulAT(y)
+		F1 := make([]float64, m) // A x - b tau
+		for i := 0; i < m; i++ {
+			F1[i] = Ax[i] - b[i]*tau
+		}
+		F2 := make([]float64, n) // A' y + s - c tau
+		for j := 0; j < n; j++ {
+			F2[j] = ATy[j] + s[j] - c[j]*tau
+		}
+		cx, by := dot(c, x), dot(b, y)
+		F3 := cx - by + kappa
+		mu := (dot(x, s) + tau*kappa) / float64(n+1)
+
+		result.PrimalResidual = infNorm(F1) / tau
+		result.DualResidual = infNorm(F2) / tau
+
+		// Check for optimality
+		if result.PrimalResidual <= tol*(1+normB) &&
+			result.DualResidual <= tol*(1+normC) &&
+			math.Abs(cx-by)/tau <= tol*(1+math.Abs(by/tau)) {
+			result.Status = optim.OptimizationStatus_OPTIMAL
+			result.X = make([]float64, n)
+			for j := 0; j < n; j++ {
+				result.X[j] = x[j] / tau
+			}
+			return result, nil
+		}
+
+		// Check for certificates of infeasibility. Since x, s >= 0,
+		//	b' y > 0 and A' y <= 0 proves that A x = b has no nonnegative solution, and
+		//	c' x < 0 and A x = 0 proves that the dual problem is infeasible.
+		if by > 0 && positivePartNorm(ATy) <= tol*by {
+			result.Status = optim.OptimizationStatus_INFEASIBLE
+			return result, nil
+		}
+		if cx < 0 && infNorm(Ax) <= tol*(-cx) {
+			// The problem is unbounded if (and only if) it is feasible.
+			feasibility, err := solveHSDE(make([]float64, n), A, b, tol, maxIter, deadline)
+			switch feasibility.Status {
+			case optim.OptimizationStatus_OPTIMAL:
+				result.Status = optim.OptimizationStatus_UNBOUNDED
+			case optim.OptimizationStatus_INFEASIBLE:
+				result.Status = optim.OptimizationStatus_INFEASIBLE
+			default:
+				result.Status = optim.OptimizationStatus_INF_OR_UNBD
+			}
+			return result, err
+		}
+
+		if !deadline.IsZero() && time.Now().After(deadline) {
+			result.Status = optim.OptimizationStatus_TIME_LIMIT
+			return result, nil
+		}
+
+		// Factorize M = A D^{-1} A', where D = X^{-1} S
+		dInv := make([]float64, n)
+		for j := 0; j < n; j++ {
+			dInv[j] = x[j] / s[j]
+		}
+		chol, err := factorizeNormalEquations(A, dInv)
+		if err != nil {
+			result.Status = optim.OptimizationStatus_NUMERIC
+			return result, fmt.Errorf("There was an issue factorizing the normal equations: %v", err)
+		}
+
+		solveM := func(rhs []float64) []float64 {
+			if m == 0 {
+				return nil
+			}
+			var sol mat.VecDense
+			chol.SolveVecTo(&sol, mat.NewVecDense(m, rhs))
+			return sol.RawVector().Data
+		}
+
+		// p solves M p = b + A D^{-1} c; it is shared by the predictor and corrector.
+		dInvC := make([]float64, n)
+		for j := 0; j < n; j++ {
+			dInvC[j] = dInv[j] * c[j]
+		}
+		rhsP := mulA(dInvC)
+		for i := 0; i < m; i++ {
+			rhsP[i] += b[i]
+		}
+		p := solveM(rhsP)
+		ATp := mulAT(p)
+		denominator := dot(dInvC, ATp) - dot(c, dInvC) - dot(b, p) - kappa/tau
+
+		// solveNewton computes the search direction for the residual scaling eta and the
+		// complementarity right hand sides rXS and rTK.
+		solveNewton := func(eta float64, rXS []float64, rTK float64) (dx, dy, ds []float64, dTau, dKappa float64) {
+			r2 := make([]float64, n)
+			for j := 0; j < n; j++ {
+				r2[j] = eta*F2[j] + rXS[j]/x[j]
+			}
+			dInvR2 := make([]float64, n)
+			for j := 0; j < n; j++ {
+				dInvR2[j] = dInv[j] * r2[j]
+			}
+			rhsQ := mulA(dInvR2)
+			for i := 0; i < m; i++ {
+				rhsQ[i] = -eta*F1[i] - rhsQ[i]
+			}
+			q := solveM(rhsQ)
+			ATq := mulAT(q)
+
+			dTau = (-eta*F3 - dot(dInvC, ATq) - dot(c, dInvR2) + dot(b, q) - rTK/tau) / denominator
+
+			dy = make([]float64, m)
+			for i := 0; i < m; i++ {
+				dy[i] = q[i] + p[i]*dTau
+			}
+			dx = make([]float64, n)
+			ds = make([]float64, n)
+			for j := 0; j < n; j++ {
+				dx[j] = dInv[j]*(ATq[j]+ATp[j]*dTau) - dInvC[j]*dTau + dInvR2[j]
+				ds[j] = (rXS[j] - s[j]*dx[j]) / x[j]
+			}
+			dKappa = (rTK - kappa*dTau) / tau
+			return dx, dy, ds, dTau, dKappa
+		}
+
+		stepLength := func(dx, ds []float64, dTau, dKappa float64) float64 {
+			alpha := math.Min(maxStep(x, dx), maxStep(s, ds))
+			alpha = math.Min(alpha, maxStep([]float64{tau, kappa}, []float64{dTau, dKappa}))
+			return alpha
+		}
+
+		// Predictor (affine scaling) step
+		rXS := make([]float64, n)
+		for j := 0; j < n; j++ {
+			rXS[j] = -x[j] * s[j]
+		}
+		dxAff, _, dsAff, dTauAff, dKappaAff := solveNewton(1.0, rXS, -tau*kappa)
+		alphaAff := stepLength(dxAff, dsAff, dTauAff, dKappaAff)
+
+		muAff := (tau + alphaAff*dTauAff) * (kappa + alphaAff*dKappaAff)
+		for j := 0; j < n; j++ {
+			muAff += (x[j] + alphaAff*dxAff[j]) * (s[j] + alphaAff*dsAff[j])
+		}
+		muAff /= float64(n + 1)
+		sigma := math.Pow(muAff/mu, 3)
+
+		// Corrector step
+		for j := 0; j < n; j++ {
+			rXS[j] = -x[j]*s[j] + sigma*mu - dxAff[j]*dsAff[j]
+		}
+		rTK := -tau*kappa + sigma*mu - dTauAff*dKappaAff
+		dx, dy, ds, dTau, dKappa := solveNewton(1.0-sigma, rXS, rTK)
+		alpha := math.Min(1.0, stepScale*stepLength(dx, ds, dTau, dKappa))
+
+		// Update
+		for j := 0; j < n; j++ {
+			x[j] += alpha * dx[j]
+			s[j] += alpha * ds[j]
+		}
+		for i := 0; i < m; i++ {
+			y[i] += alpha * dy[i]
+		}
+		tau += alpha * dTau
+		kappa += alpha * dKappa
+	}
+
+	result.Iterations = maxIter
+	return result, nil
+}
+
+/*
+positivePartNorm
+Description:
+
+	Computes the largest positive entry of v (or zero, if there are none).
+*/
+func positivePartNorm(v []float64) float64 {
+	norm := 0.0
+	for _, vi := range v {
+		norm = math.Max(norm, vi)
+	}
+	return norm
+}
+
+/*
+factorizeNormalEquations
+Description:
+
+	Computes the Cholesky factorization of A diag(dInv) A'. A small regularization is added to
+	the diagonal when the matrix is numerically singular (as happens near the end of the
+	iterations).
+*/
+func factorizeNormalEquations(A [][]float64, dInv []float64) (*mat.Cholesky, error) {
+	// Constants
+	m := len(A)
+	var chol mat.Cholesky
+	if m == 0 {
+		return &chol, nil
+	}
+
+	// Algorithm
+	M := mat.NewSymDense(m, nil)
+	maxDiagonal := 0.0
+	for i1 := 0; i1 < m; i1++ {
+		for i2 := i1; i2 < m; i2++ {
+			sum := 0.0
+			for j, dj := range dInv {
+				sum += A[i1][j] * dj * A[i2][j]
+			}
+			M.SetSym(i1, i2, sum)
+		}
+		maxDiagonal = math.Max(maxDiagonal, M.At(i1, i1))
+	}
+
+	for regularization := 1e-14; regularization <= 1e-2; regularization *= 100 {
+		if ok := chol.Factorize(M); ok {
+			return &chol, nil
+		}
+		for i := 0; i < m; i++ {
+			M.SetSym(i, i, M.At(i, i)+regularization*math.Max(maxDiagonal, 1.0))
+		}
+	}
+
+	return nil, fmt.Errorf("the matrix A D A' is not positive definite")
+}
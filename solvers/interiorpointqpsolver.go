@@ -0,0 +1,482 @@
+package solvers
+
+/*
+interiorpointqpsolver.go
+Description:
+	Defines a pure-Go solver for small, dense convex quadratic programs. The solver uses
+	Mehrotra's primal-dual interior point method and the dense linear algebra in gonum/mat.
+*/
+
+import (
+	"fmt"
+	"log"
+	"math"
+
+	"github.com/kwesiRutledge/goop2/optim"
+	"gonum.org/v1/gonum/mat"
+)
+
+// Type Definition
+
+type InteriorPointQPSolver struct {
+	problemStore
+
+	Tolerance          float64 // Tolerance on the residuals and complementarity gap
+	ConvexityTolerance float64 // Relative tolerance used when checking the eigenvalues of the objective
+	MaxIterations      int
+	Iterations         int // Number of iterations used in the last call to Optimize()
+	showLog            bool
+	timeLimit          float64
+}
+
+// Functions
+
+/*
+NewInteriorPointQPSolver
+Description:
+
+	Create a new, empty InteriorPointQPSolver object with default tolerances.
+*/
+func NewInteriorPointQPSolver() *InteriorPointQPSolver {
+	ips := &InteriorPointQPSolver{
+		Tolerance:          1e-8,
+		ConvexityTolerance: 1e-10,
+		MaxIterations:      100,
+	}
+	ips.problemStore = newProblemStore("InteriorPointQPSolver", ips.checkObjective, optim.Continuous)
+
+	return ips
+}
+
+/*
+ShowLog
+Description:
+
+	Decides whether or not to print the progress of the solver to the terminal.
+*/
+func (ips *InteriorPointQPSolver) ShowLog(tf bool) error {
+	ips.showLog = tf
+	return nil
+}
+
+/*
+SetTimeLimit
+Description:
+
+	Saves the time limit for the solver. The solver is limited by MaxIterations instead, so
+	this value is currently only recorded.
+
+Input:
+
+	limitInS = Value of time limit in seconds (float)
+*/
+func (ips *InteriorPointQPSolver) SetTimeLimit(limitInS float64) error {
+	if limitInS < 0 {
+		return fmt.Errorf("The time limit must be nonnegative; received %v", limitInS)
+	}
+	ips.timeLimit = limitInS
+	return nil
+}
+
+/*
+checkObjective
+Description:
+
+	Checks that the objective can be used by the solver. The objective may be linear or a
+	ScalarQuadraticExpression x' Q x + L' x + C. Quadratic objectives must be convex when
+	minimized (and concave when maximized).
+*/
+func (ips *InteriorPointQPSolver) checkObjective(objIn optim.Objective) error {
+	_, Q, _, _, _, err := quadraticTerms(objIn.ScalarExpression)
+	if err != nil {
+		return err
+	}
+
+	return checkConvexity(Q, objIn.Sense, ips.ConvexityTolerance)
+}
+
+/*
+Optimize
+Description:
+
+	Solves the quadratic program
+
+		minimize   1/2 x' H x + g' x
+		subject to A x = b
+		           C x <= d
+
+	(where the rows of C include all finite variable bounds) with Mehrotra's predictor-corrector
+	method. Problems whose iterates diverge are reported as infeasible (when the multipliers
+	diverge) or unbounded (when x diverges).
+*/
+func (ips *InteriorPointQPSolver) Optimize() (optim.Solution, error) {
+	// Input Checking
+	if len(ips.Variables) == 0 {
+		return optim.Solution{}, fmt.Errorf("There are no variables in the InteriorPointQPSolver.")
+	}
+
+	// Create quadratic program
+	qp, err := newQuadraticProgram(ips.Variables, ips.Constraints, ips.Objective)
+	if err != nil {
+		return optim.Solution{}, fmt.Errorf("There was an issue creating the quadratic program: %v", err)
+	}
+
+	if !qp.HasConsistentBounds() {
+		return optim.Solution{Status: optim.OptimizationStatus_INFEASIBLE}, nil
+	}
+
+	// Solve
+	H := qp.MinimizationHessian()
+	g := qp.MinimizationCost()
+	A, b, C, d := qp.linearProgram.equalityInequalityForm()
+
+	status, x, iterations := solveQPWithInteriorPoint(H, g, A, b, C, d, ips.Tolerance, ips.MaxIterations)
+	ips.Iterations = iterations
+
+	tempSolution := optim.Solution{Status: status}
+	if status == optim.OptimizationStatus_OPTIMAL || status == optim.OptimizationStatus_ITERATION_LIMIT {
+		tempSolution.Values = make(map[uint64]float64)
+		for varIndex, tempVar := range qp.Variables {
+			tempSolution.Values[tempVar.ID] = x[varIndex]
+		}
+		tempSolution.Objective = qp.ObjectiveValue(x)
+	}
+
+	if ips.showLog {
+		statusMessage, _ := status.ToMessage()
+		log.Printf(
+			"InteriorPointQPSolver: %v iterations. %v Objective = %v",
+			iterations, statusMessage, tempSolution.Objective,
+		)
+	}
+
+	return tempSolution, nil
+}
+
+/*
+equalityInequalityForm
+Description:
+
+	Writes the constraints of the linear program as
+		A x = b
+		C x <= d
+	where every finite bound on a variable becomes a row of C.
+*/
+func (lp linearProgram) equalityInequalityForm() (A [][]float64, b []float64, C [][]float64, d []float64) {
+	// Constants
+	n := len(lp.Variables)
+
+	// Algorithm
+	for _, row := range lp.Rows {
+		switch row.Sense {
+		case optim.SenseEqual:
+			A = append(A, row.Coeffs)
+			b = append(b, row.RHS)
+		case optim.SenseLessThanEqual:
+			C = append(C, row.Coeffs)
+			d = append(d, row.RHS)
+		case optim.SenseGreaterThanEqual:
+			negRow := make([]float64, n)
+			for j, coeff := range row.Coeffs {
+				negRow[j] = -coeff
+			}
+			C = append(C, negRow)
+			d = append(d, -row.RHS)
+		}
+	}
+
+	for j := 0; j < n; j++ {
+		if !isInfiniteBound(lp.Upper[j]) {
+			boundRow := make([]float64, n)
+			boundRow[j] = 1.0
+			C = append(C, boundRow)
+			d = append(d, lp.Upper[j])
+		}
+		if !isInfiniteBound(lp.Lower[j]) {
+			boundRow := make([]float64, n)
+			boundRow[j] = -1.0
+			C = append(C, boundRow)
+			d = append(d, -lp.Lower[j])
+		}
+	}
+
+	return A, b, C, d
+}
+
+/*
+kktMatrix
+Description:
+
+	Forms the regularized KKT matrix
+		[ H + C' S^{-1} Z C + reg I   A'     ]
+		[ A                           -reg I ]
+	of the interior point method.
+*/
+func kktMatrix(H *mat.SymDense, A [][]float64, C [][]float64, z, s []float64, reg float64) *mat.Dense {
+	// Constants
+	n, _ := H.Dims()
+	mE := len(A)
+
+	// Algorithm
+	kkt := mat.NewDense(n+mE, n+mE, nil)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			kkt.Set(i, j, H.At(i, j))
+		}
+		kkt.Set(i, i, kkt.At(i, i)+reg)
+	}
+	for k := range C {
+		w := z[k] / s[k]
+		for i := 0; i < n; i++ {
+			if C[k][i] == 0 {
+				continue
+			}
+			for j := 0; j < n; j++ {
+				kkt.Set(i, j, kkt.At(i, j)+w*C[k][i]*C[k][j])
+			}
+		}
+	}
+	for i := 0; i < mE; i++ {
+		for j := 0; j < n; j++ {
+			kkt.Set(n+i, j, A[i][j])
+			kkt.Set(j, n+i, A[i][j])
+		}
+		kkt.Set(n+i, n+i, -reg)
+	}
+
+	return kkt
+}
+
+/*
+solveQPWithInteriorPoint
+Description:
+
+	Solves the convex quadratic program
+
+		minimize   1/2 x' H x + g' x
+		subject to A x = b
+		           C x + s = d, s >= 0
+
+	with Mehrotra's predictor-corrector primal-dual interior point method. At each iteration,
+	the Newton system is reduced to the (regularized) KKT system
+
+		[ H + C' S^{-1} Z C   A' ] [ dx ]   [ r1 ]
+		[ A                   0  ] [ dy ] = [ r2 ]
+
+	which is solved with a dense LU factorization. If the matrix is singular in floating point
+	arithmetic, its regularization is increased before it is factorized again.
+*/
+func solveQPWithInteriorPoint(H *mat.SymDense, g []float64, A [][]float64, b []float64, C [][]float64, d []float64, tol float64, maxIter int) (optim.OptimizationStatus, []float64, int) {
+	// Constants
+	n := len(g)
+	mE := len(A)
+	mI := len(C)
+	regularization := 1e-10
+	maxRegularization := 1e-2
+	divergenceLimit := 1e12
+
+	// Initial point
+	x := make([]float64, n)
+	y := make([]float64, mE)
+	z := make([]float64, mI)
+	s := make([]float64, mI)
+	for i := 0; i < mI; i++ {
+		s[i] = math.Max(d[i]-dot(C[i], x), 1.0)
+		z[i] = 1.0
+	}
+
+	normB, normD, normG := infNorm(b), infNorm(d), infNorm(g)
+
+	for iter := 0; iter < maxIter; iter++ {
+		// Compute residuals
+		rd := make([]float64, n) // H x + g + A' y + C' z
+		for i := 0; i < n; i++ {
+			rd[i] = g[i]
+			for j := 0; j < n; j++ {
+				rd[i] += H.At(i, j) * x[j]
+			}
+		}
+		for i := 0; i < mE; i++ {
+			for j := 0; j < n; j++ {
+				rd[j] += A[i][j] * y[i]
+			}
+		}
+		for i := 0; i < mI; i++ {
+			for j := 0; j < n; j++ {
+				rd[j] += C[i][j] * z[i]
+			}
+		}
+
+		rp := make([]float64, mE) // A x - b
+		for i := 0; i < mE; i++ {
+			rp[i] = dot(A[i], x) - b[i]
+		}
+
+		ri := make([]float64, mI) // C x + s - d
+		for i := 0; i < mI; i++ {
+			ri[i] = dot(C[i], x) + s[i] - d[i]
+		}
+
+		mu := 0.0
+		if mI > 0 {
+			mu = dot(s, z) / float64(mI)
+		}
+
+		// Check convergence
+		if infNorm(rp) <= tol*(1+normB) && infNorm(ri) <= tol*(1+normD) && infNorm(rd) <= tol*(1+normG) && mu <= tol {
+			return optim.OptimizationStatus_OPTIMAL, x, iter
+		}
+
+		// Check divergence
+		if infNorm(x) > divergenceLimit {
+			return optim.OptimizationStatus_UNBOUNDED, nil, iter
+		}
+		if infNorm(y) > divergenceLimit || infNorm(z) > divergenceLimit {
+			return optim.OptimizationStatus_INFEASIBLE, nil, iter
+		}
+
+		// Form and factorize the KKT matrix. When the matrix is singular in floating point
+		// arithmetic, the regularization is increased until it can be factorized.
+		var lu mat.LU
+		for reg := regularization; ; reg *= 100 {
+			if reg > maxRegularization {
+				return optim.OptimizationStatus_NUMERIC, nil, iter
+			}
+			lu.Factorize(kktMatrix(H, A, C, z, s, reg))
+			if !math.IsInf(lu.Cond(), 1) {
+				break
+			}
+		}
+
+		// solveNewton computes the step for the complementarity right hand side rsz.
+		solveNewton := func(rsz []float64) (dx, dy, dz, ds []float64, ok bool) {
+			rhs := mat.NewVecDense(n+mE, nil)
+			for i := 0; i < n; i++ {
+				rhs.SetVec(i, -rd[i])
+			}
+			for k := 0; k < mI; k++ {
+				factor := (rsz[k] + z[k]*ri[k]) / s[k]
+				for i := 0; i < n; i++ {
+					rhs.SetVec(i, rhs.AtVec(i)-C[k][i]*factor)
+				}
+			}
+			for i := 0; i < mE; i++ {
+				rhs.SetVec(n+i, -rp[i])
+			}
+
+			// A finite Condition error only warns that the matrix is ill-conditioned; the
+			// solution is still written.
+			var sol mat.VecDense
+			if err := lu.SolveVecTo(&sol, false, rhs); err != nil {
+				if cond, isCond := err.(mat.Condition); !isCond || math.IsInf(float64(cond), 1) {
+					return nil, nil, nil, nil, false
+				}
+			}
+
+			dx = make([]float64, n)
+			dy = make([]float64, mE)
+			for i := 0; i < n; i++ {
+				dx[i] = sol.AtVec(i)
+			}
+			for i := 0; i < mE; i++ {
+				dy[i] = sol.AtVec(n + i)
+			}
+			ds = make([]float64, mI)
+			dz = make([]float64, mI)
+			for k := 0; k < mI; k++ {
+				ds[k] = -ri[k] - dot(C[k], dx)
+				dz[k] = (rsz[k] - z[k]*ds[k]) / s[k]
+			}
+			return dx, dy, dz, ds, true
+		}
+
+		// Predictor (affine scaling) step
+		rsz := make([]float64, mI)
+		for k := 0; k < mI; k++ {
+			rsz[k] = -s[k] * z[k]
+		}
+		_, _, dzAff, dsAff, ok := solveNewton(rsz)
+		if !ok {
+			return optim.OptimizationStatus_NUMERIC, nil, iter
+		}
+		alphaAff := math.Min(maxStep(s, dsAff), maxStep(z, dzAff))
+
+		// Centering parameter
+		sigma := 0.0
+		if mI > 0 {
+			muAff := 0.0
+			for k := 0; k < mI; k++ {
+				muAff += (s[k] + alphaAff*dsAff[k]) * (z[k] + alphaAff*dzAff[k])
+			}
+			muAff /= float64(mI)
+			sigma = math.Pow(muAff/mu, 3)
+		}
+
+		// Corrector step
+		for k := 0; k < mI; k++ {
+			rsz[k] = -s[k]*z[k] + sigma*mu - dsAff[k]*dzAff[k]
+		}
+		dx, dy, dz, ds, ok := solveNewton(rsz)
+		if !ok {
+			return optim.OptimizationStatus_NUMERIC, nil, iter
+		}
+
+		alpha := math.Min(1.0, 0.99*math.Min(maxStep(s, ds), maxStep(z, dz)))
+		for i := 0; i < n; i++ {
+			x[i] += alpha * dx[i]
+		}
+		for i := 0; i < mE; i++ {
+			y[i] += alpha * dy[i]
+		}
+		for k := 0; k < mI; k++ {
+			s[k] += alpha * ds[k]
+			z[k] += alpha * dz[k]
+		}
+	}
+
+	return optim.OptimizationStatus_ITERATION_LIMIT, x, maxIter
+}
+
+/*
+maxStep
+Description:
+
+	Returns the largest step alpha (up to 1) such that v + alpha * dv stays nonnegative.
+*/
+func maxStep(v, dv []float64) float64 {
+	alpha := 1.0
+	for i := range v {
+		if dv[i] < 0 {
+			alpha = math.Min(alpha, -v[i]/dv[i])
+		}
+	}
+	return alpha
+}
+
+/*
+dot
+Description:
+
+	Computes the inner product of two slices of the same length.
+*/
+func dot(a, b []float64) float64 {
+	sum := 0.0
+	for i := range a {
+		sum += a[i] * b[i]
+	}
+	return sum
+}
+
+/*
+infNorm
+Description:
+
+	Computes the infinity norm (largest magnitude) of a slice.
+*/
+func infNorm(v []float64) float64 {
+	norm := 0.0
+	for _, vi := range v {
+		norm = math.Max(norm, math.Abs(vi))
+	}
+	return norm
+}
@@ -0,0 +1,410 @@
+package solvers
+
+/*
+linearprogram.go
+Description:
+	Defines the general form linear program that the pure-Go solvers in this package build
+	out of the variables, constraints and objective that they receive through the optim.Solver
+	interface. It also contains the conversion of that program into the standard form
+		minimize c' x  s.t.  A x = b, x >= 0
+	which most textbook algorithms expect.
+*/
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/kwesiRutledge/goop2/optim"
+)
+
+// Type Definitions
+// ================
+
+/*
+linearProgram
+Description:
+
+	A linear program written in the general form
+
+		optimize   c' x + Offset
+		subject to Rows[i].Coeffs' x (Rows[i].Sense) Rows[i].RHS
+		           Lower <= x <= Upper
+
+	where the entries of x are ordered in the same way as Variables.
+*/
+type linearProgram struct {
+	Variables []optim.Variable
+	C         []float64
+	Offset    float64
+	Sense     optim.ObjSense
+	Lower     []float64
+	Upper     []float64
+	Rows      []linearRow
+
+	idToIndex map[uint64]int
+}
+
+/*
+linearRow
+Description:
+
+	A single dense row of a linear program.
+*/
+type linearRow struct {
+	Coeffs []float64
+	Sense  optim.ConstrSense
+	RHS    float64
+}
+
+/*
+standardForm
+Description:
+
+	The standard form version of a linearProgram. Every column of the original program is
+	recovered from the standard form columns through Columns, so that
+		x[j] = Columns[j].Shift + x_std[Columns[j].Pos] - x_std[Columns[j].Neg]
+	where a negative Pos or Neg index means that the term is not present.
+*/
+type standardForm struct {
+	C       []float64
+	A       [][]float64
+	B       []float64
+	Offset  float64
+	Columns []standardColumn
+}
+
+type standardColumn struct {
+	Shift float64
+	Pos   int
+	Neg   int
+}
+
+// Functions
+// =========
+
+/*
+newLinearProgram
+Description:
+
+	Collects the variables, scalar constraints and (linear) objective given to one of the
+	pure-Go solvers into a linearProgram. A nil objective is treated as the objective 0.
+*/
+func newLinearProgram(vars []optim.Variable, constrs []optim.ScalarConstraint, obj *optim.Objective) (linearProgram, error) {
+	// Constants
+	n := len(vars)
+
+	// Algorithm
+	lp := linearProgram{
+		Variables: vars,
+		C:         make([]float64, n),
+		Sense:     optim.SenseMinimize,
+		Lower:     make([]float64, n),
+		Upper:     make([]float64, n),
+		idToIndex: make(map[uint64]int),
+	}
+
+	for varIndex, tempVar := range vars {
+		if _, found := lp.idToIndex[tempVar.ID]; found {
+			return lp, fmt.Errorf("The variable with ID %v was added to the solver more than once.", tempVar.ID)
+		}
+		lp.idToIndex[tempVar.ID] = varIndex
+		lp.Lower[varIndex] = tempVar.Lower
+		lp.Upper[varIndex] = tempVar.Upper
+	}
+
+	// Collect constraints
+	for constrIndex, constr := range constrs {
+		row, err := lp.toRow(constr)
+		if err != nil {
+			return lp, fmt.Errorf("There was an issue converting constraint #%v: %v", constrIndex, err)
+		}
+		lp.Rows = append(lp.Rows, row)
+	}
+
+	// Collect objective
+	if obj != nil {
+		ids, coeffs, constant, err := linearTerms(obj.ScalarExpression)
+		if err != nil {
+			return lp, fmt.Errorf("There was an issue converting the objective: %v", err)
+		}
+		for termIndex, id := range ids {
+			varIndex, found := lp.idToIndex[id]
+			if !found {
+				return lp, fmt.Errorf("The objective contains a variable with ID %v which was never added to the solver.", id)
+			}
+			lp.C[varIndex] += coeffs[termIndex]
+		}
+		lp.Offset = constant
+		lp.Sense = obj.Sense
+	}
+
+	return lp, nil
+}
+
+/*
+toRow
+Description:
+
+	Converts the scalar constraint constr into a dense row of the linear program by moving all
+	variables to the left hand side and all constants to the right hand side.
+*/
+func (lp linearProgram) toRow(constr optim.ScalarConstraint) (linearRow, error) {
+	// Constants
+	row := linearRow{
+		Coeffs: make([]float64, len(lp.Variables)),
+		Sense:  constr.Sense,
+	}
+
+	// Algorithm
+	lhsIDs, lhsCoeffs, lhsConstant, err := linearTerms(constr.LeftHandSide)
+	if err != nil {
+		return row, fmt.Errorf("left hand side: %v", err)
+	}
+	rhsIDs, rhsCoeffs, rhsConstant, err := linearTerms(constr.RightHandSide)
+	if err != nil {
+		return row, fmt.Errorf("right hand side: %v", err)
+	}
+
+	for termIndex, id := range lhsIDs {
+		varIndex, found := lp.idToIndex[id]
+		if !found {
+			return row, fmt.Errorf("the variable with ID %v was never added to the solver", id)
+		}
+		row.Coeffs[varIndex] += lhsCoeffs[termIndex]
+	}
+	for termIndex, id := range rhsIDs {
+		varIndex, found := lp.idToIndex[id]
+		if !found {
+			return row, fmt.Errorf("the variable with ID %v was never added to the solver", id)
+		}
+		row.Coeffs[varIndex] -= rhsCoeffs[termIndex]
+	}
+	row.RHS = rhsConstant - lhsConstant
+
+	return row, nil
+}
+
+/*
+MinimizationCost
+Description:
+
+	Returns the cost vector of the equivalent minimization problem.
+*/
+func (lp linearProgram) MinimizationCost() []float64 {
+	cOut := make([]float64, len(lp.C))
+	for i, ci := range lp.C {
+		if lp.Sense == optim.SenseMaximize {
+			cOut[i] = -ci
+		} else {
+			cOut[i] = ci
+		}
+	}
+	return cOut
+}
+
+/*
+ObjectiveValue
+Description:
+
+	Evaluates the objective of the linear program (in its original sense) at x.
+*/
+func (lp linearProgram) ObjectiveValue(x []float64) float64 {
+	value := lp.Offset
+	for i, ci := range lp.C {
+		value += ci * x[i]
+	}
+	return value
+}
+
+/*
+HasConsistentBounds
+Description:
+
+	Returns false if any variable in the program has a lower bound which is larger than its
+	upper bound. Such a program is trivially infeasible.
+*/
+func (lp linearProgram) HasConsistentBounds() bool {
+	for i := range lp.Variables {
+		if lp.Lower[i] > lp.Upper[i] {
+			return false
+		}
+	}
+	return true
+}
+
+/*
+ToStandardForm
+Description:
+
+	Converts the (minimization form of the) linear program into standard form:
+	- Variables with a finite lower bound are shifted so that they are nonnegative,
+	- Variables with only a finite upper bound are reflected,
+	- Free variables are split into a positive and a negative part,
+	- Finite upper bounds become rows with an additional slack variable, and
+	- Inequality rows receive a slack (or surplus) variable.
+*/
+func (lp linearProgram) ToStandardForm() standardForm {
+	// Constants
+	n := len(lp.Variables)
+	cMin := lp.MinimizationCost()
+
+	// Algorithm
+	sf := standardForm{
+		Columns: make([]standardColumn, n),
+	}
+	if lp.Sense == optim.SenseMaximize {
+		sf.Offset = -lp.Offset
+	} else {
+		sf.Offset = lp.Offset
+	}
+
+	// Create the columns for each of the original variables
+	type boundRow struct {
+		col int
+		rhs float64
+	}
+	var boundRows []boundRow
+	numCols := 0
+	for j := 0; j < n; j++ {
+		lower, upper := lp.Lower[j], lp.Upper[j]
+		switch {
+		case !isInfiniteBound(lower):
+			sf.Columns[j] = standardColumn{Shift: lower, Pos: numCols, Neg: -1}
+			if !isInfiniteBound(upper) {
+				boundRows = append(boundRows, boundRow{col: numCols, rhs: upper - lower})
+			}
+			numCols++
+		case !isInfiniteBound(upper):
+			sf.Columns[j] = standardColumn{Shift: upper, Pos: -1, Neg: numCols}
+			numCols++
+		default:
+			sf.Columns[j] = standardColumn{Shift: 0.0, Pos: numCols, Neg: numCols + 1}
+			numCols += 2
+		}
+	}
+
+	// Count slack variables
+	numSlacks := len(boundRows)
+	for _, row := range lp.Rows {
+		if row.Sense != optim.SenseEqual {
+			numSlacks++
+		}
+	}
+	totalCols := numCols + numSlacks
+
+	// Create cost
+	sf.C = make([]float64, totalCols)
+	for j, col := range sf.Columns {
+		sf.Offset += cMin[j] * col.Shift
+		if col.Pos >= 0 {
+			sf.C[col.Pos] += cMin[j]
+		}
+		if col.Neg >= 0 {
+			sf.C[col.Neg] -= cMin[j]
+		}
+	}
+
+	// Create rows
+	slackIndex := numCols
+	for _, row := range lp.Rows {
+		newRow := make([]float64, totalCols)
+		rhs := row.RHS
+		for j, coeff := range row.Coeffs {
+			if coeff == 0 {
+				continue
+			}
+			col := sf.Columns[j]
+			rhs -= coeff * col.Shift
+			if col.Pos >= 0 {
+				newRow[col.Pos] += coeff
+			}
+			if col.Neg >= 0 {
+				newRow[col.Neg] -= coeff
+			}
+		}
+
+		switch row.Sense {
+		case optim.SenseLessThanEqual:
+			newRow[slackIndex] = 1.0
+			slackIndex++
+		case optim.SenseGreaterThanEqual:
+			newRow[slackIndex] = -1.0
+			slackIndex++
+		}
+
+		sf.A = append(sf.A, newRow)
+		sf.B = append(sf.B, rhs)
+	}
+
+	for _, br := range boundRows {
+		newRow := make([]float64, totalCols)
+		newRow[br.col] = 1.0
+		newRow[slackIndex] = 1.0
+		slackIndex++
+
+		sf.A = append(sf.A, newRow)
+		sf.B = append(sf.B, br.rhs)
+	}
+
+	return sf
+}
+
+/*
+Recover
+Description:
+
+	Maps a point of the standard form problem back to the variables of the original problem.
+*/
+func (sf standardForm) Recover(xStd []float64) []float64 {
+	xOut := make([]float64, len(sf.Columns))
+	for j, col := range sf.Columns {
+		xOut[j] = col.Shift
+		if col.Pos >= 0 {
+			xOut[j] += xStd[col.Pos]
+		}
+		if col.Neg >= 0 {
+			xOut[j] -= xStd[col.Neg]
+		}
+	}
+	return xOut
+}
+
+/*
+linearTerms
+Description:
+
+	Extracts the variable IDs, nonzero coefficients and constant of a linear scalar expression.
+	Each variable appears at most once. The terms are read with optim.SparseTermsOf, so sparse
+	expressions are never converted to dense vectors.
+*/
+func linearTerms(se optim.ScalarExpression) ([]uint64, []float64, float64, error) {
+	terms, err := optim.SparseTermsOf(se)
+	if err != nil {
+		return nil, nil, 0.0, fmt.Errorf("expected a linear expression, but received expression of type %T: %v", se, err)
+	}
+	if len(terms.Quadratic) > 0 {
+		return nil, nil, 0.0, fmt.Errorf("expected a linear expression, but received expression of type %T", se)
+	}
+
+	var (
+		ids    []uint64
+		coeffs []float64
+	)
+	for _, term := range terms.Linear {
+		ids = append(ids, term.ID)
+		coeffs = append(coeffs, term.Coeff)
+	}
+
+	return ids, coeffs, terms.Constant, nil
+}
+
+/*
+isInfiniteBound
+Description:
+
+	Determines whether or not a variable bound should be treated as infinite. Following Gurobi's
+	convention, any bound whose magnitude is at least 1e30 is considered infinite.
+*/
+func isInfiniteBound(bound float64) bool {
+	return math.IsInf(bound, 0) || math.Abs(bound) >= 1e30
+}
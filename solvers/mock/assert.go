@@ -0,0 +1,302 @@
+package mock
+
+/*
+assert.go
+Description:
+	Defines helpers for checking the constraints, objective and calls recorded by the mock Solver
+	in unit tests. Expressions are compared by their coefficients, so the expression x + 2y
+	matches the expression 2y + x regardless of how either was built.
+*/
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/kwesiRutledge/goop2/optim"
+)
+
+// Type Definitions
+// ================
+
+/*
+Terms
+Description:
+
+	The coefficients of a (linear or quadratic) scalar expression
+		sum_{(i,j)} Quadratic[(i,j)] x_i x_j + sum_i Linear[i] x_i + Constant
+	keyed by variable ID. Quadratic terms are keyed with the smaller ID first.
+*/
+type Terms struct {
+	Linear    map[uint64]float64
+	Quadratic map[[2]uint64]float64
+	Constant  float64
+}
+
+// Constants
+// =========
+
+// Tolerance is the tolerance used when comparing coefficients.
+const Tolerance = 1e-9
+
+// Functions
+// =========
+
+/*
+TermsOf
+Description:
+
+	Collects the coefficients of the scalar expression se.
+*/
+func TermsOf(se optim.ScalarExpression) (Terms, error) {
+	// Constants
+	termsOut := Terms{
+		Linear:    make(map[uint64]float64),
+		Quadratic: make(map[[2]uint64]float64),
+	}
+
+	// Algorithm
+	terms, err := optim.SparseTermsOf(se)
+	if err != nil {
+		return termsOut, err
+	}
+	for _, term := range terms.Linear {
+		termsOut.Linear[term.ID] += term.Coeff
+	}
+	for _, term := range terms.Quadratic {
+		termsOut.Quadratic[[2]uint64{term.ID1, term.ID2}] += term.Coeff
+	}
+	termsOut.Constant = terms.Constant
+
+	return termsOut, nil
+}
+
+/*
+Minus
+Description:
+
+	Returns the terms of the expression t - other.
+*/
+func (t Terms) Minus(other Terms) Terms {
+	termsOut := Terms{
+		Linear:    make(map[uint64]float64),
+		Quadratic: make(map[[2]uint64]float64),
+		Constant:  t.Constant - other.Constant,
+	}
+	for id, coeff := range t.Linear {
+		termsOut.Linear[id] += coeff
+	}
+	for id, coeff := range other.Linear {
+		termsOut.Linear[id] -= coeff
+	}
+	for key, coeff := range t.Quadratic {
+		termsOut.Quadratic[key] += coeff
+	}
+	for key, coeff := range other.Quadratic {
+		termsOut.Quadratic[key] -= coeff
+	}
+	return termsOut
+}
+
+/*
+Equals
+Description:
+
+	Determines whether or not two sets of terms have the same coefficients (up to Tolerance).
+	Coefficients which are missing are treated as zero.
+*/
+func (t Terms) Equals(other Terms) bool {
+	diff := t.Minus(other)
+	if math.Abs(diff.Constant) > Tolerance {
+		return false
+	}
+	for _, coeff := range diff.Linear {
+		if math.Abs(coeff) > Tolerance {
+			return false
+		}
+	}
+	for _, coeff := range diff.Quadratic {
+		if math.Abs(coeff) > Tolerance {
+			return false
+		}
+	}
+	return true
+}
+
+/*
+Negate
+Description:
+
+	Returns the terms of the expression -t.
+*/
+func (t Terms) Negate() Terms {
+	return Terms{Constant: 0.0}.Minus(t)
+}
+
+/*
+ConstraintsMatch
+Description:
+
+	Determines whether or not two scalar constraints are equivalent, i.e. whether or not
+	(LeftHandSide - RightHandSide) has the same coefficients and sense in both. The constraint
+	a <= b also matches the constraint b >= a.
+*/
+func ConstraintsMatch(c1, c2 optim.ScalarConstraint) (bool, error) {
+	// Collect terms
+	diff1, err := constraintTerms(c1)
+	if err != nil {
+		return false, err
+	}
+	diff2, err := constraintTerms(c2)
+	if err != nil {
+		return false, err
+	}
+
+	// Compare
+	if c1.Sense == c2.Sense && diff1.Equals(diff2) {
+		return true, nil
+	}
+
+	return c1.Sense.Reverse() == c2.Sense && diff1.Equals(diff2.Negate()), nil
+}
+
+func constraintTerms(c optim.ScalarConstraint) (Terms, error) {
+	lhs, err := TermsOf(c.LeftHandSide)
+	if err != nil {
+		return Terms{}, fmt.Errorf("left hand side: %v", err)
+	}
+	rhs, err := TermsOf(c.RightHandSide)
+	if err != nil {
+		return Terms{}, fmt.Errorf("right hand side: %v", err)
+	}
+	return lhs.Minus(rhs), nil
+}
+
+/*
+AssertCallCount
+Description:
+
+	Fails the test if the method was not called exactly count times.
+*/
+func (s *Solver) AssertCallCount(t testing.TB, method string, count int) {
+	t.Helper()
+	if calls := s.CallsTo(method); len(calls) != count {
+		t.Errorf("Expected %v to be called %v times; it was called %v times.", method, count, len(calls))
+	}
+}
+
+/*
+AssertVariables
+Description:
+
+	Fails the test if any of the given variables (matched by ID, bounds and type) was never added
+	to the solver.
+*/
+func (s *Solver) AssertVariables(t testing.TB, vars ...optim.Variable) {
+	t.Helper()
+	for _, expected := range vars {
+		found := false
+		for _, tempVar := range s.Variables {
+			if tempVar == expected {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("Expected variable %v to be added to the solver, but it was not.", expected)
+		}
+	}
+}
+
+/*
+AssertNumConstraints
+Description:
+
+	Fails the test if the number of recorded constraints is not count.
+*/
+func (s *Solver) AssertNumConstraints(t testing.TB, count int) {
+	t.Helper()
+	if len(s.Constraints) != count {
+		t.Errorf("Expected %v constraints to be added to the solver; received %v.", count, len(s.Constraints))
+	}
+}
+
+/*
+AssertConstraint
+Description:
+
+	Fails the test if the recorded constraint with the given index is not equivalent to
+		lhs (sense) rhs.
+*/
+func (s *Solver) AssertConstraint(t testing.TB, index int, lhs optim.ScalarExpression, sense optim.ConstrSense, rhs optim.ScalarExpression) {
+	t.Helper()
+
+	constrs := s.ScalarConstraints()
+	if index < 0 || index >= len(constrs) {
+		t.Errorf("Expected a scalar constraint with index %v, but only %v were added.", index, len(constrs))
+		return
+	}
+
+	expected := optim.ScalarConstraint{LeftHandSide: lhs, RightHandSide: rhs, Sense: sense}
+	match, err := ConstraintsMatch(constrs[index], expected)
+	if err != nil {
+		t.Errorf("There was an issue comparing constraint %v: %v", index, err)
+		return
+	}
+	if !match {
+		t.Errorf("Expected constraint %v to be %v; received %v", index, expected, constrs[index])
+	}
+}
+
+/*
+AssertHasConstraint
+Description:
+
+	Fails the test if none of the recorded constraints is equivalent to lhs (sense) rhs.
+*/
+func (s *Solver) AssertHasConstraint(t testing.TB, lhs optim.ScalarExpression, sense optim.ConstrSense, rhs optim.ScalarExpression) {
+	t.Helper()
+
+	expected := optim.ScalarConstraint{LeftHandSide: lhs, RightHandSide: rhs, Sense: sense}
+	for _, constr := range s.ScalarConstraints() {
+		if match, err := ConstraintsMatch(constr, expected); err == nil && match {
+			return
+		}
+	}
+	t.Errorf("Expected a constraint equivalent to %v, but none was added.", expected)
+}
+
+/*
+AssertObjective
+Description:
+
+	Fails the test if the recorded objective does not have the same coefficients and sense as
+	the given expression and sense.
+*/
+func (s *Solver) AssertObjective(t testing.TB, expr optim.ScalarExpression, sense optim.ObjSense) {
+	t.Helper()
+
+	if s.Objective == nil {
+		t.Errorf("Expected an objective to be set, but SetObjective was never called.")
+		return
+	}
+
+	if s.Objective.Sense != sense {
+		t.Errorf("Expected the objective sense to be %v; received %v", sense, s.Objective.Sense)
+	}
+
+	recorded, err := TermsOf(s.Objective.ScalarExpression)
+	if err != nil {
+		t.Errorf("There was an issue reading the recorded objective: %v", err)
+		return
+	}
+	expected, err := TermsOf(expr)
+	if err != nil {
+		t.Errorf("There was an issue reading the expected objective: %v", err)
+		return
+	}
+
+	if !recorded.Equals(expected) {
+		t.Errorf("Expected the objective to be %v; received %v", expr, s.Objective.ScalarExpression)
+	}
+}
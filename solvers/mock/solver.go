@@ -0,0 +1,362 @@
+package mock
+
+/*
+solver.go
+Description:
+	Defines a Solver which does not solve anything. It records every call that is made to it and
+	returns a scripted result from Optimize, so that tests can check how a model was built without
+	needing a real solver (or a license for one).
+*/
+
+import (
+	"fmt"
+
+	"github.com/kwesiRutledge/goop2/optim"
+)
+
+// Type Definitions
+// ================
+
+/*
+Call
+Description:
+
+	A single call made to the mock Solver. Method is the name of the method that was called and
+	Args contains its arguments.
+*/
+type Call struct {
+	Method string
+	Args   []interface{}
+}
+
+/*
+Solver
+Description:
+
+	A recording implementation of optim.Solver (and of optim.IncrementalSolver). Every call is
+	appended to Calls, and the variables, constraints, objective and settings that it receives are
+	saved. Optimize returns
+	Solution and OptimizeError; any method can be made to fail with FailOn.
+*/
+type Solver struct {
+	Calls       []Call
+	Variables   []optim.Variable
+	Constraints []optim.Constraint
+	Objective   *optim.Objective
+	LogShown    bool
+	TimeLimit   float64
+	Deleted     bool
+
+	// Scripted results
+	Solution      optim.Solution
+	OptimizeError error
+	errors        map[string]error
+}
+
+// Functions
+// =========
+
+/*
+NewSolver
+Description:
+
+	Creates a new mock Solver whose Optimize method returns an empty solution with the status
+	OptimizationStatus_OPTIMAL.
+*/
+func NewSolver() *Solver {
+	return &Solver{
+		Solution: optim.Solution{
+			Values: make(map[uint64]float64),
+			Status: optim.OptimizationStatus_OPTIMAL,
+		},
+		errors: make(map[string]error),
+	}
+}
+
+/*
+ReturnSolution
+Description:
+
+	Scripts the solution and error that Optimize returns.
+*/
+func (s *Solver) ReturnSolution(solIn optim.Solution, errIn error) *Solver {
+	s.Solution = solIn
+	s.OptimizeError = errIn
+	return s
+}
+
+/*
+FailOn
+Description:
+
+	Makes the method with the given name (e.g. "AddConstraint") return err. The call is still
+	recorded, but its argument is not saved.
+*/
+func (s *Solver) FailOn(method string, err error) *Solver {
+	if s.errors == nil {
+		s.errors = make(map[string]error)
+	}
+	s.errors[method] = err
+	return s
+}
+
+/*
+record
+Description:
+
+	Records a call to the method and returns the error scripted for it (if any).
+*/
+func (s *Solver) record(method string, args ...interface{}) error {
+	s.Calls = append(s.Calls, Call{Method: method, Args: args})
+	return s.errors[method]
+}
+
+/*
+ShowLog
+Description:
+
+	Records the call and saves whether or not the log should be shown.
+*/
+func (s *Solver) ShowLog(tf bool) error {
+	if err := s.record("ShowLog", tf); err != nil {
+		return err
+	}
+	s.LogShown = tf
+	return nil
+}
+
+/*
+SetTimeLimit
+Description:
+
+	Records the call and saves the time limit (in seconds).
+*/
+func (s *Solver) SetTimeLimit(timeLimit float64) error {
+	if err := s.record("SetTimeLimit", timeLimit); err != nil {
+		return err
+	}
+	s.TimeLimit = timeLimit
+	return nil
+}
+
+/*
+AddVariable
+Description:
+
+	Records the call and saves the variable.
+*/
+func (s *Solver) AddVariable(varIn optim.Variable) error {
+	if err := s.record("AddVariable", varIn); err != nil {
+		return err
+	}
+	s.Variables = append(s.Variables, varIn)
+	return nil
+}
+
+/*
+AddVariables
+Description:
+
+	Records the call and saves the variables.
+*/
+func (s *Solver) AddVariables(varSlice []optim.Variable) error {
+	if err := s.record("AddVariables", varSlice); err != nil {
+		return err
+	}
+	s.Variables = append(s.Variables, varSlice...)
+	return nil
+}
+
+/*
+AddConstraint
+Description:
+
+	Records the call and saves the constraint.
+*/
+func (s *Solver) AddConstraint(constrIn optim.Constraint) error {
+	if err := s.record("AddConstraint", constrIn); err != nil {
+		return err
+	}
+	if !optim.IsConstraint(constrIn) {
+		return fmt.Errorf("The input to AddConstraint is not recognized as a constraint!")
+	}
+	s.Constraints = append(s.Constraints, constrIn)
+	return nil
+}
+
+/*
+SetObjective
+Description:
+
+	Records the call and saves the objective.
+*/
+func (s *Solver) SetObjective(objectiveIn optim.Objective) error {
+	if err := s.record("SetObjective", objectiveIn); err != nil {
+		return err
+	}
+	s.Objective = &objectiveIn
+	return nil
+}
+
+/*
+Optimize
+Description:
+
+	Records the call and returns the scripted solution and error.
+*/
+func (s *Solver) Optimize() (optim.Solution, error) {
+	if err := s.record("Optimize"); err != nil {
+		return optim.Solution{}, err
+	}
+	return s.Solution, s.OptimizeError
+}
+
+/*
+DeleteSolver
+Description:
+
+	Records the call and marks the solver as deleted. The recorded calls and saved data are kept,
+	so that they can still be inspected after Model.Optimize returns.
+*/
+func (s *Solver) DeleteSolver() error {
+	if err := s.record("DeleteSolver"); err != nil {
+		return err
+	}
+	s.Deleted = true
+	return nil
+}
+
+/*
+ClearModel
+Description:
+
+	Records the call and removes the saved variables, constraints and objective.
+*/
+func (s *Solver) ClearModel() error {
+	if err := s.record("ClearModel"); err != nil {
+		return err
+	}
+	s.Variables, s.Constraints, s.Objective = nil, nil, nil
+	return nil
+}
+
+/*
+SetVariableBounds
+Description:
+
+	Records the call and replaces the saved variable with the same ID.
+*/
+func (s *Solver) SetVariableBounds(varIn optim.Variable) error {
+	if err := s.record("SetVariableBounds", varIn); err != nil {
+		return err
+	}
+	for varIndex, tempVar := range s.Variables {
+		if tempVar.ID == varIn.ID {
+			s.Variables[varIndex] = varIn
+			return nil
+		}
+	}
+	return fmt.Errorf("The variable with ID %v was not added to the solver", varIn.ID)
+}
+
+/*
+RemoveVariable
+Description:
+
+	Records the call and removes the saved variable with the same ID.
+*/
+func (s *Solver) RemoveVariable(varIn optim.Variable) error {
+	if err := s.record("RemoveVariable", varIn); err != nil {
+		return err
+	}
+	for varIndex, tempVar := range s.Variables {
+		if tempVar.ID == varIn.ID {
+			s.Variables = append(s.Variables[:varIndex], s.Variables[varIndex+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("The variable with ID %v was not added to the solver", varIn.ID)
+}
+
+/*
+SetConstraint
+Description:
+
+	Records the call and replaces the saved constraint at the given index.
+*/
+func (s *Solver) SetConstraint(index int, constrIn optim.ScalarConstraint) error {
+	if err := s.record("SetConstraint", index, constrIn); err != nil {
+		return err
+	}
+	if index < 0 || index >= len(s.Constraints) {
+		return fmt.Errorf("The solver does not have a constraint with index %v", index)
+	}
+	s.Constraints[index] = constrIn
+	return nil
+}
+
+/*
+RemoveConstraint
+Description:
+
+	Records the call and removes the saved constraint at the given index.
+*/
+func (s *Solver) RemoveConstraint(index int) error {
+	if err := s.record("RemoveConstraint", index); err != nil {
+		return err
+	}
+	if index < 0 || index >= len(s.Constraints) {
+		return fmt.Errorf("The solver does not have a constraint with index %v", index)
+	}
+	s.Constraints = append(s.Constraints[:index], s.Constraints[index+1:]...)
+	return nil
+}
+
+/*
+MethodNames
+Description:
+
+	Returns the names of the recorded calls in the order they were made.
+*/
+func (s *Solver) MethodNames() []string {
+	names := make([]string, len(s.Calls))
+	for callIndex, call := range s.Calls {
+		names[callIndex] = call.Method
+	}
+	return names
+}
+
+/*
+CallsTo
+Description:
+
+	Returns the recorded calls to the method with the given name.
+*/
+func (s *Solver) CallsTo(method string) []Call {
+	var callsOut []Call
+	for _, call := range s.Calls {
+		if call.Method == method {
+			callsOut = append(callsOut, call)
+		}
+	}
+	return callsOut
+}
+
+/*
+ScalarConstraints
+Description:
+
+	Returns the recorded constraints that are scalar constraints.
+*/
+func (s *Solver) ScalarConstraints() []optim.ScalarConstraint {
+	var constrsOut []optim.ScalarConstraint
+	for _, constr := range s.Constraints {
+		switch c := constr.(type) {
+		case optim.ScalarConstraint:
+			constrsOut = append(constrsOut, c)
+		case *optim.ScalarConstraint:
+			constrsOut = append(constrsOut, *c)
+		}
+	}
+	return constrsOut
+}
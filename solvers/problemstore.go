@@ -0,0 +1,218 @@
+package solvers
+
+/*
+problemstore.go
+Description:
+	Defines the store of variables, constraints and the objective that is shared by the pure-Go
+	solvers in this package. Each solver embeds a problemStore and only decides which variable
+	types and objectives it supports.
+*/
+
+import (
+	"fmt"
+
+	"github.com/kwesiRutledge/goop2/optim"
+)
+
+// Type Definitions
+// ================
+
+/*
+problemStore
+Description:
+
+	Holds the problem that is given to a solver through the optim.Solver interface. The name of
+	the solver is used in error messages, variableTypes lists the variable types that the solver
+	supports and checkObjective (if not nil) rejects the objectives that it can not handle.
+*/
+type problemStore struct {
+	Variables   []optim.Variable
+	Constraints []optim.ScalarConstraint
+	Objective   *optim.Objective
+
+	solverName     string
+	variableTypes  []optim.VarType
+	checkObjective func(objIn optim.Objective) error
+	variableIDs    map[uint64]bool
+}
+
+// Functions
+// =========
+
+/*
+newProblemStore
+Description:
+
+	Creates an empty store for the solver with the given name.
+*/
+func newProblemStore(solverName string, checkObjective func(objIn optim.Objective) error, variableTypes ...optim.VarType) problemStore {
+	return problemStore{
+		solverName:     solverName,
+		variableTypes:  variableTypes,
+		checkObjective: checkObjective,
+	}
+}
+
+/*
+AddVariable
+Description:
+
+	Adds a single variable to the problem. The variable must have one of the supported types and
+	must not have been added before.
+*/
+func (ps *problemStore) AddVariable(varIn optim.Variable) error {
+	// Input Checking
+	supported := false
+	for _, vtype := range ps.variableTypes {
+		supported = supported || varIn.Vtype == vtype
+	}
+	if !supported {
+		return fmt.Errorf(
+			"The %v does not support the variable %v, which has type %v.",
+			ps.solverName,
+			varIn.DisplayName(),
+			string(rune(varIn.Vtype)),
+		)
+	}
+
+	if ps.variableIDs[varIn.ID] {
+		return fmt.Errorf("The variable with ID %v was added to the %v more than once.", varIn.ID, ps.solverName)
+	}
+
+	// Algorithm
+	if ps.variableIDs == nil {
+		ps.variableIDs = make(map[uint64]bool)
+	}
+	ps.variableIDs[varIn.ID] = true
+	ps.Variables = append(ps.Variables, varIn)
+
+	return nil
+}
+
+/*
+AddVariables
+Description:
+
+	Adds a set of variables to the problem.
+*/
+func (ps *problemStore) AddVariables(varSliceIn []optim.Variable) error {
+	for _, tempVar := range varSliceIn {
+		err := ps.AddVariable(tempVar)
+		if err != nil {
+			// Terminate early.
+			return fmt.Errorf("Error in AddVariable(): %v", err)
+		}
+	}
+
+	return nil
+}
+
+/*
+AddConstraint
+Description:
+
+	Adds a single scalar constraint to the problem.
+*/
+func (ps *problemStore) AddConstraint(constrIn optim.Constraint) error {
+	// Input Checking
+	if !optim.IsConstraint(constrIn) {
+		return fmt.Errorf("The input to AddConstraint is not recognized as a constraint!")
+	}
+
+	// Algorithm
+	switch constrIn.(type) {
+	case optim.ScalarConstraint:
+		constrAsSC, _ := constrIn.(optim.ScalarConstraint)
+		ps.Constraints = append(ps.Constraints, constrAsSC)
+	case *optim.ScalarConstraint:
+		constrAsSC, _ := constrIn.(*optim.ScalarConstraint)
+		ps.Constraints = append(ps.Constraints, *constrAsSC)
+	default:
+		return fmt.Errorf("Unexpected type of constraint input: %T (%v)", constrIn, constrIn)
+	}
+
+	return nil
+}
+
+/*
+SetObjective
+Description:
+
+	Sets the objective of the problem after checking that the solver supports it.
+*/
+func (ps *problemStore) SetObjective(objIn optim.Objective) error {
+	// Input Checking
+	if ps.checkObjective != nil {
+		if err := ps.checkObjective(objIn); err != nil {
+			return fmt.Errorf("The %v could not use the objective: %v", ps.solverName, err)
+		}
+	}
+
+	// Algorithm
+	ps.Objective = &objIn
+
+	return nil
+}
+
+/*
+ClearModel
+Description:
+
+	Removes all variables, constraints and the objective from the store, so that a model which is
+	kept alive (see optim.Model.KeepSolverAlive) can be loaded again.
+*/
+func (ps *problemStore) ClearModel() error {
+	ps.clearProblem()
+
+	return nil
+}
+
+/*
+DeleteSolver
+Description:
+
+	Removes all variables, constraints and the objective from the store.
+*/
+func (ps *problemStore) DeleteSolver() error {
+	ps.clearProblem()
+
+	return nil
+}
+
+/*
+clearProblem
+Description:
+
+	Removes all variables, constraints and the objective from the store.
+*/
+func (ps *problemStore) clearProblem() {
+	ps.Variables = nil
+	ps.Constraints = nil
+	ps.Objective = nil
+	ps.variableIDs = nil
+}
+
+/*
+removeVariable
+Description:
+
+	Removes the variable at the given index of ps.Variables.
+*/
+func (ps *problemStore) removeVariable(varIndex int) {
+	delete(ps.variableIDs, ps.Variables[varIndex].ID)
+	ps.Variables = append(ps.Variables[:varIndex], ps.Variables[varIndex+1:]...)
+}
+
+/*
+linearObjective
+Description:
+
+	Rejects objectives that are not linear. It is the objective check of the solvers that only
+	handle linear programs.
+*/
+func linearObjective(objIn optim.Objective) error {
+	if _, _, _, err := linearTerms(objIn.ScalarExpression); err != nil {
+		return fmt.Errorf("only linear objectives are supported: %v", err)
+	}
+	return nil
+}
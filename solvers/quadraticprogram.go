@@ -0,0 +1,218 @@
+package solvers
+
+/*
+quadraticprogram.go
+Description:
+	Defines the quadratic program that the pure-Go QP solvers in this package build out of the
+	variables, constraints and objective that they receive through the optim.Solver interface.
+*/
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/kwesiRutledge/goop2/optim"
+	"gonum.org/v1/gonum/mat"
+)
+
+// Type Definitions
+// ================
+
+/*
+quadraticProgram
+Description:
+
+	A quadratic program written in the general form
+
+		optimize   x' Q x + c' x + Offset
+		subject to Rows[i].Coeffs' x (Rows[i].Sense) Rows[i].RHS
+		           Lower <= x <= Upper
+
+	where the linear pieces are stored in the embedded linearProgram and Q is symmetric. Q and
+	the rows are dense, so solvers meant for large sparse problems (such as the ADMMSolver) build
+	their data from the sparse terms of the expressions instead.
+*/
+type quadraticProgram struct {
+	linearProgram
+	Q *mat.SymDense
+}
+
+// Functions
+// =========
+
+/*
+newQuadraticProgram
+Description:
+
+	Collects the variables, scalar constraints and (linear or quadratic) objective given to one
+	of the pure-Go QP solvers into a quadraticProgram. A nil objective is treated as the
+	objective 0.
+*/
+func newQuadraticProgram(vars []optim.Variable, constrs []optim.ScalarConstraint, obj *optim.Objective) (quadraticProgram, error) {
+	// Create the linear part of the program
+	linProg, err := newLinearProgram(vars, constrs, nil)
+	if err != nil {
+		return quadraticProgram{}, err
+	}
+
+	qp := quadraticProgram{
+		linearProgram: linProg,
+		Q:             mat.NewSymDense(len(vars), nil),
+	}
+	if obj == nil {
+		return qp, nil
+	}
+
+	// Collect the objective
+	qp.Sense = obj.Sense
+	ids, Q, linIDs, linCoeffs, constant, err := quadraticTerms(obj.ScalarExpression)
+	if err != nil {
+		return qp, fmt.Errorf("There was an issue converting the objective: %v", err)
+	}
+
+	for termIndex, id := range linIDs {
+		varIndex, found := qp.idToIndex[id]
+		if !found {
+			return qp, fmt.Errorf("The objective contains a variable with ID %v which was never added to the solver.", id)
+		}
+		qp.C[varIndex] += linCoeffs[termIndex]
+	}
+	qp.Offset = constant
+
+	for i, id1 := range ids {
+		varIndex1, found := qp.idToIndex[id1]
+		if !found {
+			return qp, fmt.Errorf("The objective contains a variable with ID %v which was never added to the solver.", id1)
+		}
+		for j := i; j < len(ids); j++ {
+			varIndex2 := qp.idToIndex[ids[j]]
+			qp.Q.SetSym(varIndex1, varIndex2, qp.Q.At(varIndex1, varIndex2)+Q.At(i, j))
+		}
+	}
+
+	return qp, nil
+}
+
+/*
+MinimizationHessian
+Description:
+
+	Returns the Hessian H of the minimization form of the objective, so that the objective to be
+	minimized is 1/2 x' H x + MinimizationCost()' x.
+*/
+func (qp quadraticProgram) MinimizationHessian() *mat.SymDense {
+	// Constants
+	n := len(qp.Variables)
+	factor := 2.0
+	if qp.Sense == optim.SenseMaximize {
+		factor = -2.0
+	}
+
+	// Algorithm
+	H := mat.NewSymDense(n, nil)
+	H.ScaleSym(factor, qp.Q)
+	return H
+}
+
+/*
+ObjectiveValue
+Description:
+
+	Evaluates the objective of the quadratic program (in its original sense) at x.
+*/
+func (qp quadraticProgram) ObjectiveValue(x []float64) float64 {
+	xVec := mat.NewVecDense(len(x), x)
+	return qp.linearProgram.ObjectiveValue(x) + mat.Inner(xVec, qp.Q, xVec)
+}
+
+/*
+quadraticTerms
+Description:
+
+	Extracts the terms of a linear or quadratic scalar expression e, written as
+		e = x' Q x + linCoeffs' x_lin + constant
+	where x contains the variables with IDs ids (each ID appears only once) and Q is symmetric.
+	Only variables which appear in a nonzero quadratic term are in ids, so Q is built from the
+	sparse terms of e. For linear expressions, ids is empty and Q is nil.
+*/
+func quadraticTerms(se optim.ScalarExpression) (ids []uint64, Q *mat.SymDense, linIDs []uint64, linCoeffs []float64, constant float64, err error) {
+	terms, err := optim.SparseTermsOf(se)
+	if err != nil {
+		return nil, nil, nil, nil, 0.0, fmt.Errorf("expected a linear or quadratic expression, but received expression of type %T: %v", se, err)
+	}
+
+	for _, term := range terms.Linear {
+		linIDs = append(linIDs, term.ID)
+		linCoeffs = append(linCoeffs, term.Coeff)
+	}
+	if len(terms.Quadratic) == 0 {
+		return nil, nil, linIDs, linCoeffs, terms.Constant, nil
+	}
+
+	// Number the variables of the quadratic terms
+	idToIndex := make(map[uint64]int)
+	for _, term := range terms.Quadratic {
+		for _, id := range []uint64{term.ID1, term.ID2} {
+			if _, found := idToIndex[id]; !found {
+				idToIndex[id] = len(ids)
+				ids = append(ids, id)
+			}
+		}
+	}
+
+	// Split each cross term evenly between Q[i][j] and Q[j][i]
+	Q = mat.NewSymDense(len(ids), nil)
+	for _, term := range terms.Quadratic {
+		i, j := idToIndex[term.ID1], idToIndex[term.ID2]
+		if i == j {
+			Q.SetSym(i, i, Q.At(i, i)+term.Coeff)
+		} else {
+			Q.SetSym(i, j, Q.At(i, j)+0.5*term.Coeff)
+		}
+	}
+
+	return ids, Q, linIDs, linCoeffs, terms.Constant, nil
+}
+
+/*
+checkConvexity
+Description:
+
+	Verifies that the objective x' Q x is convex when it is minimized (Q is positive
+	semidefinite) or concave when it is maximized (Q is negative semidefinite).
+*/
+func checkConvexity(Q *mat.SymDense, sense optim.ObjSense, tol float64) error {
+	// Input Checking
+	if Q == nil {
+		return nil
+	}
+
+	// Compute eigenvalues
+	var eig mat.EigenSym
+	if ok := eig.Factorize(Q, false); !ok {
+		return fmt.Errorf("could not compute the eigenvalues of the quadratic objective's matrix")
+	}
+	eigenvalues := eig.Values(nil)
+
+	maxMagnitude := 1.0
+	for _, value := range eigenvalues {
+		maxMagnitude = math.Max(maxMagnitude, math.Abs(value))
+	}
+
+	for _, value := range eigenvalues {
+		if sense == optim.SenseMaximize && value > tol*maxMagnitude {
+			return fmt.Errorf(
+				"the quadratic objective is not concave (its matrix has the positive eigenvalue %v), so it can not be maximized by a convex solver",
+				value,
+			)
+		}
+		if sense != optim.SenseMaximize && value < -tol*maxMagnitude {
+			return fmt.Errorf(
+				"the quadratic objective is not convex (its matrix has the negative eigenvalue %v), so it can not be minimized by a convex solver",
+				value,
+			)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,146 @@
+package solvers
+
+/*
+sparsematrix.go
+Description:
+	Defines a minimal compressed sparse row matrix that the iterative solvers in this package
+	use to evaluate products with large, mostly empty constraint matrices.
+*/
+
+import "sort"
+
+// Type Definitions
+// ================
+
+/*
+sparseVector
+Description:
+
+	Stores the nonzero entries of a vector (or of one row of a sparseMatrix).
+*/
+type sparseVector struct {
+	Indices []int
+	Values  []float64
+}
+
+/*
+sparseMatrix
+Description:
+
+	A matrix with NumCols columns stored row by row, where each row only stores its nonzero
+	entries.
+*/
+type sparseMatrix struct {
+	NumCols int
+	Rows    []sparseVector
+}
+
+// Functions
+// =========
+
+/*
+newSparseVector
+Description:
+
+	Creates a sparseVector out of the nonzero entries of the dense slice denseIn.
+*/
+func newSparseVector(denseIn []float64) sparseVector {
+	var sv sparseVector
+	for index, value := range denseIn {
+		if value != 0.0 {
+			sv.Indices = append(sv.Indices, index)
+			sv.Values = append(sv.Values, value)
+		}
+	}
+	return sv
+}
+
+/*
+newSparseVectorFromEntries
+Description:
+
+	Creates a sparseVector out of the entries (indices[k], values[k]). Entries with the same index
+	are summed, zeros are dropped and the result is sorted by index.
+*/
+func newSparseVectorFromEntries(indices []int, values []float64) sparseVector {
+	// Constants
+	valueAt := make(map[int]float64, len(indices))
+	var sv sparseVector
+
+	// Algorithm
+	for k, index := range indices {
+		if _, found := valueAt[index]; !found {
+			sv.Indices = append(sv.Indices, index)
+		}
+		valueAt[index] += values[k]
+	}
+	sort.Ints(sv.Indices)
+
+	nonzero := sv.Indices[:0]
+	for _, index := range sv.Indices {
+		if valueAt[index] != 0.0 {
+			nonzero = append(nonzero, index)
+			sv.Values = append(sv.Values, valueAt[index])
+		}
+	}
+	sv.Indices = nonzero
+
+	return sv
+}
+
+/*
+Dot
+Description:
+
+	Computes the inner product of the sparse vector with the dense slice x.
+*/
+func (sv sparseVector) Dot(x []float64) float64 {
+	sum := 0.0
+	for k, index := range sv.Indices {
+		sum += sv.Values[k] * x[index]
+	}
+	return sum
+}
+
+/*
+NumRows
+Description:
+
+	Returns the number of rows in the matrix.
+*/
+func (sm sparseMatrix) NumRows() int {
+	return len(sm.Rows)
+}
+
+/*
+MulVec
+Description:
+
+	Computes the product of the matrix with the dense vector x.
+*/
+func (sm sparseMatrix) MulVec(x []float64) []float64 {
+	out := make([]float64, len(sm.Rows))
+	for rowIndex, row := range sm.Rows {
+		out[rowIndex] = row.Dot(x)
+	}
+	return out
+}
+
+/*
+MulTransVec
+Description:
+
+	Computes the product of the transpose of the matrix with the dense vector y.
+*/
+func (sm sparseMatrix) MulTransVec(y []float64) []float64 {
+	out := make([]float64, sm.NumCols)
+	for rowIndex, row := range sm.Rows {
+		if y[rowIndex] == 0.0 {
+			continue
+		}
+		for k, colIndex := range row.Indices {
+			out[colIndex] += row.Values[k] * y[rowIndex]
+		}
+	}
+	return out
+}
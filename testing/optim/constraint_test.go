@@ -49,7 +49,7 @@ func TestConstraint_IsConstraint2(t *testing.T) {
 	// Create a scalar constraint.
 
 	lhs0 := optim.OnesVector(4)
-	x := m.AddVariableClassic(0, 3.0, optim.Continuous)
+	x, _ := m.AddVariableClassic(0, 3.0, optim.Continuous)
 	vv1 := optim.VarVector{
 		Elements: []optim.Variable{x, x, x, x},
 	}
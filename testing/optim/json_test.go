@@ -0,0 +1,249 @@
+package optim_test
+
+/*
+json_test.go
+Description:
+	Tests for the JSON encoding of models, expressions and constraints.
+*/
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kwesiRutledge/goop2/optim"
+	"gonum.org/v1/gonum/mat"
+)
+
+/*
+TestModel_JSON1
+Description:
+
+	Writes a model with every kind of scalar expression, infinite bounds and settings to JSON and
+	verifies that it is read back exactly.
+*/
+func TestModel_JSON1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x, _ := m.AddVariableClassic(math.Inf(-1), 10, optim.Continuous)
+	y := m.AddVariable()
+	z, _ := m.AddVariableClassic(-5, 5, optim.Integer)
+	b := m.AddBinaryVariable()
+	m.ShowLog(true)
+	m.SetTimeLimit(90 * time.Second)
+
+	vv := optim.VarVector{Elements: []optim.Variable{x, y}}
+	sle := optim.ScalarLinearExpr{X: vv, L: *mat.NewVecDense(2, []float64{0.1, -2}), C: 1.0 / 3.0}
+	m.AddConstr(sle.LessEq(z))
+	m.AddConstr(b.GreaterEq(optim.K(math.Inf(-1))))
+	m.AddConstr(optim.K(4).Eq(sle))
+
+	sqe := optim.ScalarQuadraticExpression{
+		Q: *mat.NewDense(2, 2, []float64{1, 0.5, 0.5, 3}),
+		L: *mat.NewVecDense(2, []float64{2, -1}),
+		C: 7,
+		X: vv,
+	}
+	m.AddConstr(sqe.LessEq(optim.K(100)))
+	m.SetObjective(sqe, optim.SenseMaximize)
+
+	// Algorithm
+	var buf bytes.Buffer
+	if err := m.WriteJSON(&buf); err != nil {
+		t.Fatalf("There was an issue writing the model: %v", err)
+	}
+	firstJSON := buf.String()
+
+	m2, err := optim.ReadJSON(&buf)
+	if err != nil {
+		t.Fatalf("There was an issue reading the model: %v", err)
+	}
+
+	if !reflect.DeepEqual(m, m2) {
+		t.Errorf("Expected the model to be read back exactly; wrote\n%v", firstJSON)
+	}
+
+	var buf2 bytes.Buffer
+	if err := m2.WriteJSON(&buf2); err != nil {
+		t.Fatalf("There was an issue writing the model a second time: %v", err)
+	}
+	if buf2.String() != firstJSON {
+		t.Errorf("Expected the JSON to be identical after a round trip; received\n%v\nand\n%v", firstJSON, buf2.String())
+	}
+
+	for _, expected := range []string{`"version": 1`, `"lower": "-Infinity"`, `"kind": "ScalarQuadraticExpression"`, `"sense": "maximize"`} {
+		if !strings.Contains(firstJSON, expected) {
+			t.Errorf("Expected the JSON to contain %v; received\n%v", expected, firstJSON)
+		}
+	}
+}
+
+/*
+TestModel_JSON2
+Description:
+
+	Verifies that models with an unknown schema version or malformed contents are rejected.
+*/
+func TestModel_JSON2(t *testing.T) {
+	// Constants
+	badModels := []string{
+		`{"version": 2, "variables": [], "constraints": []}`,
+		`{"version": 1, "variables": [{"id": 0, "lower": 0, "upper": 1, "type": "Q"}], "constraints": []}`,
+		`{"version": 1, "variables": [], "constraints": [{"kind": "ScalarConstraint", "lhs": {"kind": "Foo"}, "rhs": {"kind": "K", "value": 1}, "sense": "<="}]}`,
+		`{"version": 1, "variables": [], "constraints": [{"kind": "ScalarConstraint", "lhs": {"kind": "K", "value": 0}, "rhs": {"kind": "K", "value": 1}, "sense": "<"}]}`,
+	}
+
+	// Algorithm
+	for _, badModel := range badModels {
+		if _, err := optim.ReadJSON(strings.NewReader(badModel)); err == nil {
+			t.Errorf("Expected an error reading %v, but received none.", badModel)
+		}
+	}
+}
+
+/*
+TestVectorConstraint_JSON1
+Description:
+
+	Verifies that vector constraints built from a VectorLinearExpr and a KVector can be written
+	to and read from JSON.
+*/
+func TestVectorConstraint_JSON1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	vv, _ := m.AddVariableVectorClassic(2, 0, math.Inf(1), optim.Continuous)
+
+	vle := optim.VectorLinearExpr{
+		X: vv,
+		L: *mat.NewDense(2, 2, []float64{1, 2, 3, 4}),
+		C: *mat.NewVecDense(2, []float64{0, -1}),
+	}
+	kv := optim.KVector(*mat.NewVecDense(2, []float64{5, math.Inf(1)}))
+	vc := optim.VectorConstraint{LeftHandSide: vle, RightHandSide: kv, Sense: optim.SenseLessThanEqual}
+
+	// Algorithm
+	data, err := json.Marshal(vc)
+	if err != nil {
+		t.Fatalf("There was an issue writing the constraint: %v", err)
+	}
+
+	var vc2 optim.VectorConstraint
+	if err := json.Unmarshal(data, &vc2); err != nil {
+		t.Fatalf("There was an issue reading the constraint: %v", err)
+	}
+
+	if !reflect.DeepEqual(vc, vc2) {
+		t.Errorf("Expected the constraint %v to be read back exactly; received %v", vc, vc2)
+	}
+
+	// The variable vector on its own
+	data, err = json.Marshal(vv)
+	if err != nil {
+		t.Fatalf("There was an issue writing the variable vector: %v", err)
+	}
+
+	var vv2 optim.VarVector
+	if err := json.Unmarshal(data, &vv2); err != nil {
+		t.Fatalf("There was an issue reading the variable vector: %v", err)
+	}
+	if !reflect.DeepEqual(vv, vv2) {
+		t.Errorf("Expected the variable vector %v to be read back exactly; received %v", vv, vv2)
+	}
+}
+
+/*
+TestMatrixConstraint_JSON1
+Description:
+
+	Verifies that a named matrix constraint between a MatrixLinearExpr and a KMatrix (and a model
+	holding it) can be written to and read from JSON.
+*/
+func TestMatrixConstraint_JSON1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	X, _ := m.AddVariableMatrix(2, 2, 0, math.Inf(1), optim.Continuous)
+
+	XPlusXT, _ := X.Plus(X.T())
+	mc, err := XPlusXT.LessEq(optim.KMatrix(*mat.NewDense(2, 2, []float64{1, math.Inf(1), 2, 3})))
+	if err != nil {
+		t.Fatalf("There was an issue creating the constraint: %v", err)
+	}
+	mc.Name = "sym"
+
+	// Algorithm
+	data, err := json.Marshal(mc)
+	if err != nil {
+		t.Fatalf("There was an issue writing the constraint: %v", err)
+	}
+
+	var mc2 optim.MatrixConstraint
+	if err := json.Unmarshal(data, &mc2); err != nil {
+		t.Fatalf("There was an issue reading the constraint: %v", err)
+	}
+	if !reflect.DeepEqual(mc, mc2) {
+		t.Errorf("Expected the constraint %v to be read back exactly; received %v", mc, mc2)
+	}
+
+	// The model holding the constraint and a VarMatrix on its own
+	if _, err := m.AddConstr(mc); err != nil {
+		t.Fatalf("There was an issue adding the constraint: %v", err)
+	}
+	if _, err := m.AddConstr(X.GreaterEq(optim.K(0))); err != nil {
+		t.Fatalf("There was an issue adding the constraint: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := m.WriteJSON(&buf); err != nil {
+		t.Fatalf("There was an issue writing the model: %v", err)
+	}
+	firstJSON := buf.String()
+
+	m2, err := optim.ReadJSON(&buf)
+	if err != nil {
+		t.Fatalf("There was an issue reading the model: %v", err)
+	}
+	if !reflect.DeepEqual(m, m2) {
+		t.Errorf("Expected the model to be read back exactly; wrote\n%v", firstJSON)
+	}
+}
+
+/*
+TestVectorQuadraticExpression_JSON1
+Description:
+
+	Verifies that a quadratic vector constraint can be written to and read from JSON.
+*/
+func TestVectorQuadraticExpression_JSON1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x, _ := m.AddVariableVector(2)
+
+	vqe, err := optim.NewVectorQuadraticExpression(
+		[]mat.Dense{*mat.NewDense(2, 2, []float64{1, 0, 0, 2}), *mat.NewDense(2, 2, []float64{0, 1, 1, 0})},
+		*mat.NewDense(2, 2, []float64{1, 2, 3, 4}),
+		*mat.NewVecDense(2, []float64{-1, math.Inf(-1)}),
+		x,
+	)
+	if err != nil {
+		t.Fatalf("There was an issue creating the expression: %v", err)
+	}
+	vc, _ := vqe.LessEq(x)
+
+	// Algorithm
+	data, err := json.Marshal(vc)
+	if err != nil {
+		t.Fatalf("There was an issue writing the constraint: %v", err)
+	}
+
+	var vc2 optim.VectorConstraint
+	if err := json.Unmarshal(data, &vc2); err != nil {
+		t.Fatalf("There was an issue reading the constraint: %v", err)
+	}
+	if !reflect.DeepEqual(vc, vc2) {
+		t.Errorf("Expected the constraint %v to be read back exactly; received %v", vc, vc2)
+	}
+}
@@ -0,0 +1,144 @@
+package optim_test
+
+/*
+linear_expr_builder_test.go
+Description:
+	Tests for the LinearExprBuilder object and the sums which use it.
+*/
+
+import (
+	"testing"
+
+	"github.com/kwesiRutledge/goop2/optim"
+	"gonum.org/v1/gonum/mat"
+)
+
+/*
+TestLinearExprBuilder_AddTerm1
+Description:
+
+	Adds terms with a repeated variable and a constant and verifies the built expression. Also
+	verifies that the built expression does not share data with the builder.
+*/
+func TestLinearExprBuilder_AddTerm1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x := m.AddVariable()
+	y := m.AddVariable()
+
+	// Algorithm
+	var b optim.LinearExprBuilder
+	b.AddTerm(2, x)
+	b.AddTerm(-1, y)
+	b.AddTerm(3, x)
+	b.AddConstant(4)
+
+	sle := b.Build()
+	expected := optim.ScalarLinearExpr{
+		X: optim.VarVector{Elements: []optim.Variable{x, y}},
+		L: *mat.NewVecDense(2, []float64{5, -1}),
+		C: 4,
+	}
+	if sle.X.Len() != 2 || sle.X.Elements[0].ID != x.ID || !sle.Equals(expected, 0) {
+		t.Errorf("Expected the expression %v; received %v", expected, sle)
+	}
+
+	b.AddTerm(1, y)
+	if sle.L.AtVec(1) != -1 {
+		t.Errorf("Expected the built expression to not change with the builder; received %v", sle)
+	}
+	if sparse := b.BuildSparse(); len(sparse.Linear) != 1 || sparse.NumVars() != 2 {
+		t.Errorf("Expected the sparse expression to keep only the term 5 x; received %v", sparse)
+	}
+
+	b.Reset()
+	if b.NumVars() != 0 || b.Build().Constant() != 0 {
+		t.Errorf("Expected the builder to be empty after Reset; received %v", b.Build())
+	}
+}
+
+/*
+TestLinearExprBuilder_Add1
+Description:
+
+	Adds constants, variables and dense and sparse linear expressions to a builder and verifies
+	that quadratic expressions are rejected.
+*/
+func TestLinearExprBuilder_Add1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x := m.AddVariable()
+	y := m.AddVariable()
+
+	twoX, _ := x.Mult(2)
+	sparseY := optim.SparseScalarLinearExpr{
+		X:      optim.VarVector{Elements: []optim.Variable{y}},
+		Linear: []optim.LinearTerm{{ID: y.ID, Coeff: -3}},
+		C:      1,
+	}
+
+	// Algorithm
+	b := optim.NewLinearExprBuilder(2)
+	for _, se := range []optim.ScalarExpression{optim.K(2), y, twoX, sparseY} {
+		if err := b.Add(se); err != nil {
+			t.Fatalf("There was an issue adding %v: %v", se, err)
+		}
+	}
+
+	expected := optim.ScalarLinearExpr{
+		X: optim.VarVector{Elements: []optim.Variable{x, y}},
+		L: *mat.NewVecDense(2, []float64{2, -2}),
+		C: 3,
+	}
+	assertSameTerms(t, b.Build(), expected)
+
+	xy, _ := x.Multiply(y)
+	if err := b.Add(xy.(optim.ScalarExpression)); err == nil {
+		t.Errorf("Expected an error adding a quadratic expression, but received none.")
+	}
+}
+
+/*
+TestLinearExprBuilder_Sum1
+Description:
+
+	Sums 50000 variables with Sum, Dot and a builder. Each sum is built in linear time, so this
+	test runs quickly; adding the terms one at a time with Plus would copy the expression on every
+	step.
+*/
+func TestLinearExprBuilder_Sum1(t *testing.T) {
+	// Constants
+	n := 50000
+	m := optim.NewModel()
+	x, _ := m.AddVariableVector(n)
+
+	terms := make([]interface{}, n)
+	coeffs := make([]float64, n)
+	for eltIndex, tempVar := range x.Elements {
+		terms[eltIndex] = tempVar
+		coeffs[eltIndex] = float64(eltIndex)
+	}
+
+	// Algorithm
+	sum, err := optim.Sum(terms...)
+	if err != nil {
+		t.Fatalf("There was an issue computing the sum: %v", err)
+	}
+	sumAsSLE, ok := sum.(optim.ScalarLinearExpr)
+	if !ok || sumAsSLE.X.Len() != n || sumAsSLE.L.AtVec(n-1) != 1 {
+		t.Errorf("Expected the sum of %v variables; received an expression of type %T", n, sum)
+	}
+
+	dot := optim.Dot(x.Elements, coeffs)
+	if dot.NumVars() != n || dot.Coeffs()[n-1] != float64(n-1) {
+		t.Errorf("Expected the dot product to have %v variables; received %v", n, dot.NumVars())
+	}
+
+	var b optim.LinearExprBuilder
+	for _, tempVar := range x.Elements {
+		b.AddTerm(1, tempVar)
+	}
+	if !b.Build().Equals(sumAsSLE, 0) {
+		t.Errorf("Expected the builder to give the same expression as Sum.")
+	}
+}
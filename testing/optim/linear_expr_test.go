@@ -1,8 +1,10 @@
 package optim_test
 
 import (
+	"fmt"
 	"github.com/kwesiRutledge/goop2/optim"
 	"gonum.org/v1/gonum/mat"
+	"strings"
 	"testing"
 )
 
@@ -78,7 +80,7 @@ func TestScalarLinearExpr_Plus1(t *testing.T) {
 	c2 := 5.0
 
 	m := optim.NewModel()
-	vv1 := m.AddVariableVector(2)
+	vv1, _ := m.AddVariableVector(2)
 
 	// Create sle's
 	sle1 := optim.ScalarLinearExpr{
@@ -130,7 +132,7 @@ func TestScalarLinearExpr_Plus2(t *testing.T) {
 	c2 := 5.0
 
 	m := optim.NewModel()
-	vv1 := m.AddVariableVector(3)
+	vv1, _ := m.AddVariableVector(3)
 
 	vv2 := optim.VarVector{
 		vv1.Elements[:2],
@@ -214,7 +216,7 @@ func TestScalarLinearExpr_Plus3(t *testing.T) {
 	K1 := optim.K(5)
 
 	m := optim.NewModel()
-	vv1 := m.AddVariableVector(2)
+	vv1, _ := m.AddVariableVector(2)
 
 	// Create sle's
 	sle1 := optim.ScalarLinearExpr{
@@ -265,9 +267,9 @@ func TestScalarLinearExpression_Plus3(t *testing.T) {
 	// Constants
 	m := optim.NewModel()
 
-	v1 := m.AddVariableClassic(-10, 10, optim.Continuous)
-	v2 := m.AddVariableClassic(-10, 10, optim.Continuous)
-	v3 := m.AddVariableClassic(-10, 10, optim.Continuous)
+	v1, _ := m.AddVariableClassic(-10, 10, optim.Continuous)
+	v2, _ := m.AddVariableClassic(-10, 10, optim.Continuous)
+	v3, _ := m.AddVariableClassic(-10, 10, optim.Continuous)
 
 	Q1_aoa := [][]float64{
 		[]float64{1.0, 2.0},
@@ -352,9 +354,9 @@ func TestScalarLinearExpression_Plus4(t *testing.T) {
 	// Constants
 	m := optim.NewModel()
 
-	v1 := m.AddVariableClassic(-10, 10, optim.Continuous)
-	v2 := m.AddVariableClassic(-10, 10, optim.Continuous)
-	v3 := m.AddVariableClassic(-10, 10, optim.Continuous)
+	v1, _ := m.AddVariableClassic(-10, 10, optim.Continuous)
+	v2, _ := m.AddVariableClassic(-10, 10, optim.Continuous)
+	v3, _ := m.AddVariableClassic(-10, 10, optim.Continuous)
 
 	// Quantities for Second Expression
 	L2 := *mat.NewVecDense(2, []float64{2.0, 11.0})
@@ -403,3 +405,165 @@ func TestScalarLinearExpression_Plus4(t *testing.T) {
 	}
 
 }
+
+/*
+TestScalarLinearExpression_Minus1
+Description:
+
+	Computes x - 2 y - 3 by passing the results of Mult directly to Minus and verifies that
+	errors given to Minus are returned.
+*/
+func TestScalarLinearExpression_Minus1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x := m.AddVariable()
+	y := m.AddVariable()
+
+	// Algorithm
+	diff, err := x.Minus(y.Mult(2))
+	if err != nil {
+		t.Fatalf("There was an issue computing x - 2 y: %v", err)
+	}
+	diff, err = diff.Minus(3.0)
+	if err != nil {
+		t.Fatalf("There was an issue subtracting a float64: %v", err)
+	}
+
+	expected := optim.ScalarLinearExpr{
+		X: optim.VarVector{Elements: []optim.Variable{x, y}},
+		L: *mat.NewVecDense(2, []float64{1, -2}),
+		C: -3,
+	}
+	assertSameTerms(t, diff, expected)
+
+	// The difference of an expression with itself is zero
+	zero, err := diff.Minus(diff)
+	if err != nil {
+		t.Fatalf("There was an issue subtracting the expression from itself: %v", err)
+	}
+	assertSameTerms(t, zero, optim.ScalarLinearExpr{X: expected.X, L: *mat.NewVecDense(2, nil), C: 0})
+
+	// Errors given as extras are returned
+	if _, err := x.Minus(y, fmt.Errorf("test error")); err == nil || !strings.Contains(err.Error(), "test error") {
+		t.Errorf("Expected the error given to Minus to be returned; received %v", err)
+	}
+	if _, err := x.Minus("y"); err == nil {
+		t.Errorf("Expected an error subtracting a string, but received none.")
+	}
+}
+
+/*
+TestScalarLinearExpression_Div1
+Description:
+
+	Divides a linear expression by a constant, verifies that the input is unchanged and that
+	division by zero returns an error.
+*/
+func TestScalarLinearExpression_Div1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x := m.AddVariable()
+	sle := optim.ScalarLinearExpr{
+		X: optim.VarVector{Elements: []optim.Variable{x}},
+		L: *mat.NewVecDense(1, []float64{4}),
+		C: 2,
+	}
+
+	// Algorithm
+	half, err := sle.Div(2)
+	if err != nil {
+		t.Fatalf("There was an issue dividing by 2: %v", err)
+	}
+	halfAsSLE, ok := half.(optim.ScalarLinearExpr)
+	if !ok || halfAsSLE.L.AtVec(0) != 2 || halfAsSLE.C != 1 {
+		t.Errorf("Expected the expression 2 x + 1; received %v", half)
+	}
+	if sle.L.AtVec(0) != 4 || sle.C != 2 {
+		t.Errorf("Expected the input to be unchanged; received %v", sle)
+	}
+
+	if negated := sle.Neg().(optim.ScalarLinearExpr); negated.L.AtVec(0) != -4 || negated.C != -2 {
+		t.Errorf("Expected the negation -4 x - 2; received %v", negated)
+	}
+
+	if _, err := sle.Div(0); err == nil {
+		t.Errorf("Expected an error dividing by zero, but received none.")
+	}
+}
+
+/*
+TestScalarLinearExpression_Simplify1
+Description:
+
+	Simplifies the expression 2 y + x - x + 3 y + 1, written with duplicate variables, and verifies
+	that the result is 5 y + 1 with x dropped.
+*/
+func TestScalarLinearExpression_Simplify1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x := m.AddVariable()
+	y := m.AddVariable()
+
+	sle := optim.ScalarLinearExpr{
+		X: optim.VarVector{Elements: []optim.Variable{y, x, x, y}},
+		L: *mat.NewVecDense(4, []float64{2, 1, -1, 3}),
+		C: 1,
+	}
+
+	// Algorithm
+	simplified := sle.Simplify()
+	if simplified.X.Len() != 1 || simplified.X.Elements[0].ID != y.ID {
+		t.Fatalf("Expected the simplified expression to only contain y; received %v", simplified.X)
+	}
+	if simplified.L.AtVec(0) != 5 || simplified.C != 1 {
+		t.Errorf("Expected the simplified expression 5 y + 1; received %v", simplified)
+	}
+	if !simplified.Equals(sle, 0) || !sle.Equals(simplified, 0) {
+		t.Errorf("Expected the simplified expression to equal the original expression.")
+	}
+
+	// Variables are sorted by ID
+	yPlusX := optim.ScalarLinearExpr{
+		X: optim.VarVector{Elements: []optim.Variable{y, x}},
+		L: *mat.NewVecDense(2, []float64{1, 1}),
+	}
+	if sorted := yPlusX.Simplify(); sorted.X.Elements[0].ID != x.ID {
+		t.Errorf("Expected the variables to be sorted by ID; received %v", sorted.X)
+	}
+}
+
+/*
+TestScalarLinearExpression_Equals1
+Description:
+
+	Compares scalar expressions of different types which describe the same function, and
+	expressions which differ by less and more than the tolerance.
+*/
+func TestScalarLinearExpression_Equals1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x := m.AddVariable()
+	y := m.AddVariable()
+
+	xAsSLE := optim.ScalarLinearExpr{
+		X: optim.VarVector{Elements: []optim.Variable{x, y}},
+		L: *mat.NewVecDense(2, []float64{1, 0}),
+	}
+
+	// Algorithm
+	if !xAsSLE.Equals(x, 0) || !x.Equals(xAsSLE, 0) {
+		t.Errorf("Expected x to equal the expression 1 x + 0 y.")
+	}
+	if x.Equals(y, 0) {
+		t.Errorf("Expected x to not equal y.")
+	}
+
+	close, _ := xAsSLE.Plus(optim.K(1e-9))
+	if !close.Equals(x, 1e-6) || close.Equals(x, 1e-12) {
+		t.Errorf("Expected x + 1e-9 to equal x only within the tolerance 1e-6.")
+	}
+
+	if !optim.K(2).Equals(2.0, 0) || optim.K(2).Equals("2", 0) {
+		t.Errorf("Expected K(2) to equal the float64 2 and to not equal a string.")
+	}
+}
@@ -0,0 +1,289 @@
+package optim_test
+
+/*
+lp_reader_test.go
+Description:
+	Tests for the function that reads a model written in the CPLEX LP format.
+*/
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/kwesiRutledge/goop2/optim"
+	"github.com/kwesiRutledge/goop2/solvers/mock"
+	"gonum.org/v1/gonum/mat"
+)
+
+/*
+TestReadLP1
+Description:
+
+	Reads a small mixed integer LP with comments, labels, bounds and integer sections.
+*/
+func TestReadLP1(t *testing.T) {
+	// Constants
+	lpFile := strings.Join([]string{
+		"\\ A small test problem",
+		"Maximize",
+		" profit: 3 x + 2y - z",
+		"Subject To",
+		" capacity: x + y + z <= 10",
+		" x - y >= -2",
+		" balance: 2 x + 3.5 z",
+		"   + b = 7",
+		"Bounds",
+		" x <= 4",
+		" -1 <= y <= 1e1",
+		" z free",
+		"General",
+		" x",
+		"Binary",
+		" b",
+		"End",
+	}, "\n")
+
+	// Algorithm
+	m, vars, err := optim.ReadLP(strings.NewReader(lpFile))
+	if err != nil {
+		t.Fatalf("There was an issue reading the LP file: %v", err)
+	}
+
+	if len(m.Variables) != 4 {
+		t.Fatalf("Expected 4 variables; received %v", len(m.Variables))
+	}
+
+	x, y, z, b := vars["x"], vars["y"], vars["z"], vars["b"]
+	if x.Lower != 0 || x.Upper != 4 || x.Vtype != optim.Integer {
+		t.Errorf("Expected x to be an integer variable in [0, 4]; received %v", x)
+	}
+	if y.Lower != -1 || y.Upper != 10 || y.Vtype != optim.Continuous {
+		t.Errorf("Expected y to be a continuous variable in [-1, 10]; received %v", y)
+	}
+	if z.Lower > -1e30 || z.Upper < 1e30 {
+		t.Errorf("Expected z to be free; received bounds [%v, %v]", z.Lower, z.Upper)
+	}
+	if b.Lower != 0 || b.Upper != 1 || b.Vtype != optim.Binary {
+		t.Errorf("Expected b to be a binary variable; received %v", b)
+	}
+
+	// Check the constraints and objective that the model loads into a solver
+	solver := mock.NewSolver()
+	if _, err := m.Optimize(solver); err != nil {
+		t.Fatalf("There was an issue loading the model into the mock solver: %v", err)
+	}
+
+	solver.AssertNumConstraints(t, 3)
+
+	sum1, _ := x.Plus(y)
+	sum1, _ = sum1.Plus(z)
+	solver.AssertConstraint(t, 0, sum1, optim.SenseLessThanEqual, optim.K(10))
+
+	diff2, _ := x.Plus(y.Mult(-1))
+	solver.AssertConstraint(t, 1, diff2, optim.SenseGreaterThanEqual, optim.K(-2))
+
+	sum3, _ := x.Mult(2)
+	sum3, _ = sum3.Plus(z.Mult(3.5))
+	sum3, _ = sum3.Plus(b)
+	solver.AssertConstraint(t, 2, sum3, optim.SenseEqual, optim.K(7))
+
+	obj, _ := x.Mult(3)
+	obj, _ = obj.Plus(y.Mult(2))
+	obj, _ = obj.Plus(z.Mult(-1))
+	solver.AssertObjective(t, obj, optim.SenseMaximize)
+}
+
+/*
+TestReadLP2
+Description:
+
+	Reads a quadratic objective and verifies that the [ ... ] / 2 block produces a
+	ScalarQuadraticExpression with the right coefficients.
+		minimize x^2 + 3 xy + 2 y^2 - x
+*/
+func TestReadLP2(t *testing.T) {
+	// Constants
+	lpFile := "Minimize\n obj: - x + [ 2 x ^ 2 + 6 x * y + 4 y ^ 2 ] / 2\nSubject To\n c0: x + y >= 1\nEnd\n"
+
+	// Algorithm
+	m, vars, err := optim.ReadLP(strings.NewReader(lpFile))
+	if err != nil {
+		t.Fatalf("There was an issue reading the LP file: %v", err)
+	}
+
+	solver := mock.NewSolver()
+	if _, err := m.Optimize(solver); err != nil {
+		t.Fatalf("There was an issue loading the model into the mock solver: %v", err)
+	}
+
+	if _, ok := solver.Objective.ScalarExpression.(optim.ScalarQuadraticExpression); !ok {
+		t.Errorf("Expected the objective to be a ScalarQuadraticExpression; received %T", solver.Objective.ScalarExpression)
+	}
+
+	x, y := vars["x"], vars["y"]
+	expected := optim.ScalarQuadraticExpression{
+		Q: *mat.NewDense(2, 2, []float64{1, 1.5, 1.5, 2}),
+		L: *mat.NewVecDense(2, []float64{-1, 0}),
+		X: optim.VarVector{Elements: []optim.Variable{x, y}},
+	}
+	solver.AssertObjective(t, expected, optim.SenseMinimize)
+}
+
+/*
+TestReadLP3
+Description:
+
+	Verifies that a model written with WriteLP is read back unchanged.
+*/
+func TestReadLP3(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x, _ := m.AddVariableClassic(-1, 10, optim.Continuous)
+	y := m.AddVariable()
+	z, _ := m.AddVariableClassic(-5, 5, optim.Integer)
+	b := m.AddBinaryVariable()
+
+	sum1, _ := x.Plus(y.Mult(2))
+	sum1, _ = sum1.Plus(b.Mult(0.25))
+	m.AddConstr(sum1.LessEq(optim.K(4)))
+
+	diff1, _ := z.Plus(b.Mult(-3))
+	m.AddConstr(diff1.GreaterEq(optim.K(-1.5e-3)))
+
+	vv := optim.VarVector{Elements: []optim.Variable{x, y}}
+	m.SetObjective(optim.ScalarQuadraticExpression{
+		Q: *mat.NewDense(2, 2, []float64{1, 0.5, 0.5, 3}),
+		L: *mat.NewVecDense(2, []float64{2, -1}),
+		C: 7,
+		X: vv,
+	}, optim.SenseMinimize)
+
+	var buf bytes.Buffer
+	if err := m.WriteLP(&buf); err != nil {
+		t.Fatalf("There was an issue writing the model: %v", err)
+	}
+
+	// Algorithm
+	m2, vars, err := optim.ReadLP(strings.NewReader(buf.String()))
+	if err != nil {
+		t.Fatalf("There was an issue reading the model: %v", err)
+	}
+
+	// The variables are renumbered in the order in which they appear in the file, so map the
+	// IDs of the original model to the IDs of the model that was read.
+	newIDs := make(map[uint64]uint64)
+	for _, v := range m.Variables {
+		v2, found := vars[fmt.Sprintf("x%v", v.ID)]
+		if !found {
+			t.Fatalf("Expected the variable x%v to be read back, but it was not.", v.ID)
+		}
+		if v2.Lower != v.Lower || v2.Upper != v.Upper || v2.Vtype != v.Vtype {
+			t.Errorf("Expected variable %v to be read back unchanged; received %v", v, v2)
+		}
+		newIDs[v.ID] = v2.ID
+	}
+
+	solver1, solver2 := mock.NewSolver(), mock.NewSolver()
+	if _, err := m.Optimize(solver1); err != nil {
+		t.Fatalf("There was an issue loading the model into the mock solver: %v", err)
+	}
+	if _, err := m2.Optimize(solver2); err != nil {
+		t.Fatalf("There was an issue loading the model that was read into the mock solver: %v", err)
+	}
+
+	objTerms1, _ := mock.TermsOf(solver1.Objective.ScalarExpression)
+	objTerms2, _ := mock.TermsOf(solver2.Objective.ScalarExpression)
+	if !renumberTerms(objTerms1, newIDs).Equals(objTerms2) || solver2.Objective.Sense != optim.SenseMinimize {
+		t.Errorf("Expected the objective to be read back unchanged; received %v", solver2.Objective)
+	}
+
+	constrs1, constrs2 := solver1.ScalarConstraints(), solver2.ScalarConstraints()
+	if len(constrs1) != len(constrs2) {
+		t.Fatalf("Expected %v constraints to be read back; received %v", len(constrs1), len(constrs2))
+	}
+	for constrIndex := range constrs1 {
+		lhs1, _ := mock.TermsOf(constrs1[constrIndex].LeftHandSide)
+		rhs1, _ := mock.TermsOf(constrs1[constrIndex].RightHandSide)
+		lhs2, _ := mock.TermsOf(constrs2[constrIndex].LeftHandSide)
+		rhs2, _ := mock.TermsOf(constrs2[constrIndex].RightHandSide)
+		if !renumberTerms(lhs1.Minus(rhs1), newIDs).Equals(lhs2.Minus(rhs2)) ||
+			constrs1[constrIndex].Sense != constrs2[constrIndex].Sense {
+			t.Errorf(
+				"Expected constraint %v to be read back unchanged; wrote %v and read %v",
+				constrIndex, constrs1[constrIndex], constrs2[constrIndex],
+			)
+		}
+	}
+}
+
+/*
+renumberTerms
+Description:
+
+	Replaces the variable IDs in terms using the map newIDs.
+*/
+func renumberTerms(terms mock.Terms, newIDs map[uint64]uint64) mock.Terms {
+	termsOut := mock.Terms{
+		Linear:    make(map[uint64]float64),
+		Quadratic: make(map[[2]uint64]float64),
+		Constant:  terms.Constant,
+	}
+	for id, coeff := range terms.Linear {
+		termsOut.Linear[newIDs[id]] += coeff
+	}
+	for key, coeff := range terms.Quadratic {
+		id1, id2 := newIDs[key[0]], newIDs[key[1]]
+		if id2 < id1 {
+			id1, id2 = id2, id1
+		}
+		termsOut.Quadratic[[2]uint64{id1, id2}] += coeff
+	}
+	return termsOut
+}
+
+/*
+TestReadLP4
+Description:
+
+	Verifies that parse errors report the line and column of the problem.
+*/
+func TestReadLP4(t *testing.T) {
+	// Constants
+	testCases := []struct {
+		LPFile string
+		Line   int
+		Column int
+	}{
+		{"Minimize\n obj: x + y\nSubject To\n c0: x + y 3\nEnd\n", 4, 12},
+		{"Minimize\n obj: x +\nSubject To\n c0: x >= 1\nEnd\n", 3, 1},
+		{"Minimize\n obj: [ x ^ 2 ]\nEnd\n", 2, 7},
+		{"Minimize\n obj: x\nBounds\n x <= 3 ^\nEnd\n", 4, 9},
+		{"x + y\n", 1, 1},
+		{"Minimize\n obj: x\nSubject To\n c0: x >= 1 = 2\nEnd\n", 4, 13},
+	}
+
+	// Algorithm
+	for _, testCase := range testCases {
+		_, _, err := optim.ReadLP(strings.NewReader(testCase.LPFile))
+		if err == nil {
+			t.Errorf("Expected an error reading %q, but received none.", testCase.LPFile)
+			continue
+		}
+
+		var parseErr optim.ParseError
+		if !errors.As(err, &parseErr) {
+			t.Errorf("Expected a ParseError reading %q; received %v", testCase.LPFile, err)
+			continue
+		}
+
+		if parseErr.Line != testCase.Line || parseErr.Column != testCase.Column {
+			t.Errorf(
+				"Expected the error reading %q at line %v, column %v; received %v",
+				testCase.LPFile, testCase.Line, testCase.Column, err,
+			)
+		}
+	}
+}
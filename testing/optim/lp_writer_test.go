@@ -0,0 +1,153 @@
+package optim_test
+
+/*
+lp_writer_test.go
+Description:
+	Tests for the function that writes a model in the CPLEX LP format.
+*/
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/kwesiRutledge/goop2/optim"
+	"gonum.org/v1/gonum/mat"
+)
+
+/*
+TestModel_WriteLP1
+Description:
+
+	Writes a small mixed integer LP and compares it with the expected file.
+*/
+func TestModel_WriteLP1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x, _ := m.AddVariableClassic(0, 10, optim.Continuous)
+	y := m.AddVariable()
+	z, _ := m.AddVariableClassic(-5, 5, optim.Integer)
+	b := m.AddBinaryVariable()
+
+	sum1, _ := x.Plus(y.Mult(2))
+	m.AddConstr(sum1.LessEq(optim.K(4)))
+
+	diff1, _ := z.Plus(b.Mult(-3))
+	m.AddConstr(diff1.GreaterEq(x))
+
+	sum2, _ := x.Plus(b)
+	m.AddConstr(sum2.Eq(optim.K(1)))
+
+	obj, _ := x.Plus(y.Mult(-1.5))
+	obj, _ = obj.Plus(optim.K(2))
+	m.SetObjective(obj, optim.SenseMaximize)
+
+	// Algorithm
+	var buf bytes.Buffer
+	err := m.WriteLP(&buf)
+	if err != nil {
+		t.Fatalf("There was an issue writing the model: %v", err)
+	}
+
+	expected := strings.Join([]string{
+		"\\ Model written by goop2",
+		"Maximize",
+		" obj: x0 - 1.5 x1 + 2",
+		"Subject To",
+		" c0: x0 + 2 x1 <= 4",
+		" c1: x2 - 3 x3 - x0 >= 0",
+		" c2: x0 + x3 = 1",
+		"Bounds",
+		" 0 <= x0 <= 10",
+		" x1 free",
+		" -5 <= x2 <= 5",
+		"General",
+		" x2",
+		"Binary",
+		" x3",
+		"End",
+		"",
+	}, "\n")
+
+	if buf.String() != expected {
+		t.Errorf("Expected the LP file\n%v\nreceived\n%v", expected, buf.String())
+	}
+}
+
+/*
+TestModel_WriteLP2
+Description:
+
+	Writes a model with a quadratic objective and verifies that the quadratic terms are written
+	in the [ ... ] / 2 syntax with doubled coefficients.
+		minimize x^2 + 3 xy + 2 y^2 - x
+*/
+func TestModel_WriteLP2(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	vv, _ := m.AddVariableVectorClassic(2, 0, 100, optim.Continuous)
+
+	Q := *mat.NewDense(2, 2, []float64{1, 1, 2, 2})
+	L := *mat.NewVecDense(2, []float64{-1, 0})
+	m.SetObjective(optim.ScalarQuadraticExpression{Q: Q, L: L, X: vv}, optim.SenseMinimize)
+
+	// Algorithm
+	var buf bytes.Buffer
+	err := m.WriteLP(&buf)
+	if err != nil {
+		t.Fatalf("There was an issue writing the model: %v", err)
+	}
+
+	expectedObj := " obj: - x0 + [ 2 x0 ^ 2 + 6 x0 * x1 + 4 x1 ^ 2 ] / 2"
+	if !strings.Contains(buf.String(), expectedObj+"\n") {
+		t.Errorf("Expected the LP file to contain the objective %q; received\n%v", expectedObj, buf.String())
+	}
+
+	if !strings.Contains(buf.String(), "Minimize\n") {
+		t.Errorf("Expected the LP file to contain a Minimize section; received\n%v", buf.String())
+	}
+}
+
+/*
+TestModel_WriteLP3
+Description:
+
+	Verifies that writing the same model twice gives the same file and that long constraints are
+	split over several lines.
+*/
+func TestModel_WriteLP3(t *testing.T) {
+	// Constants
+	N := 40
+	m := optim.NewModel()
+	vv, _ := m.AddVariableVectorClassic(N, 0, 1, optim.Continuous)
+
+	coeffs := make([]float64, N)
+	for i := range coeffs {
+		coeffs[i] = float64(i + 1)
+	}
+	sle := optim.ScalarLinearExpr{X: vv, L: *mat.NewVecDense(N, coeffs)}
+	m.AddConstr(sle.LessEq(optim.K(100)))
+
+	// Algorithm
+	var buf1, buf2 bytes.Buffer
+	if err := m.WriteLP(&buf1); err != nil {
+		t.Fatalf("There was an issue writing the model: %v", err)
+	}
+	if err := m.WriteLP(&buf2); err != nil {
+		t.Fatalf("There was an issue writing the model a second time: %v", err)
+	}
+
+	if buf1.String() != buf2.String() {
+		t.Errorf("Expected both files to be identical; received\n%v\nand\n%v", buf1.String(), buf2.String())
+	}
+
+	for _, line := range strings.Split(buf1.String(), "\n") {
+		if len(line) > 255 {
+			t.Errorf("Expected every line to be at most 255 characters long; received %q", line)
+		}
+	}
+
+	if !strings.Contains(buf1.String(), " 40 x39 <= 100\n") {
+		t.Errorf("Expected the constraint to end with the last term and its right hand side; received\n%v", buf1.String())
+	}
+}
@@ -0,0 +1,93 @@
+package optim_test
+
+/*
+matrix_constant_test.go
+Description:
+	Tests for the KMatrix object.
+*/
+
+import (
+	"testing"
+
+	"github.com/kwesiRutledge/goop2/optim"
+	"gonum.org/v1/gonum/mat"
+)
+
+/*
+TestKMatrix_Plus1
+Description:
+
+	Adds a KMatrix, a mat.Dense and a constant to a KMatrix and verifies that the result is still
+	a KMatrix.
+*/
+func TestKMatrix_Plus1(t *testing.T) {
+	// Constants
+	A := optim.KMatrix(*mat.NewDense(2, 2, []float64{1, 2, 3, 4}))
+	B := *mat.NewDense(2, 2, []float64{10, 20, 30, 40})
+
+	// Algorithm
+	sum, err := A.Plus(A)
+	if err != nil {
+		t.Fatalf("There was an issue adding the matrices: %v", err)
+	}
+	sum, err = sum.Plus(B)
+	if err != nil {
+		t.Fatalf("There was an issue adding the mat.Dense: %v", err)
+	}
+	sum, err = sum.Plus(0.5)
+	if err != nil {
+		t.Fatalf("There was an issue adding the constant: %v", err)
+	}
+
+	sumAsKM, ok := sum.(optim.KMatrix)
+	if !ok {
+		t.Fatalf("Expected the sum to be a KMatrix; received %T", sum)
+	}
+	sumAsDense := mat.Dense(sumAsKM)
+	expected := mat.NewDense(2, 2, []float64{12.5, 24.5, 36.5, 48.5})
+	if !mat.Equal(&sumAsDense, expected) {
+		t.Errorf("Expected the sum %v; received %v", mat.Formatted(expected), mat.Formatted(&sumAsDense))
+	}
+
+	// The original matrix is unchanged
+	if A.At(0, 0) != optim.K(1) {
+		t.Errorf("Expected the original matrix to be unchanged; element (0, 0) is %v", A.At(0, 0))
+	}
+}
+
+/*
+TestKMatrix_T1
+Description:
+
+	Verifies the transpose, rows, columns and trace of a constant matrix.
+*/
+func TestKMatrix_T1(t *testing.T) {
+	// Constants
+	A := optim.KMatrix(*mat.NewDense(2, 3, []float64{1, 2, 3, 4, 5, 6}))
+
+	// Algorithm
+	AT := A.T()
+	if nR, nC := AT.Dims(); nR != 3 || nC != 2 || AT.At(2, 0) != optim.K(3) {
+		t.Errorf("Expected the transpose [1 4; 2 5; 3 6]; received %v", AT)
+	}
+
+	if row := A.Row(1); row.Len() != 3 || row.At(0) != 4 {
+		t.Errorf("Expected row 1 to be [4 5 6]; received %v", row)
+	}
+	if col := A.Col(2); col.Len() != 2 || col.At(1) != 6 {
+		t.Errorf("Expected column 2 to be [3 6]; received %v", col)
+	}
+
+	if _, err := A.Trace(); err == nil {
+		t.Errorf("Expected an error computing the trace of a 2 x 3 matrix, but received none.")
+	}
+	B := optim.KMatrix(*mat.NewDense(2, 2, []float64{1, 2, 3, 4}))
+	if trace, err := B.Trace(); err != nil || trace != optim.K(5) {
+		t.Errorf("Expected the trace 5; received %v (%v)", trace, err)
+	}
+
+	scaled, _ := A.Mult(2)
+	if scaled.At(1, 2) != optim.K(12) {
+		t.Errorf("Expected element (1, 2) of 2 A to be 12; received %v", scaled.At(1, 2))
+	}
+}
@@ -0,0 +1,111 @@
+package optim_test
+
+/*
+matrix_constraint_test.go
+Description:
+	Tests for the MatrixConstraint object and for adding matrix constraints to a Model.
+*/
+
+import (
+	"testing"
+
+	"github.com/kwesiRutledge/goop2/optim"
+	"github.com/kwesiRutledge/goop2/solvers/mock"
+	"gonum.org/v1/gonum/mat"
+)
+
+/*
+TestMatrixConstraint_ScalarConstraints1
+Description:
+
+	Expands the constraint X + C <= Y between 2 x 2 matrices and verifies each element, in
+	row-major order.
+*/
+func TestMatrixConstraint_ScalarConstraints1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	X, _ := m.AddVariableMatrix(2, 2, 0, 1, optim.Continuous)
+	Y, _ := m.AddVariableMatrix(2, 2, 0, 1, optim.Continuous)
+	C := optim.KMatrix(*mat.NewDense(2, 2, []float64{1, 2, 3, 4}))
+
+	XPlusC, _ := X.Plus(C)
+	mc, err := XPlusC.LessEq(Y)
+	if err != nil {
+		t.Fatalf("There was an issue creating the constraint: %v", err)
+	}
+
+	// Algorithm
+	elements, err := mc.ScalarConstraints()
+	if err != nil {
+		t.Fatalf("There was an issue expanding the constraint: %v", err)
+	}
+	if len(elements) != 4 {
+		t.Fatalf("Expected 4 scalar constraints; received %v", len(elements))
+	}
+
+	for i := 0; i < 2; i++ {
+		for j := 0; j < 2; j++ {
+			lhs, _ := X.Elements[i][j].Plus(optim.K(float64(2*i + j + 1)))
+			expected := optim.ScalarConstraint{LeftHandSide: lhs, RightHandSide: Y.Elements[i][j], Sense: optim.SenseLessThanEqual}
+			if match, err := mock.ConstraintsMatch(elements[2*i+j], expected); err != nil || !match {
+				t.Errorf("Expected element (%v, %v) to be %v; received %v (%v)", i, j, expected, elements[2*i+j], err)
+			}
+		}
+	}
+
+	// A constraint with sides of different dimensions is not valid
+	Z, _ := m.AddVariableMatrix(2, 3, 0, 1, optim.Continuous)
+	badConstr := optim.MatrixConstraint{LeftHandSide: X, RightHandSide: Z, Sense: optim.SenseEqual}
+	if _, err := badConstr.ScalarConstraints(); err == nil {
+		t.Errorf("Expected an error expanding a constraint between a 2 x 2 and a 2 x 3 matrix, but received none.")
+	}
+}
+
+/*
+TestModel_AddConstr_Matrix1
+Description:
+
+	Adds the named matrix constraint X <= 1 and verifies the constraints given to the solver and
+	their names.
+*/
+func TestModel_AddConstr_Matrix1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	X, _ := m.AddBinaryVariableMatrix(2, 3)
+
+	// Algorithm
+	capConstr, _ := X.LessEq(optim.K(1))
+	handle, err := m.AddNamedConstr("cap", capConstr)
+	if err != nil {
+		t.Fatalf("There was an issue adding the matrix constraint: %v", err)
+	}
+	if constr, err := m.Constraint(handle); err != nil || !optim.IsConstraint(constr) {
+		t.Errorf("Expected the handle to refer to the matrix constraint; received %v (%v)", constr, err)
+	}
+
+	row1, err := m.ConstraintByName("cap[1,2]")
+	if err != nil {
+		t.Fatalf("There was an issue finding the element by name: %v", err)
+	}
+	if rowAsSC, ok := row1.(optim.ScalarConstraint); !ok || rowAsSC.LeftHandSide != X.Elements[1][2] {
+		t.Errorf("Expected cap[1,2] to be the constraint on %v; received %v", X.Elements[1][2], row1)
+	}
+	nonnegConstr, _ := X.GreaterEq(optim.K(0))
+	if _, err := m.AddNamedConstr("cap", nonnegConstr); err == nil {
+		t.Errorf("Expected an error reusing the name of a matrix constraint, but received none.")
+	}
+	if _, err := m.AddConstr(X.Eq(*mat.NewDense(3, 2, nil))); err == nil {
+		t.Errorf("Expected an error adding a constraint between matrices of different dimensions, but received none.")
+	}
+
+	solver := mock.NewSolver()
+	if _, err := m.Optimize(solver); err != nil {
+		t.Fatalf("There was an issue loading the model: %v", err)
+	}
+
+	solver.AssertNumConstraints(t, 6)
+	solver.AssertConstraint(t, 4, X.Elements[1][1], optim.SenseLessThanEqual, optim.K(1))
+	if rows := solver.ScalarConstraints(); rows[5].Name != "cap[1,2]" {
+		t.Errorf("Expected the last row to be named cap[1,2]; received %q", rows[5].Name)
+	}
+}
@@ -0,0 +1,137 @@
+package optim_test
+
+/*
+matrix_linear_expr_test.go
+Description:
+	Tests for the MatrixLinearExpr object.
+*/
+
+import (
+	"testing"
+
+	"github.com/kwesiRutledge/goop2/optim"
+	"gonum.org/v1/gonum/mat"
+)
+
+/*
+TestMatrixLinearExpr_Check1
+Description:
+
+	Verifies that expressions whose L does not match C or X are rejected.
+*/
+func TestMatrixLinearExpr_Check1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x, _ := m.AddVariableVector(2)
+
+	good := optim.MatrixLinearExpr{X: x, L: *mat.NewDense(4, 2, nil), C: *mat.NewDense(2, 2, nil)}
+	wrongRows := optim.MatrixLinearExpr{X: x, L: *mat.NewDense(3, 2, nil), C: *mat.NewDense(2, 2, nil)}
+	wrongCols := optim.MatrixLinearExpr{X: x, L: *mat.NewDense(4, 3, nil), C: *mat.NewDense(2, 2, nil)}
+
+	// Algorithm
+	if err := good.Check(); err != nil {
+		t.Errorf("Expected the expression to be valid; received %v", err)
+	}
+	if err := wrongRows.Check(); err == nil {
+		t.Errorf("Expected an error for an L with 3 rows and a 2 x 2 C, but received none.")
+	}
+	if err := wrongCols.Check(); err == nil {
+		t.Errorf("Expected an error for an L with 3 columns and 2 variables, but received none.")
+	}
+	if _, err := wrongRows.Plus(optim.K(1)); err == nil {
+		t.Errorf("Expected an error adding to an invalid expression, but received none.")
+	}
+}
+
+/*
+TestMatrixLinearExpr_RowCol1
+Description:
+
+	Builds the 2 x 2 expression [x0 + 1, 2 x1; 3 x0, x0 + x1 + 4] and verifies its rows, columns,
+	transpose and trace.
+*/
+func TestMatrixLinearExpr_RowCol1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x, _ := m.AddVariableVector(2)
+	x0, x1 := x.Elements[0], x.Elements[1]
+
+	mle := optim.MatrixLinearExpr{
+		X: x,
+		L: *mat.NewDense(4, 2, []float64{
+			1, 0,
+			0, 2,
+			3, 0,
+			1, 1,
+		}),
+		C: *mat.NewDense(2, 2, []float64{1, 0, 0, 4}),
+	}
+
+	// Algorithm
+	row1 := mle.Row(1)
+	if row1.Len() != 2 || row1.L.At(0, 0) != 3 || row1.L.At(1, 1) != 1 || row1.C.AtVec(1) != 4 {
+		t.Errorf("Expected row 1 to be [3 x0, x0 + x1 + 4]; received %v", row1)
+	}
+	col1 := mle.Col(1)
+	if col1.Len() != 2 || col1.L.At(0, 1) != 2 || col1.L.At(1, 0) != 1 || col1.C.AtVec(0) != 0 {
+		t.Errorf("Expected column 1 to be [2 x1, x0 + x1 + 4]; received %v", col1)
+	}
+
+	twoX1, _ := x1.Mult(2)
+	threeX0, _ := x0.Mult(3)
+	mleT := mle.T()
+	assertMatrixElement(t, mleT, 0, 1, threeX0)
+	assertMatrixElement(t, mleT, 1, 0, twoX1)
+
+	trace, err := mle.Trace()
+	if err != nil {
+		t.Fatalf("There was an issue computing the trace: %v", err)
+	}
+	expected, _ := x0.Plus(x0)
+	expected, _ = expected.Plus(x1)
+	expected, _ = expected.Plus(optim.K(5))
+	assertSameTerms(t, trace, expected)
+}
+
+/*
+TestMatrixLinearExpr_Plus1
+Description:
+
+	Adds two matrix linear expressions with different (overlapping) variables and a constant, and
+	verifies that the inputs are not modified.
+*/
+func TestMatrixLinearExpr_Plus1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	xy, _ := m.AddVariableVector(2)
+	yz, _ := m.AddVariableVector(1)
+	x, y, z := xy.Elements[0], xy.Elements[1], yz.Elements[0]
+	yz.Elements = append([]optim.Variable{y}, yz.Elements...)
+
+	// [x, y] and [y, 2 z] as 1 x 2 expressions
+	mle1 := optim.MatrixLinearExpr{X: xy, L: *mat.NewDense(2, 2, []float64{1, 0, 0, 1}), C: *mat.NewDense(1, 2, nil)}
+	mle2 := optim.MatrixLinearExpr{X: yz, L: *mat.NewDense(2, 2, []float64{1, 0, 0, 2}), C: *mat.NewDense(1, 2, []float64{1, 1})}
+
+	// Algorithm
+	sum, err := mle1.Plus(mle2)
+	if err != nil {
+		t.Fatalf("There was an issue adding the expressions: %v", err)
+	}
+	sum, err = sum.Plus(optim.K(-1))
+	if err != nil {
+		t.Fatalf("There was an issue adding the constant: %v", err)
+	}
+
+	xPlusY, _ := x.Plus(y)
+	twoZ, _ := z.Mult(2)
+	yPlusTwoZ, _ := y.Plus(twoZ)
+	assertMatrixElement(t, sum, 0, 0, xPlusY)
+	assertMatrixElement(t, sum, 0, 1, yPlusTwoZ)
+	if sum.NumVars() != 3 {
+		t.Errorf("Expected the sum to have 3 variables; received %v", sum.NumVars())
+	}
+
+	if mle1.L.At(1, 1) != 1 || mle1.C.At(0, 0) != 0 || mle2.C.At(0, 1) != 1 {
+		t.Errorf("Expected the inputs to be unchanged; received %v and %v", mle1, mle2)
+	}
+}
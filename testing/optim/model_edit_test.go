@@ -0,0 +1,313 @@
+package optim_test
+
+/*
+model_edit_test.go
+Description:
+	Tests for the methods which edit a Model after it has been built and for sending those edits
+	to a kept alive IncrementalSolver.
+*/
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/kwesiRutledge/goop2/optim"
+	"github.com/kwesiRutledge/goop2/solvers/mock"
+)
+
+/*
+TestModel_SetVariableBounds1
+Description:
+
+	Changes, fixes and unfixes the bounds of a variable and verifies that invalid bounds are
+	rejected.
+*/
+func TestModel_SetVariableBounds1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x, _ := m.AddVariableClassic(0, 10, optim.Integer)
+
+	// Algorithm
+	if err := m.SetVariableBounds(x, -2, 5); err != nil {
+		t.Fatalf("There was an issue setting the bounds: %v", err)
+	}
+	if m.Variables[0].Lower != -2 || m.Variables[0].Upper != 5 {
+		t.Errorf("Expected the bounds [-2, 5]; received %v", m.Variables[0])
+	}
+	if err := m.SetVariableBounds(x, 3, 1); err == nil {
+		t.Errorf("Expected an error setting a lower bound above the upper bound, but received none.")
+	}
+
+	if err := m.UnfixVariable(x); err == nil {
+		t.Errorf("Expected an error unfixing a variable which is not fixed, but received none.")
+	}
+	if err := m.FixVariable(x, math.Inf(1)); err == nil {
+		t.Errorf("Expected an error fixing a variable to +Inf, but received none.")
+	}
+
+	m.FixVariable(x, 4)
+	m.FixVariable(x, 3) // Fixing again keeps the original bounds
+	if m.Variables[0].Lower != 3 || m.Variables[0].Upper != 3 {
+		t.Errorf("Expected the fixed bounds [3, 3]; received %v", m.Variables[0])
+	}
+	if err := m.UnfixVariable(x); err != nil {
+		t.Fatalf("There was an issue unfixing the variable: %v", err)
+	}
+	if m.Variables[0].Lower != -2 || m.Variables[0].Upper != 5 {
+		t.Errorf("Expected the bounds [-2, 5] to be restored; received %v", m.Variables[0])
+	}
+
+	// Setting the bounds of a fixed variable replaces the bounds it is restored to.
+	m.FixVariable(x, 1)
+	m.SetVariableBounds(x, 0, 2)
+	if err := m.UnfixVariable(x); err == nil {
+		t.Errorf("Expected an error unfixing a variable whose bounds were set, but received none.")
+	}
+
+	other := optim.Variable{ID: 42}
+	if err := m.SetVariableBounds(other, 0, 1); err == nil {
+		t.Errorf("Expected an error setting the bounds of a variable which is not in the model, but received none.")
+	}
+}
+
+/*
+TestModel_RemoveConstr1
+Description:
+
+	Removes and edits constraints using their handles and verifies the constraints given to the
+	solver.
+*/
+func TestModel_RemoveConstr1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x := m.AddVariable()
+	y := m.AddVariable()
+
+	xPlusY, _ := x.Plus(y)
+	h0, _ := m.AddConstr(x.LessEq(optim.K(1)))
+	h1, err := m.AddConstr(xPlusY.GreaterEq(optim.K(2)))
+	if err != nil {
+		t.Fatalf("There was an issue adding the constraint: %v", err)
+	}
+	h2, _ := m.AddConstr(y.Eq(optim.K(3)))
+
+	// Algorithm
+	if err := m.RemoveConstr(h0); err != nil {
+		t.Fatalf("There was an issue removing the constraint: %v", err)
+	}
+	if err := m.RemoveConstr(h0); err == nil {
+		t.Errorf("Expected an error removing a constraint twice, but received none.")
+	}
+	if _, err := m.Constraint(h0); err == nil {
+		t.Errorf("Expected an error getting a removed constraint, but received none.")
+	}
+
+	// The other handles still refer to the same constraints.
+	constr, err := m.Constraint(h2)
+	if sc, ok := constr.(optim.ScalarConstraint); err != nil || !ok || sc.Sense != optim.SenseEqual {
+		t.Errorf("Expected the handle %v to refer to y = 3; received %v (%v)", h2, constr, err)
+	}
+
+	// x + y >= 2 becomes 3 x + y >= 4
+	if err := m.SetConstrRHS(h1, 4); err != nil {
+		t.Fatalf("There was an issue setting the right hand side: %v", err)
+	}
+	if err := m.SetConstrCoeff(h1, x, 3); err != nil {
+		t.Fatalf("There was an issue setting the coefficient: %v", err)
+	}
+	// Removing y from y = 3 leaves 0 = 3
+	if err := m.SetConstrCoeff(h2, y, 0); err != nil {
+		t.Fatalf("There was an issue removing the coefficient: %v", err)
+	}
+	if err := m.SetConstrRHS(h0, 1); err == nil {
+		t.Errorf("Expected an error editing a removed constraint, but received none.")
+	}
+
+	solver := mock.NewSolver()
+	if _, err := m.Optimize(solver); err != nil {
+		t.Fatalf("There was an issue loading the model into the mock solver: %v", err)
+	}
+
+	threeX, _ := x.Mult(3)
+	threeXPlusY, _ := threeX.Plus(y)
+	solver.AssertNumConstraints(t, 2)
+	solver.AssertConstraint(t, 0, threeXPlusY, optim.SenseGreaterThanEqual, optim.K(4))
+	solver.AssertConstraint(t, 1, optim.K(0), optim.SenseEqual, optim.K(3))
+}
+
+/*
+TestModel_RemoveVariable1
+Description:
+
+	Verifies that variables which are used by the model can not be removed, and that the IDs of
+	removed variables are not given out again.
+*/
+func TestModel_RemoveVariable1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x := m.AddVariable()
+	y := m.AddVariable()
+	z := m.AddVariable()
+
+	handle, _ := m.AddConstr(y.LessEq(optim.K(1)))
+	m.SetObjective(x, optim.SenseMinimize)
+
+	// Algorithm
+	if err := m.RemoveVariable(x); err == nil {
+		t.Errorf("Expected an error removing a variable used in the objective, but received none.")
+	}
+	if err := m.RemoveVariable(y); err == nil {
+		t.Errorf("Expected an error removing a variable used in a constraint, but received none.")
+	}
+	if err := m.RemoveVariable(z); err != nil {
+		t.Errorf("There was an issue removing an unused variable: %v", err)
+	}
+
+	m.RemoveConstr(handle)
+	if err := m.RemoveVariable(y); err != nil {
+		t.Errorf("There was an issue removing the variable after removing its constraint: %v", err)
+	}
+
+	w := m.AddVariable()
+	if w.ID != 3 || len(m.Variables) != 2 {
+		t.Errorf("Expected the new variable to have ID 3 and the model to have 2 variables; received %v", m.Variables)
+	}
+}
+
+/*
+TestModel_Optimize_Incremental1
+Description:
+
+	Optimizes a kept alive model twice with the same (mock) IncrementalSolver and verifies that
+	only the edits are sent the second time.
+*/
+func TestModel_Optimize_Incremental1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	m.KeepSolverAlive(true)
+	xs, _ := m.AddVariableVectorClassic(2, 0, 10, optim.Continuous)
+	x, y := xs.Elements[0], xs.Elements[1]
+	z := m.AddVariable()
+
+	m.AddConstr(x.LessEq(optim.K(4)))
+	hv, _ := m.AddConstr(xs.LessEq(optim.KVector(optim.OnesVector(2))))
+	hy, _ := m.AddConstr(y.GreaterEq(optim.K(1)))
+	m.SetObjective(x, optim.SenseMaximize)
+
+	solver := mock.NewSolver()
+	if _, err := m.Optimize(solver); err != nil {
+		t.Fatalf("There was an issue loading the model: %v", err)
+	}
+
+	// Algorithm
+	m.SetVariableBounds(x, 0, 2)
+	m.RemoveConstr(hv)
+	m.SetConstrRHS(hy, 0.5)
+	m.RemoveVariable(z)
+	w := m.AddVariable()
+	m.AddConstr(w.Eq(optim.K(1)))
+
+	solver.Calls = nil
+	if _, err := m.Optimize(solver); err != nil {
+		t.Fatalf("There was an issue updating the model: %v", err)
+	}
+
+	expectedCalls := []string{
+		"ShowLog", "RemoveConstraint", "RemoveConstraint", "SetVariableBounds", "RemoveVariable",
+		"AddVariables", "SetConstraint", "AddConstraint", "SetObjective", "Optimize",
+	}
+	if !reflect.DeepEqual(solver.MethodNames(), expectedCalls) {
+		t.Errorf("Expected the calls %v; received %v", expectedCalls, solver.MethodNames())
+	}
+	if calls := solver.CallsTo("RemoveConstraint"); calls[0].Args[0] != 2 || calls[1].Args[0] != 1 {
+		t.Errorf("Expected the rows 2 and 1 to be removed; received %v", calls)
+	}
+
+	// The solver holds the same model as a solver which receives the whole model.
+	fresh := mock.NewSolver()
+	m.KeepSolverAlive(false)
+	m.Optimize(fresh)
+	if !reflect.DeepEqual(solver.Variables, fresh.Variables) {
+		t.Errorf("Expected the variables %v; received %v", fresh.Variables, solver.Variables)
+	}
+	solver.AssertNumConstraints(t, len(fresh.Constraints))
+	for constrIndex, constr := range fresh.ScalarConstraints() {
+		solver.AssertConstraint(t, constrIndex, constr.LeftHandSide, constr.Sense, constr.RightHandSide)
+	}
+}
+
+/*
+TestModel_Optimize_Incremental2
+Description:
+
+	Verifies that the model is loaded again (after ClearModel) when sending the edits to the
+	solver fails, and that a different solver receives the whole model.
+*/
+func TestModel_Optimize_Incremental2(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	m.KeepSolverAlive(true)
+	x := m.AddVariable()
+	handle, _ := m.AddConstr(x.LessEq(optim.K(4)))
+
+	solver := mock.NewSolver()
+	m.Optimize(solver)
+
+	// Algorithm
+	m.RemoveConstr(handle)
+	solverErr := errors.New("scripted failure")
+	solver.FailOn("RemoveConstraint", solverErr)
+	if _, err := m.Optimize(solver); err == nil {
+		t.Errorf("Expected the error from RemoveConstraint to be returned, but received none.")
+	}
+
+	solver.FailOn("RemoveConstraint", nil)
+	solver.Calls = nil
+	if _, err := m.Optimize(solver); err != nil {
+		t.Fatalf("There was an issue loading the model again: %v", err)
+	}
+	if names := solver.MethodNames(); names[0] != "ClearModel" || len(solver.CallsTo("AddVariables")) != 1 {
+		t.Errorf("Expected the model to be cleared and loaded again; received the calls %v", names)
+	}
+	solver.AssertNumConstraints(t, 0)
+
+	other := mock.NewSolver()
+	m.Optimize(other)
+	if len(other.CallsTo("AddVariables")) != 1 || len(other.CallsTo("RemoveConstraint")) != 0 {
+		t.Errorf("Expected a new solver to receive the whole model; received the calls %v", other.MethodNames())
+	}
+}
+
+/*
+TestModel_Optimize_KeepSolverAlive1
+Description:
+
+	Verifies that a solver which is not a SessionSolver can not be kept alive, since it could not
+	drop the model that it holds before the model is loaded again.
+*/
+func TestModel_Optimize_KeepSolverAlive1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	m.KeepSolverAlive(true)
+	x := m.AddVariable()
+	m.AddConstr(x.LessEq(optim.K(4)))
+
+	solver := mock.NewSolver()
+	plainSolver := struct{ optim.Solver }{solver} // Hides ClearModel and the incremental methods
+
+	// Algorithm
+	if _, err := m.Optimize(plainSolver); err == nil {
+		t.Errorf("Expected an error when keeping a solver which is not a SessionSolver alive.")
+	}
+
+	if len(solver.Calls) != 0 {
+		t.Errorf("Expected the solver to not be called; received the calls %v", solver.MethodNames())
+	}
+
+	m.KeepSolverAlive(false)
+	if _, err := m.Optimize(plainSolver); err != nil {
+		t.Errorf("There was an issue optimizing without keeping the solver alive: %v", err)
+	}
+}
@@ -0,0 +1,225 @@
+package optim_test
+
+/*
+model_test.go
+Description:
+	Tests for the functions that add variables, constraints and objectives to a Model and for
+	Model.Optimize.
+*/
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/kwesiRutledge/goop2/optim"
+	"github.com/kwesiRutledge/goop2/solvers/mock"
+)
+
+/*
+TestModel_AddVariableClassic1
+Description:
+
+	Verifies that variables with invalid bounds or types are rejected and not added to the model.
+*/
+func TestModel_AddVariableClassic1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+
+	badSettings := []struct {
+		Lower float64
+		Upper float64
+		Vtype optim.VarType
+	}{
+		{2, 1, optim.Continuous},
+		{math.NaN(), 1, optim.Continuous},
+		{0, math.Inf(-1), optim.Integer},
+		{math.Inf(1), math.Inf(1), optim.Continuous},
+		{0, 1, optim.VarType('Q')},
+	}
+
+	// Algorithm
+	for _, settings := range badSettings {
+		if _, err := m.AddVariableClassic(settings.Lower, settings.Upper, settings.Vtype); err == nil {
+			t.Errorf("Expected an error adding a variable with settings %v, but received none.", settings)
+		}
+		if _, err := m.AddVariableVectorClassic(3, settings.Lower, settings.Upper, settings.Vtype); err == nil {
+			t.Errorf("Expected an error adding a variable vector with settings %v, but received none.", settings)
+		}
+		if _, err := m.AddVariableMatrix(2, 2, settings.Lower, settings.Upper, settings.Vtype); err == nil {
+			t.Errorf("Expected an error adding a variable matrix with settings %v, but received none.", settings)
+		}
+	}
+
+	if _, err := m.AddVariableVector(-1); err == nil {
+		t.Errorf("Expected an error adding a vector with a negative length, but received none.")
+	}
+	if _, err := m.AddBinaryVariableMatrix(2, -3); err == nil {
+		t.Errorf("Expected an error adding a matrix with a negative dimension, but received none.")
+	}
+
+	if len(m.Variables) != 0 {
+		t.Errorf("Expected no variables to be added; received %v", m.Variables)
+	}
+
+	// Valid variables are numbered in order.
+	x, err := m.AddVariableClassic(-1, 1, optim.Integer)
+	if err != nil {
+		t.Fatalf("There was an issue adding a valid variable: %v", err)
+	}
+	vv, err := m.AddBinaryVariableVector(2)
+	if err != nil {
+		t.Fatalf("There was an issue adding a valid vector: %v", err)
+	}
+	if x.ID != 0 || vv.Elements[0].ID != 1 || vv.Elements[1].ID != 2 || len(m.Variables) != 3 {
+		t.Errorf("Expected the variables to have IDs 0, 1 and 2; received %v", m.Variables)
+	}
+}
+
+/*
+TestModel_AddConstr3
+Description:
+
+	Verifies that AddConstr accepts a constraint with or without its error, and that it returns
+	an error (without adding the constraint) for bad inputs instead of panicking.
+*/
+func TestModel_AddConstr3(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x := m.AddVariable()
+	y := m.AddVariable()
+
+	constr, err := x.LessEq(y)
+	if err != nil {
+		t.Fatalf("There was an issue creating the constraint: %v", err)
+	}
+
+	// Algorithm
+	if _, err := m.AddConstr(constr); err != nil {
+		t.Errorf("Expected a constraint without extra arguments to be added; received %v", err)
+	}
+	if _, err := m.AddConstr(x.GreaterEq(optim.K(-1))); err != nil {
+		t.Errorf("Expected a constraint with a nil error to be added; received %v", err)
+	}
+	if _, err := m.AddConstr(&constr, nil); err != nil {
+		t.Errorf("Expected a pointer to a constraint to be added; received %v", err)
+	}
+
+	computeErr := errors.New("the dimensions do not match")
+	badCalls := [][]interface{}{
+		{constr, computeErr},
+		{constr, nil, nil},
+		{constr, "not an error"},
+		{x},
+		{optim.ScalarConstraint{LeftHandSide: x, Sense: optim.SenseEqual}},
+		{(*optim.ScalarConstraint)(nil)},
+	}
+	for _, call := range badCalls {
+		if _, err := m.AddConstr(call[0], call[1:]...); err == nil {
+			t.Errorf("Expected an error from AddConstr%v, but received none.", call)
+		}
+	}
+
+	if _, err := m.AddConstr(constr, computeErr); err == nil || !strings.Contains(err.Error(), computeErr.Error()) {
+		t.Errorf("Expected the error computing the constraint to be returned; received %v", err)
+	}
+
+	solver := mock.NewSolver()
+	if _, err := m.Optimize(solver); err != nil {
+		t.Fatalf("There was an issue loading the model into the mock solver: %v", err)
+	}
+	solver.AssertNumConstraints(t, 3)
+}
+
+/*
+TestModel_SetObjective1
+Description:
+
+	Verifies that SetObjective rejects a missing expression or an unknown sense.
+*/
+func TestModel_SetObjective1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x := m.AddVariable()
+
+	// Algorithm
+	if err := m.SetObjective(nil, optim.SenseMinimize); err == nil {
+		t.Errorf("Expected an error setting a nil objective, but received none.")
+	}
+	if err := m.SetObjective(x, optim.ObjSense(0)); err == nil {
+		t.Errorf("Expected an error setting an objective with sense 0, but received none.")
+	}
+	if err := m.SetObjective(x, optim.SenseMaximize); err != nil {
+		t.Errorf("There was an issue setting a valid objective: %v", err)
+	}
+}
+
+/*
+TestModel_SetObjective2
+Description:
+
+	Verifies that SetObjective accepts the error returned while computing the objective, and
+	that it returns a non-nil error without changing the objective.
+*/
+func TestModel_SetObjective2(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x := m.AddVariable()
+	y := m.AddVariable()
+
+	// Algorithm
+	obj, err := x.Plus(y)
+	if err := m.SetObjective(obj, optim.SenseMinimize, err); err != nil {
+		t.Errorf("Expected an objective with a nil error to be set; received %v", err)
+	}
+
+	computeErr := errors.New("the dimensions do not match")
+	if err := m.SetObjective(x, optim.SenseMaximize, computeErr); err == nil || !strings.Contains(err.Error(), computeErr.Error()) {
+		t.Errorf("Expected the error computing the objective to be returned; received %v", err)
+	}
+	if err := m.SetObjective(x, optim.SenseMaximize, nil, nil); err == nil {
+		t.Errorf("Expected an error from SetObjective with two extras, but received none.")
+	}
+	if err := m.SetObjective(x, optim.SenseMaximize, "not an error"); err == nil {
+		t.Errorf("Expected an error from SetObjective with an extra which is not an error, but received none.")
+	}
+
+	solver := mock.NewSolver()
+	if _, err := m.Optimize(solver); err != nil {
+		t.Fatalf("There was an issue loading the model into the mock solver: %v", err)
+	}
+	solver.AssertObjective(t, obj, optim.SenseMinimize)
+}
+
+/*
+TestModel_Optimize1
+Description:
+
+	Verifies that errors from each of the solver's methods are returned by Optimize and that the
+	solver is still deleted.
+*/
+func TestModel_Optimize1(t *testing.T) {
+	// Constants
+	methods := []string{"ShowLog", "AddVariables", "AddConstraint", "SetObjective", "Optimize"}
+
+	for _, method := range methods {
+		m := optim.NewModel()
+		x := m.AddVariable()
+		m.AddConstr(x.LessEq(optim.K(1)))
+		m.SetObjective(x, optim.SenseMaximize)
+
+		solverErr := errors.New("scripted failure")
+		solver := mock.NewSolver().FailOn(method, solverErr)
+
+		// Algorithm
+		sol, err := m.Optimize(solver)
+		if err == nil || !strings.Contains(err.Error(), solverErr.Error()) {
+			t.Errorf("Expected the error from %v to be returned; received %v", method, err)
+		}
+		if sol != nil {
+			t.Errorf("Expected no solution when %v fails; received %v", method, sol)
+		}
+		solver.AssertCallCount(t, "DeleteSolver", 1)
+	}
+}
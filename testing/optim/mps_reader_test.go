@@ -0,0 +1,285 @@
+package optim_test
+
+/*
+mps_reader_test.go
+Description:
+	Tests for the function that reads a model written in the MPS format.
+*/
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/kwesiRutledge/goop2/optim"
+	"github.com/kwesiRutledge/goop2/solvers/mock"
+	"gonum.org/v1/gonum/mat"
+)
+
+/*
+TestReadMPS1
+Description:
+
+	Reads a fixed format MPS file with ranges, integer markers, a free row, an objective constant
+	and every bound type.
+*/
+func TestReadMPS1(t *testing.T) {
+	// Constants
+	mpsFile := strings.Join([]string{
+		"* A small test problem",
+		"NAME          TESTPROB",
+		"ROWS",
+		" N  COST",
+		" L  LIM1",
+		" G  LIM2",
+		" E  MYEQN",
+		" N  FREE",
+		"COLUMNS",
+		"    X1        COST         1.0   LIM1         1.0",
+		"    X1        LIM2         1.0   FREE         9.0",
+		"    MARKER                 'MARKER'                 'INTORG'",
+		"    X2        COST         2.0   LIM1         1.0",
+		"    X2        MYEQN       -1.0",
+		"    MARKER                 'MARKER'                 'INTEND'",
+		"    X3        COST        -1.0   MYEQN        1.0",
+		"    X4        COST         0.0",
+		"    X5        COST         0.0",
+		"    X6        COST         0.0",
+		"    X7        COST         0.0",
+		"    X8        COST         0.0",
+		"RHS",
+		"    RHS       COST        -3.5",
+		"    RHS       LIM1         4.0   LIM2         1.0",
+		"              MYEQN        7.0",
+		"RANGES",
+		"    RNG       LIM1         2.5   MYEQN       -1.0",
+		"BOUNDS",
+		" UP BND       X1           4.0",
+		" LO BND       X2          -1.0",
+		" UP BND       X2           1.0",
+		" MI BND       X3",
+		" FX BND       X4           2.0",
+		" FR BND       X5",
+		" BV BND       X6",
+		" LI BND       X7           1.0",
+		" UI BND       X7           9.0",
+		" UP BND       X8          -2.0",
+		" PL BND       X8",
+		"ENDATA",
+	}, "\n")
+
+	// Algorithm
+	m, vars, err := optim.ReadMPS(strings.NewReader(mpsFile))
+	if err != nil {
+		t.Fatalf("There was an issue reading the MPS file: %v", err)
+	}
+
+	if len(m.Variables) != 8 {
+		t.Fatalf("Expected 8 variables; received %v", len(m.Variables))
+	}
+
+	inf := 1e30
+	expectedBounds := []struct {
+		Name   string
+		Lower  float64
+		Upper  float64
+		Vtype  optim.VarType
+		IsFree [2]bool
+	}{
+		{"X1", 0, 4, optim.Continuous, [2]bool{false, false}},
+		{"X2", -1, 1, optim.Integer, [2]bool{false, false}},
+		{"X3", -inf, inf, optim.Continuous, [2]bool{true, true}},
+		{"X4", 2, 2, optim.Continuous, [2]bool{false, false}},
+		{"X5", -inf, inf, optim.Continuous, [2]bool{true, true}},
+		{"X6", 0, 1, optim.Binary, [2]bool{false, false}},
+		{"X7", 1, 9, optim.Integer, [2]bool{false, false}},
+		{"X8", -inf, inf, optim.Continuous, [2]bool{true, true}},
+	}
+	for _, expected := range expectedBounds {
+		v := vars[expected.Name]
+		lowerOK := (expected.IsFree[0] && v.Lower <= -inf) || v.Lower == expected.Lower
+		upperOK := (expected.IsFree[1] && v.Upper >= inf) || v.Upper == expected.Upper
+		if !lowerOK || !upperOK || v.Vtype != expected.Vtype {
+			t.Errorf("Expected %v to be in [%v, %v] with type %v; received %v", expected.Name, expected.Lower, expected.Upper, expected.Vtype, v)
+		}
+	}
+
+	// Constraints and objective
+	solver := mock.NewSolver()
+	if _, err := m.Optimize(solver); err != nil {
+		t.Fatalf("There was an issue loading the model into the mock solver: %v", err)
+	}
+
+	x1, x2, x3 := vars["X1"], vars["X2"], vars["X3"]
+
+	// LIM1 is ranged: 1.5 <= x1 + x2 <= 4
+	lim1, _ := x1.Plus(x2)
+	// MYEQN is ranged with a negative range: 6 <= -x2 + x3 <= 7
+	myeqn, _ := x3.Plus(x2.Mult(-1))
+
+	solver.AssertNumConstraints(t, 5)
+	solver.AssertConstraint(t, 0, lim1, optim.SenseGreaterThanEqual, optim.K(1.5))
+	solver.AssertConstraint(t, 1, lim1, optim.SenseLessThanEqual, optim.K(4))
+	solver.AssertConstraint(t, 2, x1, optim.SenseGreaterThanEqual, optim.K(1))
+	solver.AssertConstraint(t, 3, myeqn, optim.SenseGreaterThanEqual, optim.K(6))
+	solver.AssertConstraint(t, 4, myeqn, optim.SenseLessThanEqual, optim.K(7))
+
+	obj, _ := x1.Plus(x2.Mult(2))
+	obj, _ = obj.Plus(x3.Mult(-1))
+	obj, _ = obj.Plus(optim.K(3.5))
+	solver.AssertObjective(t, obj, optim.SenseMinimize)
+}
+
+/*
+TestReadMPS2
+Description:
+
+	Reads free format files with an OBJSENSE section and a quadratic objective given by QUADOBJ
+	or by QMATRIX. Both describe
+		maximize -(x^2 + 3 xy + 2 y^2) + x
+*/
+func TestReadMPS2(t *testing.T) {
+	// Constants
+	header := "NAME qp\nOBJSENSE\n    MAX\nROWS\n N obj\n G c0\nCOLUMNS\n x obj 1 c0 1\n y c0 1\nRHS\n rhs c0 1\n"
+	quadObjFile := header + "QUADOBJ\n x x -2\n x y -3\n y y -4\nENDATA\n"
+	qMatrixFile := header + "QMATRIX\n x x -2\n x y -3\n y x -3\n y y -4\nENDATA\n"
+
+	for _, mpsFile := range []string{quadObjFile, qMatrixFile} {
+		// Algorithm
+		m, vars, err := optim.ReadMPS(strings.NewReader(mpsFile))
+		if err != nil {
+			t.Fatalf("There was an issue reading the MPS file: %v", err)
+		}
+
+		solver := mock.NewSolver()
+		if _, err := m.Optimize(solver); err != nil {
+			t.Fatalf("There was an issue loading the model into the mock solver: %v", err)
+		}
+
+		x, y := vars["x"], vars["y"]
+		expected := optim.ScalarQuadraticExpression{
+			Q: *mat.NewDense(2, 2, []float64{-1, -1.5, -1.5, -2}),
+			L: *mat.NewVecDense(2, []float64{1, 0}),
+			X: optim.VarVector{Elements: []optim.Variable{x, y}},
+		}
+		solver.AssertObjective(t, expected, optim.SenseMaximize)
+
+		sum, _ := x.Plus(y)
+		solver.AssertConstraint(t, 0, sum, optim.SenseGreaterThanEqual, optim.K(1))
+	}
+}
+
+/*
+TestReadMPS3
+Description:
+
+	Verifies that models written with WriteMPS (in both formats) are read back unchanged.
+*/
+func TestReadMPS3(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x, _ := m.AddVariableClassic(-1, 10, optim.Continuous)
+	y := m.AddVariable()
+	z, _ := m.AddVariableClassic(-5, -2, optim.Integer)
+	b := m.AddBinaryVariable()
+	w, _ := m.AddVariableClassic(0, 1e100, optim.Integer)
+
+	sum1, _ := x.Plus(y.Mult(2))
+	sum1, _ = sum1.Plus(b.Mult(0.25))
+	m.AddConstr(sum1.LessEq(w))
+
+	diff1, _ := z.Plus(b.Mult(-3))
+	m.AddConstr(diff1.GreaterEq(optim.K(-1.5e-3)))
+
+	m.SetObjective(optim.ScalarQuadraticExpression{
+		Q: *mat.NewDense(2, 2, []float64{1, 0.5, 0.5, 3}),
+		L: *mat.NewVecDense(2, []float64{2, -1}),
+		C: 7,
+		X: optim.VarVector{Elements: []optim.Variable{x, y}},
+	}, optim.SenseMaximize)
+
+	solver1 := mock.NewSolver()
+	if _, err := m.Optimize(solver1); err != nil {
+		t.Fatalf("There was an issue loading the model into the mock solver: %v", err)
+	}
+
+	for _, free := range []bool{false, true} {
+		var buf bytes.Buffer
+		if err := m.WriteMPS(&buf, optim.MPSOptions{Free: free}); err != nil {
+			t.Fatalf("There was an issue writing the model: %v", err)
+		}
+
+		// Algorithm
+		m2, vars, err := optim.ReadMPS(&buf)
+		if err != nil {
+			t.Fatalf("There was an issue reading the model (free = %v): %v", free, err)
+		}
+
+		// The columns are read in the order in which they were written, so the IDs match. The
+		// variables are named after their columns.
+		for _, v := range m.Variables {
+			v.Name = fmt.Sprintf("x%v", v.ID)
+			v2 := vars[v.Name]
+			if v2 != v {
+				t.Errorf("Expected variable %v to be read back unchanged (free = %v); received %v", v, free, v2)
+			}
+		}
+
+		solver2 := mock.NewSolver()
+		if _, err := m2.Optimize(solver2); err != nil {
+			t.Fatalf("There was an issue loading the model that was read into the mock solver: %v", err)
+		}
+
+		solver2.AssertObjective(t, solver1.Objective.ScalarExpression, optim.SenseMaximize)
+		solver2.AssertNumConstraints(t, 2)
+		for constrIndex, constr := range solver1.ScalarConstraints() {
+			solver2.AssertConstraint(t, constrIndex, constr.LeftHandSide, constr.Sense, constr.RightHandSide)
+		}
+	}
+}
+
+/*
+TestReadMPS4
+Description:
+
+	Verifies that errors report the line and column of the problem.
+*/
+func TestReadMPS4(t *testing.T) {
+	// Constants
+	testCases := []struct {
+		MPSFile string
+		Line    int
+		Column  int
+	}{
+		{"NAME test\nROWS\n N obj\n X c0\nENDATA\n", 4, 2},
+		{"NAME test\nROWS\n N obj\nCOLUMNS\n x obj abc\nENDATA\n", 5, 8},
+		{"NAME test\nROWS\n N obj\nCOLUMNS\n x c1 1\nENDATA\n", 5, 4},
+		{"NAME test\nROWS\n N obj\nCOLUMNS\n x obj 1\nBOUNDS\n UP BND y 1\nENDATA\n", 7, 9},
+		{"NAME test\nROWS\n N obj\nCOLUMNS\n x obj 1\nBOUNDS\n SC BND x 1\nENDATA\n", 7, 2},
+		{" x obj 1\n", 1, 1},
+	}
+
+	// Algorithm
+	for _, testCase := range testCases {
+		_, _, err := optim.ReadMPS(strings.NewReader(testCase.MPSFile))
+		if err == nil {
+			t.Errorf("Expected an error reading %q, but received none.", testCase.MPSFile)
+			continue
+		}
+
+		var parseErr optim.ParseError
+		if !errors.As(err, &parseErr) {
+			t.Errorf("Expected a ParseError reading %q; received %v", testCase.MPSFile, err)
+			continue
+		}
+
+		if parseErr.Line != testCase.Line || parseErr.Column != testCase.Column {
+			t.Errorf(
+				"Expected the error reading %q at line %v, column %v; received %v",
+				testCase.MPSFile, testCase.Line, testCase.Column, err,
+			)
+		}
+	}
+}
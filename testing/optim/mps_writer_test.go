@@ -0,0 +1,265 @@
+package optim_test
+
+/*
+mps_writer_test.go
+Description:
+	Tests for the function that writes a model in the MPS format.
+*/
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/kwesiRutledge/goop2/optim"
+	"github.com/kwesiRutledge/goop2/solvers/mock"
+	"gonum.org/v1/gonum/mat"
+)
+
+/*
+TestModel_WriteMPS1
+Description:
+
+	Writes a small mixed integer LP in the fixed format and compares it with the expected file.
+	The second constraint has a variable on its right hand side.
+*/
+func TestModel_WriteMPS1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x, _ := m.AddVariableClassic(0, 10, optim.Continuous)
+	y := m.AddVariable()
+	z, _ := m.AddVariableClassic(-5, 5, optim.Integer)
+	b := m.AddBinaryVariable()
+	m.AddVariableClassic(1, 1, optim.Continuous)
+
+	sum1, _ := x.Plus(y.Mult(2))
+	m.AddConstr(sum1.LessEq(optim.K(4)))
+
+	diff1, _ := z.Plus(b.Mult(-3))
+	m.AddConstr(diff1.GreaterEq(x))
+
+	sum2, _ := x.Plus(b)
+	m.AddConstr(sum2.Eq(optim.K(1)))
+
+	obj, _ := x.Plus(y.Mult(-1.5))
+	obj, _ = obj.Plus(optim.K(2))
+	m.SetObjective(obj, optim.SenseMaximize)
+
+	// Algorithm
+	var buf bytes.Buffer
+	err := m.WriteMPS(&buf, optim.MPSOptions{Name: "test"})
+	if err != nil {
+		t.Fatalf("There was an issue writing the model: %v", err)
+	}
+
+	expected := strings.Join([]string{
+		"NAME          test",
+		"OBJSENSE",
+		"    MAX",
+		"ROWS",
+		" N  obj",
+		" L  c0",
+		" G  c1",
+		" E  c2",
+		"COLUMNS",
+		"    x0        obj       1",
+		"    x0        c0        1",
+		"    x0        c1        -1",
+		"    x0        c2        1",
+		"    x1        obj       -1.5",
+		"    x1        c0        2",
+		"    MARKER1   'MARKER'                 'INTORG'",
+		"    x2        c1        1",
+		"    x3        c1        -3",
+		"    x3        c2        1",
+		"    MARKER1   'MARKER'                 'INTEND'",
+		"    x4        obj       0",
+		"RHS",
+		"    RHS       obj       -2",
+		"    RHS       c0        4",
+		"    RHS       c2        1",
+		"BOUNDS",
+		" UP BND       x0        10",
+		" FR BND       x1",
+		" LO BND       x2        -5",
+		" UP BND       x2        5",
+		" BV BND       x3",
+		" FX BND       x4        1",
+		"ENDATA",
+		"",
+	}, "\n")
+
+	if buf.String() != expected {
+		t.Errorf("Expected the MPS file\n%v\nreceived\n%v", expected, buf.String())
+	}
+}
+
+/*
+TestModel_WriteMPS2
+Description:
+
+	Writes a model with a quadratic objective in the free format and verifies that the QUADOBJ
+	section contains H = Q + Q'.
+		minimize x^2 + 3 xy + 2 y^2 - x
+*/
+func TestModel_WriteMPS2(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	vv, _ := m.AddVariableVectorClassic(2, 0, 100, optim.Continuous)
+
+	Q := *mat.NewDense(2, 2, []float64{1, 1, 2, 2})
+	L := *mat.NewVecDense(2, []float64{-1, 0})
+	m.SetObjective(optim.ScalarQuadraticExpression{Q: Q, L: L, X: vv}, optim.SenseMinimize)
+
+	sum, _ := vv.Elements[0].Plus(vv.Elements[1])
+	m.AddConstr(sum.GreaterEq(optim.K(1)))
+
+	// Algorithm
+	var buf bytes.Buffer
+	err := m.WriteMPS(&buf, optim.MPSOptions{Free: true})
+	if err != nil {
+		t.Fatalf("There was an issue writing the model: %v", err)
+	}
+
+	expectedQuadObj := "QUADOBJ\n    x0 x0 2\n    x0 x1 3\n    x1 x1 4\nENDATA\n"
+	if !strings.HasSuffix(buf.String(), expectedQuadObj) {
+		t.Errorf("Expected the MPS file to end with\n%v\nreceived\n%v", expectedQuadObj, buf.String())
+	}
+
+	for _, expected := range []string{"    x0 obj -1\n", "    x1 c0 1\n", "    RHS c0 1\n", " UP BND x0 100\n"} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Errorf("Expected the MPS file to contain %q; received\n%v", expected, buf.String())
+		}
+	}
+
+	if strings.Contains(buf.String(), "OBJSENSE") {
+		t.Errorf("Expected no OBJSENSE section for a minimization problem; received\n%v", buf.String())
+	}
+}
+
+/*
+TestModel_WriteMPS3
+Description:
+
+	Verifies that quadratic constraints are rejected.
+*/
+func TestModel_WriteMPS3(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	vv, _ := m.AddVariableVector(2)
+
+	sqe := optim.ScalarQuadraticExpression{
+		Q: *mat.NewDense(2, 2, []float64{1, 0, 0, 1}),
+		L: *mat.NewVecDense(2, []float64{0, 0}),
+		X: vv,
+	}
+	m.AddConstr(sqe.LessEq(optim.K(1)))
+
+	// Algorithm
+	var buf bytes.Buffer
+	err := m.WriteMPS(&buf, optim.MPSOptions{})
+	if err == nil {
+		t.Errorf("Expected an error writing a quadratic constraint, but received none.")
+	}
+}
+
+/*
+TestModel_WriteMPS4
+Description:
+
+	Writes a model whose coefficients do not have a short decimal expansion in the free format
+	and verifies that reading the file back gives exactly the same coefficients.
+		minimize (1/3) x + y
+		subject to (1/3) x + y <= 2/3
+*/
+func TestModel_WriteMPS4(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	vv, _ := m.AddVariableVector(2)
+	third := 1.0 / 3.0
+
+	expr := optim.ScalarLinearExpr{
+		X: vv,
+		L: *mat.NewVecDense(2, []float64{third, 1}),
+	}
+	m.AddConstr(expr.LessEq(optim.K(2 * third)))
+	m.SetObjective(expr, optim.SenseMinimize)
+
+	// Algorithm
+	var buf bytes.Buffer
+	if err := m.WriteMPS(&buf, optim.MPSOptions{Free: true}); err != nil {
+		t.Fatalf("There was an issue writing the model: %v", err)
+	}
+
+	mRead, vars, err := optim.ReadMPS(&buf)
+	if err != nil {
+		t.Fatalf("There was an issue reading the model back: %v", err)
+	}
+	solver := mock.NewSolver()
+	if _, err := mRead.Optimize(solver); err != nil {
+		t.Fatalf("There was an issue loading the model into the mock solver: %v", err)
+	}
+
+	// The coefficients must match exactly, not only up to the tolerance of the mock solver.
+	objTerms, _ := mock.TermsOf(solver.Objective.ScalarExpression)
+	if objTerms.Linear[vars["x0"].ID] != third {
+		t.Errorf("Expected the objective coefficient of x0 to be %v; received %v", third, objTerms.Linear[vars["x0"].ID])
+	}
+
+	solver.AssertNumConstraints(t, 1)
+	constr := solver.Constraints[0].(optim.ScalarConstraint)
+	lhsTerms, _ := mock.TermsOf(constr.LeftHandSide)
+	if lhsTerms.Linear[vars["x0"].ID] != third || constr.RightHandSide.Constant() != 2*third {
+		t.Errorf("Expected the constraint (1/3) x0 + x1 <= 2/3; received %v", constr)
+	}
+}
+
+/*
+TestModel_WriteMPS5
+Description:
+
+	Verifies that a pair of constraints with the same left hand side and opposite senses is
+	written as one ranged row, and that reading the file back gives the same bounds.
+		1 <= x + y <= 3
+		2 x <= 5
+*/
+func TestModel_WriteMPS5(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x := m.AddVariable()
+	y := m.AddVariable()
+
+	sum1, _ := x.Plus(y)
+	m.AddConstr(sum1.GreaterEq(optim.K(1)))
+	twoX, _ := x.Mult(2)
+	m.AddConstr(twoX.LessEq(optim.K(5)))
+	sum2, _ := y.Plus(x)
+	m.AddConstr(sum2.LessEq(optim.K(3)))
+
+	// Algorithm
+	var buf bytes.Buffer
+	if err := m.WriteMPS(&buf, optim.MPSOptions{Free: true}); err != nil {
+		t.Fatalf("There was an issue writing the model: %v", err)
+	}
+
+	for _, expected := range []string{"ROWS\n N obj\n G c0\n L c1\nCOLUMNS\n", "RANGES\n    RNG c0 2\n"} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Errorf("Expected the MPS file to contain %q; received\n%v", expected, buf.String())
+		}
+	}
+
+	mRead, vars, err := optim.ReadMPS(&buf)
+	if err != nil {
+		t.Fatalf("There was an issue reading the model back: %v", err)
+	}
+	solver := mock.NewSolver()
+	if _, err := mRead.Optimize(solver); err != nil {
+		t.Fatalf("There was an issue loading the model into the mock solver: %v", err)
+	}
+
+	xRead, yRead := vars[x.DisplayName()], vars[y.DisplayName()]
+	sumRead, _ := xRead.Plus(yRead)
+	solver.AssertNumConstraints(t, 3)
+	solver.AssertHasConstraint(t, sumRead, optim.SenseGreaterThanEqual, optim.K(1))
+	solver.AssertHasConstraint(t, sumRead, optim.SenseLessThanEqual, optim.K(3))
+}
@@ -0,0 +1,236 @@
+package optim_test
+
+/*
+multiply_test.go
+Description:
+	Tests for the Multiply operator and the Multiply methods of each expression.
+*/
+
+import (
+	"testing"
+
+	"github.com/kwesiRutledge/goop2/optim"
+	"gonum.org/v1/gonum/mat"
+)
+
+/*
+TestMultiply_Variable1
+Description:
+
+	Multiplies two variables (and a variable with itself) and verifies that the products are
+	ScalarQuadraticExpressions with the right coefficients.
+*/
+func TestMultiply_Variable1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x := m.AddVariable()
+	y := m.AddVariable()
+
+	// Algorithm
+	xy, err := optim.Multiply(x, y)
+	if err != nil {
+		t.Fatalf("There was an issue multiplying x and y: %v", err)
+	}
+	xyAsQE, ok := xy.(optim.ScalarQuadraticExpression)
+	if !ok {
+		t.Fatalf("Expected x * y to be a ScalarQuadraticExpression; received %T", xy)
+	}
+	if xyAsQE.Q.At(0, 1) != 0.5 || xyAsQE.Q.At(1, 0) != 0.5 || xyAsQE.Q.At(0, 0) != 0 {
+		t.Errorf("Expected Q = [0 0.5; 0.5 0]; received %v", mat.Formatted(&xyAsQE.Q))
+	}
+
+	xSquared, err := x.Multiply(x)
+	if err != nil {
+		t.Fatalf("There was an issue multiplying x with itself: %v", err)
+	}
+	xSquaredAsQE, ok := xSquared.(optim.ScalarQuadraticExpression)
+	if !ok || xSquaredAsQE.X.Len() != 1 || xSquaredAsQE.Q.At(0, 0) != 1 {
+		t.Errorf("Expected x * x to be the quadratic expression x^2; received %v", xSquared)
+	}
+
+	// Constants only scale the variable
+	threeX, err := optim.Multiply(3.0, x)
+	if err != nil {
+		t.Fatalf("There was an issue multiplying 3 and x: %v", err)
+	}
+	expected, _ := x.Mult(3)
+	assertSameTerms(t, threeX.(optim.ScalarExpression), expected)
+}
+
+/*
+TestMultiply_ScalarLinearExpr1
+Description:
+
+	Multiplies (x + 1) by (2 y - 3) and verifies the expanded quadratic expression
+	2 x y - 3 x + 2 y - 3.
+*/
+func TestMultiply_ScalarLinearExpr1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x := m.AddVariable()
+	y := m.AddVariable()
+
+	xPlusOne, _ := x.Plus(optim.K(1))
+	twoY, _ := y.Mult(2)
+	twoYMinusThree, _ := twoY.Plus(optim.K(-3))
+
+	// Algorithm
+	product, err := xPlusOne.Multiply(twoYMinusThree)
+	if err != nil {
+		t.Fatalf("There was an issue multiplying the linear expressions: %v", err)
+	}
+	productAsQE, ok := product.(optim.ScalarQuadraticExpression)
+	if !ok {
+		t.Fatalf("Expected the product to be a ScalarQuadraticExpression; received %T", product)
+	}
+
+	expected := optim.ScalarQuadraticExpression{
+		Q: *mat.NewDense(2, 2, []float64{0, 1, 1, 0}),
+		L: *mat.NewVecDense(2, []float64{-3, 2}),
+		C: -3,
+		X: optim.VarVector{Elements: []optim.Variable{x, y}},
+	}
+	assertSameTerms(t, productAsQE, expected)
+
+	// Multiplying by a constant keeps the degree
+	scaled, err := optim.Multiply(productAsQE, optim.K(2))
+	if err != nil {
+		t.Fatalf("There was an issue scaling the quadratic expression: %v", err)
+	}
+	if scaledAsQE, ok := scaled.(optim.ScalarQuadraticExpression); !ok || scaledAsQE.C != -6 {
+		t.Errorf("Expected the scaled expression to have the constant -6; received %v", scaled)
+	}
+}
+
+/*
+TestMultiply_Degree1
+Description:
+
+	Verifies that products of degree three or more return an error.
+*/
+func TestMultiply_Degree1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x := m.AddVariable()
+	xSquared, _ := x.Multiply(x)
+
+	// Algorithm
+	if _, err := optim.Multiply(xSquared, x); err == nil {
+		t.Errorf("Expected an error multiplying a quadratic expression by a variable, but received none.")
+	}
+	if _, err := x.Multiply(x, x); err == nil {
+		t.Errorf("Expected an error computing x * x * x, but received none.")
+	}
+	if _, err := optim.Multiply(x, "x"); err == nil {
+		t.Errorf("Expected an error multiplying a variable by a string, but received none.")
+	}
+}
+
+/*
+TestMultiply_Vector1
+Description:
+
+	Multiplies a constant matrix by a vector of variables and verifies that the result is the
+	VectorLinearExpr A x. Also multiplies vectors by constants and by each other.
+*/
+func TestMultiply_Vector1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x, _ := m.AddVariableVector(3)
+	A := *mat.NewDense(2, 3, []float64{1, 2, 3, 4, 5, 6})
+
+	// Algorithm
+	Ax, err := optim.KMatrix(A).Multiply(x)
+	if err != nil {
+		t.Fatalf("There was an issue computing A x: %v", err)
+	}
+	AxAsVLE, ok := Ax.(optim.VectorLinearExpr)
+	if !ok {
+		t.Fatalf("Expected A x to be a VectorLinearExpr; received %T", Ax)
+	}
+	if AxAsVLE.Len() != 2 {
+		t.Errorf("Expected A x to have length 2; received %v", AxAsVLE.Len())
+	}
+	for i := 0; i < 2; i++ {
+		expected := optim.ScalarLinearExpr{X: x, L: *mat.VecDenseCopyOf(A.RowView(i)), C: 0}
+		assertSameTerms(t, AxAsVLE.AtVec(i), expected)
+	}
+
+	// A mat.Dense is treated as a KMatrix
+	if AxFromDense, err := optim.Multiply(A, x); err != nil {
+		t.Errorf("There was an issue multiplying a mat.Dense and x: %v", err)
+	} else if _, ok := AxFromDense.(optim.VectorLinearExpr); !ok {
+		t.Errorf("Expected A x to be a VectorLinearExpr; received %T", AxFromDense)
+	}
+
+	// The dimensions must match
+	if _, err := optim.Multiply(A, optim.OnesVector(2)); err == nil {
+		t.Errorf("Expected an error multiplying a 2 x 3 matrix by a vector of length 2, but received none.")
+	}
+
+	// Constant times a vector
+	twoOnes, err := optim.K(2).Multiply(optim.KVector(optim.OnesVector(3)))
+	if err != nil {
+		t.Fatalf("There was an issue multiplying a constant and a KVector: %v", err)
+	}
+	if twoOnesAsKV, ok := twoOnes.(optim.KVector); !ok || twoOnesAsKV.At(2) != 2 {
+		t.Errorf("Expected 2 * [1 1 1] to be the KVector [2 2 2]; received %v", twoOnes)
+	}
+
+	// The inner product of two vectors of variables is quadratic
+	xDotX, err := x.Multiply(x)
+	if err != nil {
+		t.Fatalf("There was an issue computing the inner product of x with itself: %v", err)
+	}
+	xDotXAsQE, ok := xDotX.(optim.ScalarQuadraticExpression)
+	if !ok {
+		t.Fatalf("Expected x' x to be a ScalarQuadraticExpression; received %T", xDotX)
+	}
+	identity := optim.Identity(3)
+	if !mat.Equal(&xDotXAsQE.Q, &identity) {
+		t.Errorf("Expected x' x to have Q = I; received %v", mat.Formatted(&xDotXAsQE.Q))
+	}
+}
+
+/*
+TestMultiply_VectorQuadratic1
+Description:
+
+	Multiplies a vector of variables elementwise by a variable and a matrix of variables by a
+	vector of variables, which both give vectors of quadratic expressions.
+*/
+func TestMultiply_VectorQuadratic1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x, _ := m.AddVariableVector(2)
+	s := m.AddVariable()
+	X, _ := m.AddVariableMatrix(2, 2, 0, 1, optim.Continuous)
+
+	// Algorithm
+	sx, err := optim.Multiply(s, x)
+	if err != nil {
+		t.Fatalf("There was an issue computing s x: %v", err)
+	}
+	if _, ok := sx.(optim.VectorQuadraticExpression); !ok {
+		t.Fatalf("Expected s x to be a VectorQuadraticExpression; received %T", sx)
+	}
+	expected, _ := s.Multiply(x.Elements[1])
+	assertSameTerms(t, sx.(optim.VectorExpression).AtVec(1), expected.(optim.ScalarExpression))
+
+	Xx, err := X.Multiply(x)
+	if err != nil {
+		t.Fatalf("There was an issue computing X x: %v", err)
+	}
+	if _, ok := Xx.(optim.VectorQuadraticExpression); !ok {
+		t.Fatalf("Expected X x to be a VectorQuadraticExpression; received %T", Xx)
+	}
+	term1, _ := X.Elements[1][0].Multiply(x.Elements[0])
+	term2, _ := X.Elements[1][1].Multiply(x.Elements[1])
+	expected, _ = optim.Sum(term1, term2)
+	assertSameTerms(t, Xx.(optim.VectorExpression).AtVec(1), expected.(optim.ScalarExpression))
+
+	// There is no matrix of quadratic expressions
+	if _, err := X.Multiply(X); err == nil {
+		t.Errorf("Expected an error computing X X, but received none.")
+	}
+}
@@ -0,0 +1,397 @@
+package optim_test
+
+/*
+names_test.go
+Description:
+	Tests for the names of variables and constraints: adding named variables and constraints to a
+	Model, looking them up and passing them to solvers and files.
+*/
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kwesiRutledge/goop2/optim"
+	"github.com/kwesiRutledge/goop2/solvers/mock"
+)
+
+/*
+newNamedTestModel
+Description:
+
+	Creates a model with a named variable, vector and matrix, a named scalar constraint, a named
+	vector constraint and an unnamed constraint.
+*/
+func newNamedTestModel(t *testing.T) *optim.Model {
+	// Constants
+	m := optim.NewModel()
+
+	// Algorithm
+	flow, err := m.AddNamedVariable("flow", 0, 10, optim.Continuous)
+	if err != nil {
+		t.Fatalf("There was an issue adding the variable: %v", err)
+	}
+	open, err := m.AddNamedVariableVector("open", 2, 0, 1, optim.Binary)
+	if err != nil {
+		t.Fatalf("There was an issue adding the vector: %v", err)
+	}
+	assign, err := m.AddNamedVariableMatrix("assign", 2, 2, 0, 5, optim.Integer)
+	if err != nil {
+		t.Fatalf("There was an issue adding the matrix: %v", err)
+	}
+	unnamed := m.AddVariable()
+
+	capacity, err := flow.LessEq(optim.K(8))
+	if err != nil {
+		t.Fatalf("There was an issue creating the scalar constraint: %v", err)
+	}
+	if _, err := m.AddNamedConstr("capacity", capacity); err != nil {
+		t.Fatalf("There was an issue adding the scalar constraint: %v", err)
+	}
+	links, err := open.LessEq(optim.KVector(optim.OnesVector(2)))
+	if err != nil {
+		t.Fatalf("There was an issue creating the vector constraint: %v", err)
+	}
+	if _, err := m.AddNamedConstr("links", &links, nil); err != nil {
+		t.Fatalf("There was an issue adding the vector constraint: %v", err)
+	}
+	if _, err := m.AddConstr(assign.Elements[1][0].GreaterEq(unnamed)); err != nil {
+		t.Fatalf("There was an issue adding the unnamed constraint: %v", err)
+	}
+	if err := m.SetObjective(flow, optim.SenseMaximize); err != nil {
+		t.Fatalf("There was an issue setting the objective: %v", err)
+	}
+
+	return m
+}
+
+/*
+TestModel_AddNamedVariable1
+Description:
+
+	Verifies the names given to variables, vectors and matrices, that they can be looked up and
+	that duplicate names are rejected.
+*/
+func TestModel_AddNamedVariable1(t *testing.T) {
+	// Constants
+	m := newNamedTestModel(t)
+
+	expectedNames := []string{"flow", "open[0]", "open[1]", "assign[0,0]", "assign[0,1]", "assign[1,0]", "assign[1,1]", ""}
+	expectedDisplayNames := append(append([]string{}, expectedNames[:7]...), "x7")
+
+	// Algorithm
+	for varIndex, tempVar := range m.Variables {
+		if tempVar.Name != expectedNames[varIndex] || tempVar.DisplayName() != expectedDisplayNames[varIndex] {
+			t.Errorf(
+				"Expected variable %v to be named %q (displayed as %q); received %q (%q)",
+				varIndex, expectedNames[varIndex], expectedDisplayNames[varIndex], tempVar.Name, tempVar.DisplayName(),
+			)
+		}
+	}
+
+	v, err := m.VariableByName("assign[1,0]")
+	if err != nil || v.ID != 5 {
+		t.Errorf("Expected to find the variable with ID 5; received %v (%v)", v, err)
+	}
+	for _, missingName := range []string{"x7", "assign", ""} {
+		if _, err := m.VariableByName(missingName); err == nil {
+			t.Errorf("Expected an error looking up %q, but received none.", missingName)
+		}
+	}
+
+	if _, err := m.AddNamedVariable("flow", 0, 1, optim.Continuous); err == nil {
+		t.Errorf("Expected an error adding a second variable named flow, but received none.")
+	}
+	if _, err := m.AddNamedVariable("", 0, 1, optim.Continuous); err == nil {
+		t.Errorf("Expected an error adding a variable with an empty name, but received none.")
+	}
+	if _, err := m.AddNamedVariableVector("open", 3, 0, 1, optim.Binary); err == nil {
+		t.Errorf("Expected an error adding a vector whose elements reuse existing names, but received none.")
+	}
+	if _, err := m.AddNamedVariableMatrix("assign", 1, 1, 0, 1, optim.Binary); err == nil {
+		t.Errorf("Expected an error adding a matrix whose elements reuse existing names, but received none.")
+	}
+	if len(m.Variables) != 8 {
+		t.Errorf("Expected no variables to be added by the failed calls; received %v variables", len(m.Variables))
+	}
+}
+
+/*
+TestModel_AddNamedConstr1
+Description:
+
+	Verifies that named constraints (and the rows of named vector constraints) can be looked up,
+	that duplicate names are rejected and that the names are given to the solver.
+*/
+func TestModel_AddNamedConstr1(t *testing.T) {
+	// Constants
+	m := newNamedTestModel(t)
+	flow, _ := m.VariableByName("flow")
+	lowerBound, _ := flow.GreaterEq(optim.K(1))
+
+	// Algorithm
+	constr, err := m.ConstraintByName("capacity")
+	if sc, ok := constr.(optim.ScalarConstraint); err != nil || !ok || sc.Name != "capacity" {
+		t.Errorf("Expected to find the scalar constraint capacity; received %v (%v)", constr, err)
+	}
+	constr, err = m.ConstraintByName("links")
+	if vc, ok := constr.(optim.VectorConstraint); err != nil || !ok || vc.Len() != 2 {
+		t.Errorf("Expected to find the vector constraint links; received %v (%v)", constr, err)
+	}
+	constr, err = m.ConstraintByName("links[1]")
+	if sc, ok := constr.(optim.ScalarConstraint); err != nil || !ok || sc.Name != "links[1]" {
+		t.Errorf("Expected to find the row links[1]; received %v (%v)", constr, err)
+	}
+	if _, err := m.ConstraintByName("c2"); err == nil {
+		t.Errorf("Expected an error looking up an unnamed constraint, but received none.")
+	}
+
+	for _, name := range []string{"capacity", "links", "links[0]"} {
+		if _, err := m.AddNamedConstr(name, lowerBound); err == nil {
+			t.Errorf("Expected an error adding a second constraint named %q, but received none.", name)
+		}
+	}
+	if _, err := m.AddNamedConstr("", lowerBound); err == nil {
+		t.Errorf("Expected an error adding a constraint with an empty name, but received none.")
+	}
+
+	solver := mock.NewSolver()
+	if _, err := m.Optimize(solver); err != nil {
+		t.Fatalf("There was an issue loading the model into the mock solver: %v", err)
+	}
+	solver.AssertNumConstraints(t, 4)
+	for constrIndex, expected := range []string{"capacity", "links[0]", "links[1]", ""} {
+		if name := solver.ScalarConstraints()[constrIndex].Name; name != expected {
+			t.Errorf("Expected constraint %v to be named %q in the solver; received %q", constrIndex, expected, name)
+		}
+	}
+	solver.AssertVariables(t, m.Variables...)
+}
+
+/*
+TestModel_RemoveNamed1
+Description:
+
+	Verifies that the names of the model follow its edits: a variable found by name has its
+	current bounds, and the names of removed variables and constraints can be used again.
+*/
+func TestModel_RemoveNamed1(t *testing.T) {
+	// Constants
+	m := newNamedTestModel(t)
+	flow, _ := m.VariableByName("flow")
+	extra, _ := m.AddNamedVariable("extra", 0, 1, optim.Continuous)
+	limits, _ := optim.VarVector{Elements: []optim.Variable{flow, extra}}.LessEq(optim.KVector(optim.OnesVector(2)))
+	limitsHandle, err := m.AddNamedConstr("limits", limits)
+	if err != nil {
+		t.Fatalf("There was an issue adding limits: %v", err)
+	}
+
+	// Algorithm
+	if err := m.SetVariableBounds(flow, 2, 3); err != nil {
+		t.Fatalf("There was an issue setting the bounds of flow: %v", err)
+	}
+	if found, _ := m.VariableByName("flow"); found.Lower != 2 || found.Upper != 3 {
+		t.Errorf("Expected flow to have the bounds [2, 3]; received [%v, %v]", found.Lower, found.Upper)
+	}
+
+	if err := m.RemoveVariable(extra); err == nil {
+		t.Errorf("Expected an error removing extra while limits uses it, but received none.")
+	}
+	if err := m.RemoveConstr(limitsHandle); err != nil {
+		t.Fatalf("There was an issue removing limits: %v", err)
+	}
+	if err := m.RemoveVariable(extra); err != nil {
+		t.Fatalf("There was an issue removing extra: %v", err)
+	}
+	if _, err := m.VariableByName("extra"); err == nil {
+		t.Errorf("Expected an error looking up the removed variable extra, but received none.")
+	}
+	if _, err := m.AddNamedVariable("extra", 0, 1, optim.Continuous); err != nil {
+		t.Errorf("There was an issue adding a new variable named extra: %v", err)
+	}
+
+	for _, name := range []string{"limits", "limits[0]"} {
+		if _, err := m.ConstraintByName(name); err == nil {
+			t.Errorf("Expected an error looking up the removed constraint %q, but received none.", name)
+		}
+	}
+
+	lowerBound, _ := flow.GreaterEq(optim.K(1))
+	if _, err := m.AddNamedConstr("limits[0]", lowerBound); err != nil {
+		t.Errorf("There was an issue adding a new constraint named limits[0]: %v", err)
+	}
+	constr, err := m.ConstraintByName("limits[0]")
+	if sc, ok := constr.(optim.ScalarConstraint); err != nil || !ok || sc.Name != "limits[0]" {
+		t.Errorf("Expected to find the new constraint limits[0]; received %v (%v)", constr, err)
+	}
+}
+
+/*
+TestModel_WriteLP_Names1
+Description:
+
+	Writes a model with named variables and constraints to an LP file and verifies that the names
+	are written and read back.
+*/
+func TestModel_WriteLP_Names1(t *testing.T) {
+	// Constants
+	m := newNamedTestModel(t)
+
+	// Algorithm
+	var buf bytes.Buffer
+	if err := m.WriteLP(&buf); err != nil {
+		t.Fatalf("There was an issue writing the model: %v", err)
+	}
+	lpFile := buf.String()
+
+	for _, expected := range []string{
+		" obj: flow", " capacity: flow <= 8", " links[0]: open[0] <= 1", " links[1]: open[1] <= 1",
+		" c3: assign[1,0] - x7 >= 0", " 0 <= flow <= 10", " 0 <= assign[0,0] <= 5", " x7 free",
+		" assign[0,0] assign[0,1] assign[1,0] assign[1,1]", " open[0] open[1]",
+	} {
+		if !strings.Contains(lpFile, expected) {
+			t.Errorf("Expected the LP file to contain %q; received\n%v", expected, lpFile)
+		}
+	}
+
+	m2, vars, err := optim.ReadLP(&buf)
+	if err != nil {
+		t.Fatalf("There was an issue reading the model: %v", err)
+	}
+	for _, name := range []string{"flow", "open[1]", "assign[1,1]", "x7"} {
+		v, err := m2.VariableByName(name)
+		if err != nil || vars[name] != v {
+			t.Errorf("Expected the variable %q to be read back; received %v (%v)", name, v, err)
+		}
+	}
+	for _, name := range []string{"capacity", "links[0]", "links[1]", "c3"} {
+		if _, err := m2.ConstraintByName(name); err != nil {
+			t.Errorf("Expected the constraint %q to be read back; received %v", name, err)
+		}
+	}
+}
+
+/*
+TestModel_WriteLP_Names2
+Description:
+
+	Verifies that names which can not be written to LP or MPS files (or which are used twice)
+	are rejected.
+*/
+func TestModel_WriteLP_Names2(t *testing.T) {
+	for _, badName := range []string{"two words", "end", "Subject", "free", "inf", "3x", "x+y", "a:b"} {
+		m := optim.NewModel()
+		if _, err := m.AddNamedVariable(badName, 0, 1, optim.Continuous); err != nil {
+			t.Fatalf("There was an issue adding the variable: %v", err)
+		}
+		if err := m.WriteLP(&bytes.Buffer{}); err == nil {
+			t.Errorf("Expected an error writing a variable named %q to an LP file, but received none.", badName)
+		}
+	}
+
+	// A name which is also the default name of another variable
+	m := optim.NewModel()
+	x := m.AddVariable()
+	if _, err := m.AddNamedVariable("x0", 0, 1, optim.Continuous); err != nil {
+		t.Fatalf("There was an issue adding the variable: %v", err)
+	}
+	if err := m.WriteLP(&bytes.Buffer{}); err == nil {
+		t.Errorf("Expected an error writing two variables named x0, but received none.")
+	}
+
+	// A named constraint which is also the default name of another constraint
+	m.AddConstr(x.LessEq(optim.K(1)))
+	nonnegative, _ := x.GreaterEq(optim.K(0))
+	m.AddNamedConstr("c0", nonnegative)
+	if err := m.WriteMPS(&bytes.Buffer{}, optim.MPSOptions{Free: true}); err == nil {
+		t.Errorf("Expected an error writing two constraints named c0, but received none.")
+	}
+
+	m3 := optim.NewModel()
+	m3.AddNamedVariable("two words", 0, 1, optim.Continuous)
+	if err := m3.WriteMPS(&bytes.Buffer{}, optim.MPSOptions{Free: true}); err == nil {
+		t.Errorf("Expected an error writing a name with a space to an MPS file, but received none.")
+	}
+}
+
+/*
+TestModel_WriteMPS_Names1
+Description:
+
+	Verifies that the names are kept when a model is written to a free MPS file and read back,
+	and that they are rejected by the fixed format when they are too long.
+*/
+func TestModel_WriteMPS_Names1(t *testing.T) {
+	// Constants
+	m := newNamedTestModel(t)
+
+	// Algorithm
+	if err := m.WriteMPS(&bytes.Buffer{}, optim.MPSOptions{}); err == nil {
+		t.Errorf("Expected an error writing the name assign[0,0] in the fixed MPS format, but received none.")
+	}
+
+	var buf bytes.Buffer
+	if err := m.WriteMPS(&buf, optim.MPSOptions{Free: true}); err != nil {
+		t.Fatalf("There was an issue writing the model: %v", err)
+	}
+	m2, _, err := optim.ReadMPS(&buf)
+	if err != nil {
+		t.Fatalf("There was an issue reading the model: %v", err)
+	}
+	for varIndex, tempVar := range m2.Variables {
+		if tempVar.Name != m.Variables[varIndex].DisplayName() {
+			t.Errorf("Expected variable %v to be named %q; received %q", varIndex, m.Variables[varIndex].DisplayName(), tempVar.Name)
+		}
+	}
+	for _, name := range []string{"capacity", "links[0]", "links[1]", "c3"} {
+		if _, err := m2.ConstraintByName(name); err != nil {
+			t.Errorf("Expected the constraint %q to be read back; received %v", name, err)
+		}
+	}
+}
+
+/*
+TestModel_WriteJSON_Names1
+Description:
+
+	Verifies that the names are kept in the JSON encoding of a model and in solution files.
+*/
+func TestModel_WriteJSON_Names1(t *testing.T) {
+	// Constants
+	m := newNamedTestModel(t)
+
+	// Algorithm
+	var buf bytes.Buffer
+	if err := m.WriteJSON(&buf); err != nil {
+		t.Fatalf("There was an issue writing the model: %v", err)
+	}
+	for _, expected := range []string{`"name": "open[1]"`, `"name": "links"`} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Errorf("Expected the JSON to contain %v; received\n%v", expected, buf.String())
+		}
+	}
+
+	m2, err := optim.ReadJSON(&buf)
+	if err != nil {
+		t.Fatalf("There was an issue reading the model: %v", err)
+	}
+	if !reflect.DeepEqual(m, m2) {
+		t.Errorf("Expected the model with names to be read back exactly.")
+	}
+
+	flow, _ := m.VariableByName("flow")
+	sol := optim.Solution{Values: map[uint64]float64{flow.ID: 8, 7: 1}}
+	var solBuf bytes.Buffer
+	if err := sol.WriteSol(&solBuf, m); err != nil {
+		t.Fatalf("There was an issue writing the solution: %v", err)
+	}
+	if !strings.Contains(solBuf.String(), "\nflow 8\nx7 1\n") {
+		t.Errorf("Expected the solution to use the names of the variables; received\n%v", solBuf.String())
+	}
+	sol2, err := optim.ReadSol(&solBuf, m)
+	if err != nil || !reflect.DeepEqual(sol2.Values, sol.Values) {
+		t.Errorf("Expected the solution to be read back; received %v (%v)", sol2, err)
+	}
+}
@@ -16,7 +16,7 @@ func TestOperators_LessEq1(t *testing.T) {
 	desLength := 5
 
 	m := optim.NewModel()
-	var vec1 = m.AddVariableVector(desLength)
+	vec1, _ := m.AddVariableVector(desLength)
 	var vec2 = optim.OnesVector(desLength)
 
 	// Algorithm
@@ -50,3 +50,49 @@ func TestOperators_LessEq1(t *testing.T) {
 	}
 
 }
+
+/*
+TestOperators_Minus1
+Description:
+
+	Tests the Minus operator with scalar, vector and matrix terms.
+*/
+func TestOperators_Minus1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x := m.AddVariable()
+	vec1, _ := m.AddVariableVector(3)
+	X, _ := m.AddVariableMatrix(2, 2, 0, 1, optim.Continuous)
+
+	// Algorithm
+	diff, err := optim.Minus(2.0, x)
+	if err != nil {
+		t.Fatalf("There was an issue computing 2 - x: %v", err)
+	}
+	if diffAsSLE, ok := diff.(optim.ScalarLinearExpr); !ok || diffAsSLE.C != 2 || diffAsSLE.L.AtVec(0) != -1 {
+		t.Errorf("Expected the expression 2 - x; received %v", diff)
+	}
+
+	vecDiff, err := optim.Minus(vec1, optim.OnesVector(3))
+	if err != nil {
+		t.Fatalf("There was an issue computing vec1 - 1: %v", err)
+	}
+	if vecDiffAsVLE, ok := vecDiff.(optim.VectorLinearExpr); !ok || vecDiffAsVLE.C.AtVec(2) != -1 {
+		t.Errorf("Expected the expression vec1 - 1; received %v", vecDiff)
+	}
+
+	matDiff, err := optim.Minus(X, X.T())
+	if err != nil {
+		t.Fatalf("There was an issue computing X - X': %v", err)
+	}
+	element := matDiff.(optim.MatrixExpression).At(0, 0)
+	for _, coeff := range element.Coeffs() {
+		if coeff != 0 {
+			t.Errorf("Expected the diagonal of X - X' to be zero; received %v", element)
+		}
+	}
+
+	if _, err := optim.Minus("x", x); err == nil {
+		t.Errorf("Expected an error subtracting from a string, but received none.")
+	}
+}
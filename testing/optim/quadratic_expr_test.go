@@ -124,8 +124,8 @@ func TestQuadraticExpr_NumVars1(t *testing.T) {
 	// Constants
 	m := optim.NewModel()
 
-	v1 := m.AddVariableClassic(-10, 10, optim.Continuous)
-	v2 := m.AddVariableClassic(-10, 10, optim.Continuous)
+	v1, _ := m.AddVariableClassic(-10, 10, optim.Continuous)
+	v2, _ := m.AddVariableClassic(-10, 10, optim.Continuous)
 
 	Q1 := [][]float64{
 		[]float64{1.0, 2.0},
@@ -158,9 +158,9 @@ func TestQuadraticExpr_NumVars2(t *testing.T) {
 	// Constants
 	m := optim.NewModel()
 
-	v1 := m.AddVariableClassic(-10, 10, optim.Continuous)
-	v2 := m.AddVariableClassic(-10, 10, optim.Continuous)
-	v3 := m.AddVariableClassic(-10, 10, optim.Continuous)
+	v1, _ := m.AddVariableClassic(-10, 10, optim.Continuous)
+	v2, _ := m.AddVariableClassic(-10, 10, optim.Continuous)
+	v3, _ := m.AddVariableClassic(-10, 10, optim.Continuous)
 
 	Q2 := [][]float64{
 		[]float64{1.0, 2.0, 3.0},
@@ -199,7 +199,7 @@ func TestQuadraticExpr_NumVars3(t *testing.T) {
 	// Constants
 	m := optim.NewModel()
 
-	v1 := m.AddVariableClassic(-10, 10, optim.Continuous)
+	v1, _ := m.AddVariableClassic(-10, 10, optim.Continuous)
 
 	Q3 := [][]float64{
 		[]float64{2.3},
@@ -235,8 +235,8 @@ func TestQuadraticExpr_Vars1(t *testing.T) {
 	// Constants
 	m := optim.NewModel()
 
-	v1 := m.AddVariableClassic(-10, 10, optim.Continuous)
-	v2 := m.AddVariableClassic(-10, 10, optim.Continuous)
+	v1, _ := m.AddVariableClassic(-10, 10, optim.Continuous)
+	v2, _ := m.AddVariableClassic(-10, 10, optim.Continuous)
 
 	Q1 := [][]float64{
 		[]float64{1.0, 2.0},
@@ -283,8 +283,8 @@ func TestQuadraticExpr_Plus1(t *testing.T) {
 	// Constants
 	m := optim.NewModel()
 
-	v1 := m.AddVariableClassic(-10, 10, optim.Continuous)
-	v2 := m.AddVariableClassic(-10, 10, optim.Continuous)
+	v1, _ := m.AddVariableClassic(-10, 10, optim.Continuous)
+	v2, _ := m.AddVariableClassic(-10, 10, optim.Continuous)
 
 	Q1_aoa := [][]float64{
 		[]float64{1.0, 2.0},
@@ -361,8 +361,8 @@ func TestQuadraticExpr_Plus2(t *testing.T) {
 	// Constants
 	m := optim.NewModel()
 
-	v1 := m.AddVariableClassic(-10, 10, optim.Continuous)
-	v2 := m.AddVariableClassic(-10, 10, optim.Continuous)
+	v1, _ := m.AddVariableClassic(-10, 10, optim.Continuous)
+	v2, _ := m.AddVariableClassic(-10, 10, optim.Continuous)
 
 	Q1_aoa := [][]float64{
 		[]float64{1.0, 2.0},
@@ -434,9 +434,9 @@ func TestQuadraticExpr_Plus3(t *testing.T) {
 	// Constants
 	m := optim.NewModel()
 
-	v1 := m.AddVariableClassic(-10, 10, optim.Continuous)
-	v2 := m.AddVariableClassic(-10, 10, optim.Continuous)
-	v3 := m.AddVariableClassic(-10, 10, optim.Continuous)
+	v1, _ := m.AddVariableClassic(-10, 10, optim.Continuous)
+	v2, _ := m.AddVariableClassic(-10, 10, optim.Continuous)
+	v3, _ := m.AddVariableClassic(-10, 10, optim.Continuous)
 
 	Q1_aoa := [][]float64{
 		[]float64{1.0, 2.0, 3.0},
@@ -514,9 +514,9 @@ func TestQuadraticExpr_Plus4(t *testing.T) {
 	// Constants
 	m := optim.NewModel()
 
-	v1 := m.AddVariableClassic(-10, 10, optim.Continuous)
-	v2 := m.AddVariableClassic(-10, 10, optim.Continuous)
-	v3 := m.AddVariableClassic(-10, 10, optim.Continuous)
+	v1, _ := m.AddVariableClassic(-10, 10, optim.Continuous)
+	v2, _ := m.AddVariableClassic(-10, 10, optim.Continuous)
+	v3, _ := m.AddVariableClassic(-10, 10, optim.Continuous)
 
 	Q1_aoa := [][]float64{
 		[]float64{1.0, 2.0},
@@ -600,8 +600,8 @@ func TestQuadraticExpr_Plus5(t *testing.T) {
 	// Constants
 	m := optim.NewModel()
 
-	v1 := m.AddVariableClassic(-10, 10, optim.Continuous)
-	v2 := m.AddVariableClassic(-10, 10, optim.Continuous)
+	v1, _ := m.AddVariableClassic(-10, 10, optim.Continuous)
+	v2, _ := m.AddVariableClassic(-10, 10, optim.Continuous)
 
 	Q1_aoa := [][]float64{
 		[]float64{1.0, 2.0},
@@ -670,8 +670,8 @@ func TestQuadraticExpr_Plus6(t *testing.T) {
 	// Constants
 	m := optim.NewModel()
 
-	v1 := m.AddVariableClassic(-10, 10, optim.Continuous)
-	v2 := m.AddVariableClassic(-10, 10, optim.Continuous)
+	v1, _ := m.AddVariableClassic(-10, 10, optim.Continuous)
+	v2, _ := m.AddVariableClassic(-10, 10, optim.Continuous)
 	v3 := m.AddVariable()
 
 	Q1_aoa := [][]float64{
@@ -786,8 +786,8 @@ func TestQuadraticExpr_RewriteInTermsOfIndices1(t *testing.T) {
 	// Constants
 	m := optim.NewModel()
 
-	v1 := m.AddVariableClassic(-10, 10, optim.Continuous)
-	v2 := m.AddVariableClassic(-10, 10, optim.Continuous)
+	v1, _ := m.AddVariableClassic(-10, 10, optim.Continuous)
+	v2, _ := m.AddVariableClassic(-10, 10, optim.Continuous)
 
 	Q1_aoa := [][]float64{
 		[]float64{1.0, 2.0},
@@ -808,7 +808,7 @@ func TestQuadraticExpr_RewriteInTermsOfIndices1(t *testing.T) {
 		t.Errorf("There was an issue creating a basic quadratic expression: %v", err)
 	}
 
-	v3 := m.AddVariableClassic(-10, 10, optim.Continuous)
+	v3, _ := m.AddVariableClassic(-10, 10, optim.Continuous)
 	vv2 := optim.VarVector{
 		[]optim.Variable{v1, v2, v3},
 	}
@@ -835,3 +835,107 @@ func TestQuadraticExpr_RewriteInTermsOfIndices1(t *testing.T) {
 	}
 
 }
+
+/*
+TestQuadraticExpr_Mult1
+Description:
+
+	Scales the quadratic expression x1^2 + 2 x1 + 3 and verifies every term of the result as well
+	as its negation and division by a constant.
+*/
+func TestQuadraticExpr_Mult1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x1 := m.AddVariable()
+
+	qe := optim.ScalarQuadraticExpression{
+		Q: *mat.NewDense(1, 1, []float64{1}),
+		L: *mat.NewVecDense(1, []float64{2}),
+		C: 3,
+		X: optim.VarVector{Elements: []optim.Variable{x1}},
+	}
+
+	// Algorithm
+	scaled, err := qe.Mult(2)
+	if err != nil {
+		t.Fatalf("There was an issue scaling the quadratic expression: %v", err)
+	}
+	scaledAsQE, ok := scaled.(optim.ScalarQuadraticExpression)
+	if !ok {
+		t.Fatalf("Expected the scaled expression to be a ScalarQuadraticExpression; received %T", scaled)
+	}
+	if scaledAsQE.Q.At(0, 0) != 2 || scaledAsQE.L.AtVec(0) != 4 || scaledAsQE.C != 6 {
+		t.Errorf("Expected the expression 2 x1^2 + 4 x1 + 6; received %v", scaled)
+	}
+	if qe.Q.At(0, 0) != 1 || qe.C != 3 {
+		t.Errorf("Expected the input to be unchanged; received %v", qe)
+	}
+
+	negated := qe.Neg().(optim.ScalarQuadraticExpression)
+	if negated.Q.At(0, 0) != -1 || negated.L.AtVec(0) != -2 || negated.C != -3 {
+		t.Errorf("Expected the expression -x1^2 - 2 x1 - 3; received %v", negated)
+	}
+
+	divided, err := qe.Div(4)
+	if err != nil {
+		t.Fatalf("There was an issue dividing the quadratic expression: %v", err)
+	}
+	if divided.(optim.ScalarQuadraticExpression).C != 0.75 {
+		t.Errorf("Expected the constant 0.75; received %v", divided)
+	}
+
+	// x1^2 + 2 x1 + 3 - x1 = x1^2 + x1 + 3
+	diff, err := qe.Minus(x1)
+	if err != nil {
+		t.Fatalf("There was an issue subtracting x1: %v", err)
+	}
+	if diffAsQE := diff.(optim.ScalarQuadraticExpression); diffAsQE.L.AtVec(0) != 1 {
+		t.Errorf("Expected the linear coefficient 1; received %v", diff)
+	}
+}
+
+/*
+TestQuadraticExpr_Simplify1
+Description:
+
+	Simplifies the quadratic expression x2 x1 + 0 x1^2 written with an asymmetric Q and verifies
+	that Q is symmetric, that the variables are sorted by ID and that a zero quadratic term of a
+	third variable is dropped.
+*/
+func TestQuadraticExpr_Simplify1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x1 := m.AddVariable()
+	x2 := m.AddVariable()
+	x3 := m.AddVariable()
+
+	qe := optim.ScalarQuadraticExpression{
+		Q: *mat.NewDense(3, 3, []float64{
+			0, 0, 0,
+			1, 0, 0,
+			0, 0, 0,
+		}),
+		L: *mat.NewVecDense(3, []float64{0, 2, 0}),
+		C: 0,
+		X: optim.VarVector{Elements: []optim.Variable{x2, x1, x3}},
+	}
+
+	// Algorithm
+	simplified := qe.Simplify()
+	if simplified.X.Len() != 2 || simplified.X.Elements[0].ID != x1.ID || simplified.X.Elements[1].ID != x2.ID {
+		t.Fatalf("Expected the variables [x1, x2]; received %v", simplified.X)
+	}
+	if simplified.Q.At(0, 1) != 0.5 || simplified.Q.At(1, 0) != 0.5 {
+		t.Errorf("Expected the symmetric Q = [0 0.5; 0.5 0]; received %v", mat.Formatted(&simplified.Q))
+	}
+	if simplified.L.AtVec(0) != 2 || simplified.L.AtVec(1) != 0 {
+		t.Errorf("Expected the linear term 2 x1; received %v", simplified.L)
+	}
+
+	if !simplified.Equals(qe, 1e-12) {
+		t.Errorf("Expected the simplified expression to equal the original expression.")
+	}
+	if simplified.Equals(x1, 1e-12) {
+		t.Errorf("Expected the quadratic expression to not equal x1.")
+	}
+}
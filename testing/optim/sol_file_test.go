@@ -0,0 +1,174 @@
+package optim_test
+
+/*
+sol_file_test.go
+Description:
+	Tests for the functions that write solutions to .sol and JSON files and read them back.
+*/
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kwesiRutledge/goop2/optim"
+)
+
+/*
+TestSolution_WriteSol1
+Description:
+
+	Writes a solution in the .sol format and verifies the contents and that it is read back
+	exactly. Variables without a value are not written.
+*/
+func TestSolution_WriteSol1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x := m.AddVariable()
+	y, _ := m.AddVariableClassic(-10, 10, optim.Integer)
+	z := m.AddBinaryVariable()
+	w := m.AddVariable()
+
+	sol := optim.Solution{
+		Values:    map[uint64]float64{x.ID: 1.0 / 3.0, y.ID: -4, z.ID: 1},
+		Objective: 12.5,
+		Status:    optim.OptimizationStatus_OPTIMAL,
+	}
+
+	// Algorithm
+	var buf bytes.Buffer
+	if err := sol.WriteSol(&buf, m); err != nil {
+		t.Fatalf("There was an issue writing the solution: %v", err)
+	}
+
+	expected := "# Solution written by goop2\n# Objective value = 12.5\nx0 0.3333333333333333\nx1 -4\nx2 1\n"
+	if buf.String() != expected {
+		t.Errorf("Expected the solution file\n%v\nreceived\n%v", expected, buf.String())
+	}
+
+	sol2, err := optim.ReadSol(&buf, m)
+	if err != nil {
+		t.Fatalf("There was an issue reading the solution: %v", err)
+	}
+	if !reflect.DeepEqual(sol2.Values, sol.Values) || sol2.Objective != sol.Objective {
+		t.Errorf("Expected the solution %v to be read back; received %v", sol, *sol2)
+	}
+	if _, found := sol2.Values[w.ID]; found {
+		t.Errorf("Expected no value for the variable %v; received %v", w, sol2.Values[w.ID])
+	}
+}
+
+/*
+TestReadSol1
+Description:
+
+	Verifies that comments and blank lines are skipped and that errors report the line and column
+	of the problem.
+*/
+func TestReadSol1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x := m.AddVariable()
+	y := m.AddVariable()
+
+	sol, err := optim.ReadSol(strings.NewReader("# A comment\n\n  x1   2.5\nx0 -1e-3\n"), m)
+	if err != nil {
+		t.Fatalf("There was an issue reading the solution: %v", err)
+	}
+	if sol.Value(x) != -1e-3 || sol.Value(y) != 2.5 {
+		t.Errorf("Expected x = -1e-3 and y = 2.5; received %v", sol.Values)
+	}
+
+	testCases := []struct {
+		SolFile string
+		Line    int
+		Column  int
+	}{
+		{"x0 1\nx5 2\n", 2, 1},
+		{"x0 1\n  x1   abc\n", 2, 8},
+		{"x0 1 2\n", 1, 1},
+		{"x0 1\nx0 2\n", 2, 1},
+		{"# Objective value = many\n", 1, 21},
+	}
+
+	// Algorithm
+	for _, testCase := range testCases {
+		_, err := optim.ReadSol(strings.NewReader(testCase.SolFile), m)
+		if err == nil {
+			t.Errorf("Expected an error reading %q, but received none.", testCase.SolFile)
+			continue
+		}
+
+		var parseErr optim.ParseError
+		if !errors.As(err, &parseErr) {
+			t.Errorf("Expected a ParseError reading %q; received %v", testCase.SolFile, err)
+			continue
+		}
+		if parseErr.Line != testCase.Line || parseErr.Column != testCase.Column {
+			t.Errorf(
+				"Expected the error reading %q at line %v, column %v; received %v",
+				testCase.SolFile, testCase.Line, testCase.Column, err,
+			)
+		}
+	}
+}
+
+/*
+TestSolution_WriteJSON1
+Description:
+
+	Verifies that the JSON form of a solution keeps the values, status, objective and solver
+	metadata, and that solutions for other models are rejected.
+*/
+func TestSolution_WriteJSON1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x := m.AddVariable()
+	y, _ := m.AddVariableClassic(0, 5, optim.Integer)
+
+	sol := optim.Solution{
+		Values:         map[uint64]float64{x.ID: 2.25, y.ID: 3},
+		Objective:      -7,
+		Status:         optim.OptimizationStatus_TIME_LIMIT,
+		Gap:            0.05,
+		BestBound:      math.Inf(-1),
+		Iterations:     42,
+		PrimalResidual: 1e-9,
+		DualResidual:   2e-8,
+	}
+
+	// Algorithm
+	var buf bytes.Buffer
+	if err := sol.WriteJSON(&buf, m); err != nil {
+		t.Fatalf("There was an issue writing the solution: %v", err)
+	}
+	solJSON := buf.String()
+
+	for _, expected := range []string{`"version": 1`, `"status": 9`, `"bestBound": "-Infinity"`, `"name": "x1"`} {
+		if !strings.Contains(solJSON, expected) {
+			t.Errorf("Expected the JSON to contain %v; received\n%v", expected, solJSON)
+		}
+	}
+
+	sol2, err := optim.ReadSolutionJSON(&buf, m)
+	if err != nil {
+		t.Fatalf("There was an issue reading the solution: %v", err)
+	}
+	if !reflect.DeepEqual(*sol2, sol) {
+		t.Errorf("Expected the solution %v to be read back exactly; received %v", sol, *sol2)
+	}
+
+	// A model with fewer variables does not contain x1.
+	m2 := optim.NewModel()
+	m2.AddVariable()
+	if _, err := optim.ReadSolutionJSON(strings.NewReader(solJSON), m2); err == nil {
+		t.Errorf("Expected an error reading a solution with an unknown variable, but received none.")
+	}
+
+	if _, err := optim.ReadSolutionJSON(strings.NewReader(`{"version": 2, "values": []}`), m); err == nil {
+		t.Errorf("Expected an error reading a solution with an unknown version, but received none.")
+	}
+}
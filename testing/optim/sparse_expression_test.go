@@ -0,0 +1,307 @@
+package optim_test
+
+/*
+sparse_expression_test.go
+Description:
+	Tests for the sparse expressions SparseScalarLinearExpr, SparseScalarQuadraticExpression and
+	SparseVectorLinearExpr.
+*/
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/kwesiRutledge/goop2/optim"
+	"gonum.org/v1/gonum/mat"
+)
+
+/*
+TestSparseScalarLinearExpr_ToSparse1
+Description:
+
+	Converts a linear expression with a repeated variable and a zero coefficient to a sparse
+	expression and back, and verifies that only the nonzero terms are stored.
+*/
+func TestSparseScalarLinearExpr_ToSparse1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x := m.AddVariable()
+	y := m.AddVariable()
+	z := m.AddVariable()
+
+	// 1 x + 2 y + 0 z + 3 x + 5
+	sle := optim.ScalarLinearExpr{
+		X: optim.VarVector{Elements: []optim.Variable{x, y, z, x}},
+		L: *mat.NewVecDense(4, []float64{1, 2, 0, 3}),
+		C: 5,
+	}
+
+	// Algorithm
+	sparse := sle.ToSparse()
+	if err := sparse.Check(); err != nil {
+		t.Fatalf("Expected the sparse expression to be valid; received %v", err)
+	}
+	if len(sparse.Linear) != 2 || sparse.NumVars() != 3 {
+		t.Errorf("Expected 2 terms over 3 variables; received %v terms over %v variables", len(sparse.Linear), sparse.NumVars())
+	}
+	if !sparse.Equals(sle, 0) || !sparse.ToDense().Equals(sle, 0) {
+		t.Errorf("Expected the sparse and dense expressions to be equal; received %v and %v", sparse, sle)
+	}
+	if coeffs := sparse.Coeffs(); coeffs[0] != 4 || coeffs[1] != 2 || coeffs[2] != 0 {
+		t.Errorf("Expected the coefficients [4 2 0]; received %v", coeffs)
+	}
+
+	// Terms must refer to the variables of X
+	bad := optim.SparseScalarLinearExpr{
+		X:      optim.VarVector{Elements: []optim.Variable{x}},
+		Linear: []optim.LinearTerm{{ID: y.ID, Coeff: 1}},
+	}
+	if err := bad.Check(); err == nil {
+		t.Errorf("Expected an error for a term whose variable is not in X, but received none.")
+	}
+}
+
+/*
+TestSparseScalarLinearExpr_Plus1
+Description:
+
+	Adds dense and sparse expressions in both orders and verifies that the sums stay sparse and
+	that terms which cancel are dropped.
+*/
+func TestSparseScalarLinearExpr_Plus1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x := m.AddVariable()
+	y := m.AddVariable()
+
+	// 2 x - y + 1
+	sparse := optim.SparseScalarLinearExpr{
+		X:      optim.VarVector{Elements: []optim.Variable{x, y}},
+		Linear: []optim.LinearTerm{{ID: x.ID, Coeff: 2}, {ID: y.ID, Coeff: -1}},
+		C:      1,
+	}
+
+	// Algorithm
+	sum, err := sparse.Plus(y)
+	if err != nil {
+		t.Fatalf("There was an issue adding y: %v", err)
+	}
+	sumAsSparse, ok := sum.(optim.SparseScalarLinearExpr)
+	if !ok {
+		t.Fatalf("Expected the sum to be a SparseScalarLinearExpr; received %T", sum)
+	}
+	if len(sumAsSparse.Linear) != 1 || sumAsSparse.NumVars() != 1 {
+		t.Errorf("Expected y to cancel out of the sum; received %v", sumAsSparse)
+	}
+
+	sum, err = optim.K(2).Plus(sparse)
+	if err != nil {
+		t.Fatalf("There was an issue adding the sparse expression to a constant: %v", err)
+	}
+	if _, ok := sum.(optim.SparseScalarLinearExpr); !ok || sum.Constant() != 3 {
+		t.Errorf("Expected 2 + (2 x - y + 1) to be sparse with constant 3; received %v", sum)
+	}
+
+	xy, _ := x.Multiply(y)
+	sum, err = xy.(optim.ScalarExpression).Plus(sparse)
+	if err != nil {
+		t.Fatalf("There was an issue adding the sparse expression to x y: %v", err)
+	}
+	if _, ok := sum.(optim.SparseScalarQuadraticExpression); !ok {
+		t.Errorf("Expected x y + (2 x - y + 1) to be a SparseScalarQuadraticExpression; received %T", sum)
+	}
+	expected, _ := optim.Sum(xy, sparse.ToDense())
+	assertSameTerms(t, sum, expected.(optim.ScalarExpression))
+}
+
+/*
+TestSparseScalarQuadraticExpression_ToDense1
+Description:
+
+	Builds the sparse quadratic x^2 + 2 x y - 3 y and verifies its dense form and coefficients.
+*/
+func TestSparseScalarQuadraticExpression_ToDense1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x := m.AddVariable()
+	y := m.AddVariable()
+
+	sparse := optim.SparseScalarQuadraticExpression{
+		X: optim.VarVector{Elements: []optim.Variable{x, y}},
+		Quadratic: []optim.QuadraticTerm{
+			{ID1: x.ID, ID2: x.ID, Coeff: 1},
+			{ID1: x.ID, ID2: y.ID, Coeff: 2},
+		},
+		Linear: []optim.LinearTerm{{ID: y.ID, Coeff: -3}},
+	}
+
+	// Algorithm
+	dense := sparse.ToDense()
+	expectedQ := mat.NewDense(2, 2, []float64{1, 1, 1, 0})
+	if !mat.Equal(&dense.Q, expectedQ) {
+		t.Errorf("Expected Q = [1 1; 1 0]; received %v", mat.Formatted(&dense.Q))
+	}
+	assertSameTerms(t, dense, sparse)
+
+	if coeffs := sparse.Coeffs(); len(coeffs) != 5 || coeffs[1] != 2 || coeffs[4] != -3 {
+		t.Errorf("Expected the coefficients [1 2 0 0 -3]; received %v", coeffs)
+	}
+
+	if !dense.ToSparse().Equals(sparse, 0) {
+		t.Errorf("Expected the round trip to give the same expression; received %v", dense.ToSparse())
+	}
+
+	scaled, _ := sparse.Div(2)
+	if scaled.(optim.SparseScalarQuadraticExpression).Quadratic[1].Coeff != 1 || sparse.Quadratic[1].Coeff != 2 {
+		t.Errorf("Expected Div to scale a copy of the terms; received %v", scaled)
+	}
+}
+
+/*
+TestSparseScalarQuadraticExpression_Large1
+Description:
+
+	Builds the sum of squares of 10000 variables as a sparse expression, adds it to a linear
+	term and reads its terms with SparseTermsOf. A dense Q for this expression would have 10^8
+	entries.
+*/
+func TestSparseScalarQuadraticExpression_Large1(t *testing.T) {
+	// Constants
+	n := 10000
+	m := optim.NewModel()
+	x, _ := m.AddVariableVector(n)
+
+	sparse := optim.SparseScalarQuadraticExpression{X: x}
+	for _, tempVar := range x.Elements {
+		sparse.Quadratic = append(sparse.Quadratic, optim.QuadraticTerm{ID1: tempVar.ID, ID2: tempVar.ID, Coeff: 1})
+	}
+
+	// Algorithm
+	sum, err := sparse.Plus(x.Elements[0])
+	if err != nil {
+		t.Fatalf("There was an issue adding a variable: %v", err)
+	}
+
+	terms, err := optim.SparseTermsOf(sum)
+	if err != nil {
+		t.Fatalf("There was an issue collecting the terms: %v", err)
+	}
+	if len(terms.Quadratic) != n || len(terms.Linear) != 1 {
+		t.Errorf("Expected %v quadratic terms and 1 linear term; received %v and %v", n, len(terms.Quadratic), len(terms.Linear))
+	}
+}
+
+/*
+TestSparseVectorLinearExpr_Plus1
+Description:
+
+	Adds a VarVector and a dense VectorLinearExpr to a sparse vector expression and verifies each
+	element, then compares the result with a KVector.
+*/
+func TestSparseVectorLinearExpr_Plus1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x, _ := m.AddVariableVector(3)
+
+	// [x0 + 2 x2 + 1; -x1]
+	svle := optim.SparseVectorLinearExpr{
+		X: x,
+		Rows: [][]optim.LinearTerm{
+			{{ID: x.Elements[0].ID, Coeff: 1}, {ID: x.Elements[2].ID, Coeff: 2}},
+			{{ID: x.Elements[1].ID, Coeff: -1}},
+		},
+		C: *mat.NewVecDense(2, []float64{1, 0}),
+	}
+	firstTwo := optim.VarVector{Elements: x.Elements[:2]}
+
+	// Algorithm
+	sum, err := svle.Plus(firstTwo)
+	if err != nil {
+		t.Fatalf("There was an issue adding the VarVector: %v", err)
+	}
+	sumAsSparse, ok := sum.(optim.SparseVectorLinearExpr)
+	if !ok {
+		t.Fatalf("Expected the sum to be a SparseVectorLinearExpr; received %T", sum)
+	}
+	if len(sumAsSparse.Rows[1]) != 0 {
+		t.Errorf("Expected -x1 + x1 to cancel; received %v", sumAsSparse.Rows[1])
+	}
+	expected0 := optim.ScalarLinearExpr{X: x, L: *mat.NewVecDense(3, []float64{2, 0, 2}), C: 1}
+	assertSameTerms(t, sum.AtVec(0), expected0)
+
+	if !svle.ToDense().ToSparse().Equals(svle, 0) || !svle.Equals(svle.ToDense(), 0) {
+		t.Errorf("Expected the sparse expression to equal its dense form.")
+	}
+
+	denseSum, err := svle.ToDense().Plus(svle)
+	if err != nil {
+		t.Fatalf("There was an issue adding the sparse expression to its dense form: %v", err)
+	}
+	doubled, _ := svle.Mult(2)
+	if !denseSum.Equals(doubled, 1e-12) {
+		t.Errorf("Expected the sum to be twice the expression; received %v", denseSum)
+	}
+
+	// Comparisons
+	constr, err := svle.LessEq(optim.KVector(*mat.NewVecDense(2, []float64{4, 5})))
+	if err != nil {
+		t.Fatalf("There was an issue comparing with a KVector: %v", err)
+	}
+	if err := constr.Check(); err != nil {
+		t.Errorf("Expected the constraint to be valid; received %v", err)
+	}
+	if _, err := svle.Eq(x); err == nil {
+		t.Errorf("Expected an error comparing vectors of length 2 and 3, but received none.")
+	}
+}
+
+/*
+TestSparseExpression_JSON1
+Description:
+
+	Writes each sparse expression to JSON and reads it back as part of a constraint.
+*/
+func TestSparseExpression_JSON1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x, _ := m.AddVariableVector(2)
+
+	sqe := optim.SparseScalarQuadraticExpression{
+		X:         x,
+		Quadratic: []optim.QuadraticTerm{{ID1: x.Elements[0].ID, ID2: x.Elements[1].ID, Coeff: 2}},
+		Linear:    []optim.LinearTerm{{ID: x.Elements[1].ID, Coeff: -1}},
+		C:         3,
+	}
+	svle := optim.SparseVectorLinearExpr{
+		X:    x,
+		Rows: [][]optim.LinearTerm{{{ID: x.Elements[0].ID, Coeff: 1}}, nil},
+		C:    *mat.NewVecDense(2, []float64{0, 1}),
+	}
+
+	// Algorithm
+	sc, _ := sqe.LessEq(optim.K(1))
+	data, err := json.Marshal(sc)
+	if err != nil {
+		t.Fatalf("There was an issue writing the scalar constraint: %v", err)
+	}
+	var scOut optim.ScalarConstraint
+	if err := json.Unmarshal(data, &scOut); err != nil {
+		t.Fatalf("There was an issue reading the scalar constraint: %v", err)
+	}
+	if lhs, ok := scOut.LeftHandSide.(optim.SparseScalarQuadraticExpression); !ok || !lhs.Equals(sqe, 0) {
+		t.Errorf("Expected the left hand side %v; received %v", sqe, scOut.LeftHandSide)
+	}
+
+	vc, _ := svle.Eq(x)
+	data, err = json.Marshal(vc)
+	if err != nil {
+		t.Fatalf("There was an issue writing the vector constraint: %v", err)
+	}
+	var vcOut optim.VectorConstraint
+	if err := json.Unmarshal(data, &vcOut); err != nil {
+		t.Fatalf("There was an issue reading the vector constraint: %v", err)
+	}
+	if lhs, ok := vcOut.LeftHandSide.(optim.SparseVectorLinearExpr); !ok || !lhs.Equals(svle, 0) {
+		t.Errorf("Expected the left hand side %v; received %v", svle, vcOut.LeftHandSide)
+	}
+}
@@ -58,3 +58,54 @@ func TestUtil_OnesVector2(t *testing.T) {
 		}
 	}
 }
+
+/*
+TestUtil_SumVars1
+Description:
+
+	Verifies that SumVars, SumRow and SumCol return the sums of their variables (and not an
+	empty expression).
+*/
+func TestUtil_SumVars1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	X, _ := m.AddVariableMatrix(2, 3, 0, 1, optim.Continuous)
+
+	// Algorithm
+	sum := optim.SumVars(X.Elements[0][0], X.Elements[0][1], X.Elements[0][0])
+	if sum.NumVars() != 2 || sum.Coeffs()[0] != 2 || sum.Coeffs()[1] != 1 {
+		t.Errorf("Expected the sum 2 x00 + x01; received %v", sum)
+	}
+
+	rowSum := optim.SumRow(X.Elements, 1)
+	expectedRow, _ := optim.Sum(X.Elements[1][0], X.Elements[1][1], X.Elements[1][2])
+	assertSameTerms(t, rowSum, expectedRow.(optim.ScalarExpression))
+
+	colSum := optim.SumCol(X.Elements, 2)
+	expectedCol, _ := X.Elements[0][2].Plus(X.Elements[1][2])
+	assertSameTerms(t, colSum, expectedCol)
+}
+
+/*
+TestUtil_Dot1
+Description:
+
+	Verifies that Dot returns the weighted sum of its variables.
+*/
+func TestUtil_Dot1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x, _ := m.AddVariableVector(3)
+	coeffs := []float64{1, 2, 3}
+
+	// Algorithm
+	dot := optim.Dot(x.Elements, coeffs)
+	for coeffIndex, coeff := range dot.Coeffs() {
+		if coeff != coeffs[coeffIndex] {
+			t.Errorf("Expected coefficient %v to be %v; received %v", coeffIndex, coeffs[coeffIndex], coeff)
+		}
+	}
+	if dot.NumVars() != 3 || dot.Constant() != 0 {
+		t.Errorf("Expected 3 variables and no constant; received %v", dot)
+	}
+}
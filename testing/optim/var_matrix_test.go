@@ -0,0 +1,256 @@
+package optim_test
+
+/*
+var_matrix_test.go
+Description:
+	Tests for the VarMatrix object.
+*/
+
+import (
+	"testing"
+
+	"github.com/kwesiRutledge/goop2/optim"
+	"github.com/kwesiRutledge/goop2/solvers/mock"
+	"gonum.org/v1/gonum/mat"
+)
+
+/*
+assertSameTerms
+Description:
+
+	Verifies that the scalar expression received has the same coefficients as expected.
+*/
+func assertSameTerms(t *testing.T, received, expected optim.ScalarExpression) {
+	t.Helper()
+
+	receivedTerms, err := mock.TermsOf(received)
+	if err != nil {
+		t.Fatalf("There was an issue collecting the terms of %v: %v", received, err)
+	}
+	expectedTerms, err := mock.TermsOf(expected)
+	if err != nil {
+		t.Fatalf("There was an issue collecting the terms of %v: %v", expected, err)
+	}
+
+	if !receivedTerms.Equals(expectedTerms) {
+		t.Errorf("Expected the expression %v; received %v", expected, received)
+	}
+}
+
+/*
+assertMatrixElement
+Description:
+
+	Verifies that the element in row i and column j of me has the same coefficients as expected.
+*/
+func assertMatrixElement(t *testing.T, me optim.MatrixExpression, i, j int, expected optim.ScalarExpression) {
+	t.Helper()
+	assertSameTerms(t, me.At(i, j), expected)
+}
+
+/*
+TestVarMatrix_RowCol1
+Description:
+
+	Extracts rows and columns of a 2 x 3 VarMatrix and verifies its transpose.
+*/
+func TestVarMatrix_RowCol1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	vm, err := m.AddVariableMatrix(2, 3, 0, 1, optim.Continuous)
+	if err != nil {
+		t.Fatalf("There was an issue creating the variable matrix: %v", err)
+	}
+
+	// Algorithm
+	if nR, nC := vm.Dims(); nR != 2 || nC != 3 {
+		t.Errorf("Expected the dimensions 2 x 3; received %v x %v", nR, nC)
+	}
+	if vm.NumVars() != 6 {
+		t.Errorf("Expected 6 variables; received %v", vm.NumVars())
+	}
+
+	row1 := vm.Row(1)
+	if row1.Len() != 3 || row1.Elements[2].ID != vm.Elements[1][2].ID {
+		t.Errorf("Expected row 1 to be %v; received %v", vm.Elements[1], row1)
+	}
+	col2 := vm.Col(2)
+	if col2.Len() != 2 || col2.Elements[0].ID != vm.Elements[0][2].ID || col2.Elements[1].ID != vm.Elements[1][2].ID {
+		t.Errorf("Expected column 2 to hold the variables %v and %v; received %v", vm.Elements[0][2], vm.Elements[1][2], col2)
+	}
+
+	vmT, ok := vm.T().(optim.VarMatrix)
+	if !ok {
+		t.Fatalf("Expected the transpose to be a VarMatrix; received %T", vm.T())
+	}
+	if nR, nC := vmT.Dims(); nR != 3 || nC != 2 {
+		t.Errorf("Expected the transpose to be 3 x 2; received %v x %v", nR, nC)
+	}
+	if vmT.Elements[2][1].ID != vm.Elements[1][2].ID {
+		t.Errorf("Expected element (2, 1) of the transpose to be %v; received %v", vm.Elements[1][2], vmT.Elements[2][1])
+	}
+}
+
+/*
+TestVarMatrix_Plus1
+Description:
+
+	Adds a KMatrix and another VarMatrix to a VarMatrix and verifies each element.
+*/
+func TestVarMatrix_Plus1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	X, _ := m.AddVariableMatrix(2, 2, 0, 1, optim.Continuous)
+	Y, _ := m.AddVariableMatrix(2, 2, 0, 1, optim.Continuous)
+	C := optim.KMatrix(*mat.NewDense(2, 2, []float64{1, 2, 3, 4}))
+
+	// Algorithm
+	sum, err := X.Plus(C)
+	if err != nil {
+		t.Fatalf("There was an issue adding the constant: %v", err)
+	}
+	sum, err = sum.Plus(Y)
+	if err != nil {
+		t.Fatalf("There was an issue adding the variables: %v", err)
+	}
+	if _, ok := sum.(optim.MatrixLinearExpr); !ok {
+		t.Errorf("Expected the sum to be a MatrixLinearExpr; received %T", sum)
+	}
+
+	for i := 0; i < 2; i++ {
+		for j := 0; j < 2; j++ {
+			expected, _ := X.Elements[i][j].Plus(Y.Elements[i][j])
+			expected, _ = expected.Plus(optim.K(float64(2*i + j + 1)))
+			assertMatrixElement(t, sum, i, j, expected)
+		}
+	}
+
+	// The dimensions must match
+	Z, _ := m.AddVariableMatrix(2, 3, 0, 1, optim.Continuous)
+	if _, err := X.Plus(Z); err == nil {
+		t.Errorf("Expected an error adding a 2 x 3 matrix to a 2 x 2 matrix, but received none.")
+	}
+}
+
+/*
+TestVarMatrix_Plus2
+Description:
+
+	Adds a VarMatrix to its transpose so that each off diagonal variable appears twice in the
+	expression.
+*/
+func TestVarMatrix_Plus2(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	X, _ := m.AddVariableMatrix(2, 2, 0, 1, optim.Continuous)
+
+	// Algorithm
+	sum, err := X.Plus(X.T())
+	if err != nil {
+		t.Fatalf("There was an issue adding the transpose: %v", err)
+	}
+	sum, err = sum.Mult(0.5)
+	if err != nil {
+		t.Fatalf("There was an issue scaling the sum: %v", err)
+	}
+
+	assertMatrixElement(t, sum, 0, 0, X.Elements[0][0])
+	offDiagonal, _ := X.Elements[0][1].Plus(X.Elements[1][0])
+	offDiagonal, _ = offDiagonal.Mult(0.5)
+	assertMatrixElement(t, sum, 0, 1, offDiagonal)
+	assertMatrixElement(t, sum, 1, 0, offDiagonal)
+}
+
+/*
+TestVarMatrix_Trace1
+Description:
+
+	Computes the trace of a square VarMatrix and verifies that non-square matrices are rejected.
+*/
+func TestVarMatrix_Trace1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	X, _ := m.AddVariableMatrix(2, 2, 0, 1, optim.Continuous)
+	Y, _ := m.AddVariableMatrix(2, 3, 0, 1, optim.Continuous)
+
+	// Algorithm
+	trace, err := X.Trace()
+	if err != nil {
+		t.Fatalf("There was an issue computing the trace: %v", err)
+	}
+
+	expected, _ := X.Elements[0][0].Plus(X.Elements[1][1])
+	assertSameTerms(t, trace, expected)
+
+	if _, err := Y.Trace(); err == nil {
+		t.Errorf("Expected an error computing the trace of a 2 x 3 matrix, but received none.")
+	}
+}
+
+/*
+TestVarMatrix_Comparison1
+Description:
+
+	Compares a VarMatrix with a constant, a mat.Dense and a matrix of the wrong size.
+*/
+func TestVarMatrix_Comparison1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	X, _ := m.AddVariableMatrix(2, 2, 0, 1, optim.Continuous)
+
+	// Algorithm
+	mc, err := X.LessEq(optim.K(1))
+	if err != nil {
+		t.Fatalf("There was an issue comparing with a constant: %v", err)
+	}
+	if rhs, ok := mc.RightHandSide.(optim.KMatrix); !ok || rhs.At(1, 0) != optim.K(1) {
+		t.Errorf("Expected the right hand side to be a KMatrix of ones; received %v", mc.RightHandSide)
+	}
+
+	mc, err = X.GreaterEq(*mat.NewDense(2, 2, []float64{0, 1, 2, 3}))
+	if err != nil {
+		t.Fatalf("There was an issue comparing with a mat.Dense: %v", err)
+	}
+	if mc.Sense != optim.SenseGreaterThanEqual || mc.RightHandSide.At(1, 1) != optim.K(3) {
+		t.Errorf("Expected the constraint X >= [0 1; 2 3]; received %v", mc)
+	}
+
+	if _, err := X.Eq(*mat.NewDense(3, 2, nil)); err == nil {
+		t.Errorf("Expected an error comparing a 2 x 2 matrix with a 3 x 2 matrix, but received none.")
+	}
+	if _, err := X.Eq("X"); err == nil {
+		t.Errorf("Expected an error comparing a matrix with a string, but received none.")
+	}
+}
+
+/*
+TestVarMatrix_Equals1
+Description:
+
+	Verifies that X + X' equals its transpose and that X does not equal X'.
+*/
+func TestVarMatrix_Equals1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	X, _ := m.AddVariableMatrix(2, 2, 0, 1, optim.Continuous)
+
+	// Algorithm
+	sum, err := X.Plus(X.T())
+	if err != nil {
+		t.Fatalf("There was an issue adding the transpose: %v", err)
+	}
+	if !sum.Equals(sum.T(), 0) {
+		t.Errorf("Expected X + X' to be symmetric.")
+	}
+	if X.Equals(X.T(), 0) {
+		t.Errorf("Expected X to not equal its transpose.")
+	}
+	if !X.Equals(X, 0) {
+		t.Errorf("Expected X to equal itself.")
+	}
+
+	A := mat.NewDense(2, 2, []float64{1, 2, 3, 4})
+	if !optim.KMatrix(*A).Equals(A, 0) || optim.KMatrix(*A).Equals(X, 0) {
+		t.Errorf("Expected the KMatrix to equal only the matching mat.Dense.")
+	}
+}
@@ -297,8 +297,8 @@ func TestVarVector_Comparison1(t *testing.T) {
 	// Constants
 	desLength := 10
 	m := optim.NewModel()
-	var vec1 = m.AddVariableVector(desLength)
-	var vec2 = m.AddVariableVector(desLength - 1)
+	vec1, _ := m.AddVariableVector(desLength)
+	vec2, _ := m.AddVariableVector(desLength - 1)
 
 	L1 := optim.Identity(desLength - 1)
 	c1 := optim.OnesVector(desLength - 1)
@@ -326,8 +326,8 @@ func TestVarVector_Comparison2(t *testing.T) {
 	// Constants
 	desLength := 10
 	m := optim.NewModel()
-	var vec1 = m.AddVariableVector(desLength)
-	var vec2 = m.AddVariableVector(desLength)
+	vec1, _ := m.AddVariableVector(desLength)
+	vec2, _ := m.AddVariableVector(desLength)
 
 	L1 := optim.Identity(desLength)
 	c1 := optim.OnesVector(desLength)
@@ -354,7 +354,7 @@ func TestVarVector_Plus1(t *testing.T) {
 	// Constants
 	desLength := 10
 	m := optim.NewModel()
-	vec1 := m.AddVariableVector(desLength)
+	vec1, _ := m.AddVariableVector(desLength)
 	k2 := optim.KVector(optim.OnesVector(desLength))
 
 	// Algorithm
@@ -410,7 +410,7 @@ func TestVarVector_Plus2(t *testing.T) {
 	// Constants
 	desLength := 10
 	m := optim.NewModel()
-	vec1 := m.AddVariableVector(desLength)
+	vec1, _ := m.AddVariableVector(desLength)
 	k2 := optim.KVector(optim.OnesVector(desLength - 1))
 
 	// Algorithm
@@ -435,7 +435,7 @@ func TestVarVector_Plus3(t *testing.T) {
 	// Constants
 	desLength := 10
 	m := optim.NewModel()
-	vec1 := m.AddVariableVector(desLength)
+	vec1, _ := m.AddVariableVector(desLength)
 	k2 := optim.OnesVector(desLength)
 
 	// Algorithm
@@ -491,8 +491,8 @@ func TestVarVector_Plus4(t *testing.T) {
 	// Constants
 	desLength := 10
 	m := optim.NewModel()
-	vec1 := m.AddVariableVector(desLength)
-	vec2 := m.AddVariableVector(desLength - 2)
+	vec1, _ := m.AddVariableVector(desLength)
+	vec2, _ := m.AddVariableVector(desLength - 2)
 	vec3 := optim.VarVector{
 		append(vec2.Elements, vec1.AtVec(0).(optim.Variable), vec1.AtVec(1).(optim.Variable)),
 	}
@@ -614,8 +614,8 @@ func TestVarVector_Plus5(t *testing.T) {
 	// Constants
 	desLength := 10
 	m := optim.NewModel()
-	vec1 := m.AddVariableVector(desLength)
-	vec3 := m.AddVariableVector(desLength)
+	vec1, _ := m.AddVariableVector(desLength)
+	vec3, _ := m.AddVariableVector(desLength)
 
 	// Algorithm
 	sum3, err := vec1.Plus(vec3)
@@ -733,7 +733,7 @@ func TestVarVector_AtVec1(t *testing.T) {
 	// Constants
 	desLength := 10
 	m := optim.NewModel()
-	vec1 := m.AddVariableVector(desLength)
+	vec1, _ := m.AddVariableVector(desLength)
 	idx1 := 2
 
 	// Algorithm
@@ -757,3 +757,54 @@ func TestVarVector_AtVec1(t *testing.T) {
 		)
 	}
 }
+
+/*
+TestVarVector_Minus1
+Description:
+
+	Subtracts a vector of variables and a constant vector from a vector of variables and verifies
+	each element of the difference, as well as the negation and division of the vector.
+*/
+func TestVarVector_Minus1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x, _ := m.AddVariableVector(2)
+	y, _ := m.AddVariableVector(2)
+	ones := optim.OnesVector(2)
+
+	// Algorithm
+	diff, err := x.Minus(y)
+	if err != nil {
+		t.Fatalf("There was an issue computing x - y: %v", err)
+	}
+	diff, err = diff.Minus(ones)
+	if err != nil {
+		t.Fatalf("There was an issue subtracting the ones vector: %v", err)
+	}
+	if _, ok := diff.(optim.VectorLinearExpr); !ok {
+		t.Errorf("Expected the difference to be a VectorLinearExpr; received %T", diff)
+	}
+
+	for idx := 0; idx < 2; idx++ {
+		expected, _ := x.Elements[idx].Minus(y.Elements[idx])
+		expected, _ = expected.Minus(1.0)
+		assertSameTerms(t, diff.AtVec(idx), expected)
+	}
+
+	negated := x.Neg()
+	assertSameTerms(t, negated.AtVec(1), x.Elements[1].Neg())
+
+	halved, err := x.Div(2)
+	if err != nil {
+		t.Fatalf("There was an issue dividing x by 2: %v", err)
+	}
+	halfX0, _ := x.Elements[0].Mult(0.5)
+	assertSameTerms(t, halved.AtVec(0), halfX0)
+
+	if _, err := x.Div(0); err == nil {
+		t.Errorf("Expected an error dividing by zero, but received none.")
+	}
+	if _, err := x.Minus(optim.OnesVector(3)); err == nil {
+		t.Errorf("Expected an error subtracting a vector of length 3 from a vector of length 2, but received none.")
+	}
+}
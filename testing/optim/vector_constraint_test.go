@@ -0,0 +1,217 @@
+package optim_test
+
+/*
+vector_constraint_test.go
+Description:
+	Tests for the VectorConstraint object and for adding vector constraints to a Model.
+*/
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kwesiRutledge/goop2/optim"
+	"github.com/kwesiRutledge/goop2/solvers/mock"
+	"gonum.org/v1/gonum/mat"
+)
+
+/*
+TestVectorConstraint_ScalarConstraints1
+Description:
+
+	Expands a constraint between a VectorLinearExpr and a KVector and verifies each row.
+*/
+func TestVectorConstraint_ScalarConstraints1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	vv, _ := m.AddVariableVector(2)
+	x, y := vv.Elements[0], vv.Elements[1]
+
+	vle := optim.VectorLinearExpr{
+		X: vv,
+		L: *mat.NewDense(2, 2, []float64{1, 2, 3, 4}),
+		C: *mat.NewVecDense(2, []float64{0, -1}),
+	}
+	vc, err := vle.LessEq(optim.KVector(*mat.NewVecDense(2, []float64{5, 6})))
+	if err != nil {
+		t.Fatalf("There was an issue creating the constraint: %v", err)
+	}
+
+	// Algorithm
+	rows, err := vc.ScalarConstraints()
+	if err != nil {
+		t.Fatalf("There was an issue expanding the constraint: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("Expected 2 scalar constraints; received %v", len(rows))
+	}
+
+	row0, _ := x.Plus(y.Mult(2))
+	threeX, _ := x.Mult(3)
+	row1, _ := threeX.Plus(y.Mult(4))
+	row1, _ = row1.Plus(optim.K(-1))
+	expected := []optim.ScalarConstraint{
+		{LeftHandSide: row0, RightHandSide: optim.K(5), Sense: optim.SenseLessThanEqual},
+		{LeftHandSide: row1, RightHandSide: optim.K(6), Sense: optim.SenseLessThanEqual},
+	}
+	for rowIndex, row := range rows {
+		match, err := mock.ConstraintsMatch(row, expected[rowIndex])
+		if err != nil || !match {
+			t.Errorf("Expected row %v to be %v; received %v (%v)", rowIndex, expected[rowIndex], row, err)
+		}
+	}
+
+	// Changing the expanded row does not change the original expression.
+	row0AsSLE := rows[0].LeftHandSide.(optim.ScalarLinearExpr)
+	row0AsSLE.L.SetVec(0, 100)
+	if vle.L.At(0, 0) != 1 {
+		t.Errorf("Expected the rows to not share data with the vector expression; L is now %v", mat.Formatted(&vle.L))
+	}
+}
+
+/*
+TestVectorConstraint_Check1
+Description:
+
+	Verifies that constraints with sides of different lengths (or missing sides) are rejected.
+*/
+func TestVectorConstraint_Check1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	vv, _ := m.AddVariableVector(2)
+
+	badConstrs := []optim.VectorConstraint{
+		{LeftHandSide: vv, RightHandSide: optim.KVector(optim.OnesVector(3)), Sense: optim.SenseEqual},
+		{LeftHandSide: vv, Sense: optim.SenseEqual},
+		{
+			LeftHandSide:  optim.VectorLinearExpr{X: vv, L: optim.Identity(3), C: optim.OnesVector(3)},
+			RightHandSide: optim.KVector(optim.OnesVector(3)),
+			Sense:         optim.SenseLessThanEqual,
+		},
+	}
+
+	// Algorithm
+	for _, vc := range badConstrs {
+		if err := vc.Check(); err == nil {
+			t.Errorf("Expected an error checking %v, but received none.", vc)
+		}
+		if _, err := vc.ScalarConstraints(); err == nil {
+			t.Errorf("Expected an error expanding %v, but received none.", vc)
+		}
+	}
+}
+
+/*
+TestModel_AddConstr1
+Description:
+
+	Adds vector constraints with KVector, VarVector and VectorLinearExpr right hand sides (by value
+	and by pointer) to a model and verifies that the solver receives one scalar constraint per row
+	with the correct sense.
+*/
+func TestModel_AddConstr1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	xs, _ := m.AddVariableVector(2)
+	ys, _ := m.AddVariableVector(2)
+	x0, x1, y0, y1 := xs.Elements[0], xs.Elements[1], ys.Elements[0], ys.Elements[1]
+
+	kv := optim.KVector(*mat.NewVecDense(2, []float64{3, 4}))
+	vle := optim.VectorLinearExpr{
+		X: ys,
+		L: *mat.NewDense(2, 2, []float64{2, 0, 0, 2}),
+		C: *mat.NewVecDense(2, []float64{1, 0}),
+	}
+
+	// xs <= kv
+	m.AddConstr(xs.LessEq(kv))
+
+	// xs >= ys, given as a pointer
+	vc, err := xs.GreaterEq(ys)
+	if err != nil {
+		t.Fatalf("There was an issue creating the constraint: %v", err)
+	}
+	m.AddConstr(&vc, nil)
+
+	// xs <= 2 ys + [1, 0], which must keep xs on the smaller side
+	m.AddConstr(xs.LessEq(vle))
+
+	// kv >= 2 ys + [1, 0]
+	m.AddConstr(kv.GreaterEq(vle))
+
+	// A scalar constraint is still accepted.
+	m.AddConstr(x0.LessEq(y1))
+
+	// Algorithm
+	solver := mock.NewSolver()
+	if _, err := m.Optimize(solver); err != nil {
+		t.Fatalf("There was an issue loading the model into the mock solver: %v", err)
+	}
+
+	twoY0, _ := y0.Mult(2)
+	twoY0Plus1, _ := twoY0.Plus(optim.K(1))
+	twoY1, _ := y1.Mult(2)
+
+	solver.AssertNumConstraints(t, 9)
+	solver.AssertConstraint(t, 0, x0, optim.SenseLessThanEqual, optim.K(3))
+	solver.AssertConstraint(t, 1, x1, optim.SenseLessThanEqual, optim.K(4))
+	solver.AssertConstraint(t, 2, x0, optim.SenseGreaterThanEqual, y0)
+	solver.AssertConstraint(t, 3, x1, optim.SenseGreaterThanEqual, y1)
+	solver.AssertConstraint(t, 4, x0, optim.SenseLessThanEqual, twoY0Plus1)
+	solver.AssertConstraint(t, 5, x1, optim.SenseLessThanEqual, twoY1)
+	solver.AssertConstraint(t, 6, optim.K(3), optim.SenseGreaterThanEqual, twoY0Plus1)
+	solver.AssertConstraint(t, 7, optim.K(4), optim.SenseGreaterThanEqual, twoY1)
+	solver.AssertConstraint(t, 8, x0, optim.SenseLessThanEqual, y1)
+}
+
+/*
+TestModel_AddConstr2
+Description:
+
+	Verifies that a vector constraint with mismatched dimensions is rejected by the model, and
+	that vector constraints are written to LP files and JSON.
+*/
+func TestModel_AddConstr2(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	xs, _ := m.AddVariableVector(2)
+
+	badConstr := optim.VectorConstraint{LeftHandSide: xs, RightHandSide: optim.KVector(optim.OnesVector(3)), Sense: optim.SenseEqual}
+	if _, err := m.AddConstr(badConstr); err == nil {
+		t.Errorf("Expected an error adding a vector constraint with mismatched dimensions, but received none.")
+	}
+	if _, err := m.AddConstr(xs.Eq(optim.KVector(optim.OnesVector(2)))); err != nil {
+		t.Errorf("There was an issue adding the vector constraint: %v", err)
+	}
+
+	// Algorithm
+	solver := mock.NewSolver()
+	if _, err := m.Optimize(solver); err != nil {
+		t.Fatalf("There was an issue loading the model into the mock solver: %v", err)
+	}
+	solver.AssertNumConstraints(t, 2)
+
+	var lpBuf bytes.Buffer
+	if err := m.WriteLP(&lpBuf); err != nil {
+		t.Fatalf("There was an issue writing the model: %v", err)
+	}
+	for _, expected := range []string{" c0: x0 = 1", " c1: x1 = 1"} {
+		if !strings.Contains(lpBuf.String(), expected) {
+			t.Errorf("Expected the LP file to contain %q; received\n%v", expected, lpBuf.String())
+		}
+	}
+
+	var jsonBuf bytes.Buffer
+	if err := m.WriteJSON(&jsonBuf); err != nil {
+		t.Fatalf("There was an issue writing the model to JSON: %v", err)
+	}
+	m2, err := optim.ReadJSON(&jsonBuf)
+	if err != nil {
+		t.Fatalf("There was an issue reading the model from JSON: %v", err)
+	}
+	if !reflect.DeepEqual(m, m2) {
+		t.Errorf("Expected the model with a vector constraint to be read back exactly.")
+	}
+}
@@ -490,7 +490,7 @@ Description:
 func TestVectorLinearExpression_Eq4(t *testing.T) {
 	m := optim.NewModel()
 	dimX := 2
-	x := m.AddVariableVector(dimX)
+	x, _ := m.AddVariableVector(dimX)
 
 	L1 := optim.Identity(dimX)
 	c1 := optim.OnesVector(dimX)
@@ -526,7 +526,7 @@ Description:
 func TestVectorLinearExpression_Eq5(t *testing.T) {
 	m := optim.NewModel()
 	dimX := 2
-	x := m.AddVariableVector(dimX)
+	x, _ := m.AddVariableVector(dimX)
 
 	L1 := optim.Identity(dimX)
 	c1 := optim.OnesVector(dimX)
@@ -637,9 +637,10 @@ func TestVectorLinearExpr_Plus1(t *testing.T) {
 	kv1 := optim.KVector(
 		optim.OnesVector(n),
 	)
+	x, _ := m.AddVariableVector(n)
 	vle2 := optim.VectorLinearExpr{
 		L: optim.Identity(n),
-		X: m.AddVariableVector(n),
+		X: x,
 		C: optim.ZerosVector(n),
 	}
 
@@ -705,9 +706,10 @@ func TestVectorLinearExpr_Plus2(t *testing.T) {
 	kv1 := optim.KVector(
 		optim.OnesVector(n + 1),
 	)
+	x, _ := m.AddVariableVector(n)
 	vle2 := optim.VectorLinearExpr{
 		L: optim.Identity(n),
-		X: m.AddVariableVector(n),
+		X: x,
 		C: optim.ZerosVector(n),
 	}
 
@@ -738,9 +740,10 @@ func TestVectorLinearExpr_Plus3(t *testing.T) {
 	kv1 := optim.KVector(
 		optim.OnesVector(n),
 	)
+	x, _ := m.AddVariableVector(n)
 	vle2 := optim.VectorLinearExpr{
 		L: optim.Identity(n),
-		X: m.AddVariableVector(n),
+		X: x,
 		C: optim.OnesVector(n),
 	}
 
@@ -803,10 +806,11 @@ func TestVectorLinearExpr_Plus4(t *testing.T) {
 	n := 5
 	m := optim.NewModel()
 
-	vv1 := m.AddVariableVector(n)
+	vv1, _ := m.AddVariableVector(n)
+	x, _ := m.AddVariableVector(n)
 	vle2 := optim.VectorLinearExpr{
 		L: optim.Identity(n),
-		X: m.AddVariableVector(n),
+		X: x,
 		C: optim.OnesVector(n),
 	}
 
@@ -882,7 +886,7 @@ func TestVectorLinearExpr_Plus5(t *testing.T) {
 	n := 5
 	m := optim.NewModel()
 
-	vv1 := m.AddVariableVector(n)
+	vv1, _ := m.AddVariableVector(n)
 	vle1 := optim.VectorLinearExpr{
 		L: optim.Identity(n),
 		X: vv1,
@@ -958,7 +962,8 @@ func TestVectorLinearExpr_Plus6(t *testing.T) {
 	n := 5
 	m := optim.NewModel()
 
-	vv1 := m.AddVariableVector(n)
+	vv1, _ := m.AddVariableVector(n)
+	x, _ := m.AddVariableVector(n)
 	vle1 := optim.VectorLinearExpr{
 		L: optim.Identity(n),
 		X: vv1,
@@ -966,7 +971,7 @@ func TestVectorLinearExpr_Plus6(t *testing.T) {
 	}
 	vle2 := optim.VectorLinearExpr{
 		L: optim.Identity(n),
-		X: m.AddVariableVector(n),
+		X: x,
 		C: optim.OnesVector(n),
 	}
 
@@ -1030,3 +1035,100 @@ func TestVectorLinearExpr_Plus6(t *testing.T) {
 		}
 	}
 }
+
+/*
+TestVectorLinearExpr_Mult1
+Description:
+
+	Scales and negates the vector linear expression [x0 + 1, 2 x1 + 2] and verifies that the input
+	is unchanged.
+*/
+func TestVectorLinearExpr_Mult1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x, _ := m.AddVariableVector(2)
+	vle := optim.VectorLinearExpr{
+		X: x,
+		L: *mat.NewDense(2, 2, []float64{1, 0, 0, 2}),
+		C: *mat.NewVecDense(2, []float64{1, 2}),
+	}
+
+	// Algorithm
+	scaled, err := vle.Mult(3)
+	if err != nil {
+		t.Fatalf("There was an issue scaling the expression: %v", err)
+	}
+	scaledAsVLE, ok := scaled.(optim.VectorLinearExpr)
+	if !ok {
+		t.Fatalf("Expected the scaled expression to be a VectorLinearExpr; received %T", scaled)
+	}
+	if scaledAsVLE.L.At(1, 1) != 6 || scaledAsVLE.C.AtVec(1) != 6 {
+		t.Errorf("Expected element 1 to be 6 x1 + 6; received %v", scaledAsVLE.AtVec(1))
+	}
+	if vle.L.At(1, 1) != 2 || vle.C.AtVec(1) != 2 {
+		t.Errorf("Expected the input to be unchanged; received %v", vle)
+	}
+
+	// vle - vle is zero
+	diff, err := vle.Minus(vle)
+	if err != nil {
+		t.Fatalf("There was an issue subtracting the expression from itself: %v", err)
+	}
+	for idx := 0; idx < 2; idx++ {
+		if diff.AtVec(idx).Constant() != 0 {
+			t.Errorf("Expected element %v of vle - vle to have the constant 0; received %v", idx, diff.AtVec(idx))
+		}
+		for _, coeff := range diff.AtVec(idx).Coeffs() {
+			if coeff != 0 {
+				t.Errorf("Expected element %v of vle - vle to have zero coefficients; received %v", idx, diff.AtVec(idx))
+			}
+		}
+	}
+
+	// Errors given as extras are returned
+	if _, err := vle.Minus(vle.Mult(2)); err != nil {
+		t.Errorf("There was an issue subtracting the result of Mult: %v", err)
+	}
+	if _, err := vle.Minus(x, fmt.Errorf("test error")); err == nil {
+		t.Errorf("Expected the error given to Minus to be returned, but received none.")
+	}
+}
+
+/*
+TestVectorLinearExpr_Equals1
+Description:
+
+	Compares a vector linear expression with a vector of variables, a constant vector and a vector
+	of a different length.
+*/
+func TestVectorLinearExpr_Equals1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x, _ := m.AddVariableVector(2)
+	vle := optim.VectorLinearExpr{
+		X: x,
+		L: optim.Identity(2),
+		C: optim.ZerosVector(2),
+	}
+
+	// Algorithm
+	if !vle.Equals(x, 0) || !x.Equals(vle, 0) {
+		t.Errorf("Expected I x + 0 to equal x.")
+	}
+
+	shifted, err := vle.Plus(optim.KVector(optim.OnesVector(2)))
+	if err != nil {
+		t.Fatalf("There was an issue adding the ones vector: %v", err)
+	}
+	if shifted.Equals(x, 0.5) {
+		t.Errorf("Expected x + 1 to not equal x within the tolerance 0.5.")
+	}
+	if !optim.KVector(optim.OnesVector(2)).Equals(optim.OnesVector(2), 0) {
+		t.Errorf("Expected the KVector of ones to equal the ones vector.")
+	}
+
+	y, _ := m.AddVariableVector(3)
+	if vle.Equals(y, 0) {
+		t.Errorf("Expected vectors of different lengths to not be equal.")
+	}
+}
@@ -0,0 +1,215 @@
+package optim_test
+
+/*
+vector_quadratic_expr_test.go
+Description:
+	Tests for the VectorQuadraticExpression object and for quadratic vector constraints.
+*/
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/kwesiRutledge/goop2/optim"
+	"github.com/kwesiRutledge/goop2/solvers/mock"
+	"gonum.org/v1/gonum/mat"
+)
+
+/*
+newDistanceExpression
+Description:
+
+	Returns the vector of squared distances of two agents at (p[0], p[1]) and (p[2], p[3]) from the
+	origin, i.e. [p0^2 + p1^2, p2^2 + p3^2].
+*/
+func newDistanceExpression(t *testing.T, p optim.VarVector) optim.VectorQuadraticExpression {
+	t.Helper()
+
+	Q := []mat.Dense{
+		*mat.NewDense(4, 4, []float64{1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}),
+		*mat.NewDense(4, 4, []float64{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1}),
+	}
+	vqe, err := optim.NewVectorQuadraticExpression(Q, *mat.NewDense(2, 4, nil), *mat.NewVecDense(2, nil), p)
+	if err != nil {
+		t.Fatalf("There was an issue creating the vector quadratic expression: %v", err)
+	}
+	return vqe
+}
+
+/*
+TestVectorQuadraticExpression_Check1
+Description:
+
+	Verifies that expressions with the wrong number of quadratic terms or badly sized terms are
+	rejected.
+*/
+func TestVectorQuadraticExpression_Check1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x, _ := m.AddVariableVector(2)
+
+	Q := []mat.Dense{*mat.NewDense(2, 2, nil), *mat.NewDense(2, 2, nil)}
+
+	// Algorithm
+	if _, err := optim.NewVectorQuadraticExpression(Q, *mat.NewDense(2, 2, nil), *mat.NewVecDense(2, nil), x); err != nil {
+		t.Errorf("Expected the expression to be valid; received %v", err)
+	}
+	if _, err := optim.NewVectorQuadraticExpression(Q[:1], *mat.NewDense(2, 2, nil), *mat.NewVecDense(2, nil), x); err == nil {
+		t.Errorf("Expected an error for 1 quadratic term and 2 elements, but received none.")
+	}
+	if _, err := optim.NewVectorQuadraticExpression(Q, *mat.NewDense(2, 3, nil), *mat.NewVecDense(2, nil), x); err == nil {
+		t.Errorf("Expected an error for an L with 3 columns and 2 variables, but received none.")
+	}
+
+	badQ := []mat.Dense{*mat.NewDense(2, 2, nil), *mat.NewDense(3, 3, nil)}
+	if _, err := optim.NewVectorQuadraticExpression(badQ, *mat.NewDense(2, 2, nil), *mat.NewVecDense(2, nil), x); err == nil {
+		t.Errorf("Expected an error for a 3 x 3 quadratic term and 2 variables, but received none.")
+	}
+}
+
+/*
+TestVectorQuadraticExpression_AtVec1
+Description:
+
+	Verifies that AtVec returns each squared distance as a ScalarQuadraticExpression which does not
+	share data with the vector expression.
+*/
+func TestVectorQuadraticExpression_AtVec1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	p, _ := m.AddVariableVector(4)
+	vqe := newDistanceExpression(t, p)
+
+	// Algorithm
+	var ve optim.VectorExpression = vqe
+	if ve.Len() != 2 || ve.NumVars() != 4 {
+		t.Errorf("Expected 2 elements and 4 variables; received %v and %v", ve.Len(), ve.NumVars())
+	}
+
+	element1, ok := ve.AtVec(1).(optim.ScalarQuadraticExpression)
+	if !ok {
+		t.Fatalf("Expected element 1 to be a ScalarQuadraticExpression; received %T", ve.AtVec(1))
+	}
+	if element1.Q.At(2, 2) != 1 || element1.Q.At(0, 0) != 0 {
+		t.Errorf("Expected element 1 to be p2^2 + p3^2; received Q = %v", mat.Formatted(&element1.Q))
+	}
+
+	element1.Q.Set(0, 0, 100)
+	if vqe.Q[1].At(0, 0) != 0 {
+		t.Errorf("Expected the elements to not share data with the vector expression; Q[1] is now %v", mat.Formatted(&vqe.Q[1]))
+	}
+}
+
+/*
+TestVectorQuadraticExpression_Plus1
+Description:
+
+	Adds a VarVector, a VectorLinearExpr with another variable and a KVector to a vector of
+	squared distances and verifies each element.
+*/
+func TestVectorQuadraticExpression_Plus1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	p, _ := m.AddVariableVector(4)
+	s, _ := m.AddVariableVector(1)
+	vqe := newDistanceExpression(t, p)
+
+	// [p0, p2]
+	firstCoords := optim.VarVector{Elements: []optim.Variable{p.Elements[0], p.Elements[2]}}
+	// [-s, -2 s]
+	vle := optim.VectorLinearExpr{X: s, L: *mat.NewDense(2, 1, []float64{-1, -2}), C: *mat.NewVecDense(2, nil)}
+
+	// Algorithm
+	sum, err := vqe.Plus(firstCoords)
+	if err != nil {
+		t.Fatalf("There was an issue adding the VarVector: %v", err)
+	}
+	sum, err = sum.Plus(vle)
+	if err != nil {
+		t.Fatalf("There was an issue adding the VectorLinearExpr: %v", err)
+	}
+	sum, err = optim.KVector(*mat.NewVecDense(2, []float64{3, 4})).Plus(sum)
+	if err != nil {
+		t.Fatalf("There was an issue adding the sum to a KVector: %v", err)
+	}
+	if _, ok := sum.(optim.VectorQuadraticExpression); !ok {
+		t.Errorf("Expected the sum to be a VectorQuadraticExpression; received %T", sum)
+	}
+
+	// p0^2 + p1^2 + p0 - s + 3
+	expected0 := vqe.AtVec(0)
+	expected0, _ = expected0.Plus(p.Elements[0])
+	minusS, _ := s.Elements[0].Mult(-1)
+	expected0, _ = expected0.Plus(minusS)
+	expected0, _ = expected0.Plus(optim.K(3))
+	assertSameTerms(t, sum.AtVec(0), expected0)
+
+	// p2^2 + p3^2 + p2 - 2 s + 4
+	expected1 := vqe.AtVec(1)
+	expected1, _ = expected1.Plus(p.Elements[2])
+	minusTwoS, _ := s.Elements[0].Mult(-2)
+	expected1, _ = expected1.Plus(minusTwoS)
+	expected1, _ = expected1.Plus(optim.K(4))
+	assertSameTerms(t, sum.AtVec(1), expected1)
+
+	// Adding the expression to itself doubles it and leaves the input unchanged
+	double, err := vqe.Plus(vqe)
+	if err != nil {
+		t.Fatalf("There was an issue adding the expression to itself: %v", err)
+	}
+	scaled, _ := vqe.Mult(2)
+	for idx := 0; idx < 2; idx++ {
+		assertSameTerms(t, double.AtVec(idx), scaled.AtVec(idx))
+	}
+	if vqe.Q[0].At(0, 0) != 1 {
+		t.Errorf("Expected the input to be unchanged; Q[0] is now %v", mat.Formatted(&vqe.Q[0]))
+	}
+
+	// The lengths must match
+	if _, err := vqe.Plus(p); err == nil {
+		t.Errorf("Expected an error adding a vector of length 4 to a vector of length 2, but received none.")
+	}
+}
+
+/*
+TestVectorQuadraticExpression_Comparison1
+Description:
+
+	Adds the constraint that each agent stays within a distance of 2 of the origin and verifies the
+	quadratic constraints given to the solver and written to an LP file.
+*/
+func TestVectorQuadraticExpression_Comparison1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	p, _ := m.AddVariableVector(4)
+	vqe := newDistanceExpression(t, p)
+	radii := optim.KVector(*mat.NewVecDense(2, []float64{4, 4}))
+
+	// Algorithm
+	distConstr, _ := vqe.LessEq(radii)
+	if _, err := m.AddNamedConstr("dist", distConstr); err != nil {
+		t.Fatalf("There was an issue adding the quadratic vector constraint: %v", err)
+	}
+	if _, err := radii.GreaterEq(vqe); err != nil {
+		t.Errorf("There was an issue comparing a KVector with the vector quadratic expression: %v", err)
+	}
+	if _, err := vqe.Eq(p); err == nil {
+		t.Errorf("Expected an error comparing vectors of length 2 and 4, but received none.")
+	}
+
+	solver := mock.NewSolver()
+	if _, err := m.Optimize(solver); err != nil {
+		t.Fatalf("There was an issue loading the model: %v", err)
+	}
+	solver.AssertNumConstraints(t, 2)
+	solver.AssertConstraint(t, 1, vqe.AtVec(1), optim.SenseLessThanEqual, optim.K(4))
+
+	var buf bytes.Buffer
+	if err := m.WriteLP(&buf); err != nil {
+		t.Fatalf("There was an issue writing the model: %v", err)
+	}
+	if !strings.Contains(buf.String(), "dist[1]: [") {
+		t.Errorf("Expected the LP file to contain the quadratic constraint dist[1]; received\n%v", buf.String())
+	}
+}
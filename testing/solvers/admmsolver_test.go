@@ -0,0 +1,368 @@
+package solvers_test
+
+/*
+admmsolver_test.go
+Description:
+	Tests for the first-order quadratic program solver ADMMSolver.
+*/
+
+import (
+	"math"
+	"testing"
+
+	"github.com/kwesiRutledge/goop2/optim"
+	"github.com/kwesiRutledge/goop2/solvers"
+	"gonum.org/v1/gonum/mat"
+)
+
+/*
+TestADMMSolver_Optimize1
+Description:
+
+	Solves the quadratic program from TestQP1 with the ADMM solver.
+		minimize x^2 + y^2 - 6x - 4y + 13
+		s.t.     -10 <= x, y <= 10
+	The optimal solution is x = 3, y = 2.
+*/
+func TestADMMSolver_Optimize1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x, _ := m.AddVariableClassic(-10, 10, optim.Continuous)
+	y, _ := m.AddVariableClassic(-10, 10, optim.Continuous)
+
+	qe1, _ := optim.NewQuadraticExpr(
+		*mat.NewDense(2, 2, []float64{1.0, 0.0, 0.0, 1.0}),
+		*mat.NewVecDense(2, []float64{-6.0, -4.0}),
+		13.0,
+		optim.VarVector{Elements: []optim.Variable{x, y}},
+	)
+	m.SetObjective(qe1, optim.SenseMinimize)
+
+	as := solvers.NewADMMSolver()
+	as.EpsAbs, as.EpsRel = 1e-6, 1e-6
+
+	// Algorithm
+	sol, err := m.Optimize(as)
+	if err != nil {
+		t.Fatalf("There was an issue optimizing the model: %v", err)
+	}
+
+	if math.Abs(sol.Value(x)-3.0) > 1e-4 || math.Abs(sol.Value(y)-2.0) > 1e-4 {
+		t.Errorf("Expected x = 3, y = 2; received x = %v, y = %v", sol.Value(x), sol.Value(y))
+	}
+
+	if sol.Iterations <= 0 {
+		t.Errorf("Expected the number of iterations to be reported; received %v", sol.Iterations)
+	}
+
+	if sol.PrimalResidual > 1e-4 || sol.DualResidual > 1e-4 {
+		t.Errorf(
+			"Expected small residuals; received primal residual %v and dual residual %v",
+			sol.PrimalResidual, sol.DualResidual,
+		)
+	}
+}
+
+/*
+TestADMMSolver_Optimize2
+Description:
+
+	Solves a quadratic program with an active inequality and an equality constraint.
+		minimize x^2 + y^2 + z^2
+		s.t.     x + y >= 2
+		         z == 1
+	The optimal solution is x = y = z = 1 with objective 3.
+*/
+func TestADMMSolver_Optimize2(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	vv, _ := m.AddVariableVector(3)
+	x, y, z := vv.Elements[0], vv.Elements[1], vv.Elements[2]
+
+	sum1, _ := x.Plus(y)
+	m.AddConstr(sum1.GreaterEq(optim.K(2)))
+	m.AddConstr(z.Eq(optim.K(1)))
+
+	qe1, _ := optim.NewQuadraticExpr_qb0(
+		*mat.NewDense(3, 3, []float64{1, 0, 0, 0, 1, 0, 0, 0, 1}),
+		vv,
+	)
+	m.SetObjective(qe1, optim.SenseMinimize)
+
+	as := solvers.NewADMMSolver()
+	as.EpsAbs, as.EpsRel = 1e-6, 1e-6
+
+	// Algorithm
+	sol, err := m.Optimize(as)
+	if err != nil {
+		t.Fatalf("There was an issue optimizing the model: %v", err)
+	}
+
+	for _, tempVar := range vv.Elements {
+		if math.Abs(sol.Value(tempVar)-1.0) > 1e-4 {
+			t.Errorf("Expected variable %v to be 1; received %v", tempVar.ID, sol.Value(tempVar))
+		}
+	}
+
+	if math.Abs(sol.Objective-3.0) > 1e-4 {
+		t.Errorf("Expected the objective to be 3; received %v", sol.Objective)
+	}
+}
+
+/*
+TestADMMSolver_AddRangeConstraint1
+Description:
+
+	Solves a quadratic program with a ranged constraint.
+		minimize (x - 3)^2 + (y + 3)^2
+		s.t.     2 <= x + y + 1 <= 5
+	The unconstrained optimum (3, -3) violates the lower bound of the range, so the optimal
+	solution is x = 3.5, y = -2.5 with objective 0.5.
+*/
+func TestADMMSolver_AddRangeConstraint1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x := m.AddVariable()
+	y := m.AddVariable()
+	xy := optim.VarVector{Elements: []optim.Variable{x, y}}
+
+	qe1, _ := optim.NewQuadraticExpr(
+		*mat.NewDense(2, 2, []float64{1.0, 0.0, 0.0, 1.0}),
+		*mat.NewVecDense(2, []float64{-6.0, 6.0}),
+		18.0,
+		xy,
+	)
+
+	as := solvers.NewADMMSolver()
+	as.EpsAbs, as.EpsRel = 1e-7, 1e-7
+	as.AddVariables(m.Variables)
+	as.SetObjective(*optim.NewObjective(qe1, optim.SenseMinimize))
+
+	sum1 := optim.ScalarLinearExpr{X: xy, L: *mat.NewVecDense(2, []float64{1.0, 1.0}), C: 1.0}
+	err := as.AddRangeConstraint(sum1, 2.0, 5.0)
+	if err != nil {
+		t.Fatalf("There was an issue adding the range constraint: %v", err)
+	}
+
+	// Algorithm
+	sol, err := as.Optimize()
+	if err != nil {
+		t.Fatalf("There was an issue optimizing the model: %v", err)
+	}
+
+	if sol.Status != optim.OptimizationStatus_OPTIMAL {
+		t.Errorf("Expected status %v; received %v", optim.OptimizationStatus_OPTIMAL, sol.Status)
+	}
+
+	if math.Abs(sol.Value(x)-3.5) > 1e-4 || math.Abs(sol.Value(y)+2.5) > 1e-4 {
+		t.Errorf("Expected x = 3.5, y = -2.5; received x = %v, y = %v", sol.Value(x), sol.Value(y))
+	}
+
+	if math.Abs(sol.Objective-0.5) > 1e-4 {
+		t.Errorf("Expected the objective to be 0.5; received %v", sol.Objective)
+	}
+
+	// A range whose lower bound exceeds its upper bound should be rejected.
+	err = as.AddRangeConstraint(sum1, 5.0, 2.0)
+	if err == nil {
+		t.Errorf("Expected an error when adding an empty range; received none.")
+	}
+}
+
+/*
+TestADMMSolver_WarmStart1
+Description:
+
+	Verifies that warm starting the solver from its last iterate reduces the number of iterations
+	needed to solve the same problem.
+*/
+func TestADMMSolver_WarmStart1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	vv, _ := m.AddVariableVectorClassic(3, 0, 10, optim.Continuous)
+
+	sum1 := optim.ScalarLinearExpr{X: vv, L: *mat.NewVecDense(3, []float64{1, 1, 1}), C: 0.0}
+	constr1, _ := sum1.Eq(optim.K(4))
+
+	qe1, _ := optim.NewQuadraticExpr(
+		*mat.NewDense(3, 3, []float64{2, 1, 0, 1, 2, 0, 0, 0, 1}),
+		*mat.NewVecDense(3, []float64{-1, 0, 2}),
+		0.0,
+		vv,
+	)
+
+	as := solvers.NewADMMSolver()
+	as.AddVariables(m.Variables)
+	as.AddConstraint(constr1)
+	as.SetObjective(*optim.NewObjective(qe1, optim.SenseMinimize))
+
+	// Algorithm
+	coldSol, err := as.Optimize()
+	if err != nil {
+		t.Fatalf("There was an issue optimizing the model: %v", err)
+	}
+
+	as.SetWarmStart(*as.LastIterate)
+	warmSol, err := as.Optimize()
+	if err != nil {
+		t.Fatalf("There was an issue optimizing the warm started model: %v", err)
+	}
+
+	if warmSol.Status != optim.OptimizationStatus_OPTIMAL {
+		t.Errorf("Expected status %v; received %v", optim.OptimizationStatus_OPTIMAL, warmSol.Status)
+	}
+
+	if warmSol.Iterations >= coldSol.Iterations {
+		t.Errorf(
+			"Expected the warm started solve to use fewer iterations than the cold solve (%v); received %v",
+			coldSol.Iterations, warmSol.Iterations,
+		)
+	}
+
+	for _, tempVar := range vv.Elements {
+		if math.Abs(warmSol.Value(tempVar)-coldSol.Value(tempVar)) > 1e-2 {
+			t.Errorf(
+				"Expected variable %v to match the cold solution %v; received %v",
+				tempVar.ID, coldSol.Value(tempVar), warmSol.Value(tempVar),
+			)
+		}
+	}
+}
+
+/*
+TestADMMSolver_Optimize3
+Description:
+
+	Verifies that an infeasible problem is reported as infeasible and that a problem with an
+	unbounded objective is reported as unbounded.
+*/
+func TestADMMSolver_Optimize3(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x := m.AddVariable()
+
+	// Infeasible problem
+	as := solvers.NewADMMSolver()
+	as.AddVariables(m.Variables)
+	constr1, _ := x.GreaterEq(optim.K(2))
+	constr2, _ := x.LessEq(optim.K(1))
+	as.AddConstraint(constr1)
+	as.AddConstraint(constr2)
+	as.SetObjective(*optim.NewObjective(x, optim.SenseMinimize))
+
+	sol, err := as.Optimize()
+	if err != nil {
+		t.Fatalf("There was an issue optimizing the infeasible model: %v", err)
+	}
+
+	if sol.Status != optim.OptimizationStatus_INFEASIBLE {
+		t.Errorf("Expected status %v; received %v", optim.OptimizationStatus_INFEASIBLE, sol.Status)
+	}
+
+	// Unbounded problem
+	as.DeleteSolver()
+	as.AddVariables(m.Variables)
+	as.AddConstraint(constr2)
+	as.SetObjective(*optim.NewObjective(x, optim.SenseMinimize))
+
+	sol, err = as.Optimize()
+	if err != nil {
+		t.Fatalf("There was an issue optimizing the unbounded model: %v", err)
+	}
+
+	if sol.Status != optim.OptimizationStatus_UNBOUNDED {
+		t.Errorf("Expected status %v; received %v", optim.OptimizationStatus_UNBOUNDED, sol.Status)
+	}
+}
+
+/*
+TestADMMSolver_Optimize4
+Description:
+
+	Solves a large sparse quadratic program.
+		minimize sum_i (x_i - 0.8)^2
+		s.t.     sum_i x_i == n / 2
+		         0 <= x_i <= 1
+	with n = 20000 variables. The optimal solution is x_i = 0.5 with objective 0.09 n. A dense
+	n x n matrix would need 3.2 GB, so this test also verifies that none is created.
+*/
+func TestADMMSolver_Optimize4(t *testing.T) {
+	// Constants
+	n := 20000
+	m := optim.NewModel()
+	vv, _ := m.AddVariableVectorClassic(n, 0, 1, optim.Continuous)
+
+	obj := optim.SparseScalarQuadraticExpression{X: vv, C: 0.64 * float64(n)}
+	var sum optim.LinearExprBuilder
+	for _, tempVar := range vv.Elements {
+		obj.Quadratic = append(obj.Quadratic, optim.QuadraticTerm{ID1: tempVar.ID, ID2: tempVar.ID, Coeff: 1})
+		obj.Linear = append(obj.Linear, optim.LinearTerm{ID: tempVar.ID, Coeff: -1.6})
+		sum.AddTerm(1, tempVar)
+	}
+	m.AddConstr(sum.Build().Eq(optim.K(float64(n) / 2)))
+	m.SetObjective(obj, optim.SenseMinimize)
+
+	as := solvers.NewADMMSolver()
+	as.EpsAbs, as.EpsRel = 1e-6, 1e-6
+
+	// Algorithm
+	sol, err := m.Optimize(as)
+	if err != nil {
+		t.Fatalf("There was an issue optimizing the model: %v", err)
+	}
+
+	if sol.Status != optim.OptimizationStatus_OPTIMAL {
+		t.Errorf("Expected status %v; received %v", optim.OptimizationStatus_OPTIMAL, sol.Status)
+	}
+
+	for _, tempVar := range vv.Elements {
+		if math.Abs(sol.Value(tempVar)-0.5) > 1e-3 {
+			t.Fatalf("Expected variable %v to be 0.5; received %v", tempVar.ID, sol.Value(tempVar))
+		}
+	}
+
+	if math.Abs(sol.Objective-0.09*float64(n)) > 1e-2*float64(n) {
+		t.Errorf("Expected the objective to be %v; received %v", 0.09*float64(n), sol.Objective)
+	}
+}
+
+/*
+TestADMMSolver_SetObjective1
+Description:
+
+	Verifies that nonconvex objectives are rejected, both when they are small enough to be
+	checked with eigenvalues and when they are not.
+*/
+func TestADMMSolver_SetObjective1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x := m.AddVariable()
+	y := m.AddVariable()
+
+	// x^2 - 2 x y has a negative eigenvalue even though its squared term is positive.
+	small := optim.SparseScalarQuadraticExpression{
+		X: optim.VarVector{Elements: []optim.Variable{x, y}},
+		Quadratic: []optim.QuadraticTerm{
+			{ID1: x.ID, ID2: x.ID, Coeff: 1},
+			{ID1: x.ID, ID2: y.ID, Coeff: -2},
+		},
+	}
+
+	vv, _ := m.AddVariableVector(1000)
+	large := optim.SparseScalarQuadraticExpression{X: vv}
+	for _, tempVar := range vv.Elements {
+		large.Quadratic = append(large.Quadratic, optim.QuadraticTerm{ID1: tempVar.ID, ID2: tempVar.ID, Coeff: -1})
+	}
+
+	// Algorithm
+	as := solvers.NewADMMSolver()
+	if err := as.SetObjective(*optim.NewObjective(small, optim.SenseMinimize)); err == nil {
+		t.Errorf("Expected an error minimizing the nonconvex objective %v, but received none.", small)
+	}
+	if err := as.SetObjective(*optim.NewObjective(large, optim.SenseMinimize)); err == nil {
+		t.Errorf("Expected an error minimizing a large concave objective, but received none.")
+	}
+	if err := as.SetObjective(*optim.NewObjective(large, optim.SenseMaximize)); err != nil {
+		t.Errorf("Expected a large concave objective to be maximized; received %v", err)
+	}
+}
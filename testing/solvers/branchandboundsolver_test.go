@@ -0,0 +1,222 @@
+package solvers_test
+
+/*
+branchandboundsolver_test.go
+Description:
+	Tests for the pure-Go mixed integer solver BranchAndBoundSolver.
+*/
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/kwesiRutledge/goop2/optim"
+	"github.com/kwesiRutledge/goop2/solvers"
+	"gonum.org/v1/gonum/mat"
+)
+
+/*
+TestBranchAndBoundSolver_SimpleMIP
+Description:
+
+	Runs the simple binary program from mip_test.go through the branch-and-bound solver.
+*/
+func TestBranchAndBoundSolver_SimpleMIP(t *testing.T) {
+	solveSimpleMIPModel(t, solvers.NewBranchAndBoundSolver())
+}
+
+/*
+TestBranchAndBoundSolver_Optimize1
+Description:
+
+	Solves a small knapsack problem with binary variables.
+		maximize 5a + 4b + 3c
+		s.t.     2a + 3b + c <= 5
+		         4a + b + 2c <= 11
+		         3a + 4b + 2c <= 8
+		         a, b, c binary
+	The optimal solution is a = 1, b = 1, c = 0 with objective 9.
+*/
+func TestBranchAndBoundSolver_Optimize1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	vv, _ := m.AddBinaryVariableVector(3)
+	a, b, c := vv.Elements[0], vv.Elements[1], vv.Elements[2]
+
+	rows := [][]float64{{2, 3, 1}, {4, 1, 2}, {3, 4, 2}}
+	rhs := []float64{5, 11, 8}
+	for rowIndex, row := range rows {
+		lhs := optim.ScalarLinearExpr{X: vv, L: *mat.NewVecDense(len(row), row), C: 0.0}
+		m.AddConstr(lhs.LessEq(optim.K(rhs[rowIndex])))
+	}
+	m.SetObjective(optim.ScalarLinearExpr{X: vv, L: *mat.NewVecDense(3, []float64{5, 4, 3})}, optim.SenseMaximize)
+
+	// Algorithm
+	sol, err := m.Optimize(solvers.NewBranchAndBoundSolver())
+	if err != nil {
+		t.Fatalf("There was an issue optimizing the model: %v", err)
+	}
+
+	expected := map[optim.Variable]float64{a: 1.0, b: 1.0, c: 0.0}
+	for tempVar, expectedValue := range expected {
+		if sol.Value(tempVar) != expectedValue {
+			t.Errorf("Expected variable %v to be %v; received %v", tempVar.ID, expectedValue, sol.Value(tempVar))
+		}
+	}
+
+	if math.Abs(sol.Objective-9.0) > 1e-8 {
+		t.Errorf("Expected objective to be 9; received %v", sol.Objective)
+	}
+
+	if sol.Gap > 1e-4 {
+		t.Errorf("Expected the gap to be closed; received %v", sol.Gap)
+	}
+}
+
+/*
+TestBranchAndBoundSolver_Optimize2
+Description:
+
+	Solves a problem with general integer variables whose relaxation has a fractional optimum.
+		maximize x + y
+		s.t.     2x + 2y <= 7
+		         x - y == 0
+		         x, y integer in [0, 10]
+	The relaxation's optimum is 3.5, but the optimal integer solution is x = y = 1.
+*/
+func TestBranchAndBoundSolver_Optimize2(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x, _ := m.AddVariableClassic(0, 10, optim.Integer)
+	y, _ := m.AddVariableClassic(0, 10, optim.Integer)
+
+	twoX, _ := x.Mult(2)
+	sum1, _ := twoX.Plus(y.Mult(2))
+	m.AddConstr(sum1.LessEq(optim.K(7)))
+	m.AddConstr(x.Eq(y))
+
+	obj, _ := x.Plus(y)
+	m.SetObjective(obj, optim.SenseMaximize)
+
+	// Algorithm
+	sol, err := m.Optimize(solvers.NewBranchAndBoundSolver())
+	if err != nil {
+		t.Fatalf("There was an issue optimizing the model: %v", err)
+	}
+
+	if sol.Value(x) != 1.0 || sol.Value(y) != 1.0 {
+		t.Errorf("Expected x = y = 1; received x = %v, y = %v", sol.Value(x), sol.Value(y))
+	}
+
+	if math.Abs(sol.BestBound-sol.Objective) > 1e-8 {
+		t.Errorf("Expected the best bound (%v) to match the objective (%v).", sol.BestBound, sol.Objective)
+	}
+}
+
+/*
+TestBranchAndBoundSolver_Optimize3
+Description:
+
+	Verifies that an integer program with a feasible relaxation, but no integer solutions, is
+	reported as infeasible.
+*/
+func TestBranchAndBoundSolver_Optimize3(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x, _ := m.AddVariableClassic(0, 10, optim.Integer)
+
+	twoX, _ := x.Mult(2)
+	bbs := solvers.NewBranchAndBoundSolver()
+	bbs.AddVariables(m.Variables)
+	constr, _ := twoX.Eq(optim.K(3))
+	bbs.AddConstraint(constr)
+	bbs.SetObjective(*optim.NewObjective(x, optim.SenseMinimize))
+
+	// Algorithm
+	sol, err := bbs.Optimize()
+	if err != nil {
+		t.Fatalf("There was an issue optimizing the model: %v", err)
+	}
+
+	if sol.Status != optim.OptimizationStatus_INFEASIBLE {
+		t.Errorf("Expected status %v; received %v", optim.OptimizationStatus_INFEASIBLE, sol.Status)
+	}
+}
+
+/*
+TestBranchAndBoundSolver_Optimize4
+Description:
+
+	Solves the problem from TestGonumLPSolver_Optimize6 with an integer variable. Its relaxation
+	is feasible even though gonum's simplex method reports it as infeasible.
+		minimize -3 x0 - 3 x1
+		s.t.     x0 == -1
+		         -2 x0 + 3 x1 == 4
+		         x0 >= -1 integer, x1 >= -4
+	The optimal solution is x0 = -1, x1 = 2/3 with objective 1.
+*/
+func TestBranchAndBoundSolver_Optimize4(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x0, _ := m.AddVariableClassic(-1, 10, optim.Integer)
+	x1, _ := m.AddVariableClassic(-4, math.Inf(1), optim.Continuous)
+
+	m.AddConstr(x0.Eq(optim.K(-1)))
+	sum, _ := x0.Mult(-2)
+	sum, _ = sum.Plus(x1.Mult(3))
+	m.AddConstr(sum.Eq(optim.K(4)))
+
+	obj, _ := x0.Mult(-3)
+	obj, _ = obj.Plus(x1.Mult(-3))
+	m.SetObjective(obj, optim.SenseMinimize)
+
+	// Algorithm
+	sol, err := m.Optimize(solvers.NewBranchAndBoundSolver())
+	if err != nil {
+		t.Fatalf("There was an issue optimizing the model: %v", err)
+	}
+
+	if math.Abs(sol.Objective-1) > 1e-6 {
+		t.Errorf("Expected the objective to be 1; received %v", sol.Objective)
+	}
+}
+
+/*
+TestBranchAndBoundSolver_TimeLimit1
+Description:
+
+	Verifies that the TIME_LIMIT status is returned when the time limit runs out before the
+	search is complete.
+*/
+func TestBranchAndBoundSolver_TimeLimit1(t *testing.T) {
+	// Constants
+	N := 12
+	m := optim.NewModel()
+	vv, _ := m.AddBinaryVariableVector(N)
+
+	weights := make([]float64, N)
+	values := make([]float64, N)
+	for i := 0; i < N; i++ {
+		weights[i] = float64(2*i + 3)
+		values[i] = float64(2*i+3) + 0.5
+	}
+	lhs := optim.ScalarLinearExpr{X: vv, L: *mat.NewVecDense(N, weights)}
+	m.AddConstr(lhs.LessEq(optim.K(40)))
+	m.SetObjective(optim.ScalarLinearExpr{X: vv, L: *mat.NewVecDense(N, values)}, optim.SenseMaximize)
+	m.SetTimeLimit(time.Nanosecond)
+
+	// Algorithm
+	sol, err := m.Optimize(solvers.NewBranchAndBoundSolver())
+	if err == nil {
+		t.Errorf("Expected an error to be returned when the time limit is reached.")
+	}
+
+	if sol == nil {
+		t.Fatalf("Expected a solution to be returned along with the time limit error.")
+	}
+
+	if sol.Status != optim.OptimizationStatus_TIME_LIMIT {
+		t.Errorf("Expected status %v; received %v", optim.OptimizationStatus_TIME_LIMIT, sol.Status)
+	}
+}
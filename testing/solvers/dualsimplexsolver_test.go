@@ -0,0 +1,406 @@
+package solvers_test
+
+/*
+dualsimplexsolver_test.go
+Description:
+	Tests for the pure-Go bounded dual simplex solver DualSimplexSolver.
+*/
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/kwesiRutledge/goop2/optim"
+	"github.com/kwesiRutledge/goop2/solvers"
+	"gonum.org/v1/gonum/mat"
+)
+
+/*
+addDualSimplexTestLP
+Description:
+
+	Loads the LP
+		maximize x + y
+		s.t.     x + 2y <= rhs1
+		         3x + y <= rhs2
+		         x, y >= 0
+	into the solver dss.
+*/
+func addDualSimplexTestLP(dss *solvers.DualSimplexSolver, x, y optim.Variable, rhs1, rhs2 float64) {
+	dss.AddVariables([]optim.Variable{x, y})
+
+	sum1, _ := x.Plus(y.Mult(2))
+	constr1, _ := sum1.LessEq(optim.K(rhs1))
+	dss.AddConstraint(constr1)
+
+	tempProd, _ := x.Mult(3)
+	sum2, _ := tempProd.Plus(y)
+	constr2, _ := sum2.LessEq(optim.K(rhs2))
+	dss.AddConstraint(constr2)
+
+	obj, _ := x.Plus(y)
+	dss.SetObjective(*optim.NewObjective(obj, optim.SenseMaximize))
+}
+
+/*
+TestDualSimplexSolver_Optimize1
+Description:
+
+	Solves a small LP and verifies the solution and the final basis.
+*/
+func TestDualSimplexSolver_Optimize1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x, _ := m.AddVariableClassic(0, math.Inf(1), optim.Continuous)
+	y, _ := m.AddVariableClassic(0, math.Inf(1), optim.Continuous)
+
+	dss := solvers.NewDualSimplexSolver()
+	addDualSimplexTestLP(dss, x, y, 4, 6)
+
+	// Algorithm
+	sol, err := dss.Optimize()
+	if err != nil {
+		t.Fatalf("There was an issue optimizing the model: %v", err)
+	}
+
+	if math.Abs(sol.Value(x)-1.6) > 1e-8 || math.Abs(sol.Value(y)-1.2) > 1e-8 {
+		t.Errorf("Expected x = 1.6, y = 1.2; received x = %v, y = %v", sol.Value(x), sol.Value(y))
+	}
+
+	if math.Abs(sol.Objective-2.8) > 1e-8 {
+		t.Errorf("Expected objective to be 2.8; received %v", sol.Objective)
+	}
+
+	basis, err := dss.GetBasis()
+	if err != nil {
+		t.Fatalf("There was an issue getting the basis: %v", err)
+	}
+
+	if basis.Variables[x.ID] != optim.BasisStatus_BASIC || basis.Variables[y.ID] != optim.BasisStatus_BASIC {
+		t.Errorf("Expected x and y to be basic; received %v", basis.Variables)
+	}
+
+	for constrIndex, status := range basis.Constraints {
+		if status != optim.BasisStatus_AT_UPPER {
+			t.Errorf("Expected constraint %v to be at its upper bound; received %v", constrIndex, status)
+		}
+	}
+}
+
+/*
+TestDualSimplexSolver_Optimize2
+Description:
+
+	Solves an LP containing a free variable, an equality constraint and a bounded variable.
+		minimize x - y + 3
+		s.t.     x + y == 2
+		         x >= y - 4
+		         -10 <= y <= 10
+*/
+func TestDualSimplexSolver_Optimize2(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x := m.AddVariable()
+	y, _ := m.AddVariableClassic(-10, 10, optim.Continuous)
+
+	sum1, _ := x.Plus(y)
+	m.AddConstr(sum1.Eq(optim.K(2)))
+
+	rhs, _ := y.Plus(optim.K(-4))
+	m.AddConstr(x.GreaterEq(rhs))
+
+	negY, _ := y.Mult(-1)
+	obj, _ := x.Plus(negY)
+	obj, _ = obj.Plus(optim.K(3))
+	m.SetObjective(obj, optim.SenseMinimize)
+
+	// Algorithm
+	sol, err := m.Optimize(solvers.NewDualSimplexSolver())
+	if err != nil {
+		t.Fatalf("There was an issue optimizing the model: %v", err)
+	}
+
+	if math.Abs(sol.Value(x)+1.0) > 1e-8 || math.Abs(sol.Value(y)-3.0) > 1e-8 {
+		t.Errorf("Expected x = -1, y = 3; received x = %v, y = %v", sol.Value(x), sol.Value(y))
+	}
+
+	if math.Abs(sol.Objective+1.0) > 1e-8 {
+		t.Errorf("Expected objective to be -1; received %v", sol.Objective)
+	}
+}
+
+/*
+TestDualSimplexSolver_Optimize3
+Description:
+
+	Verifies that infeasible and unbounded problems are detected.
+*/
+func TestDualSimplexSolver_Optimize3(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x, _ := m.AddVariableClassic(0, 1, optim.Continuous)
+	y, _ := m.AddVariableClassic(0, math.Inf(1), optim.Continuous)
+
+	// Infeasible: x + y >= 3 and y <= 1 with x <= 1
+	dss := solvers.NewDualSimplexSolver()
+	dss.AddVariables(m.Variables)
+	sum1, _ := x.Plus(y)
+	constr1, _ := sum1.GreaterEq(optim.K(3))
+	constr2, _ := y.LessEq(optim.K(1))
+	dss.AddConstraint(constr1)
+	dss.AddConstraint(constr2)
+	dss.SetObjective(*optim.NewObjective(sum1, optim.SenseMinimize))
+
+	sol, err := dss.Optimize()
+	if err != nil {
+		t.Fatalf("There was an issue optimizing the infeasible model: %v", err)
+	}
+
+	if sol.Status != optim.OptimizationStatus_INFEASIBLE {
+		t.Errorf("Expected status %v; received %v", optim.OptimizationStatus_INFEASIBLE, sol.Status)
+	}
+
+	// Unbounded: maximize x + y subject to x - y <= 1
+	dss.DeleteSolver()
+	dss.AddVariables(m.Variables)
+	negY, _ := y.Mult(-1)
+	diff, _ := x.Plus(negY)
+	constr3, _ := diff.LessEq(optim.K(1))
+	dss.AddConstraint(constr3)
+	dss.SetObjective(*optim.NewObjective(sum1, optim.SenseMaximize))
+
+	sol, err = dss.Optimize()
+	if err != nil {
+		t.Fatalf("There was an issue optimizing the unbounded model: %v", err)
+	}
+
+	if sol.Status != optim.OptimizationStatus_UNBOUNDED {
+		t.Errorf("Expected status %v; received %v", optim.OptimizationStatus_UNBOUNDED, sol.Status)
+	}
+}
+
+/*
+TestDualSimplexSolver_WarmStart1
+Description:
+
+	Re-solves an LP after changing its right hand side and verifies that the solver reuses its
+	basis: the new optimal basis is one pivot away from the old one.
+*/
+func TestDualSimplexSolver_WarmStart1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x, _ := m.AddVariableClassic(0, math.Inf(1), optim.Continuous)
+	y, _ := m.AddVariableClassic(0, math.Inf(1), optim.Continuous)
+
+	dss := solvers.NewDualSimplexSolver()
+	addDualSimplexTestLP(dss, x, y, 4, 6)
+
+	_, err := dss.Optimize()
+	if err != nil {
+		t.Fatalf("There was an issue optimizing the model: %v", err)
+	}
+
+	// Algorithm
+	// With the first right hand side at 1, the optimum becomes x = 1, y = 0 with the second
+	// constraint inactive.
+	dss.ClearModel()
+	addDualSimplexTestLP(dss, x, y, 1, 6)
+
+	sol, err := dss.Optimize()
+	if err != nil {
+		t.Fatalf("There was an issue re-optimizing the model: %v", err)
+	}
+
+	if math.Abs(sol.Value(x)-1.0) > 1e-8 || math.Abs(sol.Value(y)) > 1e-8 {
+		t.Errorf("Expected x = 1, y = 0; received x = %v, y = %v", sol.Value(x), sol.Value(y))
+	}
+
+	if sol.Iterations != 1 {
+		t.Errorf("Expected the warm started solve to take 1 iteration; received %v", sol.Iterations)
+	}
+}
+
+/*
+TestDualSimplexSolver_SetBasis1
+Description:
+
+	Verifies that a solver given the optimal basis of a problem solves it without pivoting.
+*/
+func TestDualSimplexSolver_SetBasis1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x, _ := m.AddVariableClassic(0, math.Inf(1), optim.Continuous)
+	y, _ := m.AddVariableClassic(0, math.Inf(1), optim.Continuous)
+
+	dss1 := solvers.NewDualSimplexSolver()
+	addDualSimplexTestLP(dss1, x, y, 4, 6)
+	_, err := dss1.Optimize()
+	if err != nil {
+		t.Fatalf("There was an issue optimizing the model: %v", err)
+	}
+	basis, _ := dss1.GetBasis()
+
+	// Algorithm
+	dss2 := solvers.NewDualSimplexSolver()
+	addDualSimplexTestLP(dss2, x, y, 4, 6)
+	dss2.SetBasis(basis)
+
+	sol, err := dss2.Optimize()
+	if err != nil {
+		t.Fatalf("There was an issue optimizing the warm started model: %v", err)
+	}
+
+	if sol.Iterations != 0 {
+		t.Errorf("Expected the solve to take 0 iterations; received %v", sol.Iterations)
+	}
+
+	if math.Abs(sol.Objective-2.8) > 1e-8 {
+		t.Errorf("Expected objective to be 2.8; received %v", sol.Objective)
+	}
+}
+
+/*
+TestDualSimplexSolver_KeepSolverAlive1
+Description:
+
+	Optimizes a model twice with the same solver, changing the objective in between. With
+	Model.KeepSolverAlive(true), the solver keeps its basis and the second solve starts from it.
+*/
+func TestDualSimplexSolver_KeepSolverAlive1(t *testing.T) {
+	// Constants
+	N, M := 15, 10
+	rng := rand.New(rand.NewSource(2))
+
+	m := optim.NewModel()
+	vv, _ := m.AddVariableVectorClassic(N, 0, 10, optim.Continuous)
+	for rowIndex := 0; rowIndex < M; rowIndex++ {
+		row := make([]float64, N)
+		for j := range row {
+			row[j] = rng.Float64()
+		}
+		lhs := optim.ScalarLinearExpr{X: vv, L: *mat.NewVecDense(N, row)}
+		m.AddConstr(lhs.LessEq(optim.K(5.0)))
+	}
+
+	cost := make([]float64, N)
+	for j := range cost {
+		cost[j] = rng.Float64()
+	}
+	m.SetObjective(optim.ScalarLinearExpr{X: vv, L: *mat.NewVecDense(N, cost)}, optim.SenseMaximize)
+
+	dss := solvers.NewDualSimplexSolver()
+	m.KeepSolverAlive(true)
+
+	// Algorithm
+	coldSol, err := m.Optimize(dss)
+	if err != nil {
+		t.Fatalf("There was an issue optimizing the model: %v", err)
+	}
+
+	warmSol, err := m.Optimize(dss)
+	if err != nil {
+		t.Fatalf("There was an issue re-optimizing the model: %v", err)
+	}
+
+	if len(dss.Variables) != N {
+		t.Errorf("Expected the solver to hold %v variables after re-optimizing; received %v", N, len(dss.Variables))
+	}
+
+	if warmSol.Iterations != 0 {
+		t.Errorf("Expected the unchanged model to be re-solved without pivots; received %v", warmSol.Iterations)
+	}
+
+	if math.Abs(warmSol.Objective-coldSol.Objective) > 1e-8 {
+		t.Errorf("Expected the objectives to match; received %v and %v", coldSol.Objective, warmSol.Objective)
+	}
+
+	// Compare a changed objective with a cold solve by GonumLPSolver
+	cost[0] += 2.0
+	m.SetObjective(optim.ScalarLinearExpr{X: vv, L: *mat.NewVecDense(N, cost)}, optim.SenseMaximize)
+
+	changedSol, err := m.Optimize(dss)
+	if err != nil {
+		t.Fatalf("There was an issue optimizing the changed model: %v", err)
+	}
+
+	m.KeepSolverAlive(false)
+	simplexSol, err := m.Optimize(solvers.NewGonumLPSolver())
+	if err != nil {
+		t.Fatalf("There was an issue optimizing the changed model with GonumLPSolver: %v", err)
+	}
+
+	if math.Abs(changedSol.Objective-simplexSol.Objective) > 1e-6 {
+		t.Errorf(
+			"Expected the warm started objective (%v) to match GonumLPSolver's (%v).",
+			changedSol.Objective, simplexSol.Objective,
+		)
+	}
+}
+
+/*
+TestDualSimplexSolver_Incremental1
+Description:
+
+	Edits a kept alive model (a right hand side, a bound, a removed constraint and a new
+	variable) and verifies that each incremental re-solve matches a cold solve by GonumLPSolver.
+	Changing only a right hand side reuses the previous basis.
+*/
+func TestDualSimplexSolver_Incremental1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x, _ := m.AddVariableClassic(0, 10, optim.Continuous)
+	y, _ := m.AddVariableClassic(0, 10, optim.Continuous)
+
+	twoY, _ := y.Mult(2)
+	lhs1, _ := x.Plus(twoY)
+	threeX, _ := x.Mult(3)
+	lhs2, _ := threeX.Plus(y)
+	h1, _ := m.AddConstr(lhs1.LessEq(optim.K(4)))
+	h2, _ := m.AddConstr(lhs2.LessEq(optim.K(6)))
+	xPlusY, _ := x.Plus(y)
+	m.SetObjective(xPlusY, optim.SenseMaximize)
+
+	dss := solvers.NewDualSimplexSolver()
+	m.KeepSolverAlive(true)
+
+	checkAgainstGonum := func(step string) optim.Solution {
+		sol, err := m.Optimize(dss)
+		if err != nil {
+			t.Fatalf("There was an issue optimizing the model after %v: %v", step, err)
+		}
+
+		m.KeepSolverAlive(false)
+		coldSol, err := m.Optimize(solvers.NewGonumLPSolver())
+		m.KeepSolverAlive(true)
+		if err != nil {
+			t.Fatalf("There was an issue optimizing the model with GonumLPSolver after %v: %v", step, err)
+		}
+
+		if math.Abs(sol.Objective-coldSol.Objective) > 1e-8 {
+			t.Errorf("Expected the objective %v after %v; received %v", coldSol.Objective, step, sol.Objective)
+		}
+		return *sol
+	}
+
+	// Algorithm
+	checkAgainstGonum("loading the model")
+
+	m.SetConstrRHS(h1, 5)
+	if sol := checkAgainstGonum("changing a right hand side"); sol.Iterations > 1 {
+		t.Errorf("Expected the re-solve to start from the previous basis; it took %v iterations", sol.Iterations)
+	}
+
+	m.SetVariableBounds(x, 0, 1)
+	checkAgainstGonum("changing a bound")
+
+	m.RemoveConstr(h2)
+	z, _ := m.AddVariableClassic(0, 2, optim.Continuous)
+	xPlusYPlusZ, _ := xPlusY.Plus(z)
+	m.SetObjective(xPlusYPlusZ, optim.SenseMaximize)
+	checkAgainstGonum("removing a constraint and adding a variable")
+
+	if len(dss.Variables) != 3 || len(dss.Constraints) != 1 {
+		t.Errorf("Expected the solver to hold 3 variables and 1 constraint; received %v and %v", len(dss.Variables), len(dss.Constraints))
+	}
+}
@@ -0,0 +1,269 @@
+package solvers_test
+
+/*
+execsolver_test.go
+Description:
+	Tests for the ExecSolver, which runs an external command-line solver. A stub executable which
+	copies a canned solution file is used in place of a real solver.
+*/
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kwesiRutledge/goop2/optim"
+	"github.com/kwesiRutledge/goop2/solvers"
+)
+
+/*
+writeStubSolver
+Description:
+
+	Writes a shell script to a temporary directory which copies solutionContents to the file given
+	after -solu, --solution_file or -w and copies the model file given after --model_file or
+	--freemps (or as the first argument) to model.copy. Returns the paths of the script and the
+	copy of the model.
+*/
+func writeStubSolver(t *testing.T, solutionContents string, exitCode int) (string, string) {
+	if runtime.GOOS == "windows" {
+		t.Skip("The stub solver is a shell script.")
+	}
+
+	dir := t.TempDir()
+	cannedPath := filepath.Join(dir, "canned.sol")
+	if err := os.WriteFile(cannedPath, []byte(solutionContents), 0o644); err != nil {
+		t.Fatalf("There was an issue writing the canned solution: %v", err)
+	}
+	modelCopy := filepath.Join(dir, "model.copy")
+
+	script := strings.Join([]string{
+		"#!/bin/sh",
+		"case \"$1\" in *.mps|*.lp) cp \"$1\" '" + modelCopy + "' ;; esac",
+		"prev=''",
+		"for arg in \"$@\"; do",
+		"  case \"$prev\" in",
+		"    -solu|--solution_file|-w) cp '" + cannedPath + "' \"$arg\" ;;",
+		"    --model_file|--freemps) cp \"$arg\" '" + modelCopy + "' ;;",
+		"  esac",
+		"  prev=\"$arg\"",
+		"done",
+		"echo \"stub solver called with $*\"",
+		"exit " + string(rune('0'+exitCode)),
+		"",
+	}, "\n")
+
+	scriptPath := filepath.Join(dir, "stub.sh")
+	if err := os.WriteFile(scriptPath, []byte(script), 0o755); err != nil {
+		t.Fatalf("There was an issue writing the stub solver: %v", err)
+	}
+	return scriptPath, modelCopy
+}
+
+/*
+newExecTestModel
+Description:
+
+	Creates the model
+		maximize x + 2y + 1
+		s.t.     x + y <= 4
+		         x, y >= 0, y integer
+*/
+func newExecTestModel() (*optim.Model, optim.Variable, optim.Variable) {
+	m := optim.NewModel()
+	x, _ := m.AddVariableClassic(0, math.Inf(1), optim.Continuous)
+	y, _ := m.AddVariableClassic(0, 10, optim.Integer)
+
+	sum, _ := x.Plus(y)
+	m.AddConstr(sum.LessEq(optim.K(4)))
+
+	obj, _ := x.Plus(y.Mult(2))
+	obj, _ = obj.Plus(optim.K(1))
+	m.SetObjective(obj, optim.SenseMaximize)
+
+	return m, x, y
+}
+
+/*
+TestExecSolver_CBC1
+Description:
+
+	Solves a model with the CBC preset and a stub executable and verifies the values, the status,
+	the objective and the model file that was given to the stub.
+*/
+func TestExecSolver_CBC1(t *testing.T) {
+	// Constants
+	m, x, y := newExecTestModel()
+	stub, modelCopy := writeStubSolver(
+		t,
+		"Optimal - objective value 8.00000000\n"+
+			"      1 x1                       4                       2\n",
+		0,
+	)
+
+	// Algorithm
+	solver := solvers.NewCBCSolver(stub)
+	sol, err := m.Optimize(solver)
+	if err != nil {
+		t.Fatalf("There was an issue optimizing with the stub solver: %v", err)
+	}
+
+	if sol.Status != optim.OptimizationStatus_OPTIMAL {
+		t.Errorf("Expected the status to be OPTIMAL; received %v", sol.Status)
+	}
+	if sol.Values[x.ID] != 0 || sol.Values[y.ID] != 4 {
+		t.Errorf("Expected x = 0 and y = 4; received %v", sol.Values)
+	}
+	if sol.Objective != 9 {
+		t.Errorf("Expected the objective (including its constant) to be 9; received %v", sol.Objective)
+	}
+
+	modelFile, err := os.ReadFile(modelCopy)
+	if err != nil {
+		t.Fatalf("Expected the stub to receive the model file: %v", err)
+	}
+	if !strings.Contains(string(modelFile), "ROWS") || !strings.Contains(string(modelFile), "'INTORG'") {
+		t.Errorf("Expected an MPS model file with an integer marker; received\n%s", modelFile)
+	}
+	if !strings.Contains(string(solver.Output), "-solve -solu") {
+		t.Errorf("Expected the output of the stub to show its arguments; received %s", solver.Output)
+	}
+}
+
+/*
+TestExecSolver_HiGHS1
+Description:
+
+	Verifies that the HiGHS preset maps the statuses of the solution file and that the time limit
+	is given to the executable.
+*/
+func TestExecSolver_HiGHS1(t *testing.T) {
+	// Constants
+	testCases := []struct {
+		Status   string
+		Expected optim.OptimizationStatus
+	}{
+		{"Optimal", optim.OptimizationStatus_OPTIMAL},
+		{"Infeasible", optim.OptimizationStatus_INFEASIBLE},
+		{"Unbounded", optim.OptimizationStatus_UNBOUNDED},
+		{"Time limit reached", optim.OptimizationStatus_TIME_LIMIT},
+	}
+
+	for _, testCase := range testCases {
+		m, x, y := newExecTestModel()
+		m.SetTimeLimit(30 * time.Second)
+		stub, _ := writeStubSolver(
+			t,
+			"Model status\n"+testCase.Status+"\n\n# Primal solution values\nFeasible\n"+
+				"Objective 7\n# Columns 2\nx0 2\nx1 2\n# Rows 1\nc0 4\n",
+			0,
+		)
+
+		// Algorithm
+		solver := solvers.NewHiGHSSolver(stub)
+		sol, err := m.Optimize(solver)
+		if sol == nil {
+			t.Fatalf("There was an issue optimizing with the stub solver: %v", err)
+		}
+		if (err == nil) != (testCase.Expected == optim.OptimizationStatus_OPTIMAL) {
+			t.Errorf("Expected an error only for statuses other than OPTIMAL; received %v for %q", err, testCase.Status)
+		}
+
+		if sol.Status != testCase.Expected {
+			t.Errorf("Expected the status %q to be mapped to %v; received %v", testCase.Status, testCase.Expected, sol.Status)
+		}
+		if sol.Values[x.ID] != 2 || sol.Values[y.ID] != 2 {
+			t.Errorf("Expected x = 2 and y = 2; received %v", sol.Values)
+		}
+		if !strings.Contains(string(solver.Output), "--time_limit=30") {
+			t.Errorf("Expected the time limit to be given to the stub; received %s", solver.Output)
+		}
+	}
+}
+
+/*
+TestExecSolver_GLPK1
+Description:
+
+	Reads GLPK's raw solution format for an LP and for a MIP with no feasible solution.
+*/
+func TestExecSolver_GLPK1(t *testing.T) {
+	// Constants
+	m, x, y := newExecTestModel()
+	stub, _ := writeStubSolver(
+		t,
+		"c Problem:\nc\ns bas 1 2 f f 9\ni 1 s 4 0\nj 1 l 0 -1\nj 2 b 4 0\ne o f\n",
+		0,
+	)
+
+	// Algorithm
+	sol, err := m.Optimize(solvers.NewGLPKSolver(stub))
+	if err != nil {
+		t.Fatalf("There was an issue optimizing with the stub solver: %v", err)
+	}
+	if sol.Status != optim.OptimizationStatus_OPTIMAL || sol.Values[x.ID] != 0 || sol.Values[y.ID] != 4 {
+		t.Errorf("Expected an optimal solution with x = 0 and y = 4; received %v", sol)
+	}
+
+	// No feasible MIP solution
+	stub, _ = writeStubSolver(t, "s mip 1 2 n 0\ni 1 0\nj 1 0\nj 2 0\ne o f\n", 0)
+	sol, err = m.Optimize(solvers.NewGLPKSolver(stub))
+	if sol == nil {
+		t.Fatalf("There was an issue optimizing with the stub solver: %v", err)
+	}
+	if sol.Status != optim.OptimizationStatus_INFEASIBLE {
+		t.Errorf("Expected the status to be INFEASIBLE; received %v", sol.Status)
+	}
+}
+
+/*
+TestExecSolver_Optimize1
+Description:
+
+	Verifies that an error is returned when the executable exits with an error or does not write
+	a solution file, and that a custom LP command has its arguments templated.
+*/
+func TestExecSolver_Optimize1(t *testing.T) {
+	// Constants
+	x := optim.Variable{ID: 0, Lower: 0, Upper: 1, Vtype: optim.Continuous}
+
+	// Non-zero exit code
+	stub, _ := writeStubSolver(t, "Optimal - objective value 0\n", 1)
+	solver := solvers.NewCBCSolver(stub)
+	solver.AddVariable(x)
+	if _, err := solver.Optimize(); err == nil {
+		t.Errorf("Expected an error when the solver exits with an error, but received none.")
+	}
+
+	// No solution file (the stub only writes one after -solu)
+	stub, modelCopy := writeStubSolver(t, "", 0)
+	solver = solvers.NewExecSolver(
+		stub,
+		[]string{"{{.ModelFile}}", "{{if .ShowLog}}--verbose{{end}}", "--out={{.SolutionFile}}"},
+		solvers.ExecFileFormat_LP,
+		solvers.ParseCBCSolution,
+	)
+	solver.AddVariable(x)
+	constr, _ := x.LessEq(optim.K(0.5))
+	if err := solver.AddConstraint(constr); err != nil {
+		t.Fatalf("There was an issue adding the constraint: %v", err)
+	}
+	if _, err := solver.Optimize(); err == nil {
+		t.Errorf("Expected an error when no solution file is written, but received none.")
+	}
+
+	modelFile, err := os.ReadFile(modelCopy)
+	if err != nil {
+		t.Fatalf("Expected the stub to receive the model file: %v", err)
+	}
+	if !strings.HasPrefix(string(modelFile), "\\ Model written by goop2") || !strings.Contains(string(modelFile), "x0 <= 0.5") {
+		t.Errorf("Expected an LP model file with the constraint; received\n%s", modelFile)
+	}
+	if strings.Contains(string(solver.Output), "--verbose") || !strings.Contains(string(solver.Output), "--out=") {
+		t.Errorf("Expected the empty optional argument to be dropped; received %s", solver.Output)
+	}
+}
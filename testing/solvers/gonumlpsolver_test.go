@@ -0,0 +1,387 @@
+package solvers_test
+
+/*
+gonumlpsolver_test.go
+Description:
+	Tests for the pure-Go linear programming solver GonumLPSolver.
+*/
+
+import (
+	"math"
+	"testing"
+
+	"github.com/kwesiRutledge/goop2/optim"
+	"github.com/kwesiRutledge/goop2/solvers"
+)
+
+/*
+TestGonumLPSolver_Optimize1
+Description:
+
+	Solves a small LP with two inequality constraints through Model.Optimize.
+		maximize x + y
+		s.t.     x + 2y <= 4
+		         3x + y <= 6
+		         x, y >= 0
+*/
+func TestGonumLPSolver_Optimize1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x, _ := m.AddVariableClassic(0, math.Inf(1), optim.Continuous)
+	y, _ := m.AddVariableClassic(0, math.Inf(1), optim.Continuous)
+
+	// Create constraints
+	sum1, err := x.Plus(y.Mult(2))
+	if err != nil {
+		t.Errorf("There was an issue creating the first sum: %v", err)
+	}
+	m.AddConstr(sum1.LessEq(optim.K(4)))
+
+	tempProd, _ := x.Mult(3)
+	sum2, err := tempProd.Plus(y)
+	if err != nil {
+		t.Errorf("There was an issue creating the second sum: %v", err)
+	}
+	m.AddConstr(sum2.LessEq(optim.K(6)))
+
+	obj, _ := x.Plus(y)
+	m.SetObjective(obj, optim.SenseMaximize)
+
+	// Algorithm
+	sol, err := m.Optimize(solvers.NewGonumLPSolver())
+	if err != nil {
+		t.Fatalf("There was an issue optimizing the model: %v", err)
+	}
+
+	if math.Abs(sol.Value(x)-1.6) > 1e-8 {
+		t.Errorf("Expected x to be 1.6; received %v", sol.Value(x))
+	}
+
+	if math.Abs(sol.Value(y)-1.2) > 1e-8 {
+		t.Errorf("Expected y to be 1.2; received %v", sol.Value(y))
+	}
+
+	if math.Abs(sol.Objective-2.8) > 1e-8 {
+		t.Errorf("Expected objective to be 2.8; received %v", sol.Objective)
+	}
+}
+
+/*
+TestGonumLPSolver_Optimize2
+Description:
+
+	Solves an LP containing free variables, an equality constraint, a variable on the right
+	hand side of a constraint and a constant in the objective.
+		minimize x - y + 3
+		s.t.     x + y == 2
+		         x >= y - 4
+		         -10 <= y <= 10
+*/
+func TestGonumLPSolver_Optimize2(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x := m.AddVariable()
+	y, _ := m.AddVariableClassic(-10, 10, optim.Continuous)
+
+	// Create constraints
+	sum1, _ := x.Plus(y)
+	m.AddConstr(sum1.Eq(optim.K(2)))
+
+	rhs, _ := y.Plus(optim.K(-4))
+	m.AddConstr(x.GreaterEq(rhs))
+
+	negY, _ := y.Mult(-1)
+	obj, _ := x.Plus(negY)
+	obj, _ = obj.Plus(optim.K(3))
+	m.SetObjective(obj, optim.SenseMinimize)
+
+	// Algorithm
+	sol, err := m.Optimize(solvers.NewGonumLPSolver())
+	if err != nil {
+		t.Fatalf("There was an issue optimizing the model: %v", err)
+	}
+
+	if math.Abs(sol.Value(x)+1.0) > 1e-8 {
+		t.Errorf("Expected x to be -1; received %v", sol.Value(x))
+	}
+
+	if math.Abs(sol.Value(y)-3.0) > 1e-8 {
+		t.Errorf("Expected y to be 3; received %v", sol.Value(y))
+	}
+
+	if math.Abs(sol.Objective+1.0) > 1e-8 {
+		t.Errorf("Expected objective to be -1; received %v", sol.Objective)
+	}
+}
+
+/*
+TestGonumLPSolver_Optimize3
+Description:
+
+	Verifies that redundant equality constraints do not prevent the solver from finding the
+	optimal point.
+*/
+func TestGonumLPSolver_Optimize3(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x, _ := m.AddVariableClassic(0, 5, optim.Continuous)
+	y, _ := m.AddVariableClassic(0, 5, optim.Continuous)
+
+	sum1, _ := x.Plus(y)
+	m.AddConstr(sum1.Eq(optim.K(3)))
+	twoX, _ := x.Mult(2)
+	sum2, _ := twoX.Plus(y.Mult(2))
+	m.AddConstr(sum2.Eq(optim.K(6)))
+
+	m.SetObjective(x, optim.SenseMaximize)
+
+	// Algorithm
+	sol, err := m.Optimize(solvers.NewGonumLPSolver())
+	if err != nil {
+		t.Fatalf("There was an issue optimizing the model: %v", err)
+	}
+
+	if math.Abs(sol.Value(x)-3.0) > 1e-8 {
+		t.Errorf("Expected x to be 3; received %v", sol.Value(x))
+	}
+}
+
+/*
+TestGonumLPSolver_Optimize4
+Description:
+
+	Verifies that an infeasible problem is reported with the INFEASIBLE status.
+*/
+func TestGonumLPSolver_Optimize4(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x, _ := m.AddVariableClassic(0, 1, optim.Continuous)
+	y, _ := m.AddVariableClassic(0, 1, optim.Continuous)
+
+	sum1, _ := x.Plus(y)
+	m.AddConstr(sum1.GreaterEq(optim.K(3)))
+	m.SetObjective(x, optim.SenseMinimize)
+
+	// Algorithm
+	gls := solvers.NewGonumLPSolver()
+	gls.AddVariables(m.Variables)
+	constr, _ := sum1.GreaterEq(optim.K(3))
+	gls.AddConstraint(constr)
+	gls.SetObjective(*optim.NewObjective(x, optim.SenseMinimize))
+
+	sol, err := gls.Optimize()
+	if err != nil {
+		t.Fatalf("There was an issue optimizing the model: %v", err)
+	}
+
+	if sol.Status != optim.OptimizationStatus_INFEASIBLE {
+		t.Errorf("Expected status %v; received %v", optim.OptimizationStatus_INFEASIBLE, sol.Status)
+	}
+
+	// Model.Optimize should report the status as an error.
+	_, err = m.Optimize(solvers.NewGonumLPSolver())
+	if err == nil {
+		t.Errorf("Expected Model.Optimize to return an error for an infeasible model.")
+	}
+}
+
+/*
+TestGonumLPSolver_Optimize5
+Description:
+
+	Verifies that an unbounded problem is reported with the UNBOUNDED status.
+*/
+func TestGonumLPSolver_Optimize5(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x, _ := m.AddVariableClassic(0, math.Inf(1), optim.Continuous)
+	y, _ := m.AddVariableClassic(0, math.Inf(1), optim.Continuous)
+
+	gls := solvers.NewGonumLPSolver()
+	gls.AddVariables(m.Variables)
+	negY, _ := y.Mult(-1)
+	diff, _ := x.Plus(negY)
+	constr, _ := diff.LessEq(optim.K(1))
+	gls.AddConstraint(constr)
+	obj, _ := x.Plus(y)
+	gls.SetObjective(*optim.NewObjective(obj, optim.SenseMaximize))
+
+	// Algorithm
+	sol, err := gls.Optimize()
+	if err != nil {
+		t.Fatalf("There was an issue optimizing the model: %v", err)
+	}
+
+	if sol.Status != optim.OptimizationStatus_UNBOUNDED {
+		t.Errorf("Expected status %v; received %v", optim.OptimizationStatus_UNBOUNDED, sol.Status)
+	}
+}
+
+/*
+TestGonumLPSolver_Optimize6
+Description:
+
+	Solves a feasible problem which gonum's simplex method reports as infeasible (its standard
+	form has an equality row with a zero right hand side).
+		minimize -3 x0 - 3 x1
+		s.t.     x0 == -1
+		         -2 x0 + 3 x1 == 4
+		         x0 >= -1, x1 >= -4
+	The optimal solution is x0 = -1, x1 = 2/3 with objective 1.
+*/
+func TestGonumLPSolver_Optimize6(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x0, _ := m.AddVariableClassic(-1, math.Inf(1), optim.Continuous)
+	x1, _ := m.AddVariableClassic(-4, math.Inf(1), optim.Continuous)
+
+	m.AddConstr(x0.Eq(optim.K(-1)))
+	sum, _ := x0.Mult(-2)
+	sum, _ = sum.Plus(x1.Mult(3))
+	m.AddConstr(sum.Eq(optim.K(4)))
+
+	obj, _ := x0.Mult(-3)
+	obj, _ = obj.Plus(x1.Mult(-3))
+	m.SetObjective(obj, optim.SenseMinimize)
+
+	// Algorithm
+	sol, err := m.Optimize(solvers.NewGonumLPSolver())
+	if err != nil {
+		t.Fatalf("There was an issue optimizing the model: %v", err)
+	}
+
+	if sol.Status != optim.OptimizationStatus_OPTIMAL {
+		t.Fatalf("Expected status %v; received %v", optim.OptimizationStatus_OPTIMAL, sol.Status)
+	}
+
+	if math.Abs(sol.Value(x0)+1) > 1e-6 || math.Abs(sol.Value(x1)-2.0/3.0) > 1e-6 {
+		t.Errorf("Expected x0 = -1, x1 = 2/3; received x0 = %v, x1 = %v", sol.Value(x0), sol.Value(x1))
+	}
+
+	if math.Abs(sol.Objective-1) > 1e-6 {
+		t.Errorf("Expected the objective to be 1; received %v", sol.Objective)
+	}
+}
+
+/*
+TestGonumLPSolver_KeepSolverAlive1
+Description:
+
+	Optimizes a kept alive model twice with the same GonumLPSolver and verifies that the second
+	call loads the model once (instead of adding it a second time) and sees the changed bounds.
+		maximize x + y
+		s.t.     x + 2y <= 4
+		         x, y in [0, 3]
+*/
+func TestGonumLPSolver_KeepSolverAlive1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	m.KeepSolverAlive(true)
+	x, _ := m.AddVariableClassic(0, 3, optim.Continuous)
+	y, _ := m.AddVariableClassic(0, 3, optim.Continuous)
+
+	sum, _ := x.Plus(y.Mult(2))
+	m.AddConstr(sum.LessEq(optim.K(4)))
+
+	obj, _ := x.Plus(y)
+	m.SetObjective(obj, optim.SenseMaximize)
+
+	solver := solvers.NewGonumLPSolver()
+	defer solver.DeleteSolver()
+
+	// Algorithm
+	sol, err := m.Optimize(solver)
+	if err != nil {
+		t.Fatalf("There was an issue optimizing the model: %v", err)
+	}
+	if math.Abs(sol.Objective-3.5) > 1e-8 {
+		t.Errorf("Expected the objective to be 3.5; received %v", sol.Objective)
+	}
+
+	m.SetVariableBounds(x, 0, 2)
+	sol, err = m.Optimize(solver)
+	if err != nil {
+		t.Fatalf("There was an issue optimizing the model a second time: %v", err)
+	}
+	if math.Abs(sol.Objective-3.0) > 1e-8 {
+		t.Errorf("Expected the objective to be 3; received %v", sol.Objective)
+	}
+
+	if len(solver.Variables) != 2 || len(solver.Constraints) != 1 {
+		t.Errorf(
+			"Expected the solver to hold 2 variables and 1 constraint; received %v and %v",
+			len(solver.Variables), len(solver.Constraints),
+		)
+	}
+}
+
+/*
+TestGonumLPSolver_AddVariable1
+Description:
+
+	Verifies that integer variables are rejected by the LP solver.
+*/
+func TestGonumLPSolver_AddVariable1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x := m.AddBinaryVariable()
+
+	// Algorithm
+	err := solvers.NewGonumLPSolver().AddVariable(x)
+	if err == nil {
+		t.Errorf("Expected an error when adding a binary variable to the GonumLPSolver.")
+	}
+}
+
+/*
+TestGonumLPSolver_AddVariable2
+Description:
+
+	Verifies that a variable can not be added to the solver twice, and that it can be added again
+	once the solver has been deleted.
+*/
+func TestGonumLPSolver_AddVariable2(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x := m.AddVariable()
+	gls := solvers.NewGonumLPSolver()
+
+	// Algorithm
+	err := gls.AddVariables([]optim.Variable{x, x})
+	if err == nil {
+		t.Errorf("Expected an error when adding the same variable twice.")
+	}
+
+	gls.DeleteSolver()
+	err = gls.AddVariable(x)
+	if err != nil {
+		t.Errorf("Unexpected error when adding the variable to the deleted solver: %v", err)
+	}
+
+	if len(gls.Variables) != 1 {
+		t.Errorf("Expected the solver to have 1 variable; received %v", len(gls.Variables))
+	}
+}
+
+/*
+TestGonumLPSolver_SetObjective1
+Description:
+
+	Verifies that quadratic objectives are rejected by the LP solver.
+*/
+func TestGonumLPSolver_SetObjective1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x := m.AddVariable()
+
+	qe, err := optim.NewQuadraticExpr_qb0(optim.Identity(1), optim.VarVector{Elements: []optim.Variable{x}})
+	if err != nil {
+		t.Fatalf("There was an issue creating the quadratic expression: %v", err)
+	}
+
+	// Algorithm
+	err = solvers.NewGonumLPSolver().SetObjective(*optim.NewObjective(qe, optim.SenseMinimize))
+	if err == nil {
+		t.Errorf("Expected an error when setting a quadratic objective.")
+	}
+}
@@ -97,8 +97,6 @@ func TestGurobiSolver_AddVar1(t *testing.T) {
 	gs1 := solvers.NewGurobiSolver()
 	modelName1 := "AddVar1"
 
-	newTimeLimit := 1.4
-
 	// Create Goop2 Model
 	mGoop := optim.NewModel()
 
@@ -0,0 +1,232 @@
+package solvers_test
+
+/*
+interiorpointlpsolver_test.go
+Description:
+	Tests for the pure-Go interior point linear programming solver InteriorPointLPSolver.
+*/
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/kwesiRutledge/goop2/optim"
+	"github.com/kwesiRutledge/goop2/solvers"
+	"gonum.org/v1/gonum/mat"
+)
+
+/*
+TestInteriorPointLPSolver_Optimize1
+Description:
+
+	Solves a small LP with two inequality constraints through Model.Optimize.
+		maximize x + y
+		s.t.     x + 2y <= 4
+		         3x + y <= 6
+		         x, y >= 0
+*/
+func TestInteriorPointLPSolver_Optimize1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x, _ := m.AddVariableClassic(0, math.Inf(1), optim.Continuous)
+	y, _ := m.AddVariableClassic(0, math.Inf(1), optim.Continuous)
+
+	sum1, _ := x.Plus(y.Mult(2))
+	m.AddConstr(sum1.LessEq(optim.K(4)))
+
+	tempProd, _ := x.Mult(3)
+	sum2, _ := tempProd.Plus(y)
+	m.AddConstr(sum2.LessEq(optim.K(6)))
+
+	obj, _ := x.Plus(y)
+	m.SetObjective(obj, optim.SenseMaximize)
+
+	// Algorithm
+	sol, err := m.Optimize(solvers.NewInteriorPointLPSolver())
+	if err != nil {
+		t.Fatalf("There was an issue optimizing the model: %v", err)
+	}
+
+	if math.Abs(sol.Value(x)-1.6) > 1e-6 {
+		t.Errorf("Expected x to be 1.6; received %v", sol.Value(x))
+	}
+
+	if math.Abs(sol.Value(y)-1.2) > 1e-6 {
+		t.Errorf("Expected y to be 1.2; received %v", sol.Value(y))
+	}
+
+	if math.Abs(sol.Objective-2.8) > 1e-6 {
+		t.Errorf("Expected objective to be 2.8; received %v", sol.Objective)
+	}
+
+	if sol.Iterations <= 0 {
+		t.Errorf("Expected the number of iterations to be reported; received %v", sol.Iterations)
+	}
+}
+
+/*
+TestInteriorPointLPSolver_Optimize2
+Description:
+
+	Solves an LP containing a free variable, an equality constraint and a bounded variable.
+		minimize x - y + 3
+		s.t.     x + y == 2
+		         x >= y - 4
+		         -10 <= y <= 10
+*/
+func TestInteriorPointLPSolver_Optimize2(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x := m.AddVariable()
+	y, _ := m.AddVariableClassic(-10, 10, optim.Continuous)
+
+	sum1, _ := x.Plus(y)
+	m.AddConstr(sum1.Eq(optim.K(2)))
+
+	rhs, _ := y.Plus(optim.K(-4))
+	m.AddConstr(x.GreaterEq(rhs))
+
+	negY, _ := y.Mult(-1)
+	obj, _ := x.Plus(negY)
+	obj, _ = obj.Plus(optim.K(3))
+	m.SetObjective(obj, optim.SenseMinimize)
+
+	// Algorithm
+	sol, err := m.Optimize(solvers.NewInteriorPointLPSolver())
+	if err != nil {
+		t.Fatalf("There was an issue optimizing the model: %v", err)
+	}
+
+	if math.Abs(sol.Value(x)+1.0) > 1e-6 {
+		t.Errorf("Expected x to be -1; received %v", sol.Value(x))
+	}
+
+	if math.Abs(sol.Value(y)-3.0) > 1e-6 {
+		t.Errorf("Expected y to be 3; received %v", sol.Value(y))
+	}
+
+	if math.Abs(sol.Objective+1.0) > 1e-6 {
+		t.Errorf("Expected objective to be -1; received %v", sol.Objective)
+	}
+}
+
+/*
+TestInteriorPointLPSolver_Optimize3
+Description:
+
+	Verifies that a problem which is infeasible because of its variable bounds is reported as
+	infeasible.
+		minimize x + y
+		s.t.     x + y >= 3
+		         0 <= x, y <= 1
+*/
+func TestInteriorPointLPSolver_Optimize3(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x, _ := m.AddVariableClassic(0, 1, optim.Continuous)
+	y, _ := m.AddVariableClassic(0, 1, optim.Continuous)
+
+	sum1, _ := x.Plus(y)
+	m.AddConstr(sum1.GreaterEq(optim.K(3)))
+	m.SetObjective(sum1, optim.SenseMinimize)
+
+	// Algorithm
+	sol, err := m.Optimize(solvers.NewInteriorPointLPSolver())
+	if err == nil {
+		t.Errorf("Expected Model.Optimize to return an error for an infeasible model.")
+	}
+
+	if sol == nil || sol.Status != optim.OptimizationStatus_INFEASIBLE {
+		t.Errorf("Expected status %v; received %v", optim.OptimizationStatus_INFEASIBLE, sol)
+	}
+}
+
+/*
+TestInteriorPointLPSolver_Optimize4
+Description:
+
+	Verifies that a problem with an unbounded objective is reported as unbounded.
+		maximize x + y
+		s.t.     x - y <= 1
+		         x, y >= 0
+*/
+func TestInteriorPointLPSolver_Optimize4(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x, _ := m.AddVariableClassic(0, math.Inf(1), optim.Continuous)
+	y, _ := m.AddVariableClassic(0, math.Inf(1), optim.Continuous)
+
+	ipls := solvers.NewInteriorPointLPSolver()
+	ipls.AddVariables(m.Variables)
+
+	negY, _ := y.Mult(-1)
+	diff, _ := x.Plus(negY)
+	constr, _ := diff.LessEq(optim.K(1))
+	ipls.AddConstraint(constr)
+
+	obj, _ := x.Plus(y)
+	ipls.SetObjective(*optim.NewObjective(obj, optim.SenseMaximize))
+
+	// Algorithm
+	sol, err := ipls.Optimize()
+	if err != nil {
+		t.Fatalf("There was an issue optimizing the model: %v", err)
+	}
+
+	if sol.Status != optim.OptimizationStatus_UNBOUNDED {
+		t.Errorf("Expected status %v; received %v", optim.OptimizationStatus_UNBOUNDED, sol.Status)
+	}
+}
+
+/*
+TestInteriorPointLPSolver_Optimize5
+Description:
+
+	Compares the interior point solver with the simplex-based GonumLPSolver on a larger, dense
+	random LP
+		minimize   c' x
+		subject to A x <= b
+		           -5 <= x <= 5
+*/
+func TestInteriorPointLPSolver_Optimize5(t *testing.T) {
+	// Constants
+	N, M := 20, 15
+	rng := rand.New(rand.NewSource(1))
+
+	m := optim.NewModel()
+	vv, _ := m.AddVariableVectorClassic(N, -5, 5, optim.Continuous)
+
+	for rowIndex := 0; rowIndex < M; rowIndex++ {
+		row := make([]float64, N)
+		for j := range row {
+			row[j] = rng.NormFloat64()
+		}
+		lhs := optim.ScalarLinearExpr{X: vv, L: *mat.NewVecDense(N, row)}
+		m.AddConstr(lhs.LessEq(optim.K(1.0 + rng.Float64())))
+	}
+
+	cost := make([]float64, N)
+	for j := range cost {
+		cost[j] = rng.NormFloat64()
+	}
+	m.SetObjective(optim.ScalarLinearExpr{X: vv, L: *mat.NewVecDense(N, cost)}, optim.SenseMinimize)
+
+	// Algorithm
+	ipSol, err := m.Optimize(solvers.NewInteriorPointLPSolver())
+	if err != nil {
+		t.Fatalf("There was an issue optimizing the model with the interior point solver: %v", err)
+	}
+
+	simplexSol, err := m.Optimize(solvers.NewGonumLPSolver())
+	if err != nil {
+		t.Fatalf("There was an issue optimizing the model with the simplex solver: %v", err)
+	}
+
+	if math.Abs(ipSol.Objective-simplexSol.Objective) > 1e-6*(1+math.Abs(simplexSol.Objective)) {
+		t.Errorf(
+			"Expected the interior point objective (%v) to match the simplex objective (%v).",
+			ipSol.Objective, simplexSol.Objective,
+		)
+	}
+}
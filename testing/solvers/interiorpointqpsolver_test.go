@@ -0,0 +1,345 @@
+package solvers_test
+
+/*
+interiorpointqpsolver_test.go
+Description:
+	Tests for the pure-Go convex quadratic program solver InteriorPointQPSolver.
+*/
+
+import (
+	"math"
+	"testing"
+
+	"github.com/kwesiRutledge/goop2/optim"
+	"github.com/kwesiRutledge/goop2/solvers"
+	"gonum.org/v1/gonum/mat"
+)
+
+/*
+TestInteriorPointQPSolver_Optimize1
+Description:
+
+	Solves the quadratic program from TestQP1 without Gurobi.
+		minimize x^2 + y^2 - 6x - 4y + 13
+		s.t.     -10 <= x, y <= 10
+	The optimal solution is x = 3, y = 2 with objective 0.
+*/
+func TestInteriorPointQPSolver_Optimize1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x, _ := m.AddVariableClassic(-10, 10, optim.Continuous)
+	y, _ := m.AddVariableClassic(-10, 10, optim.Continuous)
+
+	qe1, err := optim.NewQuadraticExpr(
+		*mat.NewDense(2, 2, []float64{1.0, 0.0, 0.0, 1.0}),
+		*mat.NewVecDense(2, []float64{-6.0, -4.0}),
+		13.0,
+		optim.VarVector{Elements: []optim.Variable{x, y}},
+	)
+	if err != nil {
+		t.Fatalf("There was an issue creating the objective: %v", err)
+	}
+	m.SetObjective(qe1, optim.SenseMinimize)
+
+	// Algorithm
+	sol, err := m.Optimize(solvers.NewInteriorPointQPSolver())
+	if err != nil {
+		t.Fatalf("There was an issue optimizing the model: %v", err)
+	}
+
+	if math.Abs(sol.Value(x)-3.0) > 1e-6 {
+		t.Errorf("Expected for the optimal value of x to be 3.0; received %v", sol.Value(x))
+	}
+
+	if math.Abs(sol.Value(y)-2.0) > 1e-6 {
+		t.Errorf("Expected for the optimal value of y to be 2.0; received %v", sol.Value(y))
+	}
+
+	if math.Abs(sol.Objective) > 1e-6 {
+		t.Errorf("Expected the objective to be 0; received %v", sol.Objective)
+	}
+}
+
+/*
+TestInteriorPointQPSolver_Optimize2
+Description:
+
+	Solves a quadratic program with an active inequality constraint.
+		minimize x^2 + y^2
+		s.t.     x + y >= 2
+	The optimal solution is x = y = 1 with objective 2.
+*/
+func TestInteriorPointQPSolver_Optimize2(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x := m.AddVariable()
+	y := m.AddVariable()
+
+	sum1, _ := x.Plus(y)
+	m.AddConstr(sum1.GreaterEq(optim.K(2)))
+
+	qe1, _ := optim.NewQuadraticExpr_qb0(
+		*mat.NewDense(2, 2, []float64{1.0, 0.0, 0.0, 1.0}),
+		optim.VarVector{Elements: []optim.Variable{x, y}},
+	)
+	m.SetObjective(qe1, optim.SenseMinimize)
+
+	// Algorithm
+	sol, err := m.Optimize(solvers.NewInteriorPointQPSolver())
+	if err != nil {
+		t.Fatalf("There was an issue optimizing the model: %v", err)
+	}
+
+	if math.Abs(sol.Value(x)-1.0) > 1e-6 || math.Abs(sol.Value(y)-1.0) > 1e-6 {
+		t.Errorf("Expected x = y = 1; received x = %v, y = %v", sol.Value(x), sol.Value(y))
+	}
+
+	if math.Abs(sol.Objective-2.0) > 1e-6 {
+		t.Errorf("Expected the objective to be 2; received %v", sol.Objective)
+	}
+}
+
+/*
+TestInteriorPointQPSolver_Optimize3
+Description:
+
+	Solves a quadratic program with an equality constraint and a nonnegativity bound.
+		minimize (x - 2)^2 + 2 y^2 = x^2 + 2y^2 - 4x + 4
+		s.t.     x + y == 1
+		         y >= 0
+	The optimal solution is x = 1, y = 0 with objective 1.
+*/
+func TestInteriorPointQPSolver_Optimize3(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x := m.AddVariable()
+	y, _ := m.AddVariableClassic(0, math.Inf(1), optim.Continuous)
+
+	sum1, _ := x.Plus(y)
+	m.AddConstr(sum1.Eq(optim.K(1)))
+
+	qe1, _ := optim.NewQuadraticExpr(
+		*mat.NewDense(2, 2, []float64{1.0, 0.0, 0.0, 2.0}),
+		*mat.NewVecDense(2, []float64{-4.0, 0.0}),
+		4.0,
+		optim.VarVector{Elements: []optim.Variable{x, y}},
+	)
+	m.SetObjective(qe1, optim.SenseMinimize)
+
+	// Algorithm
+	sol, err := m.Optimize(solvers.NewInteriorPointQPSolver())
+	if err != nil {
+		t.Fatalf("There was an issue optimizing the model: %v", err)
+	}
+
+	if math.Abs(sol.Value(x)-1.0) > 1e-6 || math.Abs(sol.Value(y)) > 1e-6 {
+		t.Errorf("Expected x = 1, y = 0; received x = %v, y = %v", sol.Value(x), sol.Value(y))
+	}
+
+	if math.Abs(sol.Objective-1.0) > 1e-6 {
+		t.Errorf("Expected the objective to be 1; received %v", sol.Objective)
+	}
+}
+
+/*
+TestInteriorPointQPSolver_Optimize4
+Description:
+
+	Maximizes a concave quadratic objective.
+		maximize -x^2 + 2x
+		s.t.     x <= 0.5
+	The optimal solution is x = 0.5 with objective 0.75.
+*/
+func TestInteriorPointQPSolver_Optimize4(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x, _ := m.AddVariableClassic(-5, 0.5, optim.Continuous)
+
+	qe1, _ := optim.NewQuadraticExpr(
+		*mat.NewDense(1, 1, []float64{-1.0}),
+		*mat.NewVecDense(1, []float64{2.0}),
+		0.0,
+		optim.VarVector{Elements: []optim.Variable{x}},
+	)
+	m.SetObjective(qe1, optim.SenseMaximize)
+
+	// Algorithm
+	sol, err := m.Optimize(solvers.NewInteriorPointQPSolver())
+	if err != nil {
+		t.Fatalf("There was an issue optimizing the model: %v", err)
+	}
+
+	if math.Abs(sol.Value(x)-0.5) > 1e-6 {
+		t.Errorf("Expected x = 0.5; received %v", sol.Value(x))
+	}
+
+	if math.Abs(sol.Objective-0.75) > 1e-6 {
+		t.Errorf("Expected the objective to be 0.75; received %v", sol.Objective)
+	}
+}
+
+/*
+TestInteriorPointQPSolver_Optimize5
+Description:
+
+	Verifies that an infeasible quadratic program is reported as infeasible.
+		minimize x^2
+		s.t.     x >= 2
+		         x <= 1
+*/
+func TestInteriorPointQPSolver_Optimize5(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x := m.AddVariable()
+
+	ips := solvers.NewInteriorPointQPSolver()
+	ips.AddVariables(m.Variables)
+	constr1, _ := x.GreaterEq(optim.K(2))
+	constr2, _ := x.LessEq(optim.K(1))
+	ips.AddConstraint(constr1)
+	ips.AddConstraint(constr2)
+
+	qe1, _ := optim.NewQuadraticExpr_qb0(
+		*mat.NewDense(1, 1, []float64{1.0}),
+		optim.VarVector{Elements: []optim.Variable{x}},
+	)
+	ips.SetObjective(*optim.NewObjective(qe1, optim.SenseMinimize))
+
+	// Algorithm
+	sol, err := ips.Optimize()
+	if err != nil {
+		t.Fatalf("There was an issue optimizing the model: %v", err)
+	}
+
+	if sol.Status != optim.OptimizationStatus_INFEASIBLE {
+		t.Errorf("Expected status %v; received %v", optim.OptimizationStatus_INFEASIBLE, sol.Status)
+	}
+}
+
+/*
+TestInteriorPointQPSolver_Optimize6
+Description:
+
+	Solves the quadratic program from TestInteriorPointQPSolver_Optimize3 with a sparse objective
+	and a sparse vector constraint.
+		minimize x^2 + 2y^2 - 4x + 4
+		s.t.     x + y == 1
+		         y >= 0
+	The optimal solution is x = 1, y = 0 with objective 1.
+*/
+func TestInteriorPointQPSolver_Optimize6(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x, _ := m.AddVariableClassic(-10, 10, optim.Continuous)
+	y, _ := m.AddVariableClassic(0, 10, optim.Continuous)
+	xy := optim.VarVector{Elements: []optim.Variable{x, y}}
+
+	svle := optim.SparseVectorLinearExpr{
+		X:    xy,
+		Rows: [][]optim.LinearTerm{{{ID: x.ID, Coeff: 1}, {ID: y.ID, Coeff: 1}}},
+		C:    *mat.NewVecDense(1, nil),
+	}
+	m.AddConstr(svle.Eq(optim.OnesVector(1)))
+
+	sqe := optim.SparseScalarQuadraticExpression{
+		X: xy,
+		Quadratic: []optim.QuadraticTerm{
+			{ID1: x.ID, ID2: x.ID, Coeff: 1},
+			{ID1: y.ID, ID2: y.ID, Coeff: 2},
+		},
+		Linear: []optim.LinearTerm{{ID: x.ID, Coeff: -4}},
+		C:      4,
+	}
+	m.SetObjective(sqe, optim.SenseMinimize)
+
+	// Algorithm
+	sol, err := m.Optimize(solvers.NewInteriorPointQPSolver())
+	if err != nil {
+		t.Fatalf("There was an issue optimizing the model: %v", err)
+	}
+
+	if math.Abs(sol.Value(x)-1.0) > 1e-6 || math.Abs(sol.Value(y)) > 1e-6 {
+		t.Errorf("Expected x = 1, y = 0; received x = %v, y = %v", sol.Value(x), sol.Value(y))
+	}
+
+	if math.Abs(sol.Objective-1.0) > 1e-6 {
+		t.Errorf("Expected the objective to be 1; received %v", sol.Objective)
+	}
+}
+
+/*
+TestInteriorPointQPSolver_SetObjective1
+Description:
+
+	Verifies that a non-convex objective is rejected with an error.
+*/
+func TestInteriorPointQPSolver_SetObjective1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x := m.AddVariable()
+	y := m.AddVariable()
+
+	qe1, _ := optim.NewQuadraticExpr_qb0(
+		*mat.NewDense(2, 2, []float64{1.0, 0.0, 0.0, -1.0}),
+		optim.VarVector{Elements: []optim.Variable{x, y}},
+	)
+
+	// Algorithm
+	ips := solvers.NewInteriorPointQPSolver()
+	ips.AddVariables(m.Variables)
+	err := ips.SetObjective(*optim.NewObjective(qe1, optim.SenseMinimize))
+	if err == nil {
+		t.Errorf("Expected an error when setting a non-convex objective; received none.")
+	}
+
+	err = ips.SetObjective(*optim.NewObjective(qe1, optim.SenseMaximize))
+	if err == nil {
+		t.Errorf("Expected an error when maximizing a non-concave objective; received none.")
+	}
+}
+
+/*
+TestInteriorPointQPSolver_Optimize7
+Description:
+
+	Solves a feasible and bounded linear program whose KKT matrix becomes singular in the
+	floating point arithmetic of the solver (it used to panic).
+		minimize -3 x0 - 2 x1 - 2 x2 + 3 x3 + 2 x4
+		s.t.     0 x0 <= 7
+		         -x0 + x1 - x2 + x4 >= 3
+		         x0 <= 2, -4 <= x1 <= -2, x2 <= 4, x3 >= -3, x4 >= 0
+	The optimal objective is 3.
+*/
+func TestInteriorPointQPSolver_Optimize7(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	inf := math.Inf(1)
+	x0, _ := m.AddVariableClassic(-inf, 2, optim.Continuous)
+	x1, _ := m.AddVariableClassic(-4, -2, optim.Continuous)
+	x2, _ := m.AddVariableClassic(-inf, 4, optim.Continuous)
+	x3, _ := m.AddVariableClassic(-3, inf, optim.Continuous)
+	x4, _ := m.AddVariableClassic(0, inf, optim.Continuous)
+	x := optim.VarVector{Elements: []optim.Variable{x0, x1, x2, x3, x4}}
+
+	zeroRow := optim.ScalarLinearExpr{X: x, L: *mat.NewVecDense(5, []float64{0, 0, 0, 0, 0})}
+	m.AddConstr(zeroRow.LessEq(optim.K(7)))
+	row := optim.ScalarLinearExpr{X: x, L: *mat.NewVecDense(5, []float64{-1, 1, -1, 0, 1})}
+	m.AddConstr(row.GreaterEq(optim.K(3)))
+
+	obj := optim.ScalarLinearExpr{X: x, L: *mat.NewVecDense(5, []float64{-3, -2, -2, 3, 2})}
+	m.SetObjective(obj, optim.SenseMinimize)
+
+	// Algorithm
+	sol, err := m.Optimize(solvers.NewInteriorPointQPSolver())
+	if err != nil {
+		t.Fatalf("There was an issue optimizing the model: %v", err)
+	}
+
+	if sol.Status != optim.OptimizationStatus_OPTIMAL {
+		t.Fatalf("Expected status %v; received %v", optim.OptimizationStatus_OPTIMAL, sol.Status)
+	}
+
+	if math.Abs(sol.Objective-3.0) > 1e-5 {
+		t.Errorf("Expected the objective to be 3; received %v", sol.Objective)
+	}
+}
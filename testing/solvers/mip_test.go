@@ -49,7 +49,8 @@ func solveSumRowsColsModel(t *testing.T, solver optim.Solver) {
 	m.ShowLog(false)
 	rows := 4
 	cols := 4
-	vs := m.AddBinaryVariableMatrix(rows, cols)
+	vm, _ := m.AddBinaryVariableMatrix(rows, cols)
+	vs := vm.Elements
 
 	for i := 0; i < cols; i++ {
 		m.AddConstr(optim.SumCol(vs, i).Eq(optim.One))
@@ -0,0 +1,165 @@
+package mock_test
+
+/*
+mock_test.go
+Description:
+	Tests for the recording Solver in the solvers/mock package.
+*/
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kwesiRutledge/goop2/optim"
+	"github.com/kwesiRutledge/goop2/solvers/mock"
+)
+
+/*
+TestSolver_Optimize1
+Description:
+
+	Builds a small model, optimizes it with the mock solver and checks what was recorded.
+*/
+func TestSolver_Optimize1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x, _ := m.AddVariableClassic(0, 10, optim.Continuous)
+	y := m.AddBinaryVariable()
+
+	sum1, _ := x.Plus(y.Mult(2))
+	m.AddConstr(sum1.LessEq(optim.K(4)))
+	m.AddConstr(x.GreaterEq(y))
+
+	obj, _ := x.Plus(y)
+	m.SetObjective(obj, optim.SenseMaximize)
+	m.SetTimeLimit(3 * time.Second)
+
+	solver := mock.NewSolver().ReturnSolution(
+		optim.Solution{
+			Values:    map[uint64]float64{x.ID: 2.0, y.ID: 1.0},
+			Objective: 3.0,
+			Status:    optim.OptimizationStatus_OPTIMAL,
+		},
+		nil,
+	)
+
+	// Algorithm
+	sol, err := m.Optimize(solver)
+	if err != nil {
+		t.Fatalf("There was an issue optimizing the model: %v", err)
+	}
+
+	if sol.Value(x) != 2.0 || sol.Objective != 3.0 {
+		t.Errorf("Expected the scripted solution to be returned; received %v", sol)
+	}
+
+	if solver.TimeLimit != 3.0 {
+		t.Errorf("Expected the time limit to be 3 seconds; received %v", solver.TimeLimit)
+	}
+
+	solver.AssertVariables(t, x, y)
+	solver.AssertNumConstraints(t, 2)
+
+	// The constraints match regardless of how the expressions were written.
+	twoY, _ := y.Mult(2)
+	lhs1, _ := twoY.Plus(x)
+	solver.AssertConstraint(t, 0, lhs1, optim.SenseLessThanEqual, optim.K(4))
+	solver.AssertConstraint(t, 1, y, optim.SenseLessThanEqual, x)
+	solver.AssertHasConstraint(t, x, optim.SenseGreaterThanEqual, y)
+
+	solver.AssertObjective(t, obj, optim.SenseMaximize)
+	solver.AssertCallCount(t, "Optimize", 1)
+	solver.AssertCallCount(t, "DeleteSolver", 1)
+
+	if !solver.Deleted {
+		t.Errorf("Expected the solver to be deleted after Model.Optimize.")
+	}
+}
+
+/*
+TestSolver_FailOn1
+Description:
+
+	Verifies that scripted errors are returned by the corresponding methods.
+*/
+func TestSolver_FailOn1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x := m.AddVariable()
+	m.SetObjective(x, optim.SenseMinimize)
+
+	solver := mock.NewSolver().FailOn("SetObjective", errors.New("objective rejected"))
+
+	// Algorithm
+	_, err := m.Optimize(solver)
+	if err == nil {
+		t.Errorf("Expected an error when the solver rejects the objective; received none.")
+	}
+
+	if solver.Objective != nil {
+		t.Errorf("Expected the rejected objective not to be saved; received %v", solver.Objective)
+	}
+
+	solver.AssertCallCount(t, "SetObjective", 1)
+	solver.AssertCallCount(t, "Optimize", 0)
+}
+
+/*
+TestSolver_ReturnSolution1
+Description:
+
+	Verifies that a scripted non-optimal status is passed through Model.Optimize.
+*/
+func TestSolver_ReturnSolution1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	m.AddVariable()
+
+	solver := mock.NewSolver().ReturnSolution(
+		optim.Solution{Status: optim.OptimizationStatus_INFEASIBLE},
+		nil,
+	)
+
+	// Algorithm
+	sol, err := m.Optimize(solver)
+	if err == nil {
+		t.Errorf("Expected an error for an infeasible status; received none.")
+	}
+
+	if sol == nil || sol.Status != optim.OptimizationStatus_INFEASIBLE {
+		t.Errorf("Expected the scripted status to be returned; received %v", sol)
+	}
+
+	names := solver.MethodNames()
+	if names[len(names)-2] != "Optimize" || names[len(names)-1] != "DeleteSolver" {
+		t.Errorf("Expected the last calls to be Optimize and DeleteSolver; received %v", names)
+	}
+}
+
+/*
+TestConstraintsMatch1
+Description:
+
+	Verifies that constraints are compared by their coefficients.
+*/
+func TestConstraintsMatch1(t *testing.T) {
+	// Constants
+	m := optim.NewModel()
+	x := m.AddVariable()
+	y := m.AddVariable()
+
+	sum1, _ := x.Plus(y)
+	c1, _ := sum1.LessEq(optim.K(1))
+	c2, _ := optim.K(1).GreaterEq(sum1)
+	c3, _ := sum1.LessEq(optim.K(2))
+
+	// Algorithm
+	if match, err := mock.ConstraintsMatch(c1, c2); err != nil || !match {
+		t.Errorf("Expected %v and %v to match (err = %v).", c1, c2, err)
+	}
+
+	if match, err := mock.ConstraintsMatch(c1, c3); err != nil || match {
+		t.Errorf("Expected %v and %v not to match (err = %v).", c1, c3, err)
+	}
+}